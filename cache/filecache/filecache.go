@@ -48,10 +48,20 @@ type Cache struct {
 	// 0 is effectively turning this cache off.
 	maxAge time.Duration
 
+	// Max size in bytes for this cache. When the cache grows above this,
+	// the least recently used items are evicted. 0 or negative means no limit.
+	maxSize int64
+
 	// When set, we just remove this entire root directory on expiration.
 	pruneAllRootDir string
 
 	nlocker *lockTracker
+
+	access   *accessLog
+	counters *cacheCounters
+
+	// Optional remote store shared between builds.
+	remote *remote
 }
 
 type lockTracker struct {
@@ -90,7 +100,20 @@ func NewCache(fs afero.Fs, maxAge time.Duration, pruneAllRootDir string) *Cache
 		nlocker:         &lockTracker{Locker: locker.NewLocker(), seen: make(map[string]struct{})},
 		maxAge:          maxAge,
 		pruneAllRootDir: pruneAllRootDir,
+		access:          &accessLog{},
+		counters:        &cacheCounters{},
+	}
+}
+
+// WithMaxAge returns a copy of c sharing the same files, but with the given
+// max age for its items. A disabled cache stays disabled.
+func (c *Cache) WithMaxAge(maxAge time.Duration) *Cache {
+	if c.maxAge == 0 {
+		return c
 	}
+	cc := *c
+	cc.maxAge = maxAge
+	return &cc
 }
 
 // lockedFile is a file with a lock that is released on Close.
@@ -119,8 +142,11 @@ func (c *Cache) WriteCloser(id string) (ItemInfo, io.WriteCloser, error) {
 	}
 
 	return info, &lockedFile{
-		File:   f,
-		unlock: func() { c.nlocker.Unlock(id) },
+		File: f,
+		unlock: func() {
+			c.nlocker.Unlock(id)
+			c.putRemote(id)
+		},
 	}, nil
 }
 
@@ -156,6 +182,9 @@ func (c *Cache) ReadOrCreate(id string,
 	}
 
 	err = create(info, f)
+	if err == nil {
+		c.putRemote(id)
+	}
 
 	return
 }
@@ -191,9 +220,12 @@ func (c *Cache) GetOrCreate(id string, create func() (io.ReadCloser, error)) (It
 	}
 
 	var buff bytes.Buffer
-	return info,
-		hugio.ToReadCloser(&buff),
-		afero.WriteReader(c.Fs, id, io.TeeReader(r, &buff))
+	if err := afero.WriteReader(c.Fs, id, io.TeeReader(r, &buff)); err != nil {
+		return info, nil, err
+	}
+	c.putRemote(id)
+
+	return info, hugio.ToReadCloser(&buff), nil
 }
 
 // GetOrCreateBytes is the same as GetOrCreate, but produces a byte slice.
@@ -228,6 +260,8 @@ func (c *Cache) GetOrCreateBytes(id string, create func() ([]byte, error)) (Item
 	if err := afero.WriteReader(c.Fs, id, bytes.NewReader(b)); err != nil {
 		return info, nil, err
 	}
+	c.putRemote(id)
+
 	return info, b, nil
 }
 
@@ -273,21 +307,32 @@ func (c *Cache) getOrRemove(id string) hugio.ReadSeekCloser {
 
 	if c.maxAge > 0 {
 		fi, err := c.Fs.Stat(id)
-		if err != nil {
+		if err != nil && !c.getRemote(id) {
+			c.recordAccess(id, false)
 			return nil
 		}
 
-		if c.isExpired(fi.ModTime()) {
+		if err == nil && c.isExpired(fi.ModTime()) {
 			c.Fs.Remove(id)
-			return nil
+			c.forgetAccess(id)
+			if !c.getRemote(id) {
+				c.recordAccess(id, false)
+				return nil
+			}
 		}
 	}
 
 	f, err := c.Fs.Open(id)
+	if err != nil && os.IsNotExist(err) && c.getRemote(id) {
+		f, err = c.Fs.Open(id)
+	}
 	if err != nil {
+		c.recordAccess(id, false)
 		return nil
 	}
 
+	c.recordAccess(id, true)
+
 	return f
 }
 
@@ -342,6 +387,13 @@ func NewCaches(p *helpers.PathSpec) (Caches, error) {
 
 	fs := p.Fs.Source
 
+	// The hit/miss counters are stored below :cacheDir/:project.
+	cacheDir, _, err := resolveDirPlaceholder(fs, p.Cfg, ":cacheDir")
+	if err != nil {
+		return nil, err
+	}
+	countersDir := filepath.Join(cacheDir, filepath.Base(p.Cfg.GetString("workingDir")), "filecache_stats")
+
 	m := make(Caches)
 	for k, v := range dcfg {
 		var cfs afero.Fs
@@ -373,7 +425,18 @@ func NewCaches(p *helpers.PathSpec) (Caches, error) {
 			pruneAllRootDir = "pkg"
 		}
 
-		m[k] = NewCache(bfs, v.MaxAge, pruneAllRootDir)
+		c := NewCache(bfs, v.MaxAge, pruneAllRootDir)
+		c.maxSize = v.MaxSize
+		c.counters.fs = fs
+		c.counters.filename = filepath.Join(countersDir, k+".json")
+		if v.Remote != "" && pruneAllRootDir == "" {
+			store, err := newRemoteStore(v.Remote)
+			if err != nil {
+				return nil, err
+			}
+			c.remote = newRemote(store, k)
+		}
+		m[k] = c
 	}
 
 	return m, nil
@@ -0,0 +1,347 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filecache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gohugoio/hugo/common/loggers"
+	"github.com/spf13/afero"
+)
+
+// The name of the file, stored in the root of the caches with a max size,
+// holding the recorded access times. It is not a cache item.
+const accessLogFilename = ".hugo_access.json"
+
+// accessLog records when the items in a cache were last accessed, which we use
+// to evict the least recently used items when the cache grows above its
+// max size.
+type accessLog struct {
+	mu     sync.Mutex
+	loaded bool
+	dirty  bool
+
+	Access map[string]int64 `json:"access"` // Unix seconds.
+}
+
+func (l *accessLog) load(fs afero.Fs) {
+	if l.loaded {
+		return
+	}
+	l.loaded = true
+	if b, err := afero.ReadFile(fs, accessLogFilename); err == nil {
+		// Just start over if this is corrupt.
+		json.Unmarshal(b, l)
+	}
+	if l.Access == nil {
+		l.Access = make(map[string]int64)
+	}
+}
+
+// cacheCounters counts the hits and misses in a cache.
+// They are stored in filename in fs, if set, which lives below the
+// :cacheDir and not next to the cached files, as e.g. resources/_gen is
+// usually committed to source control.
+type cacheCounters struct {
+	fs       afero.Fs
+	filename string
+
+	mu     sync.Mutex
+	loaded bool
+	dirty  bool
+
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+func (c *cacheCounters) load() {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+	if c.fs == nil {
+		return
+	}
+	if b, err := afero.ReadFile(c.fs, c.filename); err == nil {
+		// Just start over if this is corrupt.
+		json.Unmarshal(b, c)
+	}
+}
+
+// save writes the counters to disk, if changed.
+func (c *cacheCounters) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty || c.fs == nil {
+		return nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := c.fs.MkdirAll(filepath.Dir(c.filename), 0777); err != nil {
+		return err
+	}
+	if err := afero.WriteFile(c.fs, c.filename, b, 0666); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
+
+func (c *Cache) recordAccess(id string, hit bool) {
+	counters := c.counters
+	counters.mu.Lock()
+	counters.load()
+	counters.dirty = true
+	if hit {
+		counters.Hits++
+	} else {
+		counters.Misses++
+	}
+	counters.mu.Unlock()
+
+	if !hit || c.maxSize <= 0 {
+		// New items will get their modification time as the access time,
+		// and we only need the access times to evict items.
+		return
+	}
+
+	l := c.access
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.load(c.Fs)
+	l.dirty = true
+	l.Access[id] = time.Now().Unix()
+}
+
+func (c *Cache) forgetAccess(id string) {
+	l := c.access
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.load(c.Fs)
+	if _, found := l.Access[id]; found {
+		delete(l.Access, id)
+		l.dirty = true
+	}
+}
+
+// lastAccess returns the last recorded access time for id, falling back to
+// modTime.
+func (c *Cache) lastAccess(id string, modTime time.Time) time.Time {
+	l := c.access
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.load(c.Fs)
+	if t, found := l.Access[id]; found {
+		if at := time.Unix(t, 0); at.After(modTime) {
+			return at
+		}
+	}
+	return modTime
+}
+
+// saveAccessLog writes the access log and the hit/miss counters to disk,
+// if changed.
+func (c *Cache) saveAccessLog() error {
+	if err := c.counters.save(); err != nil {
+		return err
+	}
+
+	l := c.access
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.dirty || c.maxSize <= 0 {
+		return nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	if err := afero.WriteFile(c.Fs, accessLogFilename, b, 0666); err != nil {
+		return err
+	}
+	l.dirty = false
+	return nil
+}
+
+type cacheItem struct {
+	name       string
+	size       int64
+	lastAccess time.Time
+}
+
+// items returns all the items in this cache.
+func (c *Cache) items() ([]cacheItem, error) {
+	var items []cacheItem
+	err := afero.Walk(c.Fs, "", func(name string, info os.FileInfo, err error) error {
+		if info == nil || info.IsDir() {
+			return nil
+		}
+		name = cleanID(name)
+		if name == accessLogFilename {
+			return nil
+		}
+		items = append(items, cacheItem{
+			name:       name,
+			size:       info.Size(),
+			lastAccess: c.lastAccess(name, info.ModTime()),
+		})
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return items, nil
+}
+
+// evict removes the least recently used items until the cache size is
+// below maxSize. It returns the number of items removed.
+func (c *Cache) evict() (int, error) {
+	if c.maxSize <= 0 || c.pruneAllRootDir != "" {
+		return 0, nil
+	}
+
+	items, err := c.items()
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+	for _, item := range items {
+		size += item.size
+	}
+	if size <= c.maxSize {
+		return 0, nil
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].lastAccess.Before(items[j].lastAccess)
+	})
+
+	counter := 0
+	for _, item := range items {
+		if size <= c.maxSize {
+			break
+		}
+		c.nlocker.Lock(item.name)
+		err := c.Fs.Remove(item.name)
+		c.nlocker.Unlock(item.name)
+		if err != nil && !os.IsNotExist(err) {
+			return counter, err
+		}
+		c.forgetAccess(item.name)
+		size -= item.size
+		counter++
+	}
+
+	return counter, nil
+}
+
+// Stats holds usage statistics for a cache.
+type Stats struct {
+	// The number of items in the cache.
+	Entries int
+
+	// The total size in bytes of the items in the cache.
+	Size int64
+
+	// The number of cache hits and misses recorded.
+	Hits   int64
+	Misses int64
+
+	// The configured max age and size, see Config.
+	MaxAge  time.Duration
+	MaxSize int64
+}
+
+// HitRate returns the share of cache lookups that were hits, from 0 to 1.
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// Stats returns the usage statistics for this cache.
+func (c *Cache) Stats() (Stats, error) {
+	s := Stats{
+		MaxAge:  c.maxAge,
+		MaxSize: c.maxSize,
+	}
+
+	if c.pruneAllRootDir != "" {
+		// The modules cache, count the files below the root dir.
+		err := afero.Walk(c.Fs, "", func(name string, info os.FileInfo, err error) error {
+			if info == nil || info.IsDir() {
+				return nil
+			}
+			s.Entries++
+			s.Size += info.Size()
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return s, err
+		}
+		return s, nil
+	}
+
+	items, err := c.items()
+	if err != nil {
+		return s, err
+	}
+	for _, item := range items {
+		s.Entries++
+		s.Size += item.size
+	}
+
+	c.counters.mu.Lock()
+	c.counters.load()
+	s.Hits, s.Misses = c.counters.Hits, c.counters.Misses
+	c.counters.mu.Unlock()
+
+	return s, nil
+}
+
+// Flush waits for any pending uploads to remote caches, evicts the least
+// recently used items from the caches above their max size and writes the
+// recorded access times to disk.
+// Failed uploads are logged as warnings, as a remote cache is only an
+// optimization.
+// It returns the number of evicted items.
+func (f Caches) Flush(logger loggers.Logger) (int, error) {
+	var counter int
+	for k, cache := range f {
+		if cache.remote != nil {
+			if err := cache.remote.wait(); err != nil {
+				logger.Warnf("Cache %q: %s", k, err)
+			}
+		}
+		count, err := cache.evict()
+		counter += count
+		if err != nil {
+			return counter, err
+		}
+		if err := cache.saveAccessLog(); err != nil {
+			return counter, err
+		}
+	}
+	return counter, nil
+}
@@ -17,6 +17,8 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,6 +30,7 @@ import (
 
 	"errors"
 
+	"github.com/dustin/go-humanize"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/afero"
 )
@@ -51,6 +54,7 @@ const (
 	cacheKeyAssets      = "assets"
 	cacheKeyModules     = "modules"
 	cacheKeyGetResource = "getresource"
+	cacheKeyRemoteData  = "remotedata"
 )
 
 type Configs map[string]Config
@@ -78,6 +82,7 @@ var defaultCacheConfigs = Configs{
 		MaxAge: -1, // Never expire
 		Dir:    cacheDirProject,
 	},
+	cacheKeyRemoteData: defaultCacheConfig,
 }
 
 type Config struct {
@@ -86,6 +91,19 @@ type Config struct {
 	// a negative value means forever, 0 means cache is disabled.
 	MaxAge time.Duration
 
+	// Max size of this cache in bytes, e.g. 1073741824 or "1GB".
+	// When the cache grows above this, the least recently used
+	// items are removed. 0 or a negative value means no limit.
+	MaxSize int64
+
+	// An optional remote store shared between builds, e.g. on CI runners.
+	// Items not found locally are read from the remote store, and new items
+	// are uploaded to it in the background. Supported values are an
+	// absolute path or a file:// URL to a local directory, an http(s):// URL
+	// to a key-value store (GET/PUT <url>/<cache name>/<key>), or a Go CDK
+	// bucket URL, e.g. s3://my-bucket?region=us-west-1.
+	Remote string
+
 	// The directory where files are stored.
 	Dir string
 
@@ -124,6 +142,11 @@ func (f Caches) GetResourceCache() *Cache {
 	return f[cacheKeyGetResource]
 }
 
+// RemoteDataCache gets the file cache for the remote data sources in site.Data.
+func (f Caches) RemoteDataCache() *Cache {
+	return f[cacheKeyRemoteData]
+}
+
 func DecodeConfig(fs afero.Fs, cfg config.Provider) (Configs, error) {
 	c := make(Configs)
 	valid := make(map[string]bool)
@@ -145,7 +168,7 @@ func DecodeConfig(fs afero.Fs, cfg config.Provider) (Configs, error) {
 
 		dc := &mapstructure.DecoderConfig{
 			Result:           &cc,
-			DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+			DecodeHook:       mapstructure.ComposeDecodeHookFunc(mapstructure.StringToTimeDurationHookFunc(), stringToBytesHookFunc),
 			WeaklyTypedInput: true,
 		}
 
@@ -230,6 +253,25 @@ func DecodeConfig(fs afero.Fs, cfg config.Provider) (Configs, error) {
 	return c, nil
 }
 
+// stringToBytesHookFunc converts human readable sizes, e.g. "500MB", to bytes.
+func stringToBytesHookFunc(from reflect.Type, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeOf(int64(0)) {
+		return data, nil
+	}
+	s := strings.TrimSpace(data.(string))
+	if s == "" {
+		return int64(0), nil
+	}
+	if strings.HasPrefix(s, "-") {
+		return strconv.ParseInt(s, 10, 64)
+	}
+	b, err := humanize.ParseBytes(s)
+	if err != nil {
+		return nil, err
+	}
+	return int64(b), nil
+}
+
 // Resolves :resourceDir => /myproject/resources etc., :cacheDir => ...
 func resolveDirPlaceholder(fs afero.Fs, cfg config.Provider, placeholder string) (cacheDir string, isResource bool, err error) {
 	workingDir := cfg.GetString("workingDir")
@@ -45,13 +45,16 @@ archetypeDir = "archetypes"
 [caches.getJSON]
 maxAge = "10m"
 dir = "/path/to/c1"
+remote = "https://cache.example.org/hugo"
 [caches.getCSV]
 maxAge = "11h"
 dir = "/path/to/c2"
 [caches.images]
 dir = "/path/to/c3"
+maxSize = "2MB"
 [caches.getResource]
 dir = "/path/to/c4"
+maxSize = 1234
 `
 
 	cfg, err := config.FromConfigString(configStr, "toml")
@@ -60,7 +63,10 @@ dir = "/path/to/c4"
 	decoded, err := DecodeConfig(fs, cfg)
 	c.Assert(err, qt.IsNil)
 
-	c.Assert(len(decoded), qt.Equals, 6)
+	c.Assert(len(decoded), qt.Equals, 7)
+
+	c1 := decoded["getjson"]
+	c.Assert(c1.Remote, qt.Equals, "https://cache.example.org/hugo")
 
 	c2 := decoded["getcsv"]
 	c.Assert(c2.MaxAge.String(), qt.Equals, "11h0m0s")
@@ -69,10 +75,13 @@ dir = "/path/to/c4"
 	c3 := decoded["images"]
 	c.Assert(c3.MaxAge, qt.Equals, time.Duration(-1))
 	c.Assert(c3.Dir, qt.Equals, filepath.FromSlash("/path/to/c3/filecache/images"))
+	c.Assert(c3.MaxSize, qt.Equals, int64(2000000))
 
 	c4 := decoded["getresource"]
 	c.Assert(c4.MaxAge, qt.Equals, time.Duration(-1))
 	c.Assert(c4.Dir, qt.Equals, filepath.FromSlash("/path/to/c4/filecache/getresource"))
+	c.Assert(c4.MaxSize, qt.Equals, int64(1234))
+	c.Assert(c2.MaxSize, qt.Equals, int64(0))
 }
 
 func TestDecodeConfigIgnoreCache(t *testing.T) {
@@ -109,7 +118,7 @@ dir = "/path/to/c4"
 	decoded, err := DecodeConfig(fs, cfg)
 	c.Assert(err, qt.IsNil)
 
-	c.Assert(len(decoded), qt.Equals, 6)
+	c.Assert(len(decoded), qt.Equals, 7)
 
 	for _, v := range decoded {
 		c.Assert(v.MaxAge, qt.Equals, time.Duration(0))
@@ -135,7 +144,7 @@ func TestDecodeConfigDefault(t *testing.T) {
 
 	c.Assert(err, qt.IsNil)
 
-	c.Assert(len(decoded), qt.Equals, 6)
+	c.Assert(len(decoded), qt.Equals, 7)
 
 	imgConfig := decoded[cacheKeyImages]
 	jsonConfig := decoded[cacheKeyGetJSON]
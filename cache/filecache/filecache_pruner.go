@@ -47,7 +47,8 @@ func (c Caches) Prune() (int, error) {
 	return counter, nil
 }
 
-// Prune removes expired and unused items from this cache.
+// Prune removes expired and unused items from this cache, and then the least
+// recently used items if the cache is above its max size.
 // If force is set, everything will be removed not considering expiry time.
 func (c *Cache) Prune(force bool) (int, error) {
 	if c.pruneAllRootDir != "" {
@@ -83,6 +84,10 @@ func (c *Cache) Prune(force bool) (int, error) {
 			return nil
 		}
 
+		if name == accessLogFilename {
+			return nil
+		}
+
 		shouldRemove := force || c.isExpired(info.ModTime())
 
 		if !shouldRemove && len(c.nlocker.seen) > 0 {
@@ -94,6 +99,7 @@ func (c *Cache) Prune(force bool) (int, error) {
 		if shouldRemove {
 			err := c.Fs.Remove(name)
 			if err == nil {
+				c.forgetAccess(name)
 				counter++
 			}
 
@@ -105,8 +111,18 @@ func (c *Cache) Prune(force bool) (int, error) {
 
 		return nil
 	})
+	if err != nil {
+		return counter, err
+	}
+
+	// Then make room for new items if the cache is above its max size.
+	count, err := c.evict()
+	counter += count
+	if err != nil {
+		return counter, err
+	}
 
-	return counter, err
+	return counter, c.saveAccessLog()
 }
 
 func (c *Cache) pruneRootDir(force bool) (int, error) {
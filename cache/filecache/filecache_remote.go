@@ -0,0 +1,347 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filecache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// The number of concurrent uploads per cache.
+const remoteMaxUploads = 8
+
+// The first line of every item stored in a remote cache:
+//
+//	hugo-filecache-v1 <sha256 of content> <modification time in Unix seconds>
+const remoteHeaderPrefix = "hugo-filecache-v1"
+
+var errRemoteCorrupt = errors.New("corrupt remote cache item")
+
+// remoteStore is a key-value store shared between Hugo builds, e.g. on
+// different CI runners.
+type remoteStore interface {
+	// get returns nil, nil if key is not found.
+	get(key string) ([]byte, error)
+	put(key string, b []byte) error
+}
+
+// remote is a remote store backing a Cache. Items are read through to the
+// local file system on cache misses and written behind to the remote store
+// when created.
+type remote struct {
+	store remoteStore
+
+	// Prepended to all keys, typically the cache name.
+	prefix string
+
+	uploads sync.WaitGroup
+	sem     chan struct{}
+
+	mu   sync.Mutex
+	errs []error
+}
+
+func newRemote(store remoteStore, prefix string) *remote {
+	return &remote{
+		store:  store,
+		prefix: prefix,
+		sem:    make(chan struct{}, remoteMaxUploads),
+	}
+}
+
+func (r *remote) key(id string) string {
+	return path.Join(r.prefix, filepath.ToSlash(id))
+}
+
+func (r *remote) addErr(err error) {
+	r.mu.Lock()
+	r.errs = append(r.errs, err)
+	r.mu.Unlock()
+}
+
+// wait waits for all pending uploads and returns the first upload error,
+// if any.
+func (r *remote) wait() error {
+	r.uploads.Wait()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.errs) == 0 {
+		return nil
+	}
+	err := r.errs[0]
+	if len(r.errs) > 1 {
+		err = fmt.Errorf("%w (and %d more)", err, len(r.errs)-1)
+	}
+	r.errs = nil
+	return err
+}
+
+// newRemoteStore creates a remote store for the given URL:
+//
+//	http(s)://...         A key-value store over HTTP, see newHTTPStore.
+//	file:///path or /path  A directory on the local file system, e.g. a mounted network volume.
+//	s3://, gs://...       A Go CDK blob bucket, see https://gocloud.dev/howto/blob/
+func newRemoteStore(s string) (remoteStore, error) {
+	if filepath.IsAbs(s) {
+		return newDirStore(s), nil
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse remote cache URL %q: %w", s, err)
+	}
+	switch u.Scheme {
+	case "http", "https":
+		return newHTTPStore(u), nil
+	case "file":
+		return newDirStore(filepath.FromSlash(u.Path)), nil
+	case "":
+		return nil, fmt.Errorf("remote cache %q must be an absolute path or an URL", s)
+	default:
+		return newBlobStore(s)
+	}
+}
+
+// getRemote tries to fetch the item with the given id from the remote store
+// and write it to the local file system.
+// It returns false if not found, expired or corrupt.
+// The caller must hold the lock for id.
+func (c *Cache) getRemote(id string) bool {
+	if c.remote == nil {
+		return false
+	}
+
+	b, err := c.remote.store.get(c.remote.key(id))
+	if err != nil || b == nil {
+		return false
+	}
+
+	content, modTime, err := decodeRemoteItem(b)
+	if err != nil {
+		return false
+	}
+
+	if c.maxAge > 0 && c.isExpired(modTime) {
+		return false
+	}
+
+	if err := afero.WriteReader(c.Fs, id, bytes.NewReader(content)); err != nil {
+		return false
+	}
+	c.Fs.Chtimes(id, modTime, modTime)
+
+	return true
+}
+
+// putRemote uploads the item with the given id to the remote store in the
+// background. See Caches.Flush.
+func (c *Cache) putRemote(id string) {
+	if c.remote == nil || c.maxAge == 0 {
+		return
+	}
+
+	c.remote.uploads.Add(1)
+	go func() {
+		defer c.remote.uploads.Done()
+		c.remote.sem <- struct{}{}
+		defer func() { <-c.remote.sem }()
+
+		if err := c.doPutRemote(id); err != nil {
+			c.remote.addErr(fmt.Errorf("failed to upload %q to remote cache: %w", id, err))
+		}
+	}()
+}
+
+func (c *Cache) doPutRemote(id string) error {
+	c.nlocker.Lock(id)
+	defer c.nlocker.Unlock(id)
+
+	fi, err := c.Fs.Stat(id)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// Removed before we got to it.
+			return nil
+		}
+		return err
+	}
+
+	content, err := afero.ReadFile(c.Fs, id)
+	if err != nil {
+		return err
+	}
+
+	return c.remote.store.put(c.remote.key(id), encodeRemoteItem(content, fi.ModTime()))
+}
+
+func encodeRemoteItem(content []byte, modTime time.Time) []byte {
+	sum := sha256.Sum256(content)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s %d\n", remoteHeaderPrefix, hex.EncodeToString(sum[:]), modTime.Unix())
+	buf.Write(content)
+	return buf.Bytes()
+}
+
+// decodeRemoteItem verifies the content hash of b and returns the content.
+func decodeRemoteItem(b []byte) ([]byte, time.Time, error) {
+	i := bytes.IndexByte(b, '\n')
+	if i == -1 {
+		return nil, time.Time{}, errRemoteCorrupt
+	}
+	header, content := strings.Fields(string(b[:i])), b[i+1:]
+	if len(header) != 3 || header[0] != remoteHeaderPrefix {
+		return nil, time.Time{}, errRemoteCorrupt
+	}
+	sum := sha256.Sum256(content)
+	if hex.EncodeToString(sum[:]) != header[1] {
+		return nil, time.Time{}, errRemoteCorrupt
+	}
+	sec, err := strconv.ParseInt(header[2], 10, 64)
+	if err != nil {
+		return nil, time.Time{}, errRemoteCorrupt
+	}
+
+	return content, time.Unix(sec, 0), nil
+}
+
+// httpStore is a key-value store with a simple protocol:
+//
+//	GET <baseURL>/<key> returns the value with status 200, or 404 if not found.
+//	PUT <baseURL>/<key> stores the request body, any 2xx status is a success.
+//
+// Credentials can be provided as user info in the URL.
+type httpStore struct {
+	baseURL *url.URL
+	client  *http.Client
+}
+
+func newHTTPStore(u *url.URL) *httpStore {
+	return &httpStore{
+		baseURL: u,
+		client:  &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+func (s *httpStore) url(key string) string {
+	u := *s.baseURL
+	u.User = nil
+	u.Path = path.Join("/", u.Path, key)
+	return u.String()
+}
+
+func (s *httpStore) newRequest(method, key string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, s.url(key), body)
+	if err != nil {
+		return nil, err
+	}
+	if s.baseURL.User != nil {
+		password, _ := s.baseURL.User.Password()
+		req.SetBasicAuth(s.baseURL.User.Username(), password)
+	}
+	return req, nil
+}
+
+func (s *httpStore) get(key string) ([]byte, error) {
+	req, err := s.newRequest("GET", key, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", req.URL, res.Status)
+	}
+
+	return ioutil.ReadAll(res.Body)
+}
+
+func (s *httpStore) put(key string, b []byte) error {
+	req, err := s.newRequest("PUT", key, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("PUT %s: %s", req.URL, res.Status)
+	}
+
+	return nil
+}
+
+// dirStore stores the items in a directory.
+type dirStore struct {
+	fs afero.Fs
+}
+
+func newDirStore(dir string) *dirStore {
+	return &dirStore{fs: afero.NewBasePathFs(afero.NewOsFs(), dir)}
+}
+
+func (s *dirStore) get(key string) ([]byte, error) {
+	b, err := afero.ReadFile(s.fs, filepath.FromSlash(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return b, err
+}
+
+func (s *dirStore) put(key string, b []byte) error {
+	// Write to a temporary file first so concurrent readers never
+	// see a partially written item.
+	filename := filepath.FromSlash(key)
+	if err := s.fs.MkdirAll(filepath.Dir(filename), 0777); err != nil {
+		return err
+	}
+	f, err := afero.TempFile(s.fs, filepath.Dir(filename), ".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		s.fs.Remove(f.Name())
+		return err
+	}
+	return s.fs.Rename(f.Name(), filename)
+}
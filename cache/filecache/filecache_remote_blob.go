@@ -0,0 +1,54 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !nodeploy
+// +build !nodeploy
+
+package filecache
+
+import (
+	"context"
+	"fmt"
+
+	"gocloud.dev/blob"
+	_ "gocloud.dev/blob/gcsblob" // import
+	_ "gocloud.dev/blob/s3blob"  // import
+	"gocloud.dev/gcerrors"
+)
+
+// blobStore stores the items in a Go CDK blob bucket.
+type blobStore struct {
+	bucket *blob.Bucket
+}
+
+func newBlobStore(bucketURL string) (remoteStore, error) {
+	bucket, err := blob.OpenBucket(context.Background(), bucketURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open remote cache bucket: %w", err)
+	}
+	return &blobStore{bucket: bucket}, nil
+}
+
+func (s *blobStore) get(key string) ([]byte, error) {
+	b, err := s.bucket.ReadAll(context.Background(), key)
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return nil, nil
+	}
+	return b, err
+}
+
+func (s *blobStore) put(key string, b []byte) error {
+	return s.bucket.WriteAll(context.Background(), key, b, &blob.WriterOptions{
+		ContentType: "application/octet-stream",
+	})
+}
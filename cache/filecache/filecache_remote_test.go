@@ -0,0 +1,194 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filecache
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/common/loggers"
+	"github.com/spf13/afero"
+	jww "github.com/spf13/jwalterweatherman"
+)
+
+// newTestHTTPStoreServer starts a server implementing the GET/PUT protocol
+// used by httpStore.
+func newTestHTTPStoreServer() (*httptest.Server, map[string][]byte) {
+	var mu sync.Mutex
+	items := make(map[string][]byte)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, _ := r.BasicAuth(); user != "hugo" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case "GET":
+			b, found := items[r.URL.Path]
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(b)
+		case "PUT":
+			b, _ := ioutil.ReadAll(r.Body)
+			items[r.URL.Path] = b
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	return srv, items
+}
+
+func TestFileCacheRemoteHTTP(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	srv, items := newTestHTTPStoreServer()
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL + "/hugo")
+	c.Assert(err, qt.IsNil)
+	u.User = url.UserPassword("hugo", "secret")
+
+	newCache := func(maxAge time.Duration) *Cache {
+		store, err := newRemoteStore(u.String())
+		c.Assert(err, qt.IsNil)
+		cache := NewCache(afero.NewMemMapFs(), maxAge, "")
+		cache.remote = newRemote(store, "images")
+		return cache
+	}
+
+	create := func(s string) func() ([]byte, error) {
+		return func() ([]byte, error) {
+			return []byte(s), nil
+		}
+	}
+
+	// Runner 1 creates the item and uploads it.
+	runner1 := newCache(-1)
+	_, b, err := runner1.GetOrCreateBytes("a/b.txt", create("v1"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Equals, "v1")
+	_, err = Caches{"images": runner1}.Flush(loggers.NewErrorLogger())
+	c.Assert(err, qt.IsNil)
+	c.Assert(items, qt.HasLen, 1)
+	c.Assert(items["/hugo/images/a/b.txt"], qt.Not(qt.IsNil))
+
+	// Runner 2 reads it through.
+	runner2 := newCache(-1)
+	_, b, err = runner2.GetOrCreateBytes("a/b.txt", create("v2"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Equals, "v1")
+	c.Assert(runner2.getString("a/b.txt"), qt.Equals, "v1")
+
+	// Corrupt items are treated as not found.
+	items["/hugo/images/a/b.txt"] = append(items["/hugo/images/a/b.txt"], "corrupt"...)
+	runner3 := newCache(-1)
+	_, b, err = runner3.GetOrCreateBytes("a/b.txt", create("v3"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Equals, "v3")
+	_, err = Caches{"images": runner3}.Flush(loggers.NewErrorLogger())
+	c.Assert(err, qt.IsNil)
+
+	// Expired items are treated as not found.
+	runner4 := newCache(time.Hour)
+	content, _, err := decodeRemoteItem(items["/hugo/images/a/b.txt"])
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(content), qt.Equals, "v3")
+	items["/hugo/images/a/b.txt"] = encodeRemoteItem(content, time.Now().Add(-2*time.Hour))
+	_, b, err = runner4.GetOrCreateBytes("a/b.txt", create("v4"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Equals, "v4")
+}
+
+func TestFileCacheRemoteHTTPPutError(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	srv, _ := newTestHTTPStoreServer()
+	defer srv.Close()
+
+	// No credentials.
+	store, err := newRemoteStore(srv.URL)
+	c.Assert(err, qt.IsNil)
+	cache := NewCache(afero.NewMemMapFs(), -1, "")
+	cache.remote = newRemote(store, "images")
+
+	_, b, err := cache.GetOrCreateBytes("a.txt", func() ([]byte, error) { return []byte("v1"), nil })
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Equals, "v1")
+
+	var logBuf bytes.Buffer
+	logger := loggers.NewBasicLoggerForWriter(jww.LevelWarn, &logBuf)
+	_, err = Caches{"images": cache}.Flush(logger)
+	c.Assert(err, qt.IsNil)
+	c.Assert(logger.LogCounters().WarnCounter.Count(), qt.Equals, uint64(1))
+	c.Assert(logBuf.String(), qt.Matches, `(?s).*Cache "images": failed to upload "a.txt" to remote cache: PUT .*: 401 Unauthorized.*`)
+	c.Assert(cache.getString("a.txt"), qt.Equals, "v1")
+}
+
+func TestFileCacheRemoteDir(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	dir := t.TempDir()
+
+	newCache := func() *Cache {
+		store, err := newRemoteStore("file://" + dir)
+		c.Assert(err, qt.IsNil)
+		cache := NewCache(afero.NewMemMapFs(), -1, "")
+		cache.remote = newRemote(store, "getresource")
+		return cache
+	}
+
+	runner1 := newCache()
+	_, w, err := runner1.WriteCloser("a.txt")
+	c.Assert(err, qt.IsNil)
+	w.Write([]byte("v1"))
+	c.Assert(w.Close(), qt.IsNil)
+	_, err = Caches{"getresource": runner1}.Flush(loggers.NewErrorLogger())
+	c.Assert(err, qt.IsNil)
+
+	runner2 := newCache()
+	_, b, err := runner2.GetBytes("a.txt")
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Equals, "v1")
+}
+
+func TestNewRemoteStore(t *testing.T) {
+	c := qt.New(t)
+
+	_, err := newRemoteStore("relative/dir")
+	c.Assert(err, qt.ErrorMatches, `.*must be an absolute path or an URL`)
+
+	s, err := newRemoteStore("https://example.org/cache")
+	c.Assert(err, qt.IsNil)
+	_, ok := s.(*httpStore)
+	c.Assert(ok, qt.IsTrue)
+
+	s, err = newRemoteStore("file:///tmp/cache")
+	c.Assert(err, qt.IsNil)
+	_, ok = s.(*dirStore)
+	c.Assert(ok, qt.IsTrue)
+}
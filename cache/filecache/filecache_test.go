@@ -31,6 +31,7 @@ import (
 	"github.com/gohugoio/hugo/modules"
 
 	"github.com/gohugoio/hugo/common/hugio"
+	"github.com/gohugoio/hugo/common/loggers"
 	"github.com/gohugoio/hugo/config"
 	"github.com/gohugoio/hugo/helpers"
 
@@ -293,6 +294,79 @@ func TestFileCacheReadOrCreateErrorInRead(t *testing.T) {
 	c.Assert(err, qt.Equals, ErrFatal)
 }
 
+func TestFileCacheEvictLRU(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	fs := afero.NewMemMapFs()
+	cache := NewCache(fs, -1, "")
+	cache.maxSize = 25
+	countersFs := afero.NewMemMapFs()
+	cache.counters.fs = countersFs
+	cache.counters.filename = "test.json"
+
+	create := func(s string) func() ([]byte, error) {
+		return func() ([]byte, error) {
+			return []byte(s), nil
+		}
+	}
+
+	old := time.Now().Add(-time.Hour)
+	for i, id := range []string{"a", "b", "c"} {
+		_, _, err := cache.GetOrCreateBytes(id, create("0123456789"))
+		c.Assert(err, qt.IsNil)
+		// Make sure the access order is deterministic.
+		modTime := old.Add(time.Duration(i) * time.Minute)
+		c.Assert(fs.Chtimes(id, modTime, modTime), qt.IsNil)
+	}
+
+	// Touch "a", which makes "b" the least recently used.
+	_, b, err := cache.GetBytes("a")
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Equals, "0123456789")
+	_, b, err = cache.GetBytes("d")
+	c.Assert(err, qt.IsNil)
+	c.Assert(b, qt.IsNil)
+
+	count, err := Caches{"test": cache}.Flush(loggers.NewErrorLogger())
+	c.Assert(err, qt.IsNil)
+	c.Assert(count, qt.Equals, 1)
+	c.Assert(cache.getString("a"), qt.Equals, "0123456789")
+	c.Assert(cache.getString("b"), qt.Equals, "")
+	c.Assert(cache.getString("c"), qt.Equals, "0123456789")
+
+	stats, err := cache.Stats()
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats.Entries, qt.Equals, 2)
+	c.Assert(stats.Size, qt.Equals, int64(20))
+	c.Assert(stats.Hits, qt.Equals, int64(1))
+	c.Assert(stats.Misses, qt.Equals, int64(4))
+	c.Assert(stats.HitRate(), qt.Equals, 0.2)
+
+	// The access log and the counters are persisted.
+	cache2 := NewCache(fs, -1, "")
+	cache2.counters.fs = countersFs
+	cache2.counters.filename = "test.json"
+	stats, err = cache2.Stats()
+	c.Assert(err, qt.IsNil)
+	c.Assert(stats.Entries, qt.Equals, 2)
+	c.Assert(stats.Hits, qt.Equals, int64(1))
+	found, _ := afero.Exists(fs, accessLogFilename)
+	c.Assert(found, qt.IsTrue)
+
+	// The access times are only needed, and stored, with a max size.
+	fs = afero.NewMemMapFs()
+	cache = NewCache(fs, -1, "")
+	_, _, err = cache.GetOrCreateBytes("a", create("0123456789"))
+	c.Assert(err, qt.IsNil)
+	_, _, err = cache.GetBytes("a")
+	c.Assert(err, qt.IsNil)
+	_, err = Caches{"test": cache}.Flush(loggers.NewErrorLogger())
+	c.Assert(err, qt.IsNil)
+	found, _ = afero.Exists(fs, accessLogFilename)
+	c.Assert(found, qt.IsFalse)
+}
+
 func TestCleanID(t *testing.T) {
 	c := qt.New(t)
 	c.Assert(cleanID(filepath.FromSlash("/a/b//c.txt")), qt.Equals, filepath.FromSlash("a/b/c.txt"))
@@ -0,0 +1,155 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/gohugoio/hugo/cache/filecache"
+	"github.com/spf13/cobra"
+)
+
+var _ cmder = (*cacheCmd)(nil)
+
+type cacheCmd struct {
+	*baseBuilderCmd
+}
+
+func (b *commandsBuilder) newCacheCmd() *cacheCmd {
+	c := &cacheCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Various file cache helpers.",
+		Long: `Various helpers to inspect and clean the file caches configured in "caches".
+
+See https://gohugo.io/getting-started/configuration/#configure-file-caches for more information.
+`,
+		RunE: nil,
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "stats",
+			Short: "Print size, entries and hit rate per file cache.",
+			Long: `Print size, entries and hit rate per file cache.
+
+The hits and misses are recorded by Hugo when it looks up items in the cache
+and are stored below the cacheDir, in filecache_stats.`,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return c.withCaches(func(caches filecache.Caches) error {
+					return printCacheStats(os.Stdout, caches)
+				})
+			},
+		},
+		c.newPruneCmd(),
+	)
+
+	c.baseBuilderCmd = b.newBuilderCmd(cmd)
+
+	return c
+}
+
+func (c *cacheCmd) newPruneCmd() *cobra.Command {
+	var (
+		name string
+		all  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove expired items and evict items above maxSize.",
+		Long: `Remove expired items from the file caches, and then the least recently used
+items from any cache above its configured maxSize.
+
+Note that unlike "hugo --gc", this does not do a build first, so it will not remove
+items that are unused but not expired.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.withCaches(func(caches filecache.Caches) error {
+				names := sortedCacheNames(caches)
+				if name != "" {
+					if caches.Get(name) == nil {
+						return fmt.Errorf("%q is not a valid cache name, valid names are %s", name, strings.Join(names, ", "))
+					}
+					names = []string{strings.ToLower(name)}
+				}
+
+				for _, k := range names {
+					count, err := caches[k].Prune(all)
+					if err != nil && !os.IsNotExist(err) {
+						return fmt.Errorf("failed to prune cache %q: %w", k, err)
+					}
+					fmt.Printf("Deleted %d files from cache %q.\n", count, k)
+				}
+
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&name, "cache", "", "", `name of the cache to prune (all if not set), e.g. "images"`)
+	cmd.Flags().BoolVarP(&all, "all", "", false, "remove all items, not considering expiry time")
+
+	return cmd
+}
+
+func (c *cacheCmd) withCaches(f func(filecache.Caches) error) error {
+	com, err := initializeConfig(false, false, false, &c.hugoBuilderCommon, c, nil)
+	if err != nil {
+		return err
+	}
+
+	return f(com.hugo().FileCaches)
+}
+
+func printCacheStats(w io.Writer, caches filecache.Caches) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tENTRIES\tSIZE\tMAXSIZE\tMAXAGE\tHITS\tMISSES\tHIT RATE")
+
+	for _, k := range sortedCacheNames(caches) {
+		s, err := caches[k].Stats()
+		if err != nil {
+			return fmt.Errorf("failed to read stats for cache %q: %w", k, err)
+		}
+
+		maxSize := "-"
+		if s.MaxSize > 0 {
+			maxSize = humanize.Bytes(uint64(s.MaxSize))
+		}
+		maxAge := "-"
+		if s.MaxAge >= 0 {
+			maxAge = s.MaxAge.String()
+		}
+
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%d\t%d\t%.1f%%\n",
+			k, s.Entries, humanize.Bytes(uint64(s.Size)), maxSize, maxAge, s.Hits, s.Misses, s.HitRate()*100)
+	}
+
+	return tw.Flush()
+}
+
+func sortedCacheNames(caches filecache.Caches) []string {
+	names := make([]string, 0, len(caches))
+	for k := range caches {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
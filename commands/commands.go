@@ -55,6 +55,8 @@ func (b *commandsBuilder) addAll() *commandsBuilder {
 		newGenCmd(),
 		createReleaser(),
 		b.newModCmd(),
+		b.newCacheCmd(),
+		b.newI18nCmd(),
 	)
 
 	return b
@@ -309,6 +311,7 @@ func (cc *hugoBuilderCommon) handleFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolP("printI18nWarnings", "", false, "print missing translations")
 	cmd.Flags().BoolP("printPathWarnings", "", false, "print warnings on duplicate target paths etc.")
 	cmd.Flags().BoolP("printUnusedTemplates", "", false, "print warnings on unused templates.")
+	cmd.Flags().BoolP("printShadowedFiles", "", false, "print warnings on template and i18n files provided by more than one module.")
 	cmd.Flags().StringVarP(&cc.cpuprofile, "profile-cpu", "", "", "write cpu profile to `file`")
 	cmd.Flags().StringVarP(&cc.memprofile, "profile-mem", "", "", "write memory profile to `file`")
 	cmd.Flags().BoolVarP(&cc.printm, "printMemoryUsage", "", false, "print memory usage to screen at intervals")
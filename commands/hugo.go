@@ -226,6 +226,7 @@ func initializeFlags(cmd *cobra.Command, cfg config.Provider) {
 		"gc",
 		"printI18nWarnings",
 		"printUnusedTemplates",
+		"printShadowedFiles",
 		"invalidateCDN",
 		"layoutDir",
 		"logFile",
@@ -343,7 +344,7 @@ func (c *commandeer) fullBuild(noBuildLock bool) error {
 		}
 		for _, s := range c.hugo().Sites {
 			// We have no way of knowing what site the garbage belonged to.
-			s.ProcessingStats.Cleaned = uint64(count)
+			s.ProcessingStats.Add(&s.ProcessingStats.Cleaned, count)
 		}
 	}
 
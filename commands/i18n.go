@@ -0,0 +1,282 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gohugoio/hugo/common/paths"
+	"github.com/gohugoio/hugo/hugolib"
+	"github.com/gohugoio/hugo/langs/i18n"
+	"github.com/gohugoio/hugo/resources/page"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var _ cmder = (*i18nCmd)(nil)
+
+type i18nCmd struct {
+	*baseBuilderCmd
+}
+
+func (b *commandsBuilder) newI18nCmd() *i18nCmd {
+	c := &i18nCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "i18n",
+		Short: "Various translation helpers.",
+		Long: `Various helpers to find and fill in missing translations, both in /i18n and in /content,
+and to exchange translations with translators as XLIFF or PO files.`,
+		RunE: nil,
+	}
+
+	cmd.AddCommand(c.newStatusCmd(), c.newScaffoldCmd(), c.newExportCmd(), c.newImportCmd())
+
+	c.baseBuilderCmd = b.newBuilderCmd(cmd)
+
+	return c
+}
+
+// i18nStatus is the translation status of a language.
+type i18nStatus struct {
+	i18n.Status
+
+	// Content files in other languages without a translation in this language.
+	MissingContent []string `json:"missingContent"`
+}
+
+func (c *i18nCmd) newStatusCmd() *cobra.Command {
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Print missing, unused and outdated translations per language.",
+		Long: `Build the site in memory and print, per language:
+
+- missing: translation IDs in the default content language or used in templates, but not translated.
+- unused: translation IDs not used when rendering the site.
+- outdated: translation IDs with a hash that does not match the default content language.
+- missing content: content files in other languages without a translation.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sites, tp, err := c.buildSites()
+			if err != nil {
+				return err
+			}
+
+			statuses := c.statuses(sites, tp)
+
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(statuses)
+			}
+
+			return printI18nStatus(os.Stdout, statuses)
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print the status as JSON")
+
+	return cmd
+}
+
+func (c *i18nCmd) newScaffoldCmd() *cobra.Command {
+	var content bool
+
+	cmd := &cobra.Command{
+		Use:   "scaffold",
+		Short: "Add the missing translations to the project's i18n files.",
+		Long: `Add the missing translations to the project's i18n files, creating /i18n/<lang>.toml
+if needed. The new entries are copies of the default content language with a hash
+set, so "hugo i18n status" will report them as outdated when the original changes.
+
+With --content, draft stubs are also created for content files missing a translation.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sites, tp, err := c.buildSites()
+			if err != nil {
+				return err
+			}
+
+			fs := sites.Fs.Source
+			defaultLang := sites.Cfg.GetString("defaultContentLanguage")
+			i18nDir := paths.AbsPathify(sites.WorkingDir, sites.Cfg.GetString("i18nDir"))
+
+			for _, st := range c.statuses(sites, tp) {
+				filename, err := tp.ScaffoldMissing(fs, i18nDir, defaultLang, st.Status)
+				if err != nil {
+					return err
+				}
+				if filename != "" {
+					fmt.Printf("Added %d translations to %s\n", len(st.Missing), c.relPath(sites, filename))
+				}
+			}
+
+			if !content {
+				return nil
+			}
+
+			for lang, pages := range sites.MissingTranslations() {
+				for _, p := range pages {
+					filename, created, err := scaffoldContentTranslation(fs, sites, p, lang)
+					if err != nil {
+						return err
+					}
+					if created {
+						fmt.Printf("Created %s\n", c.relPath(sites, filename))
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&content, "content", false, "also create draft stubs for content files missing a translation")
+
+	return cmd
+}
+
+func (c *i18nCmd) buildSites() (*hugolib.HugoSites, *i18n.TranslationProvider, error) {
+	cfgInit := func(c *commandeer) error {
+		// Render to memory to see which translations are in use.
+		c.Set("renderToMemory", true)
+		return nil
+	}
+
+	com, err := initializeConfig(true, true, false, &c.hugoBuilderCommon, c, cfgInit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tp := i18n.NewTranslationProvider()
+	com.DepsCfg.TranslationProvider = tp
+
+	sites, err := hugolib.NewHugoSites(*com.DepsCfg)
+	if err != nil {
+		return nil, nil, newSystemError("Error creating sites", err)
+	}
+
+	if err := sites.Build(hugolib.BuildCfg{}); err != nil {
+		return nil, nil, newSystemError("Error building sites", err)
+	}
+
+	return sites, tp, nil
+}
+
+func (c *i18nCmd) statuses(sites *hugolib.HugoSites, tp *i18n.TranslationProvider) []i18nStatus {
+	var langs []string
+	for _, l := range sites.Languages {
+		langs = append(langs, l.Lang)
+	}
+
+	missingContent := sites.MissingTranslations()
+
+	var statuses []i18nStatus
+	for _, st := range tp.Status(sites.Cfg.GetString("defaultContentLanguage"), langs) {
+		status := i18nStatus{Status: st, MissingContent: []string{}}
+		for _, p := range missingContent[st.Lang] {
+			status.MissingContent = append(status.MissingContent, c.relPath(sites, p.File().Filename()))
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+func (c *i18nCmd) relPath(sites *hugolib.HugoSites, filename string) string {
+	return filepath.ToSlash(strings.TrimPrefix(filename, sites.WorkingDir+string(os.PathSeparator)))
+}
+
+func printI18nStatus(w io.Writer, statuses []i18nStatus) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LANG\tMISSING\tUNUSED\tOUTDATED\tMISSING CONTENT")
+	for _, st := range statuses {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\n", st.Lang, len(st.Missing), len(st.Unused), len(st.Outdated), len(st.MissingContent))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, st := range statuses {
+		if len(st.Missing)+len(st.Unused)+len(st.Outdated)+len(st.MissingContent) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s:\n", st.Lang)
+		for _, list := range []struct {
+			name string
+			ids  []string
+		}{
+			{"missing", st.Missing},
+			{"unused", st.Unused},
+			{"outdated", st.Outdated},
+			{"missing content", st.MissingContent},
+		} {
+			if len(list.ids) > 0 {
+				fmt.Fprintf(w, "  %s: %s\n", list.name, strings.Join(list.ids, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+// contentTranslationFilename returns the filename of the translation of p in
+// lang, either in the language's content dir or next to p with a language code
+// in the filename.
+func contentTranslationFilename(sites *hugolib.HugoSites, p page.Page, lang string) string {
+	var targetContentDir string
+	for _, l := range sites.Languages {
+		if l.Lang == lang {
+			targetContentDir = l.ContentDir
+		}
+	}
+
+	f := p.File()
+	sourceContentDir := strings.TrimSuffix(f.Filename(), f.Path())
+
+	if targetContentDir != "" && paths.AbsPathify(sites.WorkingDir, targetContentDir) != filepath.Clean(sourceContentDir) {
+		return filepath.Join(paths.AbsPathify(sites.WorkingDir, targetContentDir), f.Dir(), f.TranslationBaseName()+"."+f.Ext())
+	}
+
+	return filepath.Join(sourceContentDir, f.Dir(), f.TranslationBaseName()+"."+lang+"."+f.Ext())
+}
+
+// scaffoldContentTranslation creates a draft translation of p in lang, see contentTranslationFilename.
+func scaffoldContentTranslation(fs afero.Fs, sites *hugolib.HugoSites, p page.Page, lang string) (string, bool, error) {
+	filename := contentTranslationFilename(sites, p, lang)
+
+	if exists, _ := afero.Exists(fs, filename); exists {
+		return filename, false, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	fmt.Fprintf(&sb, "title: %q\n", p.Title())
+	if key, found := p.Params()["translationkey"]; found {
+		fmt.Fprintf(&sb, "translationKey: %q\n", fmt.Sprint(key))
+	}
+	sb.WriteString("draft: true\n---\n")
+
+	if err := fs.MkdirAll(filepath.Dir(filename), 0777); err != nil {
+		return "", false, err
+	}
+
+	return filename, true, afero.WriteFile(fs, filename, []byte(sb.String()), 0666)
+}
@@ -0,0 +1,466 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gohugoio/hugo/common/paths"
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/hugolib"
+	"github.com/gohugoio/hugo/langs/i18n"
+	"github.com/gohugoio/hugo/parser"
+	"github.com/gohugoio/hugo/parser/metadecoders"
+	"github.com/gohugoio/hugo/parser/pageparser"
+	"github.com/gohugoio/hugo/resources/page"
+	"github.com/spf13/afero"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+)
+
+// The fragment prefix of the content segments in content unit IDs, e.g. "posts/p1.md#content.1".
+const contentSegmentPrefix = "content."
+
+func (c *i18nCmd) newExportCmd() *cobra.Command {
+	var (
+		format  string
+		langs   []string
+		dir     string
+		content bool
+		fields  []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export translations as XLIFF 2.0 or gettext PO files.",
+		Long: `Export the translation strings in the default content language, with any current
+translations, to one XLIFF 2.0 or gettext PO file per language for use in translation tools.
+
+With --content, the front matter fields given in --fields and the content of the
+pages in the default content language are also exported, split into paragraphs.
+
+Translations that are outdated are marked as in need of review.
+Use "hugo i18n import" to write the translated files back.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "xliff" && format != "po" {
+				return fmt.Errorf("invalid format %q, must be one of xliff or po", format)
+			}
+
+			sites, tp, err := c.buildSites()
+			if err != nil {
+				return err
+			}
+
+			defaultLang := sites.Cfg.GetString("defaultContentLanguage")
+			if len(langs) == 0 {
+				for _, l := range sites.Languages {
+					if l.Lang != defaultLang {
+						langs = append(langs, l.Lang)
+					}
+				}
+			}
+
+			dir = paths.AbsPathify(sites.WorkingDir, dir)
+
+			for _, lang := range langs {
+				doc := i18n.Document{
+					SourceLang: defaultLang,
+					TargetLang: lang,
+					Units:      tp.ExportUnits(defaultLang, lang),
+				}
+				if content {
+					doc.Units = append(doc.Units, contentUnits(sites, defaultLang, lang, fields)...)
+				}
+
+				var (
+					buf bytes.Buffer
+					ext string
+				)
+				if format == "po" {
+					ext = ".po"
+					err = i18n.WritePO(&buf, doc)
+				} else {
+					ext = ".xlf"
+					err = i18n.WriteXLIFF(&buf, doc)
+				}
+				if err != nil {
+					return err
+				}
+
+				filename := filepath.Join(dir, lang+ext)
+				if err := helpers.WriteToDisk(filename, &buf, sites.Fs.Source); err != nil {
+					return err
+				}
+
+				fmt.Printf("Exported %d units to %s\n", len(doc.Units), c.relPath(sites, filename))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "xliff", "the format to export, xliff or po")
+	cmd.Flags().StringSliceVar(&langs, "lang", nil, "the languages to export (all but the default content language if not set)")
+	cmd.Flags().StringVar(&dir, "dir", "translations", "the directory to write the files to")
+	cmd.Flags().BoolVar(&content, "content", false, "also export front matter fields and content")
+	cmd.Flags().StringSliceVar(&fields, "fields", []string{"title", "description"}, "the front matter fields to export with --content")
+
+	return cmd
+}
+
+func (c *i18nCmd) newImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [files]",
+		Short: "Import translated XLIFF 2.0 or gettext PO files.",
+		Long: `Import translated XLIFF 2.0 (.xlf, .xliff) or gettext PO (.po) files as
+exported by "hugo i18n export".
+
+The translation strings are written to the project's i18n file for the target
+language, and the front matter fields and content to the translated content files,
+which are created if needed. Untranslated strings and strings marked as in need of
+review (e.g. fuzzy) are skipped.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sites, tp, err := c.buildSites()
+			if err != nil {
+				return err
+			}
+
+			fs := sites.Fs.Source
+			defaultLang := sites.Cfg.GetString("defaultContentLanguage")
+			i18nDir := paths.AbsPathify(sites.WorkingDir, sites.Cfg.GetString("i18nDir"))
+
+			for _, filename := range args {
+				doc, err := readExchangeFile(fs, paths.AbsPathify(sites.WorkingDir, filename))
+				if err != nil {
+					return err
+				}
+				if doc.TargetLang == "" {
+					return fmt.Errorf("%s: missing target language", filename)
+				}
+				lang := strings.ToLower(doc.TargetLang)
+				if lang == defaultLang {
+					return fmt.Errorf("%s: cannot import into the default content language %q", filename, lang)
+				}
+
+				i18nFilename, count, err := tp.ImportUnits(fs, i18nDir, defaultLang, lang, doc.Units)
+				if err != nil {
+					return err
+				}
+				if count > 0 {
+					fmt.Printf("Imported %d translations to %s\n", count, c.relPath(sites, i18nFilename))
+				}
+
+				written, err := importContentUnits(fs, sites, defaultLang, lang, doc.Units)
+				if err != nil {
+					return err
+				}
+				for _, filename := range written {
+					fmt.Printf("Imported %s\n", c.relPath(sites, filename))
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func readExchangeFile(fs afero.Fs, filename string) (i18n.Document, error) {
+	f, err := fs.Open(filename)
+	if err != nil {
+		return i18n.Document{}, err
+	}
+	defer f.Close()
+
+	var doc i18n.Document
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".po":
+		doc, err = i18n.ReadPO(f)
+	case ".xlf", ".xliff":
+		doc, err = i18n.ReadXLIFF(f)
+	default:
+		return doc, fmt.Errorf("%s: unsupported file type, must be one of .xlf, .xliff or .po", filename)
+	}
+	if err != nil {
+		return doc, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return doc, nil
+}
+
+// contentUnits returns the front matter fields and content segments of the
+// pages in defaultLang as units, with the translations in lang as targets.
+func contentUnits(sites *hugolib.HugoSites, defaultLang, lang string, fields []string) []i18n.Unit {
+	var units []i18n.Unit
+
+	for _, p := range contentSourcePages(sites, defaultLang) {
+		var translation page.Page
+		for _, t := range p.Translations() {
+			if t.Lang() == lang {
+				translation = t
+			}
+		}
+
+		path := filepath.ToSlash(p.File().Path())
+		unit := func(fragment, source, target string) i18n.Unit {
+			return i18n.Unit{
+				Group:  i18n.UnitGroupContent,
+				ID:     path + "#" + fragment,
+				Source: map[string]string{"other": source},
+				Target: map[string]string{"other": target},
+			}
+		}
+
+		for _, field := range fields {
+			source := cast.ToString(p.Params()[strings.ToLower(field)])
+			if source == "" {
+				continue
+			}
+			var target string
+			if translation != nil {
+				target = cast.ToString(translation.Params()[strings.ToLower(field)])
+			}
+			units = append(units, unit(field, source, target))
+		}
+
+		segments := splitContentSegments(p.RawContent())
+		var targetSegments []string
+		if translation != nil {
+			targetSegments = splitContentSegments(translation.RawContent())
+			if len(targetSegments) != len(segments) {
+				// We cannot tell which segment is which.
+				targetSegments = nil
+			}
+		}
+		for i, s := range segments {
+			var target string
+			if targetSegments != nil {
+				target = targetSegments[i]
+			}
+			units = append(units, unit(contentSegmentPrefix+strconv.Itoa(i+1), s, target))
+		}
+	}
+
+	return units
+}
+
+// contentSourcePages returns the pages in lang backed by a content file, sorted by path.
+func contentSourcePages(sites *hugolib.HugoSites, lang string) page.Pages {
+	var pages page.Pages
+	for _, s := range sites.Sites {
+		if s.Lang() != lang {
+			continue
+		}
+		for _, p := range s.Pages() {
+			if !p.File().IsZero() {
+				pages = append(pages, p)
+			}
+		}
+	}
+	sort.Slice(pages, func(i, j int) bool {
+		return pages[i].File().Path() < pages[j].File().Path()
+	})
+	return pages
+}
+
+// splitContentSegments splits s into paragraphs, keeping fenced code blocks together.
+func splitContentSegments(s string) []string {
+	var (
+		segments []string
+		lines    []string
+		inFence  bool
+	)
+
+	flush := func() {
+		if len(lines) > 0 {
+			segments = append(segments, strings.Join(lines, "\n"))
+			lines = nil
+		}
+	}
+
+	for _, line := range strings.Split(s, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if trimmed == "" && !inFence {
+			flush()
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+
+	return segments
+}
+
+// importContentUnits writes the translated content units to the translations
+// of the pages in defaultLang, creating them from the page in defaultLang if needed.
+// It returns the filenames written to.
+func importContentUnits(fs afero.Fs, sites *hugolib.HugoSites, defaultLang, lang string, units []i18n.Unit) ([]string, error) {
+	type pageUnits struct {
+		fields   map[string]string
+		segments map[int]i18n.Unit
+	}
+
+	byPath := make(map[string]*pageUnits)
+	for _, u := range units {
+		if u.Group != i18n.UnitGroupContent {
+			continue
+		}
+		i := strings.LastIndex(u.ID, "#")
+		if i == -1 {
+			continue
+		}
+		path, fragment := u.ID[:i], u.ID[i+1:]
+		pu, found := byPath[path]
+		if !found {
+			pu = &pageUnits{fields: make(map[string]string), segments: make(map[int]i18n.Unit)}
+			byPath[path] = pu
+		}
+		if n := strings.TrimPrefix(fragment, contentSegmentPrefix); n != fragment {
+			if i, err := strconv.Atoi(n); err == nil {
+				pu.segments[i] = u
+			}
+			continue
+		}
+		if !u.NeedsReview && u.Target["other"] != "" {
+			pu.fields[fragment] = u.Target["other"]
+		}
+	}
+
+	var written []string
+
+	for _, p := range contentSourcePages(sites, defaultLang) {
+		pu, found := byPath[filepath.ToSlash(p.File().Path())]
+		if !found {
+			continue
+		}
+
+		var translated bool
+		for _, u := range pu.segments {
+			if u.Target["other"] != "" && !u.NeedsReview {
+				translated = true
+				break
+			}
+		}
+
+		if len(pu.fields) == 0 && !translated {
+			continue
+		}
+
+		filename := contentTranslationFilename(sites, p, lang)
+		sourceFilename := filename
+		exists, _ := afero.Exists(fs, filename)
+		if !exists {
+			sourceFilename = p.File().Filename()
+		}
+
+		b, err := afero.ReadFile(fs, sourceFilename)
+		if err != nil {
+			return nil, err
+		}
+		pf, err := pageparser.ParseFrontMatterAndContent(bytes.NewReader(b))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %q: %w", sourceFilename, err)
+		}
+
+		var body []string
+		if translated {
+			var existing string
+			if exists {
+				existing = string(pf.Content)
+			}
+			body = importContentSegments(splitContentSegments(p.RawContent()), splitContentSegments(existing), pu.segments)
+		}
+		if body == nil && len(pu.fields) == 0 {
+			// Nothing to write.
+			continue
+		}
+
+		if pf.FrontMatter == nil {
+			pf.FrontMatter = make(map[string]any)
+		}
+		if pf.FrontMatterFormat == "" {
+			pf.FrontMatterFormat = metadecoders.YAML
+		}
+		for k, v := range pf.FrontMatter {
+			// Better handling of dates in formats that don't have support for them.
+			if t, ok := v.(time.Time); ok {
+				pf.FrontMatter[k] = t.Format(time.RFC3339)
+			}
+		}
+		for field, v := range pu.fields {
+			setFrontMatterField(pf.FrontMatter, field, v)
+		}
+
+		var buf bytes.Buffer
+		if err := parser.InterfaceToFrontMatter(pf.FrontMatter, pf.FrontMatterFormat, &buf); err != nil {
+			return nil, err
+		}
+		if body != nil {
+			buf.WriteString("\n" + strings.Join(body, "\n\n") + "\n")
+		} else {
+			buf.Write(pf.Content)
+		}
+
+		if err := helpers.WriteToDisk(filename, &buf, fs); err != nil {
+			return nil, err
+		}
+		written = append(written, filename)
+	}
+
+	return written, nil
+}
+
+// importContentSegments returns the translated body of a page with the given
+// source segments, using the approved targets in units, keyed by segment
+// number, and the existing translation for the other segments.
+// It returns nil if a segment is not approved and there is no existing
+// translation matching the source segment by segment.
+func importContentSegments(source, existing []string, units map[int]i18n.Unit) []string {
+	if len(existing) != len(source) {
+		existing = nil
+	}
+	body := make([]string, len(source))
+	for i := range source {
+		if u, found := units[i+1]; found && u.Target["other"] != "" && !u.NeedsReview {
+			body[i] = u.Target["other"]
+		} else if existing != nil {
+			body[i] = existing[i]
+		} else {
+			return nil
+		}
+	}
+	return body
+}
+
+// setFrontMatterField sets the field in m, keeping the case of any existing key.
+func setFrontMatterField(m map[string]any, field string, v string) {
+	for k := range m {
+		if strings.EqualFold(k, field) {
+			m[k] = v
+			return
+		}
+	}
+	m[field] = v
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/hugolib"
+	"github.com/gohugoio/hugo/langs/i18n"
+)
+
+func TestSplitContentSegments(t *testing.T) {
+	c := qt.New(t)
+
+	content := "First paragraph,\nsecond line.\n\n\n```go\nfunc main() {\n\n}\n```\n\n## Heading\n"
+
+	c.Assert(splitContentSegments(content), qt.DeepEquals, []string{
+		"First paragraph,\nsecond line.",
+		"```go\nfunc main() {\n\n}\n```",
+		"## Heading",
+	})
+	c.Assert(splitContentSegments("\n\n"), qt.IsNil)
+}
+
+func TestImportContentUnitsPartial(t *testing.T) {
+	files := `
+-- config.toml --
+defaultContentLanguage = "en"
+[languages]
+[languages.en]
+weight = 1
+[languages.nn]
+weight = 2
+-- content/p1.md --
+---
+title: "P1"
+---
+First.
+
+Second.
+-- content/p1.nn.md --
+---
+title: "P1 nn"
+---
+Fyrste.
+
+Andre.
+-- content/p2.md --
+---
+title: "P2"
+---
+First.
+
+Second.
+`
+	b := hugolib.NewIntegrationTestBuilder(
+		hugolib.IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+		},
+	).Build()
+
+	unit := func(id, source, target string, needsReview bool) i18n.Unit {
+		return i18n.Unit{
+			Group:       i18n.UnitGroupContent,
+			ID:          id,
+			Source:      map[string]string{"other": source},
+			Target:      map[string]string{"other": target},
+			NeedsReview: needsReview,
+		}
+	}
+
+	units := []i18n.Unit{
+		unit("p1.md#content.1", "First.", "Fyrst.", false),
+		unit("p1.md#content.2", "Second.", "Andre, ny.", true),
+		unit("p2.md#title", "P2", "P2 nn", false),
+		unit("p2.md#content.1", "First.", "Fyrst.", false),
+		unit("p2.md#content.2", "Second.", "", false),
+	}
+
+	written, err := importContentUnits(b.H.Fs.Source, b.H, "en", "nn", units)
+	b.Assert(err, qt.IsNil)
+	b.Assert(written, qt.HasLen, 2)
+
+	// The segment needing review keeps the existing translation.
+	b.Assert(b.FileContent("content/p1.nn.md"), qt.Contains, "title: P1 nn\n---\n\nFyrst.\n\nAndre.")
+	// No existing translation, so the body is left as is.
+	b.Assert(b.FileContent("content/p2.nn.md"), qt.Contains, "title: P2 nn\n---\nFirst.\n\nSecond.")
+}
@@ -16,9 +16,14 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gohugoio/hugo/hugofs/files"
 
 	"github.com/gohugoio/hugo/hugolib"
 
@@ -39,6 +44,8 @@ func (c *modCmd) newVerifyCmd() *cobra.Command {
 		Use:   "verify",
 		Short: "Verify dependencies.",
 		Long: `Verify checks that the dependencies of the current module, which are stored in a local downloaded source cache, have not been modified since being downloaded.
+
+Modules imported from a Git repository or an archive (see the "source" import option) are verified against the content hashes in hugo.lock.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return c.withModsClient(true, func(c *modules.Client) error {
@@ -52,6 +59,111 @@ func (c *modCmd) newVerifyCmd() *cobra.Command {
 	return verifyCmd
 }
 
+func (c *modCmd) newOutdatedCmd() *cobra.Command {
+	var plan bool
+
+	cmd := &cobra.Command{
+		Use:   "outdated",
+		Short: "List modules with newer versions available.",
+		Long: `List the modules with newer versions available, and whether the latest version works with the running Hugo version.
+
+Go Modules are resolved using the configured module proxy (see the "proxy" module setting, which may be a file:// URL pointing to a local mirror).
+Modules imported from a Git repository are checked against its semver tags.
+
+Use the --plan flag to also show the mount changes each upgrade would introduce.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.withModsClient(true, func(c *modules.Client) error {
+				return c.Outdated(os.Stdout, plan)
+			})
+		},
+	}
+
+	cmd.Flags().BoolVarP(&plan, "plan", "", false, "show the mount changes for each upgrade")
+
+	return cmd
+}
+
+func (c *modCmd) newWhyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "why <path>",
+		Short: "Show which modules provide a file and which one wins.",
+		Long: `Show every module providing the file with the given path, which one Hugo uses and why.
+
+The path starts with the component folder, e.g.
+
+    hugo mod why layouts/partials/head.html
+    hugo mod why i18n/en.toml
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.withHugo(func(h *hugolib.HugoSites) error {
+				return printFileProviders(os.Stdout, h, args[0])
+			})
+		},
+	}
+}
+
+func printFileProviders(w io.Writer, h *hugolib.HugoSites, path string) error {
+	path = strings.TrimPrefix(filepath.ToSlash(path), "/")
+	component, name, _ := strings.Cut(path, "/")
+	if !files.IsComponentFolder(component) || name == "" {
+		return fmt.Errorf("invalid path %q: must start with one of %v", path, files.ComponentFolders)
+	}
+
+	sfs := h.BaseFs.Component(component)
+	if sfs == nil {
+		return fmt.Errorf("no filesystem found for %q", component)
+	}
+
+	providers, err := sfs.Providers(name)
+	if err != nil {
+		return err
+	}
+
+	if len(providers) == 0 {
+		fmt.Fprintf(w, "%s is not provided by any module.\n", path)
+		return nil
+	}
+
+	fmt.Fprintf(w, "%s is provided by:\n\n", path)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, p := range providers {
+		status := "shadowed"
+		if p.Wins {
+			status = "wins"
+		}
+		module := p.Module
+		if p.Lang != "" {
+			module += " (" + p.Lang + ")"
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", module, p.Filename, status)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(providers) == 1 {
+		return nil
+	}
+
+	fmt.Fprintln(w)
+	winner := providers[0]
+	switch {
+	case winner.IsProject:
+		fmt.Fprintln(w, "Files in the project have precedence over files in its modules.")
+	case winner.Module == providers[1].Module:
+		fmt.Fprintln(w, "The file is mounted more than once by the same module; the first mount in its configuration wins.")
+	default:
+		fmt.Fprintf(w, "Module %q is collected before %q; modules are collected depth first in import order (see \"hugo mod graph\").\n", winner.Module, providers[1].Module)
+	}
+	if component == files.ComponentFolderI18n || component == files.ComponentFolderData {
+		fmt.Fprintf(w, "The %s files are merged; for keys defined in more than one file, the first one wins.\n", component)
+	}
+
+	return nil
+}
+
 var moduleNotFoundRe = regexp.MustCompile("module.*not found")
 
 func (c *modCmd) newCleanCmd() *cobra.Command {
@@ -109,7 +221,9 @@ See https://gohugo.io/hugo-modules/ for more information.
 		Long: `Various helpers to help manage the modules in your project's dependency graph.
 
 Most operations here requires a Go version installed on your system (>= Go 1.12) and the relevant VCS client (typically Git).
-This is not needed if you only operate on modules inside /themes or if you have vendored them via "hugo mod vendor".
+This is not needed if you only operate on modules inside /themes, if you have vendored them via "hugo mod vendor",
+or if you import them with a "source" (a Git repository or a zip/tar.gz archive), which Hugo fetches itself
+and locks in hugo.lock.
 
 ` + commonUsage,
 
@@ -249,6 +363,8 @@ If a module is vendored, that is where Hugo will look for it's dependencies.
 			},
 		},
 		c.newVerifyCmd(),
+		c.newOutdatedCmd(),
+		c.newWhyCmd(),
 		&cobra.Command{
 			Use:   "tidy",
 			Short: "Remove unused entries in go.mod and go.sum.",
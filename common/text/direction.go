@@ -0,0 +1,69 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"golang.org/x/text/unicode/bidi"
+)
+
+const (
+	// DirectionLTR is the left-to-right text direction.
+	DirectionLTR = "ltr"
+
+	// DirectionRTL is the right-to-left text direction.
+	DirectionRTL = "rtl"
+)
+
+const (
+	// FirstStrongIsolate starts a bidi isolate with the direction of its
+	// first strong character.
+	FirstStrongIsolate = "\u2068"
+
+	// PopDirectionalIsolate ends a bidi isolate.
+	PopDirectionalIsolate = "\u2069"
+)
+
+// RuneDirection returns the strong direction of r, DirectionLTR or
+// DirectionRTL, or an empty string if r is neutral or weak, e.g. a digit.
+func RuneDirection(r rune) string {
+	p, _ := bidi.LookupRune(r)
+	switch p.Class() {
+	case bidi.L:
+		return DirectionLTR
+	case bidi.R, bidi.AL:
+		return DirectionRTL
+	}
+	return ""
+}
+
+// Direction returns the direction of the first strong character in s,
+// or an empty string if s has none.
+func Direction(s string) string {
+	for _, r := range s {
+		if dir := RuneDirection(r); dir != "" {
+			return dir
+		}
+	}
+	return ""
+}
+
+// HasRTL reports whether s contains any right-to-left characters.
+func HasRTL(s string) bool {
+	for _, r := range s {
+		if RuneDirection(r) == DirectionRTL {
+			return true
+		}
+	}
+	return false
+}
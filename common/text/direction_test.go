@@ -0,0 +1,33 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestDirection(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(Direction("Hugo"), qt.Equals, DirectionLTR)
+	c.Assert(Direction("123 שלום Hugo"), qt.Equals, DirectionRTL)
+	c.Assert(Direction("«مرحبا»"), qt.Equals, DirectionRTL)
+	c.Assert(Direction("123 !?"), qt.Equals, "")
+	c.Assert(Direction(""), qt.Equals, "")
+
+	c.Assert(HasRTL("Hugo שלום"), qt.IsTrue)
+	c.Assert(HasRTL("Hugo 123"), qt.IsFalse)
+}
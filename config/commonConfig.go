@@ -44,6 +44,36 @@ type Build struct {
 	// Can be used to toggle off writing of the intellinsense /assets/jsconfig.js
 	// file.
 	NoJSConfigInAssets bool
+
+	// Content Security Policy generation from the rendered HTML.
+	CSP CSP
+}
+
+// DefaultCSPDirectives is the base policy used when no directives are configured.
+var DefaultCSPDirectives = map[string][]string{
+	"default-src": {"'self'"},
+}
+
+// CSP configures collecting of Content Security Policy sources from the
+// published HTML.
+type CSP struct {
+	// When enabled, will collect the hashes of inline scripts and styles and
+	// the external origins used in every HTML page and write them,
+	// merged with Directives, to a hugo_csp.json.
+	Enable bool
+
+	// When enabled, will inject a Content-Security-Policy meta tag into the
+	// head of every HTML page.
+	InjectMeta bool
+
+	// When enabled, will write a _headers file (as used by e.g. Netlify and
+	// Cloudflare Pages) with a Content-Security-Policy header per page
+	// to the publish directory.
+	WriteHeaders bool
+
+	// The base policy, e.g. {"default-src": ["'self'"]}.
+	// The collected sources are added to this.
+	Directives map[string][]string
 }
 
 func (b Build) UseResourceCache(err error) bool {
@@ -76,6 +106,13 @@ func DecodeBuild(cfg Provider) Build {
 		b.UseResourceCacheWhen = "fallback"
 	}
 
+	if b.CSP.InjectMeta || b.CSP.WriteHeaders {
+		b.CSP.Enable = true
+	}
+	if b.CSP.Directives == nil {
+		b.CSP.Directives = DefaultCSPDirectives
+	}
+
 	return b
 }
 
@@ -55,6 +55,23 @@ func TestBuild(t *testing.T) {
 	c.Assert(b.UseResourceCache(herrors.ErrFeatureNotAvailable), qt.Equals, false)
 	c.Assert(b.UseResourceCache(errors.New("err")), qt.Equals, false)
 	c.Assert(b.UseResourceCache(nil), qt.Equals, false)
+
+	c.Assert(b.CSP.Enable, qt.Equals, false)
+	c.Assert(b.CSP.Directives, qt.DeepEquals, DefaultCSPDirectives)
+
+	v.Set("build", map[string]any{
+		"csp": map[string]any{
+			"injectMeta": true,
+			"directives": map[string]any{
+				"script-src": []any{"'self'", "https://cdn.example.org"},
+			},
+		},
+	})
+
+	b = DecodeBuild(v)
+	c.Assert(b.CSP.Enable, qt.Equals, true)
+	c.Assert(b.CSP.InjectMeta, qt.Equals, true)
+	c.Assert(b.CSP.Directives, qt.DeepEquals, map[string][]string{"script-src": {"'self'", "https://cdn.example.org"}})
 }
 
 func TestServer(t *testing.T) {
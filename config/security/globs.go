@@ -0,0 +1,99 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gobwas/glob"
+)
+
+// Globs holds a list of path globs, e.g. "data/**.json".
+// Paths are matched with forward slashes as separator.
+type Globs struct {
+	acceptNone bool
+	globs      []glob.Glob
+
+	// Store this for debugging/error reporting
+	patternsStrings []string
+}
+
+func (g Globs) MarshalJSON() ([]byte, error) {
+	if g.acceptNone {
+		return json.Marshal(acceptNoneKeyword)
+	}
+
+	return json.Marshal(g.patternsStrings)
+}
+
+// NewGlobs creates a new Globs from zero or more patterns.
+// An empty patterns list or a pattern with the value 'none' will create
+// a Globs that will Accept no path.
+func NewGlobs(patterns ...string) (Globs, error) {
+	var patternsStrings []string
+	for _, p := range patterns {
+		if p == acceptNoneKeyword {
+			return Globs{acceptNone: true}, nil
+		}
+		if ps := strings.TrimSpace(p); ps != "" {
+			patternsStrings = append(patternsStrings, ps)
+		}
+	}
+
+	if len(patternsStrings) == 0 {
+		return Globs{acceptNone: true}, nil
+	}
+
+	globs := make([]glob.Glob, len(patternsStrings))
+	for i, p := range patternsStrings {
+		g, err := glob.Compile(p, '/')
+		if err != nil {
+			return Globs{}, fmt.Errorf("invalid glob %q: %w", p, err)
+		}
+		globs[i] = g
+	}
+
+	return Globs{globs: globs, patternsStrings: patternsStrings}, nil
+}
+
+// MustNewGlobs is like NewGlobs, but panics on invalid patterns.
+func MustNewGlobs(patterns ...string) Globs {
+	g, err := NewGlobs(patterns...)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// Accept reports whether filename matches any of the globs.
+func (g Globs) Accept(filename string) bool {
+	if g.acceptNone {
+		return false
+	}
+
+	filename = strings.TrimPrefix(filepath.ToSlash(filename), "/")
+	for _, gg := range g.globs {
+		if gg.Match(filename) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g Globs) String() string {
+	return fmt.Sprint(g.patternsStrings)
+}
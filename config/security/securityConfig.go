@@ -18,8 +18,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/gohugoio/hugo/common/herrors"
 	"github.com/gohugoio/hugo/common/types"
@@ -51,6 +54,9 @@ var DefaultConfig = Config{
 		URLs:    NewWhitelist(".*"),
 		Methods: NewWhitelist("(?i)GET|POST"),
 	},
+	Files: Files{
+		Read: MustNewGlobs("**"),
+	},
 }
 
 // Config is the top level security config.
@@ -64,6 +70,9 @@ type Config struct {
 	// Restricts access to resources.Get, getJSON, getCSV.
 	HTTP HTTP `json:"http"`
 
+	// Restricts access to files in os.ReadFile, os.ReadDir etc.
+	Files Files `json:"files"`
+
 	// Allow inline shortcodes
 	EnableInlineShortcodes bool `json:"enableInlineShortcodes"`
 }
@@ -78,6 +87,21 @@ type Exec struct {
 type Funcs struct {
 	// OS env keys allowed to query in os.Getenv.
 	Getenv Whitelist `json:"getenv"`
+
+	// Template funcs to deny in templates provided by modules.
+	Modules []ModuleFuncs `json:"modules,omitempty"`
+}
+
+// ModuleFuncs holds the template funcs policy for a set of modules.
+type ModuleFuncs struct {
+	// Globs matching the module paths, e.g. "github.com/**".
+	// This never matches the project itself.
+	Path Globs `json:"path"`
+
+	// Template funcs to deny, e.g. "^resources\\.GetRemote$".
+	// Funcs are matched by their namespaced name and any alias, e.g.
+	// both "data.GetJSON" and "getJSON".
+	Deny Whitelist `json:"deny"`
 }
 
 type HTTP struct {
@@ -86,6 +110,31 @@ type HTTP struct {
 
 	// HTTP methods to allow.
 	Methods Whitelist `json:"methods"`
+
+	// The max size in bytes of a remote response body. 0 means no limit.
+	MaxBytes int64 `json:"maxBytes,omitempty"`
+
+	// The max duration of a remote request, e.g. "30s".
+	// 0 means the default for the template func.
+	Timeout Duration `json:"timeout,omitempty"`
+}
+
+// Files holds file access policies.
+type Files struct {
+	// Globs matching the files and directories, relative to the working
+	// directory, to allow in os.ReadFile, os.ReadDir, os.FileExists and os.Stat.
+	// When set explicitly, this also allows resources.Get to read the matching
+	// files outside of the asset mounts.
+	Read Globs `json:"read"`
+
+	readSet bool
+}
+
+// Duration is a time.Duration that marshals to e.g. "10s".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
 }
 
 // ToTOML converts c to TOML with [security] as the root.
@@ -146,6 +195,117 @@ func (c Config) CheckAllowedHTTPMethod(method string) error {
 	return nil
 }
 
+// HTTPTimeout returns the configured timeout for remote requests,
+// or defaultTimeout if not set.
+func (c Config) HTTPTimeout(defaultTimeout time.Duration) time.Duration {
+	if c.HTTP.Timeout > 0 {
+		return time.Duration(c.HTTP.Timeout)
+	}
+	return defaultTimeout
+}
+
+// CheckHTTPError converts a timeout error from a remote request to url into
+// an AccessDeniedError. Any other error is returned as is.
+func (c Config) CheckHTTPError(url string, err error) error {
+	var netErr net.Error
+	if c.HTTP.Timeout > 0 && errors.As(err, &netErr) && netErr.Timeout() {
+		return &AccessDeniedError{
+			name:     url,
+			path:     "security.http.timeout",
+			reason:   fmt.Sprintf("exceeded the time limit of %s", time.Duration(c.HTTP.Timeout)),
+			policies: c.ToTOML(),
+		}
+	}
+	return err
+}
+
+// LimitHTTPBody wraps the response body r from url in a reader that fails
+// with an AccessDeniedError when reading more than the configured max bytes.
+func (c Config) LimitHTTPBody(url string, r io.ReadCloser) io.ReadCloser {
+	if c.HTTP.MaxBytes <= 0 {
+		return r
+	}
+	return &limitedReadCloser{
+		ReadCloser: r,
+		remaining:  c.HTTP.MaxBytes,
+		err: &AccessDeniedError{
+			name:     url,
+			path:     "security.http.maxBytes",
+			reason:   fmt.Sprintf("exceeded the size limit of %d bytes", c.HTTP.MaxBytes),
+			policies: c.ToTOML(),
+		},
+	}
+}
+
+type limitedReadCloser struct {
+	io.ReadCloser
+	remaining int64
+	err       error
+}
+
+func (l *limitedReadCloser) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, l.err
+	}
+	// Read one byte more than allowed so we can tell a body
+	// of exactly max bytes from a larger one.
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.ReadCloser.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n + int(l.remaining), l.err
+	}
+	return n, err
+}
+
+// CheckAllowedFileRead checks filename, relative to the working directory,
+// against the security.files.read policy.
+func (c Config) CheckAllowedFileRead(filename string) error {
+	if !c.Files.Read.Accept(filename) {
+		return &AccessDeniedError{
+			name:     filename,
+			path:     "security.files.read",
+			policies: c.ToTOML(),
+		}
+	}
+	return nil
+}
+
+// HasFilesReadPolicy reports whether security.files.read is set explicitly,
+// which is needed to read files outside of the asset mounts in resources.Get.
+func (c Config) HasFilesReadPolicy() bool {
+	return c.Files.readSet
+}
+
+// HasModuleFuncsPolicies reports whether any template funcs are denied for modules.
+func (c Config) HasModuleFuncsPolicies() bool {
+	return len(c.Funcs.Modules) > 0
+}
+
+// CheckAllowedModuleFunc checks whether the template func with the given
+// names (e.g. "data.GetJSON" and its alias "getJSON") can be used in a
+// template provided by the module with the given path.
+func (c Config) CheckAllowedModuleFunc(modulePath string, names ...string) error {
+	for _, m := range c.Funcs.Modules {
+		if !m.Path.Accept(modulePath) {
+			continue
+		}
+		for _, name := range names {
+			if m.Deny.Accept(name) {
+				return &AccessDeniedError{
+					name:     names[0],
+					path:     "security.funcs.modules",
+					reason:   fmt.Sprintf("is denied for module %q", modulePath),
+					policies: c.ToTOML(),
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // ToSecurityMap converts c to a map with 'security' as the root key.
 func (c Config) ToSecurityMap() map[string]any {
 	// Take it to JSON and back to get proper casing etc.
@@ -171,7 +331,11 @@ func DecodeConfig(cfg config.Provider) (Config, error) {
 			&mapstructure.DecoderConfig{
 				WeaklyTypedInput: true,
 				Result:           &sc,
-				DecodeHook:       stringSliceToWhitelistHook(),
+				DecodeHook: mapstructure.ComposeDecodeHookFunc(
+					stringSliceToWhitelistHook(),
+					stringSliceToGlobsHook(),
+					stringToDurationHook(),
+				),
 			},
 		)
 		if err != nil {
@@ -181,6 +345,8 @@ func DecodeConfig(cfg config.Provider) (Config, error) {
 		if err = dec.Decode(m); err != nil {
 			return sc, err
 		}
+
+		sc.Files.readSet = cfg.IsSet(securityConfigKey + ".files.read")
 	}
 
 	if !sc.EnableInlineShortcodes {
@@ -209,15 +375,51 @@ func stringSliceToWhitelistHook() mapstructure.DecodeHookFuncType {
 	}
 }
 
+func stringSliceToGlobsHook() mapstructure.DecodeHookFuncType {
+	return func(
+		f reflect.Type,
+		t reflect.Type,
+		data any) (any, error) {
+
+		if t != reflect.TypeOf(Globs{}) {
+			return data, nil
+		}
+
+		return NewGlobs(types.ToStringSlicePreserveString(data)...)
+	}
+}
+
+func stringToDurationHook() mapstructure.DecodeHookFuncType {
+	return func(
+		f reflect.Type,
+		t reflect.Type,
+		data any) (any, error) {
+
+		if t != reflect.TypeOf(Duration(0)) {
+			return data, nil
+		}
+
+		d, err := types.ToDurationE(data)
+		return Duration(d), err
+	}
+}
+
 // AccessDeniedError represents a security policy conflict.
 type AccessDeniedError struct {
 	path     string
 	name     string
 	policies string
+
+	// Defaults to "is not whitelisted".
+	reason string
 }
 
 func (e *AccessDeniedError) Error() string {
-	return fmt.Sprintf("access denied: %q is not whitelisted in policy %q; the current security configuration is:\n\n%s\n\n", e.name, e.path, e.policies)
+	reason := e.reason
+	if reason == "" {
+		reason = "is not whitelisted"
+	}
+	return fmt.Sprintf("access denied: %q %s in policy %q; the current security configuration is:\n\n%s\n\n", e.name, reason, e.path, e.policies)
 }
 
 // IsAccessDenied reports whether err is an AccessDeniedError
@@ -14,7 +14,10 @@
 package security
 
 import (
+	"io/ioutil"
+	"strings"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 	"github.com/gohugoio/hugo/config"
@@ -108,6 +111,42 @@ allow="a"
 
 	})
 
+	c.Run("Files, modules and HTTP limits", func(c *qt.C) {
+		c.Parallel()
+		tomlConfig := `
+[security]
+[security.files]
+read = ["data/**", "assets/*.json"]
+[security.http]
+maxBytes = 1024
+timeout = "30s"
+[security.funcs]
+[[security.funcs.modules]]
+path = "github.com/**"
+deny = ['^resources\.GetRemote$', '^getJSON$']
+`
+
+		cfg, err := config.FromConfigString(tomlConfig, "toml")
+		c.Assert(err, qt.IsNil)
+
+		pc, err := DecodeConfig(cfg)
+		c.Assert(err, qt.IsNil)
+		c.Assert(pc.Files.Read.Accept("data/a/b.json"), qt.IsTrue)
+		c.Assert(pc.Files.Read.Accept("assets/a.json"), qt.IsTrue)
+		c.Assert(pc.Files.Read.Accept("assets/a/b.json"), qt.IsFalse)
+		c.Assert(pc.Files.Read.Accept("config.toml"), qt.IsFalse)
+		c.Assert(pc.CheckAllowedFileRead("config.toml"), qt.ErrorMatches, `(?s)access denied: "config.toml" is not whitelisted in policy "security.files.read".*`)
+
+		c.Assert(pc.HTTP.MaxBytes, qt.Equals, int64(1024))
+		c.Assert(pc.HTTPTimeout(10*time.Second), qt.Equals, 30*time.Second)
+
+		c.Assert(pc.HasModuleFuncsPolicies(), qt.IsTrue)
+		c.Assert(pc.CheckAllowedModuleFunc("github.com/bep/mytheme", "resources.GetRemote"), qt.ErrorMatches, `(?s)access denied: "resources.GetRemote" is denied for module "github.com/bep/mytheme" in policy "security.funcs.modules".*`)
+		c.Assert(pc.CheckAllowedModuleFunc("github.com/bep/mytheme", "data.GetJSON", "getJSON"), qt.Not(qt.IsNil))
+		c.Assert(pc.CheckAllowedModuleFunc("github.com/bep/mytheme", "resources.Get"), qt.IsNil)
+		c.Assert(pc.CheckAllowedModuleFunc("mytheme", "resources.GetRemote"), qt.IsNil)
+	})
+
 	c.Run("Enable inline shortcodes, legacy", func(c *qt.C) {
 		c.Parallel()
 		tomlConfig := `
@@ -140,10 +179,28 @@ func TestToTOML(t *testing.T) {
 	got := DefaultConfig.ToTOML()
 
 	c.Assert(got, qt.Equals,
-		"[security]\n  enableInlineShortcodes = false\n  [security.exec]\n    allow = ['^dart-sass-embedded$', '^go$', '^npx$', '^postcss$']\n    osEnv = ['(?i)^(PATH|PATHEXT|APPDATA|TMP|TEMP|TERM)$']\n\n  [security.funcs]\n    getenv = ['^HUGO_']\n\n  [security.http]\n    methods = ['(?i)GET|POST']\n    urls = ['.*']",
+		"[security]\n  enableInlineShortcodes = false\n  [security.exec]\n    allow = ['^dart-sass-embedded$', '^go$', '^npx$', '^postcss$']\n    osEnv = ['(?i)^(PATH|PATHEXT|APPDATA|TMP|TEMP|TERM)$']\n\n  [security.files]\n    read = ['**']\n\n  [security.funcs]\n    getenv = ['^HUGO_']\n\n  [security.http]\n    methods = ['(?i)GET|POST']\n    urls = ['.*']",
 	)
 }
 
+func TestLimitHTTPBody(t *testing.T) {
+	c := qt.New(t)
+
+	sc := DefaultConfig
+	sc.HTTP.MaxBytes = 3
+
+	r := sc.LimitHTTPBody("https://example.org", ioutil.NopCloser(strings.NewReader("abc")))
+	b, err := ioutil.ReadAll(r)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Equals, "abc")
+
+	r = sc.LimitHTTPBody("https://example.org", ioutil.NopCloser(strings.NewReader("abcd")))
+	b, err = ioutil.ReadAll(r)
+	c.Assert(IsAccessDenied(err), qt.IsTrue)
+	c.Assert(err, qt.ErrorMatches, `(?s)access denied: "https://example.org" exceeded the size limit of 3 bytes in policy "security.http.maxBytes".*`)
+	c.Assert(string(b), qt.Equals, "abc")
+}
+
 func TestDecodeConfigDefault(t *testing.T) {
 	t.Parallel()
 	c := qt.New(t)
@@ -163,4 +220,8 @@ func TestDecodeConfigDefault(t *testing.T) {
 	c.Assert(pc.HTTP.Methods.Accept("GET"), qt.IsTrue)
 	c.Assert(pc.HTTP.Methods.Accept("get"), qt.IsTrue)
 	c.Assert(pc.HTTP.Methods.Accept("DELETE"), qt.IsFalse)
+
+	c.Assert(pc.Files.Read.Accept("config.toml"), qt.IsTrue)
+	c.Assert(pc.Files.Read.Accept("data/a/b.json"), qt.IsTrue)
+	c.Assert(pc.HasModuleFuncsPolicies(), qt.IsFalse)
 }
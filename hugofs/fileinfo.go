@@ -72,6 +72,7 @@ type FileMeta struct {
 	SkipDir bool
 
 	Lang                       string
+	Version                    string
 	TranslationBaseName        string
 	TranslationBaseNameWithExt string
 	Translations               []string
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -72,10 +73,10 @@ func NewLanguageFs(langs map[string]int, fs afero.Fs) (afero.Fs, error) {
 	}
 
 	all := func(fis []os.FileInfo) {
-		// Maps translation base name to a list of language codes.
+		// Maps version and translation base name to a list of language codes.
 		translations := make(map[string][]string)
 		trackTranslation := func(meta *FileMeta) {
-			name := meta.TranslationBaseNameWithExt
+			name := path.Join(meta.Version, meta.TranslationBaseNameWithExt)
 			translations[name] = append(translations[name], meta.Lang)
 		}
 		for _, fi := range fis {
@@ -90,7 +91,7 @@ func NewLanguageFs(langs map[string]int, fs afero.Fs) (afero.Fs, error) {
 
 		for _, fi := range fis {
 			fim := fi.(FileMetaInfo)
-			langs := translations[fim.Meta().TranslationBaseNameWithExt]
+			langs := translations[path.Join(fim.Meta().Version, fim.Meta().TranslationBaseNameWithExt)]
 			if len(langs) > 0 {
 				fim.Meta().Translations = sortAndremoveStringDuplicates(langs)
 			}
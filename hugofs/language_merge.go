@@ -25,7 +25,8 @@ var LanguageDirsMerger = func(lofi, bofi []os.FileInfo) []os.FileInfo {
 		var found bool
 		for _, fi2 := range lofi {
 			fim2 := fi2.(FileMetaInfo)
-			if fi1.Name() == fi2.Name() && fim1.Meta().Lang == fim2.Meta().Lang {
+			m1, m2 := fim1.Meta(), fim2.Meta()
+			if fi1.Name() == fi2.Name() && m1.Lang == m2.Lang && m1.Version == m2.Version {
 				found = true
 				break
 			}
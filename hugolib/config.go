@@ -275,6 +275,7 @@ func (l configLoader) applyConfigDefaults() error {
 		"defaultContentLanguageInSubdir":       false,
 		"enableMissingTranslationPlaceholders": false,
 		"enableGitInfo":                        false,
+		"gitInfoMaxHistory":                    10,
 		"ignoreFiles":                          make([]string, 0),
 		"disableAliases":                       false,
 		"debug":                                false,
@@ -0,0 +1,442 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gohugoio/hugo/common/maps"
+	"github.com/gohugoio/hugo/config"
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/parser/metadecoders"
+	"github.com/gohugoio/hugo/resources/page/pagemeta"
+	"github.com/spf13/cast"
+)
+
+// dataConfig configures how the data in site.Data is loaded, as set in the
+// data section of the site config.
+type dataConfig struct {
+	// Load CSV files as slices of maps keyed by the header row.
+	CSVHeaders bool
+
+	// The schemas for the rows of CSV data keyed by their path in site.Data,
+	// e.g. "shop/products".
+	Schemas map[string]*pagemeta.Schema
+
+	// The remote data sources, sorted by name.
+	Sources []*dataSource
+}
+
+// dataSource is a remote data source declared in the site config.
+type dataSource struct {
+	// The path in site.Data, e.g. "github/releases".
+	Name string
+
+	URL     string
+	Headers map[string]string
+
+	// The data format, e.g. json. Defaults to the URL's extension.
+	Format metadecoders.Format
+
+	// Max age of the cached data. If not set, the max age of the remotedata
+	// file cache is used.
+	MaxAge    time.Duration
+	maxAgeSet bool
+}
+
+func decodeDataConfig(cfg config.Provider) (dataConfig, error) {
+	var c dataConfig
+
+	m := cfg.GetParams("data")
+	if m == nil {
+		return c, nil
+	}
+
+	for k, v := range m {
+		switch strings.ToLower(k) {
+		case "csv":
+			csvm, err := maps.ToStringMapE(v)
+			if err != nil {
+				return c, fmt.Errorf("data.csv: %w", err)
+			}
+			for kk, vv := range csvm {
+				switch strings.ToLower(kk) {
+				case "headers":
+					c.CSVHeaders = cast.ToBool(vv)
+				default:
+					return c, fmt.Errorf("data.csv: unknown setting %q", kk)
+				}
+			}
+		case "schemas":
+			schemas, err := maps.ToStringMapE(v)
+			if err != nil {
+				return c, fmt.Errorf("data.schemas: %w", err)
+			}
+			c.Schemas = make(map[string]*pagemeta.Schema)
+			for name, sv := range schemas {
+				sm, err := maps.ToStringMapE(sv)
+				if err != nil {
+					return c, fmt.Errorf("data.schemas.%s: %w", name, err)
+				}
+				schema, err := pagemeta.DecodeSchema(sm)
+				if err != nil {
+					return c, fmt.Errorf("data.schemas.%s: %w", name, err)
+				}
+				c.Schemas[cleanDataPath(name)] = schema
+			}
+		case "sources":
+			sources, err := maps.ToStringMapE(v)
+			if err != nil {
+				return c, fmt.Errorf("data.sources: %w", err)
+			}
+			for name, sv := range sources {
+				source, err := decodeDataSource(name, sv)
+				if err != nil {
+					return c, fmt.Errorf("data.sources.%s: %w", name, err)
+				}
+				c.Sources = append(c.Sources, source)
+			}
+			sort.Slice(c.Sources, func(i, j int) bool {
+				return c.Sources[i].Name < c.Sources[j].Name
+			})
+		default:
+			return c, fmt.Errorf("data: unknown setting %q", k)
+		}
+	}
+
+	return c, nil
+}
+
+func decodeDataSource(name string, v any) (*dataSource, error) {
+	m, err := maps.ToStringMapE(v)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &dataSource{Name: cleanDataPath(name)}
+
+	for k, v := range m {
+		switch strings.ToLower(k) {
+		case "url":
+			s.URL = cast.ToString(v)
+		case "headers":
+			hm, err := maps.ToStringMapE(v)
+			if err != nil {
+				return nil, fmt.Errorf("headers: %w", err)
+			}
+			s.Headers = make(map[string]string)
+			for hk, hv := range hm {
+				s.Headers[hk] = cast.ToString(hv)
+			}
+		case "format":
+			s.Format = metadecoders.FormatFromString(cast.ToString(v))
+			if s.Format == "" {
+				return nil, fmt.Errorf("unsupported format %q", v)
+			}
+		case "maxage":
+			s.MaxAge, err = cast.ToDurationE(v)
+			if err != nil {
+				return nil, fmt.Errorf("maxAge: %w", err)
+			}
+			s.maxAgeSet = true
+		default:
+			return nil, fmt.Errorf("unknown setting %q", k)
+		}
+	}
+
+	if s.URL == "" {
+		return nil, errors.New("url must be set")
+	}
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("url %q must be http or https", s.URL)
+	}
+
+	if s.Format == "" {
+		s.Format = metadecoders.FormatFromString(path.Ext(u.Path))
+		if s.Format == "" {
+			s.Format = metadecoders.JSON
+		}
+	}
+
+	return s, nil
+}
+
+// cleanDataPath normalizes a path in site.Data, e.g. "/shop/products/".
+func cleanDataPath(s string) string {
+	return strings.Trim(path.Clean("/"+strings.ReplaceAll(s, "\\", "/")), "/")
+}
+
+// csvToMaps converts the CSV records to a slice of maps keyed by the header
+// row. The values are converted using the schema, or, if the schema does not
+// define a type for the column, to int, float or bool if possible.
+func csvToMaps(records [][]string, schema *pagemeta.Schema) ([]any, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := make([]string, len(records[0]))
+	for i, h := range records[0] {
+		header[i] = strings.TrimSpace(h)
+	}
+
+	rows := make([]any, 0, len(records)-1)
+
+	for i, record := range records[1:] {
+		row := make(map[string]any)
+		for j, v := range record {
+			if j >= len(header) || header[j] == "" {
+				continue
+			}
+			key := header[j]
+			if schema != nil {
+				if f, found := schema.Fields[strings.ToLower(key)]; found && f.Type != "" {
+					row[key] = v
+					continue
+				}
+			}
+			row[key] = inferDataValue(v)
+		}
+
+		if schema != nil {
+			if err := applyDataSchema(schema, row); err != nil {
+				// Line numbers are 1-based, and the header is on the first line.
+				return nil, fmt.Errorf("line %d: %w", i+2, err)
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// applyDataSchema validates and converts the row using the schema, which
+// has lower case keys.
+func applyDataSchema(schema *pagemeta.Schema, row map[string]any) error {
+	keys := make(map[string]string, len(row))
+	lower := make(map[string]any, len(row))
+	for k, v := range row {
+		lk := strings.ToLower(k)
+		keys[lk] = k
+		if s, ok := v.(string); ok && s == "" {
+			// Empty cells are treated as missing.
+			continue
+		}
+		lower[lk] = v
+	}
+
+	err := schema.CheckUnknownFields(lower)
+	if err == nil {
+		err = schema.Apply(lower, time.UTC)
+	}
+	if err != nil {
+		var serr *pagemeta.SchemaError
+		if errors.As(err, &serr) {
+			return fmt.Errorf("column %q: %w", keys[serr.Key], serr.Err)
+		}
+		return err
+	}
+
+	for lk, v := range lower {
+		k, found := keys[lk]
+		if !found {
+			k = lk
+		}
+		row[k] = v
+	}
+
+	return nil
+}
+
+var (
+	dataFloatRe       = regexp.MustCompile(`^-?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?$`)
+	dataLeadingZeroRe = regexp.MustCompile(`^-?0\d`)
+)
+
+// inferDataValue converts s to an int, float or bool if possible.
+func inferDataValue(s string) any {
+	if s == "" {
+		return s
+	}
+	if s == "true" || s == "false" {
+		return s == "true"
+	}
+	if i, err := strconv.Atoi(s); err == nil && strconv.Itoa(i) == s {
+		// The round trip check keeps values with leading zeros, e.g. zip codes, as strings.
+		return i
+	}
+	if dataFloatRe.MatchString(s) && !dataLeadingZeroRe.MatchString(s) {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	}
+	return s
+}
+
+// mergeData merges src into dst. Values already in dst have precedence, and
+// maps are merged recursively.
+func mergeData(dst, src map[string]any, onOverride func(key string)) {
+	for k, v := range src {
+		existing, found := dst[k]
+		if !found {
+			dst[k] = v
+			continue
+		}
+		dm, ok1 := existing.(map[string]any)
+		sm, ok2 := v.(map[string]any)
+		if ok1 && ok2 {
+			mergeData(dm, sm, onOverride)
+			continue
+		}
+		if onOverride != nil {
+			onOverride(k)
+		}
+	}
+}
+
+// loadDataSources fetches the remote data sources declared in the site
+// config and merges them into the data tree, with lower precedence than the
+// data files.
+func (h *HugoSites) loadDataSources(conf dataConfig) error {
+	if len(conf.Sources) == 0 {
+		return nil
+	}
+
+	client := &http.Client{Timeout: h.ExecHelper.Sec().HTTPTimeout(10 * time.Second)}
+
+	for _, s := range conf.Sources {
+		data, err := h.fetchDataSource(client, s, conf.CSVHeaders, conf.Schemas[s.Name])
+		if err != nil {
+			return fmt.Errorf("failed to load data source %q: %w", s.Name, err)
+		}
+		if data == nil {
+			continue
+		}
+
+		parts := strings.Split(s.Name, "/")
+		var v any = data
+		for i := len(parts) - 1; i >= 0; i-- {
+			v = map[string]any{parts[i]: v}
+		}
+
+		mergeData(h.data, v.(map[string]any), func(key string) {
+			h.Log.Infof("Data for key '%s' from data source %q is overridden by higher precedence data already in the data tree", key, s.Name)
+		})
+	}
+
+	return nil
+}
+
+func (h *HugoSites) fetchDataSource(client *http.Client, s *dataSource, csvHeaders bool, schema *pagemeta.Schema) (any, error) {
+	sec := h.ExecHelper.Sec()
+	if err := sec.CheckAllowedHTTPURL(s.URL); err != nil {
+		return nil, err
+	}
+	if err := sec.CheckAllowedHTTPMethod("GET"); err != nil {
+		return nil, err
+	}
+
+	cache := h.FileCaches.RemoteDataCache()
+	if s.maxAgeSet {
+		cache = cache.WithMaxAge(s.MaxAge)
+	}
+
+	keys := make([]string, 0, len(s.Headers))
+	for k := range s.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	sb.WriteString(s.URL)
+	for _, k := range keys {
+		sb.WriteString(k + ":" + s.Headers[k])
+	}
+	id := helpers.MD5String(sb.String()) + "." + string(s.Format)
+
+	_, b, err := cache.GetOrCreateBytes(id, func() ([]byte, error) {
+		req, err := http.NewRequest("GET", s.URL, nil)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range s.Headers {
+			req.Header.Set(k, v)
+		}
+
+		h.Log.Infof("Downloading: %s ...", s.URL)
+		res, err := client.Do(req)
+		if err != nil {
+			return nil, sec.CheckHTTPError(s.URL, err)
+		}
+		defer res.Body.Close()
+
+		b, err := ioutil.ReadAll(sec.LimitHTTPBody(s.URL, res.Body))
+		if err != nil {
+			return nil, sec.CheckHTTPError(s.URL, err)
+		}
+
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			return nil, fmt.Errorf("failed to retrieve remote data: %s", http.StatusText(res.StatusCode))
+		}
+
+		// Make sure invalid data is not cached.
+		if _, err := metadecoders.Default.Unmarshal(b, s.Format); err != nil {
+			return nil, err
+		}
+
+		return b, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeData(b, s.Format, csvHeaders, schema)
+}
+
+// decodeData decodes the data in the given format. CSV with csvHeaders set
+// or a schema is decoded into a slice of maps.
+func decodeData(b []byte, format metadecoders.Format, csvHeaders bool, schema *pagemeta.Schema) (any, error) {
+	data, err := metadecoders.Default.Unmarshal(b, format)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == metadecoders.CSV && (csvHeaders || schema != nil) {
+		records, ok := data.([][]string)
+		if !ok {
+			return data, nil
+		}
+		rows, err := csvToMaps(records, schema)
+		if err != nil {
+			return nil, err
+		}
+		return rows, nil
+	}
+
+	return data, nil
+}
@@ -15,9 +15,13 @@ package hugolib
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/gohugoio/hugo/common/loggers"
@@ -442,3 +446,152 @@ Slogan from shortcode: {{< d >}}
 	c.Assert(content, qt.Contains, "Slogan from template: Hugo Rocks!")
 	c.Assert(content, qt.Contains, "Slogan from shortcode: Hugo Rocks!")
 }
+
+func TestDataDirNestedMerge(t *testing.T) {
+	t.Parallel()
+
+	var dd dataDir
+	dd.addSource("data/a.toml", "[b]\nc1 = \"main\"\n[b.d]\ne1 = \"main\"")
+	dd.addSource("themes/mytheme/data/a.toml", "[b]\nc1 = \"theme\"\nc2 = \"theme\"\n[b.d]\ne1 = \"theme\"\ne2 = \"theme\"")
+
+	expected :=
+		map[string]any{
+			"a": map[string]any{
+				"b": map[string]any{
+					"c1": "main",
+					"c2": "theme",
+					"d": map[string]any{
+						"e1": "main",
+						"e2": "theme",
+					},
+				},
+			},
+		}
+
+	doTestDataDir(t, dd, expected, "theme", "mytheme")
+}
+
+func TestDataDirCSV(t *testing.T) {
+	t.Parallel()
+
+	var dd dataDir
+	dd.addSource("data/raw.csv", "a,b\n1,2")
+	expected := map[string]any{
+		"raw": [][]string{{"a", "b"}, {"1", "2"}},
+	}
+	doTestDataDir(t, dd, expected)
+
+	dd = dataDir{}
+	dd.addSource("data/shop/products.csv", "name,price,zip,stock,active\nShoe,10.5,0123,3,true\nHat,5,,,false")
+	expected = map[string]any{
+		"shop": map[string]any{
+			"products": []any{
+				map[string]any{"name": "Shoe", "price": 10.5, "zip": "0123", "stock": 3, "active": true},
+				map[string]any{"name": "Hat", "price": 5, "zip": "", "stock": "", "active": false},
+			},
+		},
+	}
+	doTestDataDir(t, dd, expected, "data", map[string]any{"csv": map[string]any{"headers": true}})
+
+	schemas := map[string]any{
+		"schemas": map[string]any{
+			"shop/products": map[string]any{
+				"fields": map[string]any{
+					"name":  map[string]any{"type": "string", "required": true},
+					"price": map[string]any{"type": "float"},
+					"stock": map[string]any{"type": "int", "default": 0},
+				},
+			},
+		},
+	}
+	expected = map[string]any{
+		"shop": map[string]any{
+			"products": []any{
+				map[string]any{"name": "Shoe", "price": 10.5, "zip": "0123", "stock": 3, "active": true},
+				map[string]any{"name": "Hat", "price": 5.0, "zip": "", "stock": 0, "active": false},
+			},
+		},
+	}
+	doTestDataDir(t, dd, expected, "data", schemas)
+
+	files := `
+-- config.toml --
+[data.schemas."shop/products".fields.price]
+type = "float"
+-- data/shop/products.csv --
+name,price
+Shoe,abc
+-- layouts/index.html --
+{{ site.Data.shop }}
+`
+
+	b, err := NewIntegrationTestBuilder(
+		IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+		},
+	).BuildE()
+
+	b.Assert(err, qt.IsNotNil)
+	b.Assert(err.Error(), qt.Contains, `line 2: column "price": expected a float`)
+}
+
+func TestDataSources(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch r.URL.Path {
+		case "/releases.json":
+			if r.Header.Get("Authorization") != "Bearer foo" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"latest": "v1.0.0", "local": "remote"}`))
+		case "/stats":
+			w.Write([]byte("name,count\nfoo,3\n"))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	files := `
+-- config.toml --
+[data.csv]
+headers = true
+[data.sources."github/releases"]
+url = "` + srv.URL + `/releases.json"
+maxAge = "1h"
+[data.sources."github/releases".headers]
+Authorization = "Bearer foo"
+[data.sources.stats]
+url = "` + srv.URL + `/stats"
+format = "csv"
+-- data/github/releases.toml --
+local = "local"
+-- layouts/index.html --
+Latest: {{ site.Data.github.releases.latest }}|Local: {{ site.Data.github.releases.local }}|Stats: {{ range site.Data.stats }}{{ .name }}: {{ .count }}{{ end }}|
+`
+
+	b := NewIntegrationTestBuilder(
+		IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+		},
+	).Build()
+
+	b.AssertFileContent("public/index.html", "Latest: v1.0.0|Local: local|Stats: foo: 3|")
+	b.Assert(atomic.LoadInt32(&requests), qt.Equals, int32(2))
+
+	files = strings.Replace(files, "-- config.toml --", "-- config.toml --\n[security.http]\nurls = ['none']", 1)
+
+	b, err := NewIntegrationTestBuilder(
+		IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+		},
+	).BuildE()
+
+	b.Assert(err, qt.IsNotNil)
+	b.Assert(err.Error(), qt.Contains, "is not whitelisted in policy \"security.http.urls\"")
+}
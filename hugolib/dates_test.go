@@ -273,3 +273,49 @@ Home's Date should be equal mydata date: true
 Full time: 6:00:00 am UTC
 `)
 }
+
+func TestFrontMatterTimeZoneAndSidecar(t *testing.T) {
+	t.Parallel()
+
+	files := `
+-- config.toml --
+disableKinds = ["taxonomy", "term", "RSS", "sitemap"]
+timeZone = "Europe/Oslo"
+[frontmatter]
+date = [":sidecar", ":default"]
+lastmod = [":sidecar", "lastmod"]
+-- content/p1.md --
+---
+title: "P1"
+date: "2022-06-01T10:00:00"
+timezone: "America/New_York"
+---
+-- content/p2.md --
+---
+title: "P2"
+date: "2022-06-01T10:00:00"
+---
+-- content/p3.md --
+---
+title: "P3"
+date: "2022-06-01T10:00:00"
+---
+-- content/p3.dates.yaml --
+date: "2021-01-02T08:00:00"
+lastmod: 2021-01-03
+-- layouts/_default/single.html --
+Date: {{ .Date.Format "2006-01-02 15:04 MST" }}|Lastmod: {{ .Lastmod.Format "2006-01-02" }}|
+`
+
+	b := NewIntegrationTestBuilder(
+		IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+		},
+	).Build()
+
+	b.AssertFileContent("public/p1/index.html", "Date: 2022-06-01 10:00 EDT|")
+	b.AssertFileContent("public/p2/index.html", "Date: 2022-06-01 10:00 CEST|")
+	b.AssertFileContent("public/p3/index.html", "Date: 2021-01-02 08:00 CET|Lastmod: 2021-01-03|")
+	b.AssertDestinationExists("p3.dates.yaml", false)
+}
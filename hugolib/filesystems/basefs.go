@@ -709,6 +709,13 @@ func (b *sourceFilesystemsBuilder) createOverlayFs(
 
 			rm.Meta.Lang = lang
 
+			version := mount.Version
+			if version == "" && isContentMount {
+				version = b.p.DefaultContentVersion
+			}
+
+			rm.Meta.Version = version
+
 			if isContentMount {
 				fromToContent = append(fromToContent, rm)
 			} else if b.isStaticMount(mount) {
@@ -0,0 +1,175 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystems
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gohugoio/hugo/hugofs"
+	"github.com/gohugoio/hugo/hugofs/files"
+	"github.com/spf13/afero"
+)
+
+// FileProvider describes a mount providing a file for a given path in a
+// component filesystem.
+type FileProvider struct {
+	// The module path, e.g. "github.com/gohugoio/mytheme".
+	Module string
+
+	// Whether this is the main project.
+	IsProject bool
+
+	// The absolute filename on disk.
+	Filename string
+
+	// The language this mount is restricted to, if any.
+	Lang string
+
+	// Set for the provider used by Hugo, all others are shadowed by it.
+	Wins bool
+}
+
+// Component returns the filesystem for the given component, e.g. "layouts",
+// or nil if not found.
+// For static files in multihost mode, the filesystem for the first
+// language, sorted by name, is returned.
+func (s *SourceFilesystems) Component(name string) *SourceFilesystem {
+	switch name {
+	case files.ComponentFolderContent:
+		return s.Content
+	case files.ComponentFolderData:
+		return s.Data
+	case files.ComponentFolderI18n:
+		return s.I18n
+	case files.ComponentFolderLayouts:
+		return s.Layouts
+	case files.ComponentFolderArchetypes:
+		return s.Archetypes
+	case files.ComponentFolderAssets:
+		return s.Assets
+	case files.ComponentFolderStatic:
+		var langs []string
+		for k := range s.Static {
+			langs = append(langs, k)
+		}
+		if len(langs) == 0 {
+			return nil
+		}
+		sort.Strings(langs)
+		return s.Static[langs[0]]
+	}
+	return nil
+}
+
+// Providers returns every mount providing the file with the given path,
+// relative to the component root, ordered by priority with the provider
+// used by Hugo first.
+//
+// For data and i18n files, which are merged, the first provider wins for
+// any key defined in more than one file.
+func (d *SourceFilesystem) Providers(path string) ([]FileProvider, error) {
+	path = filepath.Clean(strings.TrimPrefix(filepath.FromSlash(path), string(filepath.Separator)))
+
+	var providers []FileProvider
+	for _, dir := range d.Dirs {
+		meta := dir.Meta()
+		fi, err := meta.Fs.Stat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if fi.IsDir() {
+			continue
+		}
+		filename := filepath.Join(meta.Filename, path)
+		if fim, ok := fi.(hugofs.FileMetaInfo); ok && fim.Meta().Filename != "" {
+			filename = fim.Meta().Filename
+		}
+		providers = append(providers, FileProvider{
+			Module:    meta.Module,
+			IsProject: meta.IsProject,
+			Filename:  filename,
+			Lang:      meta.Lang,
+		})
+	}
+
+	if len(providers) == 0 {
+		return nil, nil
+	}
+
+	// The overlay filesystem tells us which one wins, if possible.
+	winner := 0
+	if fi, err := d.Fs.Stat(path); err == nil {
+		if fim, ok := fi.(hugofs.FileMetaInfo); ok {
+			for i, p := range providers {
+				if p.Filename == fim.Meta().Filename {
+					winner = i
+					break
+				}
+			}
+		}
+	}
+
+	providers[winner].Wins = true
+	if winner != 0 {
+		providers = append(providers[winner:winner+1], append(providers[:winner:winner], providers[winner+1:]...)...)
+	}
+
+	return providers, nil
+}
+
+// Shadowed returns all files provided by more than one mount, keyed by
+// their path relative to the component root.
+func (d *SourceFilesystem) Shadowed() (map[string][]FileProvider, error) {
+	seen := make(map[string]int)
+	var paths []string
+	for _, dir := range d.Dirs {
+		fs := dir.Meta().Fs
+		err := afero.Walk(fs, "", func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			path = strings.TrimPrefix(path, string(filepath.Separator))
+			if seen[path] == 1 {
+				paths = append(paths, path)
+			}
+			seen[path]++
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	shadowed := make(map[string][]FileProvider)
+	for _, path := range paths {
+		providers, err := d.Providers(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(providers) > 1 {
+			shadowed[path] = providers
+		}
+	}
+
+	return shadowed, nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gohugoio/hugo/common/herrors"
+	"github.com/gohugoio/hugo/common/text"
+	"github.com/gohugoio/hugo/hugofs"
+	"github.com/gohugoio/hugo/parser/metadecoders"
+	"github.com/gohugoio/hugo/resources/page/pagemeta"
+	"github.com/spf13/afero"
+)
+
+// The suffix of the front matter schema files in /archetypes, e.g.
+// posts.schema.toml.
+const frontMatterSchemaSuffix = ".schema"
+
+// The schema used for content types without a schema of their own.
+const defaultFrontMatterSchema = "default"
+
+// Matches a key in front matter, e.g. `title:`, `title =` or `"title":`.
+var frontMatterKeyRe = regexp.MustCompile(`^["']?([^"'\s:=]+)["']?\s*[:=]`)
+
+// loadFrontMatterSchemas loads the front matter schemas stored next to the
+// archetypes, keyed by content type.
+func (h *HugoSites) loadFrontMatterSchemas() error {
+	h.frontMatterSchemas = make(map[string]*pagemeta.Schema)
+
+	fs := h.PathSpec.BaseFs.Archetypes.Fs
+	fis, err := afero.ReadDir(fs, "")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, fi := range fis {
+		if fi.IsDir() {
+			continue
+		}
+		name := fi.Name()
+		format := metadecoders.FormatFromString(name)
+		switch format {
+		case metadecoders.TOML, metadecoders.YAML, metadecoders.JSON:
+		default:
+			continue
+		}
+		typ := strings.TrimSuffix(name, filepath.Ext(name))
+		if !strings.HasSuffix(typ, frontMatterSchemaSuffix) {
+			continue
+		}
+		typ = strings.TrimSuffix(typ, frontMatterSchemaSuffix)
+
+		if _, found := h.frontMatterSchemas[typ]; found {
+			// Files in the project win over the themes.
+			continue
+		}
+
+		filename := name
+		if fim, ok := fi.(hugofs.FileMetaInfo); ok {
+			filename = fim.Meta().Filename
+		}
+
+		b, err := afero.ReadFile(fs, name)
+		if err != nil {
+			return err
+		}
+
+		m, err := metadecoders.Default.UnmarshalToMap(b, format)
+		if err != nil {
+			return herrors.NewFileErrorFromName(err, filename)
+		}
+
+		schema, err := pagemeta.DecodeSchema(m)
+		if err != nil {
+			return herrors.NewFileErrorFromName(fmt.Errorf("failed to decode front matter schema: %w", err), filename)
+		}
+
+		h.frontMatterSchemas[typ] = schema
+	}
+
+	return nil
+}
+
+// frontMatterSchema returns the front matter schema for the given content
+// type, falling back to the default schema, or nil if none found.
+func (h *HugoSites) frontMatterSchema(typ string) (*pagemeta.Schema, error) {
+	if _, err := h.init.frontMatterSchemas.Do(); err != nil {
+		return nil, err
+	}
+	if schema, found := h.frontMatterSchemas[typ]; found {
+		return schema, nil
+	}
+	return h.frontMatterSchemas[defaultFrontMatterSchema], nil
+}
+
+// frontMatterSchemaError adds the position of the front matter field to err
+// if it is a schema error.
+// The lineOffset is the line number in the content file before the front
+// matter starts.
+func (p *pageState) frontMatterSchemaError(err error, frontMatter []byte, format metadecoders.Format, lineOffset int) error {
+	var serr *pagemeta.SchemaError
+	if !errors.As(err, &serr) {
+		return err
+	}
+
+	lineNumber := 1
+	if lno := frontMatterKeyLine(frontMatter, format, serr.Key); lno > 0 {
+		lineNumber = lineOffset + lno
+	}
+
+	return herrors.NewFileErrorFromName(err, p.File().Filename()).UpdatePosition(text.Position{LineNumber: lineNumber})
+}
+
+// frontMatterKeyLine returns the 1-based line number of the top-level key
+// in the front matter in YAML, TOML or JSON, or 0 if not found.
+func frontMatterKeyLine(frontMatter []byte, format metadecoders.Format, key string) int {
+	var depth int // The JSON object and array nesting depth.
+
+	for i, line := range bytes.Split(frontMatter, []byte("\n")) {
+		switch format {
+		case metadecoders.TOML:
+			if bytes.HasPrefix(bytes.TrimSpace(line), []byte("[")) {
+				// The first table, all keys below are nested.
+				return 0
+			}
+		case metadecoders.JSON:
+			// JSON keys are usually indented, so check the depth instead.
+			line = bytes.TrimSpace(line)
+			if depth == 0 {
+				if !bytes.HasPrefix(line, []byte("{")) {
+					continue
+				}
+				line = bytes.TrimSpace(line[1:])
+				depth = 1
+			}
+			topLevel := depth == 1
+			depth = jsonDepth(line, depth)
+			if !topLevel {
+				continue
+			}
+		}
+
+		if m := frontMatterKeyRe.FindSubmatch(line); m != nil && strings.EqualFold(string(m[1]), key) {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// jsonDepth returns the object and array nesting depth at the end of the
+// JSON line, starting at depth.
+func jsonDepth(line []byte, depth int) int {
+	var inString bool
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+	return depth
+}
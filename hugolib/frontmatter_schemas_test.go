@@ -0,0 +1,169 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/common/herrors"
+)
+
+func TestFrontMatterSchema(t *testing.T) {
+	t.Parallel()
+
+	files := `
+-- config.toml --
+baseURL = "https://example.org"
+disableKinds = ["taxonomy", "term", "RSS", "sitemap"]
+-- archetypes/posts.schema.toml --
+[fields.title]
+type = "string"
+required = true
+[fields.date]
+type = "date"
+[fields.tags]
+type = "slice"
+[fields.weight]
+type = "int"
+[fields.status]
+type = "string"
+default = "published"
+values = ["draft", "published"]
+-- archetypes/default.schema.yaml --
+fields:
+  title:
+    default: "Untitled"
+-- content/posts/p1.md --
+---
+title: "P1"
+date: "2022-03-01"
+tags: "a, b"
+weight: "3"
+---
+-- content/docs/d1.md --
+-- layouts/_default/single.html --
+{{ .Title }}|{{ .Date.Format "2006-01-02" }}|{{ .Params.tags }}|{{ .Weight }}|{{ .Params.status }}|
+`
+
+	b := NewIntegrationTestBuilder(
+		IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+		},
+	).Build()
+
+	b.AssertFileContent("public/posts/p1/index.html", "P1|2022-03-01|[a b]|3|published|")
+	b.AssertFileContent("public/docs/d1/index.html", "Untitled|0001-01-01|")
+}
+
+func TestFrontMatterSchemaErrors(t *testing.T) {
+	t.Parallel()
+
+	const config = `
+-- config.toml --
+disableKinds = ["taxonomy", "term", "RSS", "sitemap"]
+-- archetypes/posts.schema.toml --
+strict = true
+[fields.title]
+type = "string"
+required = true
+[fields.date]
+type = "date"
+[fields.weight]
+type = "int"
+[fields.status]
+values = ["draft", "published"]
+[fields.params]
+type = "map"
+-- layouts/_default/single.html --
+{{ .Title }}
+`
+
+	for _, test := range []struct {
+		name       string
+		content    string
+		lineNumber int
+		expect     string
+	}{
+		{"TOML type", `+++
+title = "P1"
+weight = "abc"
++++
+`, 3, `front matter field "weight": expected a int`},
+		{"YAML type", `---
+title: "P1"
+date: 2022-01-01
+weight: abc
+---
+`, 4, `front matter field "weight": expected a int`},
+		{"JSON values", `{
+  "title": "P1",
+  "status": "publish"
+}
+`, 3, `front matter field "status": "publish" is not one of draft, published`},
+		{"Unknown field", `---
+title: "P1"
+pubishDate: 2022-01-01
+---
+`, 3, `front matter field "pubishdate": field is not defined in the schema`},
+		{"Required", `---
+weight: 3
+---
+`, 1, `front matter field "title": required field is missing`},
+		{"Required no front matter", `Content.`, 1, `front matter field "title": required field is missing`},
+		{"YAML nested", `---
+params:
+  weight: 3
+weight: abc
+title: "P1"
+---
+`, 4, `front matter field "weight": expected a int`},
+		{"TOML nested", `+++
+weight = 3
+[params]
+title = "Nested"
++++
+`, 1, `front matter field "title": required field is missing`},
+		{"JSON nested", `{
+  "params": {
+    "status": "draft",
+    "tags": ["a", "{"]
+  },
+  "title": "P1",
+  "status": "publish"
+}
+`, 7, `front matter field "status": "publish" is not one of draft, published`},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			files := config + "-- content/posts/p1.md --\n" + test.content
+
+			b, err := NewIntegrationTestBuilder(
+				IntegrationTestConfig{
+					T:           t,
+					TxtarString: files,
+				},
+			).BuildE()
+
+			fe := herrors.UnwrapFileError(err)
+			b.Assert(fe, qt.IsNotNil)
+			b.Assert(fe.Position().LineNumber, qt.Equals, test.lineNumber)
+			b.Assert(filepath.ToSlash(fe.Position().Filename), qt.Contains, "content/posts/p1.md")
+			b.Assert(err.Error(), qt.Contains, test.expect)
+		})
+	}
+}
@@ -14,33 +14,223 @@
 package hugolib
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/bep/gitmap"
 	"github.com/gohugoio/hugo/config"
 	"github.com/gohugoio/hugo/resources/page"
+	"github.com/gohugoio/hugo/source"
 )
 
+// The Git log format, one record per commit with the commit message body
+// last, followed by the names of the files changed.
+const gitLogFormat = "--format=format:%x1e%H%x1f%h%x1f%s%x1f%aN%x1f%aE%x1f%ai%x1f%ci%x1f%b%x1f"
+
+const gitDateLayout = "2006-01-02 15:04:05 -0700"
+
 type gitInfo struct {
 	contentDir string
-	repo       *gitmap.GitRepo
+
+	// The HEAD commit the log was read from.
+	head string
+
+	// The max number of commits in the page history, -1 for all.
+	maxHistory int
+
+	// All commits per file relative to contentDir, newest first.
+	files map[string][]*source.GitCommit
 }
 
-func (g *gitInfo) forPage(p page.Page) *gitmap.GitInfo {
+func (g *gitInfo) forPage(p page.Page) *source.GitInfo {
 	name := strings.TrimPrefix(filepath.ToSlash(p.File().Filename()), g.contentDir)
 	name = strings.TrimPrefix(name, "/")
 
-	return g.repo.Files[name]
+	commits := g.files[name]
+	if len(commits) == 0 {
+		return nil
+	}
+
+	history := commits
+	if g.maxHistory >= 0 && len(history) > g.maxHistory {
+		history = history[:g.maxHistory]
+	}
+
+	return &source.GitInfo{
+		GitCommit:    commits[0],
+		CreatedDate:  commits[len(commits)-1].AuthorDate,
+		History:      history,
+		Contributors: gitContributors(commits),
+	}
 }
 
-func newGitInfo(cfg config.Provider) (*gitInfo, error) {
+// gitContributors returns the unique authors and co-authors of commits,
+// identified by email, most commits first.
+func gitContributors(commits []*source.GitCommit) []*source.GitContributor {
+	var contributors []*source.GitContributor
+	seen := make(map[string]*source.GitContributor)
+
+	add := func(a source.GitAuthor) {
+		key := strings.ToLower(a.Email)
+		if key == "" {
+			key = a.Name
+		}
+		c, found := seen[key]
+		if !found {
+			c = &source.GitContributor{GitAuthor: a}
+			seen[key] = c
+			contributors = append(contributors, c)
+		}
+		c.Commits++
+	}
+
+	for _, c := range commits {
+		add(source.GitAuthor{Name: c.AuthorName, Email: c.AuthorEmail})
+		for _, a := range c.CoAuthors {
+			add(a)
+		}
+	}
+
+	// Stable, so contributors with the same count are ordered by their last commit.
+	sort.SliceStable(contributors, func(i, j int) bool {
+		return contributors[i].Commits > contributors[j].Commits
+	})
+
+	return contributors
+}
+
+// newGitInfo reads the Git log of the repository in the working dir in one
+// pass. If prev was read from the same HEAD commit, it is reused.
+func newGitInfo(cfg config.Provider, prev *gitInfo) (*gitInfo, error) {
 	workingDir := cfg.GetString("workingDir")
+	maxHistory := cfg.GetInt("gitInfoMaxHistory")
+
+	absWorkingDir, err := filepath.Abs(workingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := git("-C", workingDir, "rev-parse", "--show-cdup")
+	if err != nil {
+		return nil, err
+	}
+	contentDir := filepath.ToSlash(filepath.Join(absWorkingDir, strings.TrimSpace(string(out))))
+
+	out, err = git("-C", workingDir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	head := strings.TrimSpace(string(out))
+
+	if prev != nil && prev.head == head && prev.contentDir == contentDir {
+		if prev.maxHistory == maxHistory {
+			return prev, nil
+		}
+		return &gitInfo{contentDir: contentDir, head: head, maxHistory: maxHistory, files: prev.files}, nil
+	}
+
+	out, err = git("-c", "diff.renames=0", "-c", "log.showSignature=0", "-C", workingDir,
+		"log", "--name-only", "--no-merges", gitLogFormat, head)
+	if err != nil {
+		return nil, err
+	}
 
-	gitRepo, err := gitmap.Map(workingDir, "")
+	files, err := parseGitLog(string(out))
 	if err != nil {
 		return nil, err
 	}
 
-	return &gitInfo{contentDir: gitRepo.TopLevelAbsPath, repo: gitRepo}, nil
+	return &gitInfo{contentDir: contentDir, head: head, maxHistory: maxHistory, files: files}, nil
+}
+
+// parseGitLog parses the output of git log in gitLogFormat into the
+// commits per file, newest first.
+func parseGitLog(log string) (map[string][]*source.GitCommit, error) {
+	files := make(map[string][]*source.GitCommit)
+
+	for _, entry := range strings.Split(log, "\x1e") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		items := strings.Split(entry, "\x1f")
+		if len(items) != 9 {
+			return nil, fmt.Errorf("failed to parse Git log entry %q", entry)
+		}
+
+		authorDate, err := time.Parse(gitDateLayout, items[5])
+		if err != nil {
+			return nil, err
+		}
+		commitDate, err := time.Parse(gitDateLayout, items[6])
+		if err != nil {
+			return nil, err
+		}
+
+		commit := &source.GitCommit{
+			GitInfo: &gitmap.GitInfo{
+				Hash:            items[0],
+				AbbreviatedHash: items[1],
+				Subject:         items[2],
+				AuthorName:      items[3],
+				AuthorEmail:     items[4],
+				AuthorDate:      authorDate,
+				CommitDate:      commitDate,
+			},
+			CoAuthors: parseGitCoAuthors(items[7]),
+		}
+
+		for _, filename := range strings.Split(items[8], "\n") {
+			filename = strings.TrimSpace(filename)
+			if filename == "" {
+				continue
+			}
+			files[filename] = append(files[filename], commit)
+		}
+	}
+
+	return files, nil
+}
+
+// parseGitCoAuthors parses the Co-authored-by trailers in the commit
+// message body, e.g. "Co-authored-by: Jane Doe <jane@example.com>".
+func parseGitCoAuthors(body string) []source.GitAuthor {
+	const trailer = "co-authored-by:"
+
+	var authors []source.GitAuthor
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) <= len(trailer) || !strings.EqualFold(line[:len(trailer)], trailer) {
+			continue
+		}
+		value := strings.TrimSpace(line[len(trailer):])
+		var author source.GitAuthor
+		if i := strings.Index(value, "<"); i != -1 {
+			author.Name = strings.TrimSpace(value[:i])
+			author.Email = strings.TrimSuffix(strings.TrimSpace(value[i+1:]), ">")
+		} else {
+			author.Name = value
+		}
+		authors = append(authors, author)
+	}
+
+	return authors
+}
+
+func git(args ...string) ([]byte, error) {
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		var ee *exec.Error
+		if errors.As(err, &ee) && ee.Err == exec.ErrNotFound {
+			return nil, gitmap.GitNotFound
+		}
+		return nil, errors.New(string(bytes.TrimSpace(out)))
+	}
+	return out, nil
 }
@@ -0,0 +1,58 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/source"
+)
+
+func TestParseGitLog(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	entry := func(hash, subject, name, email, date, body string, files ...string) string {
+		return "\x1e" + strings.Join([]string{hash, hash[:3], subject, name, email, date, date, body}, "\x1f") + "\x1f\n" + strings.Join(files, "\n") + "\n"
+	}
+
+	log := entry("ccccc", "Third", "Jane", "jane@example.com", "2022-03-01 10:00:00 +0100", "Some text.\n\nCo-authored-by: Bob <BOB@example.com>\nco-authored-by: Ann <ann@example.com>\n", "content/a.md") +
+		entry("bbbbb", "Second", "Bob", "bob@example.com", "2022-02-01 10:00:00 +0100", "", "content/a.md", "content/b.md") +
+		entry("aaaaa", "First", "Jane", "jane@example.com", "2022-01-01 10:00:00 +0100", "", "content/a.md")
+
+	files, err := parseGitLog(log)
+	c.Assert(err, qt.IsNil)
+	c.Assert(files, qt.HasLen, 2)
+
+	a := files["content/a.md"]
+	c.Assert(a, qt.HasLen, 3)
+	c.Assert(a[0].Subject, qt.Equals, "Third")
+	c.Assert(a[0].CoAuthors, qt.DeepEquals, []source.GitAuthor{{Name: "Bob", Email: "BOB@example.com"}, {Name: "Ann", Email: "ann@example.com"}})
+	c.Assert(a[2].AuthorDate.Format("2006-01-02"), qt.Equals, "2022-01-01")
+	c.Assert(files["content/b.md"][0].Hash, qt.Equals, "bbbbb")
+
+	contributors := gitContributors(a)
+	c.Assert(contributors, qt.HasLen, 3)
+	c.Assert(contributors[0].Name, qt.Equals, "Jane")
+	c.Assert(contributors[0].Commits, qt.Equals, 2)
+	c.Assert(contributors[1].Name, qt.Equals, "Bob")
+	c.Assert(contributors[1].Commits, qt.Equals, 2)
+	c.Assert(contributors[2].Name, qt.Equals, "Ann")
+	c.Assert(contributors[2].Commits, qt.Equals, 1)
+
+	_, err = parseGitLog("\x1ecorrupt\x1fentry")
+	c.Assert(err, qt.IsNotNil)
+}
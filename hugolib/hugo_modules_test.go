@@ -1171,3 +1171,59 @@ target = "content/resources-b"
 	b.AssertFileContent("public/resources-a/subdir/about/index.html", "Single")
 	b.AssertFileContent("public/resources-b/subdir/about/index.html", "Single")
 }
+
+func TestModulesShadowedFiles(t *testing.T) {
+	t.Parallel()
+
+	files := `
+-- config.toml --
+baseURL = "https://example.org"
+printShadowedFiles = true
+theme = ["a", "b"]
+-- layouts/partials/head.html --
+project head
+-- layouts/index.html --
+{{ partial "head.html" . }}|{{ partial "foot.html" . }}|{{ T "hello" }}
+-- themes/a/layouts/partials/head.html --
+a head
+-- themes/a/layouts/partials/foot.html --
+a foot
+-- themes/a/i18n/en.toml --
+hello = "Hello from a"
+-- themes/b/layouts/partials/foot.html --
+b foot
+-- themes/b/i18n/en.toml --
+hello = "Hello from b"
+`
+
+	b := NewIntegrationTestBuilder(
+		IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+		},
+	).Build()
+
+	b.AssertFileContent("public/index.html", "project head|a foot|Hello from a")
+
+	b.AssertLogContains(`layouts/partials/foot.html from "b" is shadowed by "a"`)
+	b.AssertLogContains(`layouts/partials/head.html from "a" is shadowed by "project"`)
+	b.AssertLogContains(`i18n/en.toml from "b" is shadowed by "a"`)
+
+	providers, err := b.H.BaseFs.Component("layouts").Providers("partials/foot.html")
+	b.Assert(err, qt.IsNil)
+	b.Assert(providers, qt.HasLen, 2)
+	b.Assert(providers[0].Module, qt.Equals, "a")
+	b.Assert(providers[0].Wins, qt.IsTrue)
+	b.Assert(providers[0].Filename, qt.Equals, filepath.FromSlash("/themes/a/layouts/partials/foot.html"))
+	b.Assert(providers[1].Module, qt.Equals, "b")
+	b.Assert(providers[1].Wins, qt.IsFalse)
+
+	providers, err = b.H.BaseFs.Component("layouts").Providers("/partials/head.html")
+	b.Assert(err, qt.IsNil)
+	b.Assert(providers, qt.HasLen, 2)
+	b.Assert(providers[0].IsProject, qt.IsTrue)
+
+	providers, err = b.H.BaseFs.Component("layouts").Providers("partials/nope.html")
+	b.Assert(err, qt.IsNil)
+	b.Assert(providers, qt.HasLen, 0)
+}
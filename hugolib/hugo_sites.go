@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -41,7 +42,6 @@ import (
 
 	"github.com/gohugoio/hugo/source"
 
-	"github.com/bep/gitmap"
 	"github.com/gohugoio/hugo/config"
 
 	"github.com/gohugoio/hugo/publisher"
@@ -86,6 +86,10 @@ type HugoSites struct {
 	// As loaded from the /data dirs
 	data map[string]any
 
+	// The front matter schemas keyed by content type, as loaded from
+	// the /archetypes dirs.
+	frontMatterSchemas map[string]*pagemeta.Schema
+
 	contentInit sync.Once
 	content     *pageMaps
 
@@ -185,6 +189,9 @@ type hugoSitesInit struct {
 
 	// Maps page translations.
 	translations *lazy.Init
+
+	// Loads the front matter schemas from the /archetypes folders.
+	frontMatterSchemas *lazy.Init
 }
 
 func (h *hugoSitesInit) Reset() {
@@ -192,6 +199,7 @@ func (h *hugoSitesInit) Reset() {
 	h.layouts.Reset()
 	h.gitInfo.Reset()
 	h.translations.Reset()
+	h.frontMatterSchemas.Reset()
 }
 
 func (h *HugoSites) Data() map[string]any {
@@ -202,7 +210,7 @@ func (h *HugoSites) Data() map[string]any {
 	return h.data
 }
 
-func (h *HugoSites) gitInfoForPage(p page.Page) (*gitmap.GitInfo, error) {
+func (h *HugoSites) gitInfoForPage(p page.Page) (*source.GitInfo, error) {
 	if _, err := h.init.gitInfo.Do(); err != nil {
 		return nil, err
 	}
@@ -352,10 +360,11 @@ func newHugoSites(cfg deps.DepsCfg, sites ...*Site) (*HugoSites, error) {
 		numWorkers:              numWorkers,
 		skipRebuildForFilenames: make(map[string]bool),
 		init: &hugoSitesInit{
-			data:         lazy.New(),
-			layouts:      lazy.New(),
-			gitInfo:      lazy.New(),
-			translations: lazy.New(),
+			data:               lazy.New(),
+			layouts:            lazy.New(),
+			gitInfo:            lazy.New(),
+			translations:       lazy.New(),
+			frontMatterSchemas: lazy.New(),
 		},
 	}
 
@@ -385,11 +394,21 @@ func newHugoSites(cfg deps.DepsCfg, sites ...*Site) (*HugoSites, error) {
 		if len(h.Sites) > 1 {
 			allTranslations := pagesToTranslationsMap(h.Sites)
 			assignTranslationsToPages(allTranslations, h.Sites)
+			if langs.GetVersions(h.Cfg) != nil {
+				assignVersionsToPages(h.Sites)
+			}
 		}
 
 		return nil, nil
 	})
 
+	h.init.frontMatterSchemas.Add(func() (any, error) {
+		if err := h.loadFrontMatterSchemas(); err != nil {
+			return nil, fmt.Errorf("failed to load front matter schemas: %w", err)
+		}
+		return nil, nil
+	})
+
 	h.init.gitInfo.Add(func() (any, error) {
 		err := h.loadGitInfo()
 		if err != nil {
@@ -429,7 +448,7 @@ func newHugoSites(cfg deps.DepsCfg, sites ...*Site) (*HugoSites, error) {
 
 func (h *HugoSites) loadGitInfo() error {
 	if h.Cfg.GetBool("enableGitInfo") {
-		gi, err := newGitInfo(h.Cfg)
+		gi, err := newGitInfo(h.Cfg, h.gitInfo)
 		if err != nil {
 			h.Log.Errorln("Failed to read Git log:", err)
 		} else {
@@ -575,20 +594,38 @@ func createSitesFromConfig(cfg deps.DepsCfg) ([]*Site, error) {
 
 	languages := getLanguages(cfg.Cfg)
 
-	for _, lang := range languages {
-		if lang.Disabled {
-			continue
+	// One site per language and, if configured, version.
+	// The default content version goes first.
+	versions := []string{""}
+	if vs := langs.GetVersions(cfg.Cfg); vs != nil {
+		defaultVersion := cfg.Cfg.GetString("defaultContentVersion")
+		versions = []string{defaultVersion}
+		for _, v := range vs {
+			if !v.Disabled && v.Name != defaultVersion {
+				versions = append(versions, v.Name)
+			}
 		}
-		var s *Site
-		var err error
-		cfg.Language = lang
-		s, err = newSite(cfg)
+	}
 
-		if err != nil {
-			return nil, err
-		}
+	for _, version := range versions {
+		for _, lang := range languages {
+			if lang.Disabled {
+				continue
+			}
+			if version != "" {
+				lang = lang.ForVersion(version)
+			}
+			var s *Site
+			var err error
+			cfg.Language = lang
+			s, err = newSite(cfg)
 
-		sites = append(sites, s)
+			if err != nil {
+				return nil, err
+			}
+
+			sites = append(sites, s)
+		}
 	}
 
 	return sites, nil
@@ -751,7 +788,7 @@ func (cfg *BuildCfg) shouldRender(p *pageState) bool {
 }
 
 func (h *HugoSites) renderCrossSitesSitemap() error {
-	if !h.multilingual.enabled() || h.IsMultihost() {
+	if len(h.Sites) <= 1 || h.IsMultihost() {
 		return nil
 	}
 
@@ -866,6 +903,11 @@ func (h *HugoSites) Pages() page.Pages {
 func (h *HugoSites) loadData(fis []hugofs.FileMetaInfo) (err error) {
 	spec := source.NewSourceSpec(h.PathSpec, nil, nil)
 
+	conf, err := decodeDataConfig(h.Cfg)
+	if err != nil {
+		return err
+	}
+
 	h.data = make(map[string]any)
 	for _, fi := range fis {
 		fileSystem := spec.NewFilesystemFromFileMetaInfo(fi)
@@ -874,16 +916,16 @@ func (h *HugoSites) loadData(fis []hugofs.FileMetaInfo) (err error) {
 			return err
 		}
 		for _, r := range files {
-			if err := h.handleDataFile(r); err != nil {
+			if err := h.handleDataFile(r, conf); err != nil {
 				return err
 			}
 		}
 	}
 
-	return
+	return h.loadDataSources(conf)
 }
 
-func (h *HugoSites) handleDataFile(r source.File) error {
+func (h *HugoSites) handleDataFile(r source.File, conf dataConfig) error {
 	var current map[string]any
 
 	f, err := r.FileInfo().Meta().Open()
@@ -905,7 +947,7 @@ func (h *HugoSites) handleDataFile(r source.File) error {
 		}
 	}
 
-	data, err := h.readData(r)
+	data, err := h.readData(r, conf)
 	if err != nil {
 		return h.errWithFileContext(err, r)
 	}
@@ -925,27 +967,22 @@ func (h *HugoSites) handleDataFile(r source.File) error {
 		case nil:
 			current[r.BaseFileName()] = data
 		case map[string]any:
-			// merge maps: insert entries from data for keys that
+			// merge maps recursively: insert entries from data for keys that
 			// don't already exist in higherPrecedentData
-			higherPrecedentMap := higherPrecedentData.(map[string]any)
-			for key, value := range data.(map[string]any) {
-				if _, exists := higherPrecedentMap[key]; exists {
-					// this warning could happen if
-					// 1. A theme uses the same key; the main data folder wins
-					// 2. A sub folder uses the same key: the sub folder wins
-					// TODO(bep) figure out a way to detect 2) above and make that a WARN
-					h.Log.Infof("Data for key '%s' in path '%s' is overridden by higher precedence data already in the data tree", key, r.Path())
-				} else {
-					higherPrecedentMap[key] = value
-				}
-			}
+			mergeData(higherPrecedentData.(map[string]any), data.(map[string]any), func(key string) {
+				// this could happen if
+				// 1. A theme uses the same key; the main data folder wins
+				// 2. A sub folder uses the same key: the sub folder wins
+				// TODO(bep) figure out a way to detect 2) above and make that a WARN
+				h.Log.Infof("Data for key '%s' in path '%s' is overridden by higher precedence data already in the data tree", key, r.Path())
+			})
 		default:
 			// can't merge: higherPrecedentData is not a map
 			h.Log.Warnf("The %T data from '%s' overridden by "+
 				"higher precedence %T data already in the data tree", data, r.Path(), higherPrecedentData)
 		}
 
-	case []any:
+	case []any, [][]string:
 		if higherPrecedentData == nil {
 			current[r.BaseFileName()] = data
 		} else {
@@ -972,7 +1009,7 @@ func (h *HugoSites) errWithFileContext(err error, f source.File) error {
 
 }
 
-func (h *HugoSites) readData(f source.File) (any, error) {
+func (h *HugoSites) readData(f source.File, conf dataConfig) (any, error) {
 	file, err := f.FileInfo().Meta().Open()
 	if err != nil {
 		return nil, fmt.Errorf("readData: failed to open data file: %w", err)
@@ -981,7 +1018,8 @@ func (h *HugoSites) readData(f source.File) (any, error) {
 	content := helpers.ReaderToBytes(file)
 
 	format := metadecoders.FormatFromString(f.Ext())
-	return metadecoders.Default.Unmarshal(content, format)
+	schema := conf.Schemas[cleanDataPath(path.Join(filepath.ToSlash(f.Dir()), f.BaseFileName()))]
+	return decodeData(content, format, conf.CSVHeaders, schema)
 }
 
 func (h *HugoSites) findPagesByKindIn(kind string, inPages page.Pages) page.Pages {
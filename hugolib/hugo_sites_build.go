@@ -21,8 +21,11 @@ import (
 	"os"
 	"path/filepath"
 	"runtime/trace"
+	"sort"
 	"strings"
 
+	"github.com/gohugoio/hugo/hugolib/filesystems"
+
 	"github.com/gohugoio/hugo/publisher"
 
 	"github.com/gohugoio/hugo/hugofs"
@@ -154,6 +157,8 @@ func (h *HugoSites) Build(config BuildCfg, events ...fsnotify.Event) error {
 		if err = h.postProcess(); err != nil {
 			h.SendError(err)
 		}
+
+		h.flushFileCaches()
 	}
 
 	if h.Metrics != nil {
@@ -227,6 +232,31 @@ func (h *HugoSites) initRebuild(config *BuildCfg) error {
 	return nil
 }
 
+// printShadowedFiles logs a warning for every template and i18n file
+// provided by more than one module.
+func (h *HugoSites) printShadowedFiles() error {
+	for _, sfs := range []*filesystems.SourceFilesystem{h.BaseFs.Layouts, h.BaseFs.I18n} {
+		shadowed, err := sfs.Shadowed()
+		if err != nil {
+			return err
+		}
+		var paths []string
+		for path := range shadowed {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			providers := shadowed[path]
+			var modules []string
+			for _, p := range providers[1:] {
+				modules = append(modules, fmt.Sprintf("%q", p.Module))
+			}
+			h.Log.Warnf("%s/%s from %s is shadowed by %q (run \"hugo mod why %s/%s\" for details)", sfs.Name, filepath.ToSlash(path), strings.Join(modules, ", "), providers[0].Module, sfs.Name, filepath.ToSlash(path))
+		}
+	}
+	return nil
+}
+
 func (h *HugoSites) process(config *BuildCfg, init func(config *BuildCfg) error, events ...fsnotify.Event) error {
 	// We should probably refactor the Site and pull up most of the logic from there to here,
 	// but that seems like a daunting task.
@@ -240,6 +270,12 @@ func (h *HugoSites) process(config *BuildCfg, init func(config *BuildCfg) error,
 		return firstSite.processPartial(config, init, events)
 	}
 
+	if h.Cfg.GetBool("printShadowedFiles") {
+		if err := h.printShadowedFiles(); err != nil {
+			return err
+		}
+	}
+
 	return firstSite.process(*config)
 }
 
@@ -338,6 +374,23 @@ func (h *HugoSites) render(config *BuildCfg) error {
 	return nil
 }
 
+// flushFileCaches waits for any pending remote cache uploads and evicts the
+// least recently used items from any file cache above its configured maxSize.
+// This is done after every build, including the rebuilds in the server.
+func (h *HugoSites) flushFileCaches() {
+	evicted, err := h.FileCaches.Flush(h.Log)
+	if err != nil {
+		// The build itself succeeded, so don't fail it.
+		h.Log.Warnf("Failed to flush file caches: %s", err)
+	}
+	if evicted > 0 {
+		for _, s := range h.Sites {
+			// We have no way of knowing what site the evicted items belonged to.
+			s.ProcessingStats.Add(&s.ProcessingStats.Cleaned, evicted)
+		}
+	}
+}
+
 func (h *HugoSites) postProcess() error {
 	// Make sure to write any build stats to disk first so it's available
 	// to the post processors.
@@ -468,6 +521,10 @@ type publishStats struct {
 }
 
 func (h *HugoSites) writeBuildStats() error {
+	if err := h.writeCSPStats(); err != nil {
+		return err
+	}
+
 	if !h.ResourceSpec.BuildConfig.WriteStats {
 		return nil
 	}
@@ -491,14 +548,95 @@ func (h *HugoSites) writeBuildStats() error {
 
 	filename := filepath.Join(h.WorkingDir, "hugo_stats.json")
 
+	if h.running {
+		// Make sure that any CSS purged using the old stats gets rebuilt.
+		if old, err := afero.ReadFile(hugofs.Os, filename); err == nil && !bytes.Equal(old, js) {
+			h.ResourceSpec.ResourceCache.DeletePartitions("css")
+		}
+	}
+
+	return h.writeWorkingDirFile(filename, js)
+}
+
+func (h *HugoSites) writeCSPStats() error {
+	cfg := h.ResourceSpec.BuildConfig.CSP
+	if !cfg.Enable {
+		return nil
+	}
+
+	stats := &publisher.CSPStats{
+		Directives: make(publisher.CSPSources),
+		Pages:      make(map[string]string),
+	}
+	for _, s := range h.Sites {
+		if sstats := s.publisher.PublishStats().CSP; sstats != nil {
+			stats.Directives.Merge(sstats.Directives)
+			for k, v := range sstats.Pages {
+				stats.Pages[k] = v
+			}
+		}
+	}
+	for _, sources := range stats.Directives {
+		sort.Strings(sources)
+	}
+	stats.Policy = stats.Directives.Policy(cfg.Directives)
+
+	js, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := h.writeWorkingDirFile(filepath.Join(h.WorkingDir, "hugo_csp.json"), js); err != nil {
+		return err
+	}
+
+	if !cfg.WriteHeaders {
+		return nil
+	}
+
+	paths := make([]string, 0, len(stats.Pages))
+	for p := range stats.Pages {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	const marker = "# Content-Security-Policy generated by Hugo.\n"
+
+	var buf bytes.Buffer
+	if old, err := afero.ReadFile(h.BaseFs.PublishFs, "_headers"); err == nil {
+		// Keep any headers provided in e.g. /static, but not the
+		// ones we wrote in a previous build.
+		if i := bytes.Index(old, []byte(marker)); i != -1 {
+			old = old[:i]
+		}
+		buf.Write(old)
+		if len(old) > 0 && !bytes.HasSuffix(old, []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+	}
+	buf.WriteString(marker)
+	for _, p := range paths {
+		urlPath := p
+		if strings.HasSuffix(urlPath, "/index.html") {
+			urlPath = strings.TrimSuffix(urlPath, "index.html")
+		}
+		fmt.Fprintf(&buf, "%s\n  Content-Security-Policy: %s\n", urlPath, stats.Pages[p])
+	}
+
+	return afero.WriteFile(h.BaseFs.PublishFs, "_headers", buf.Bytes(), 0666)
+}
+
+// writeWorkingDirFile writes a build stats file to the OS file system and
+// to the source file system, if that is not the OS file system.
+func (h *HugoSites) writeWorkingDirFile(filename string, b []byte) error {
 	// Make sure it's always written to the OS fs.
-	if err := afero.WriteFile(hugofs.Os, filename, js, 0666); err != nil {
+	if err := afero.WriteFile(hugofs.Os, filename, b, 0666); err != nil {
 		return err
 	}
 
 	// Write to the destination as well if it's a in-memory fs.
 	if !hugofs.IsOsFs(h.Fs.Source) {
-		if err := afero.WriteFile(h.Fs.WorkingDirWritable, filename, js, 0666); err != nil {
+		if err := afero.WriteFile(h.Fs.WorkingDirWritable, filename, b, 0666); err != nil {
 			return err
 		}
 	}
@@ -137,3 +137,144 @@ FormatNumberCustom: 12,345.68
 NumFmt: -98,765.43
 `)
 }
+
+func TestLanguageFallbacks(t *testing.T) {
+	t.Parallel()
+
+	files := `
+-- config.toml --
+baseURL = "https://example.org"
+defaultContentLanguage = "en"
+disableKinds = ["taxonomy", "term", "RSS", "sitemap"]
+[languages]
+[languages.en]
+weight = 1
+[languages.de]
+weight = 2
+[languages.de-ch]
+weight = 3
+fallbacks = ["de"]
+-- content/p1.md --
+---
+title: "P1 en"
+---
+-- content/p1.de.md --
+---
+title: "P1 de"
+---
+-- content/p1.de-ch.md --
+---
+title: "P1 de-ch"
+---
+-- content/p2.md --
+---
+title: "P2 en"
+---
+-- content/p2.de.md --
+---
+title: "P2 de"
+---
+-- content/p3.md --
+---
+title: "P3 en"
+---
+-- content/docs/_index.de.md --
+---
+title: "Docs de"
+---
+-- content/docs/d1.de.md --
+---
+title: "D1 de"
+---
+-- i18n/en.toml --
+[hello]
+other = "Hello"
+[bye]
+other = "Bye"
+[thanks]
+other = "Thanks"
+-- i18n/de.toml --
+[hello]
+other = "Hallo"
+[bye]
+other = "Tschüss"
+-- i18n/de-ch.toml --
+[hello]
+other = "Grüezi"
+-- layouts/_default/single.html --
+{{ .Title }}|{{ .Language.Lang }}|{{ .RelPermalink }}|Translations: {{ range .Translations }}{{ .Language.Lang }}:{{ .Title }}|{{ end }}
+-- layouts/_default/list.html --
+{{ .Title }}|{{ .Language.Lang }}|{{ range .Pages }}{{ .Title }}|{{ end }}
+-- layouts/index.html --
+i18n: {{ i18n "hello" }}|{{ i18n "bye" }}|{{ i18n "thanks" }}
+GetPage: {{ with site.GetPage "p3" }}{{ .Title }}|{{ .Language.Lang }}{{ end }}
+Pages: {{ range site.RegularPages }}{{ .Title }}|{{ end }}
+`
+
+	b := NewIntegrationTestBuilder(
+		IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+		},
+	).Build()
+
+	b.AssertFileContent("public/de-ch/index.html",
+		"i18n: Grüezi|Tschüss|Thanks",
+		"GetPage: P3 en|de-ch",
+		"Pages: D1 de|P1 de-ch|P2 de|P3 en|",
+	)
+	b.AssertFileContent("public/de-ch/p2/index.html", "P2 de|de-ch|/de-ch/p2/|Translations: en:P2 en|de:P2 de|")
+	b.AssertFileContent("public/de-ch/p1/index.html", "P1 de-ch|de-ch|/de-ch/p1/|")
+	b.AssertFileContent("public/de-ch/docs/index.html", "Docs de|de-ch|D1 de|")
+	b.AssertFileContent("public/de/index.html", "i18n: Hallo|Tschüss|Thanks", "Pages: D1 de|P1 de|P2 de|")
+	b.AssertFileContent("public/p2/index.html", "P2 en|en|/p2/|Translations: de:P2 de|de-ch:P2 de|")
+	b.AssertDestinationExists("public/de/p3/index.html", false)
+
+	b.Assert(b.H.Sites[2].Language().Fallbacks, qt.DeepEquals, []string{"de"})
+}
+
+func TestLanguageFallbacksContentDir(t *testing.T) {
+	t.Parallel()
+
+	files := `
+-- config.toml --
+baseURL = "https://example.org"
+defaultContentLanguage = "fr"
+defaultContentLanguageInSubdir = true
+disableKinds = ["taxonomy", "term", "RSS", "sitemap"]
+[languages]
+[languages.fr]
+contentDir = "content/fr"
+weight = 1
+[languages.fr-ca]
+contentDir = "content/fr-ca"
+fallbacks = ["fr"]
+weight = 2
+-- content/fr/p1.md --
+---
+title: "P1 fr"
+---
+-- content/fr/p2.md --
+---
+title: "P2 fr"
+---
+-- content/fr-ca/p1.md --
+---
+title: "P1 fr-ca"
+---
+-- layouts/_default/single.html --
+{{ .Title }}|{{ .Language.Lang }}|{{ .RelPermalink }}
+-- layouts/index.html --
+Pages: {{ range site.RegularPages }}{{ .Title }}|{{ end }}
+`
+
+	b := NewIntegrationTestBuilder(
+		IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+		},
+	).Build()
+
+	b.AssertFileContent("public/fr-ca/index.html", "Pages: P1 fr-ca|P2 fr|")
+	b.AssertFileContent("public/fr-ca/p2/index.html", "P2 fr|fr-ca|/fr-ca/p2/")
+}
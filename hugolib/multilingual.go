@@ -52,13 +52,19 @@ func getLanguages(cfg config.Provider) langs.Languages {
 }
 
 func newMultiLingualFromSites(cfg config.Provider, sites ...*Site) (*Multilingual, error) {
-	languages := make(langs.Languages, len(sites))
+	var languages langs.Languages
+	seen := make(map[string]bool)
 
-	for i, s := range sites {
+	for _, s := range sites {
 		if s.language == nil {
 			return nil, errors.New("missing language for site")
 		}
-		languages[i] = s.language
+		// There is one site per language and version.
+		if seen[s.language.Lang] {
+			continue
+		}
+		seen[s.language.Lang] = true
+		languages = append(languages, s.language)
 	}
 
 	defaultLang := cfg.GetString("defaultContentLanguage")
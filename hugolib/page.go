@@ -32,8 +32,6 @@ import (
 
 	"github.com/gohugoio/hugo/hugofs/files"
 
-	"github.com/bep/gitmap"
-
 	"github.com/gohugoio/hugo/helpers"
 
 	"github.com/gohugoio/hugo/common/herrors"
@@ -153,7 +151,7 @@ func (p *pageState) GetIdentity() identity.Identity {
 	return identity.NewPathIdentity(files.ComponentFolderContent, filepath.FromSlash(p.Pathc()))
 }
 
-func (p *pageState) GitInfo() *gitmap.GitInfo {
+func (p *pageState) GitInfo() *source.GitInfo {
 	return p.gitInfo
 }
 
@@ -387,6 +385,18 @@ func (p *pageState) Translations() page.Pages {
 	return p.translations
 }
 
+// AllVersions returns this Page in all versions, including the current Page.
+func (p *pageState) AllVersions() page.Pages {
+	p.s.h.init.translations.Do()
+	return p.allVersions
+}
+
+// Versions returns this Page in the other versions.
+func (p *pageState) Versions() page.Pages {
+	p.s.h.init.translations.Do()
+	return p.versions
+}
+
 func (ps *pageState) initCommonProviders(pp pagePaths) error {
 	if ps.IsPage() {
 		ps.posNextPrev = &nextPrev{init: ps.s.init.prevNext}
@@ -529,6 +539,17 @@ func (p *pageState) setTranslations(pages page.Pages) {
 	p.translations = translations
 }
 
+func (p *pageState) setVersions(pages page.Pages) {
+	p.allVersions = pages
+	versions := make(page.Pages, 0)
+	for _, v := range p.allVersions {
+		if !v.Eq(p) {
+			versions = append(versions, v)
+		}
+	}
+	p.versions = versions
+}
+
 func (p *pageState) AlternativeOutputFormats() page.OutputFormats {
 	f := p.outputFormat()
 	var o page.OutputFormats
@@ -679,7 +700,7 @@ Loop:
 
 			if withFrontMatter != nil {
 				if err := withFrontMatter(m); err != nil {
-					return err
+					return p.frontMatterSchemaError(err, it.Val(result.Input()), f, iter.LineNumber(result.Input())-1)
 				}
 			}
 
@@ -775,7 +796,7 @@ Loop:
 		// Page content without front matter. Assign default front matter from
 		// cascades etc.
 		if err := withFrontMatter(nil); err != nil {
-			return err
+			return p.frontMatterSchemaError(err, nil, "", 0)
 		}
 	}
 
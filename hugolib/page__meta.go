@@ -15,6 +15,7 @@ package hugolib
 
 import (
 	"fmt"
+	"os"
 	"path"
 	"path/filepath"
 	"regexp"
@@ -35,14 +36,17 @@ import (
 
 	"github.com/gohugoio/hugo/source"
 
+	"github.com/gohugoio/hugo/common/herrors"
 	"github.com/gohugoio/hugo/common/maps"
 	"github.com/gohugoio/hugo/config"
 	"github.com/gohugoio/hugo/helpers"
 
 	"github.com/gohugoio/hugo/output"
+	"github.com/gohugoio/hugo/parser/metadecoders"
 	"github.com/gohugoio/hugo/resources/page"
 	"github.com/gohugoio/hugo/resources/page/pagemeta"
 	"github.com/gohugoio/hugo/resources/resource"
+	"github.com/spf13/afero"
 	"github.com/spf13/cast"
 )
 
@@ -347,16 +351,72 @@ func (pm *pageMeta) mergeBucketCascades(b1, b2 *pagesMapBucket) {
 	}
 }
 
+// frontMatterSchema returns the front matter schema to validate a regular
+// content page against, nil if none.
+func (pm *pageMeta) frontMatterSchema(frontmatter map[string]any) (*pagemeta.Schema, error) {
+	if pm.kind != page.KindPage || pm.f == nil || pm.f.IsZero() || pm.s.h == nil {
+		return nil, nil
+	}
+
+	typ := cast.ToString(frontmatter["type"])
+	if typ == "" {
+		typ = pm.Section()
+	}
+	if typ == "" {
+		typ = defaultContentType
+	}
+
+	return pm.s.h.frontMatterSchema(typ)
+}
+
+// loadSidecar loads the dates from the sidecar file next to the content
+// file, e.g. mypage.dates.toml for mypage.md, or nil if not found.
+func (pm *pageMeta) loadSidecar() (map[string]any, error) {
+	if pm.f == nil || pm.f.IsZero() {
+		return nil, nil
+	}
+
+	filename := pm.f.Filename()
+	base := strings.TrimSuffix(filename, filepath.Ext(filename)) + pagemeta.SidecarSuffix
+
+	for _, format := range []metadecoders.Format{metadecoders.TOML, metadecoders.YAML, metadecoders.JSON} {
+		sidecarFilename := base + "." + string(format)
+		b, err := afero.ReadFile(pm.s.Fs.Source, sidecarFilename)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		m, err := metadecoders.Default.UnmarshalToMap(b, format)
+		if err != nil {
+			return nil, herrors.NewFileErrorFromName(err, sidecarFilename)
+		}
+		maps.PrepareParams(m)
+		return m, nil
+	}
+
+	return nil, nil
+}
+
 func (pm *pageMeta) setMetadata(parentBucket *pagesMapBucket, p *pageState, frontmatter map[string]any) error {
 	pm.params = make(maps.Params)
 
-	if frontmatter == nil && (parentBucket == nil || parentBucket.cascade == nil) {
+	if frontmatter != nil {
+		// Needed for case insensitive fetching of params values
+		maps.PrepareParams(frontmatter)
+	}
+
+	schema, err := pm.frontMatterSchema(frontmatter)
+	if err != nil {
+		return err
+	}
+
+	if frontmatter == nil && schema == nil && !pm.s.frontmatterHandler.UsesSidecar() && (parentBucket == nil || parentBucket.cascade == nil) {
 		return nil
 	}
 
 	if frontmatter != nil {
-		// Needed for case insensitive fetching of params values
-		maps.PrepareParams(frontmatter)
 		if p.bucket != nil {
 			// Check for any cascade define on itself.
 			if cv, found := frontmatter["cascade"]; found {
@@ -371,6 +431,13 @@ func (pm *pageMeta) setMetadata(parentBucket *pagesMapBucket, p *pageState, fron
 		frontmatter = make(map[string]any)
 	}
 
+	if schema != nil {
+		// Cascaded values are not checked.
+		if err := schema.CheckUnknownFields(frontmatter); err != nil {
+			return err
+		}
+	}
+
 	var cascade map[page.PageMatcher]maps.Params
 
 	if p.bucket != nil {
@@ -394,6 +461,21 @@ func (pm *pageMeta) setMetadata(parentBucket *pagesMapBucket, p *pageState, fron
 		}
 	}
 
+	// The location used to parse dates without time zone info.
+	location := langs.GetLocation(pm.s.Language())
+	if tz, found := frontmatter["timezone"]; found {
+		location, err = time.LoadLocation(cast.ToString(tz))
+		if err != nil {
+			return fmt.Errorf("failed to load location from timezone %q: %w", tz, err)
+		}
+	}
+
+	if schema != nil {
+		if err := schema.Apply(frontmatter, location); err != nil {
+			return err
+		}
+	}
+
 	var mtime time.Time
 	var contentBaseName string
 	if !p.File().IsZero() {
@@ -403,26 +485,39 @@ func (pm *pageMeta) setMetadata(parentBucket *pagesMapBucket, p *pageState, fron
 		}
 	}
 
-	var gitAuthorDate time.Time
+	var gitAuthorDate, gitCommitDate, gitFirstAuthorDate time.Time
 	if p.gitInfo != nil {
 		gitAuthorDate = p.gitInfo.AuthorDate
+		gitCommitDate = p.gitInfo.CommitDate
+		gitFirstAuthorDate = p.gitInfo.CreatedDate
+	}
+
+	var sidecar map[string]any
+	if pm.s.frontmatterHandler.UsesSidecar() {
+		sidecar, err = pm.loadSidecar()
+		if err != nil {
+			return err
+		}
 	}
 
 	descriptor := &pagemeta.FrontMatterDescriptor{
-		Frontmatter:   frontmatter,
-		Params:        pm.params,
-		Dates:         &pm.Dates,
-		PageURLs:      &pm.urlPaths,
-		BaseFilename:  contentBaseName,
-		ModTime:       mtime,
-		GitAuthorDate: gitAuthorDate,
-		Location:      langs.GetLocation(pm.s.Language()),
+		Frontmatter:        frontmatter,
+		Params:             pm.params,
+		Dates:              &pm.Dates,
+		PageURLs:           &pm.urlPaths,
+		BaseFilename:       contentBaseName,
+		ModTime:            mtime,
+		GitAuthorDate:      gitAuthorDate,
+		GitCommitDate:      gitCommitDate,
+		GitFirstAuthorDate: gitFirstAuthorDate,
+		Sidecar:            sidecar,
+		Location:           location,
 	}
 
 	// Handle the date separately
 	// TODO(bep) we need to "do more" in this area so this can be split up and
 	// more easily tested without the Page, but the coupling is strong.
-	err := pm.s.frontmatterHandler.HandleDates(descriptor)
+	err = pm.s.frontmatterHandler.HandleDates(descriptor)
 	if err != nil {
 		p.s.Log.Errorf("Failed to handle dates for page %q: %s", p.pathOrTitle(), err)
 	}
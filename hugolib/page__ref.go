@@ -54,18 +54,30 @@ func (p pageRef) decodeRefArgs(args map[string]any) (refArgs, *Site, error) {
 
 	s := p.p.s
 
-	if ra.Lang != "" && ra.Lang != p.p.s.Language().Lang {
+	lang, version := p.p.s.Lang(), p.p.s.PathSpec.VersionName()
+	if ra.Lang != "" {
+		lang = ra.Lang
+	}
+	if ra.Version != "" {
+		version = ra.Version
+	}
+
+	if lang != p.p.s.Lang() || version != p.p.s.PathSpec.VersionName() {
 		// Find correct site
 		found := false
 		for _, ss := range p.p.s.h.Sites {
-			if ss.Lang() == ra.Lang {
+			if ss.Lang() == lang && ss.PathSpec.VersionName() == version {
 				found = true
 				s = ss
 			}
 		}
 
 		if !found {
-			p.p.s.siteRefLinker.logNotFound(ra.Path, fmt.Sprintf("no site found with lang %q", ra.Lang), nil, text.Position{})
+			if ra.Version != "" {
+				p.p.s.siteRefLinker.logNotFound(ra.Path, fmt.Sprintf("no site found with lang %q and version %q", lang, version), nil, text.Position{})
+			} else {
+				p.p.s.siteRefLinker.logNotFound(ra.Path, fmt.Sprintf("no site found with lang %q", lang), nil, text.Position{})
+			}
 			return ra, nil, nil
 		}
 	}
@@ -110,5 +122,6 @@ func (p pageRef) relRef(argsm map[string]any, source any) (string, error) {
 type refArgs struct {
 	Path         string
 	Lang         string
+	Version      string
 	OutputFormat string
 }
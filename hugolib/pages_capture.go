@@ -31,6 +31,7 @@ import (
 
 	"github.com/gohugoio/hugo/common/loggers"
 	"github.com/gohugoio/hugo/hugofs"
+	"github.com/gohugoio/hugo/langs"
 	"github.com/spf13/afero"
 )
 
@@ -44,14 +45,24 @@ func newPagesCollector(
 	logger loggers.Logger,
 	contentTracker *contentChangeMap,
 	proc pagesCollectorProcessorProvider, filenames ...string) *pagesCollector {
+	var fallbackChains []languageFallbackChain
+	if languages, ok := sp.Cfg.Get("languagesSorted").(langs.Languages); ok {
+		for _, l := range languages {
+			if chain := langs.GetFallbackChain(l); chain != nil && !l.Disabled {
+				fallbackChains = append(fallbackChains, languageFallbackChain{lang: l.Lang, chain: chain})
+			}
+		}
+	}
+
 	return &pagesCollector{
-		fs:         sp.SourceFs,
-		contentMap: contentMap,
-		proc:       proc,
-		sp:         sp,
-		logger:     logger,
-		filenames:  filenames,
-		tracker:    contentTracker,
+		fs:             sp.SourceFs,
+		contentMap:     contentMap,
+		proc:           proc,
+		sp:             sp,
+		logger:         logger,
+		filenames:      filenames,
+		tracker:        contentTracker,
+		fallbackChains: fallbackChains,
 	}
 }
 
@@ -92,6 +103,14 @@ type pagesCollector struct {
 	tracker *contentChangeMap
 
 	proc pagesCollectorProcessorProvider
+
+	// The languages with fallbacks configured.
+	fallbackChains []languageFallbackChain
+}
+
+type languageFallbackChain struct {
+	lang  string
+	chain []string
 }
 
 // isCascadingEdit returns whether the dir represents a cascading edit.
@@ -226,25 +245,32 @@ func (c *pagesCollector) getLang(fi hugofs.FileMetaInfo) string {
 	return c.sp.DefaultContentLanguage
 }
 
+// getSiteKey returns the key of the site, by language and version, fi
+// belongs to.
+func (c *pagesCollector) getSiteKey(fi hugofs.FileMetaInfo) string {
+	return siteKey(c.getLang(fi), fi.Meta().Version)
+}
+
 func (c *pagesCollector) addToBundle(info hugofs.FileMetaInfo, btyp bundleDirType, bundles pageBundles) error {
-	getBundle := func(lang string) *fileinfoBundle {
-		return bundles[lang]
+	getBundle := func(key string) *fileinfoBundle {
+		return bundles[key]
 	}
 
-	cloneBundle := func(lang string) *fileinfoBundle {
+	cloneBundle := func(lang, version string) *fileinfoBundle {
 		// Every bundled content file needs a content file header.
 		// Use the default content language if found, else just
-		// pick one.
+		// pick one, preferably from the same version.
 		var (
 			source *fileinfoBundle
 			found  bool
 		)
 
-		source, found = bundles[c.sp.DefaultContentLanguage]
+		source, found = bundles[siteKey(c.sp.DefaultContentLanguage, version)]
 		if !found {
 			for _, b := range bundles {
-				source = b
-				break
+				if source == nil || b.header.Meta().Version == version {
+					source = b
+				}
 			}
 		}
 
@@ -254,6 +280,7 @@ func (c *pagesCollector) addToBundle(info hugofs.FileMetaInfo, btyp bundleDirTyp
 
 		clone := c.cloneFileInfo(source.header)
 		clone.Meta().Lang = lang
+		clone.Meta().Version = version
 
 		return &fileinfoBundle{
 			header: clone,
@@ -261,7 +288,9 @@ func (c *pagesCollector) addToBundle(info hugofs.FileMetaInfo, btyp bundleDirTyp
 	}
 
 	lang := c.getLang(info)
-	bundle := getBundle(lang)
+	version := info.Meta().Version
+	key := siteKey(lang, version)
+	bundle := getBundle(key)
 	isBundleHeader := c.isBundleHeader(info)
 	if bundle != nil && isBundleHeader {
 		// index.md file inside a bundle, see issue 6208.
@@ -273,7 +302,7 @@ func (c *pagesCollector) addToBundle(info hugofs.FileMetaInfo, btyp bundleDirTyp
 	if bundle == nil {
 		if isBundleHeader {
 			bundle = &fileinfoBundle{header: info}
-			bundles[lang] = bundle
+			bundles[key] = bundle
 		} else {
 			if btyp == bundleBranch {
 				// No special logic for branch bundles.
@@ -284,8 +313,8 @@ func (c *pagesCollector) addToBundle(info hugofs.FileMetaInfo, btyp bundleDirTyp
 			}
 
 			if isContent {
-				bundle = cloneBundle(lang)
-				bundles[lang] = bundle
+				bundle = cloneBundle(lang, version)
+				bundles[key] = bundle
 			}
 		}
 	}
@@ -297,12 +326,17 @@ func (c *pagesCollector) addToBundle(info hugofs.FileMetaInfo, btyp bundleDirTyp
 	if classifier == files.ContentClassFile {
 		translations := info.Meta().Translations
 
-		for lang, b := range bundles {
-			if !stringSliceContains(lang, translations...) && !b.containsResource(info.Name()) {
+		for _, b := range bundles {
+			bmeta := b.header.Meta()
+			if bmeta.Version != version {
+				// Resources are only shared between the languages of a version.
+				continue
+			}
+			if !stringSliceContains(bmeta.Lang, translations...) && !b.containsResource(info.Name()) {
 
 				// Clone and add it to the bundle.
 				clone := c.cloneFileInfo(info)
-				clone.Meta().Lang = lang
+				clone.Meta().Lang = bmeta.Lang
 				b.resources = append(b.resources, clone)
 			}
 		}
@@ -373,6 +407,10 @@ func (c *pagesCollector) collectDir(dirname string, partial bool, inFilter func(
 	preHook := func(dir hugofs.FileMetaInfo, path string, readdir []hugofs.FileMetaInfo) ([]hugofs.FileMetaInfo, error) {
 		var btype bundleDirType
 
+		// Note that this must be done before filtering, as we may be
+		// collecting a single file in a partial build.
+		translations := c.translationsInDir(readdir)
+
 		filtered := readdir[:0]
 		for _, fi := range readdir {
 			if filter(fi) {
@@ -402,7 +440,7 @@ func (c *pagesCollector) collectDir(dirname string, partial bool, inFilter func(
 			meta.IsRootFile = walkRoot
 			class := meta.Classifier
 			translationBase := meta.TranslationBaseNameWithExt
-			key := pth.Join(meta.Lang, translationBase)
+			key := pth.Join(meta.Version, meta.Lang, translationBase)
 
 			if seen[key] {
 				duplicates = append(duplicates, i)
@@ -441,6 +479,8 @@ func (c *pagesCollector) collectDir(dirname string, partial bool, inFilter func(
 			}
 		}
 
+		readdir = c.addFallbacks(readdir, translations)
+
 		err := handleDir(btype, dir, path, readdir)
 		if err != nil {
 			return nil, err
@@ -492,8 +532,67 @@ func (c *pagesCollector) collectDir(dirname string, partial bool, inFilter func(
 	return w.Walk()
 }
 
+// translationsInDir returns the site keys, see siteKey, of the files in
+// readdir by translation base name, e.g. "page.md".
+func (c *pagesCollector) translationsInDir(readdir []hugofs.FileMetaInfo) map[string]map[string]bool {
+	if len(c.fallbackChains) == 0 {
+		return nil
+	}
+
+	translations := make(map[string]map[string]bool)
+	for _, fi := range readdir {
+		meta := fi.Meta()
+		if fi.IsDir() || meta.SkipDir || c.sp.IgnoreFile(meta.Filename) {
+			continue
+		}
+		base := meta.TranslationBaseNameWithExt
+		if translations[base] == nil {
+			translations[base] = make(map[string]bool)
+		}
+		translations[base][c.getSiteKey(fi)] = true
+	}
+
+	return translations
+}
+
+// addFallbacks adds clones of the files in readdir to the languages with
+// fallbacks configured and no translation of their own, taken from the first
+// language in the fallback chain with a translation in the same version.
+func (c *pagesCollector) addFallbacks(readdir []hugofs.FileMetaInfo, translations map[string]map[string]bool) []hugofs.FileMetaInfo {
+	if len(c.fallbackChains) == 0 {
+		return readdir
+	}
+
+	var fallbacks []hugofs.FileMetaInfo
+	for _, fi := range readdir {
+		if fi.IsDir() {
+			continue
+		}
+		base := fi.Meta().TranslationBaseNameWithExt
+		lang := c.getLang(fi)
+		version := fi.Meta().Version
+		for _, fc := range c.fallbackChains {
+			if translations[base][siteKey(fc.lang, version)] {
+				continue
+			}
+			for _, fallback := range fc.chain {
+				if translations[base][siteKey(fallback, version)] {
+					if fallback == lang {
+						clone := c.cloneFileInfo(fi)
+						clone.Meta().Lang = fc.lang
+						fallbacks = append(fallbacks, clone)
+					}
+					break
+				}
+			}
+		}
+	}
+
+	return append(readdir, fallbacks...)
+}
+
 func (c *pagesCollector) handleBundleBranch(readdir []hugofs.FileMetaInfo) error {
-	// Maps bundles to its language.
+	// Maps bundles to its language and version.
 	bundles := pageBundles{}
 
 	var contentFiles []hugofs.FileMetaInfo
@@ -526,7 +625,7 @@ func (c *pagesCollector) handleBundleBranch(readdir []hugofs.FileMetaInfo) error
 }
 
 func (c *pagesCollector) handleBundleLeaf(dir hugofs.FileMetaInfo, path string, readdir []hugofs.FileMetaInfo) error {
-	// Maps bundles to its language.
+	// Maps bundles to its language and version.
 	bundles := pageBundles{}
 
 	walk := func(path string, info hugofs.FileMetaInfo, err error) error {
@@ -17,8 +17,10 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/gohugoio/hugo/config"
+	"github.com/gohugoio/hugo/resources/page/pagemeta"
 	"github.com/gohugoio/hugo/source"
 
 	"github.com/gohugoio/hugo/hugofs/files"
@@ -31,7 +33,7 @@ import (
 func newPagesProcessor(h *HugoSites, sp *source.SourceSpec) *pagesProcessor {
 	procs := make(map[string]pagesCollectorProcessorProvider)
 	for _, s := range h.Sites {
-		procs[s.Lang()] = &sitePagesProcessor{
+		procs[s.siteKey()] = &sitePagesProcessor{
 			m:                  s.pageMap,
 			errorSender:        s.h,
 			itemChan:           make(chan interface{}, config.GetNumWorkerMultiplier()*2),
@@ -50,7 +52,7 @@ type pagesCollectorProcessorProvider interface {
 }
 
 type pagesProcessor struct {
-	// Per language/Site
+	// Per Site, keyed by language and version, see siteKey.
 	procs map[string]pagesCollectorProcessorProvider
 }
 
@@ -89,7 +91,8 @@ func (proc *pagesProcessor) Wait() error {
 }
 
 func (proc *pagesProcessor) getProcFromFi(fi hugofs.FileMetaInfo) pagesCollectorProcessorProvider {
-	if p, found := proc.procs[fi.Meta().Lang]; found {
+	meta := fi.Meta()
+	if p, found := proc.procs[siteKey(meta.Lang, meta.Version)]; found {
 		return p
 	}
 	return defaultPageProcessor
@@ -192,6 +195,9 @@ func (p *sitePagesProcessor) doProcess(item any) error {
 				return err
 			}
 		case files.ContentClassFile:
+			if p.isSidecar(v) {
+				return nil
+			}
 			if err := p.copyFile(v); err != nil {
 				return err
 			}
@@ -204,6 +210,16 @@ func (p *sitePagesProcessor) doProcess(item any) error {
 	return nil
 }
 
+// isSidecar reports whether fim is a sidecar file with the dates of a
+// content file, e.g. mypage.dates.toml, which should not be published.
+func (p *sitePagesProcessor) isSidecar(fim hugofs.FileMetaInfo) bool {
+	if !p.m.s.frontmatterHandler.UsesSidecar() {
+		return false
+	}
+	name := fim.Meta().Name
+	return strings.HasSuffix(strings.TrimSuffix(name, filepath.Ext(name)), pagemeta.SidecarSuffix)
+}
+
 func (p *sitePagesProcessor) shouldSkip(fim hugofs.FileMetaInfo) bool {
 	// TODO(ep) unify
 	return p.m.s.SourceSpec.DisabledLanguages[fim.Meta().Lang]
@@ -15,6 +15,7 @@ package paths
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -76,6 +77,12 @@ type Paths struct {
 	DefaultContentLanguage         string
 	multilingual                   bool
 
+	// The content version of this site, if versions are configured.
+	Version                       *langs.Version
+	Versions                      langs.Versions
+	DefaultContentVersion         string
+	defaultContentVersionInSubdir bool
+
 	AllModules    modules.Modules
 	ModulesClient *modules.Client
 }
@@ -116,7 +123,11 @@ func New(fs *hugofs.Fs, cfg config.Provider) (*Paths, error) {
 		languagesDefaultFirst = l
 	}
 
-	//
+	versions := langs.GetVersions(cfg)
+	var version *langs.Version
+	if language != nil && language.Version != "" {
+		version = versions.Get(language.Version)
+	}
 
 	if len(languages) == 0 {
 		// We have some old tests that does not test the entire chain, hence
@@ -174,6 +185,11 @@ func New(fs *hugofs.Fs, cfg config.Provider) (*Paths, error) {
 		defaultContentLanguageInSubdir: cfg.GetBool("defaultContentLanguageInSubdir"),
 		DefaultContentLanguage:         defaultContentLanguage,
 
+		Version:                       version,
+		Versions:                      versions,
+		DefaultContentVersion:         cfg.GetString("defaultContentVersion"),
+		defaultContentVersionInSubdir: cfg.GetBool("defaultContentVersionInSubdir"),
+
 		Language:                 language,
 		Languages:                languages,
 		LanguagesDefaultFirst:    languagesDefaultFirst,
@@ -209,22 +225,49 @@ func (p *Paths) Lang() string {
 	return p.Language.Lang
 }
 
+// VersionName returns the name of the content version of this site, or an
+// empty string if versions are not configured.
+func (p *Paths) VersionName() string {
+	if p == nil || p.Version == nil {
+		return ""
+	}
+	return p.Version.Name
+}
+
 func (p *Paths) GetTargetLanguageBasePath() string {
 	if p.Languages.IsMultihost() {
 		// In a multihost configuration all assets will be published below the language code.
-		return p.Lang()
+		return path.Join(p.Lang(), p.GetVersionPrefix())
 	}
 	return p.GetLanguagePrefix()
 }
 
 func (p *Paths) GetURLLanguageBasePath() string {
 	if p.Languages.IsMultihost() {
-		return ""
+		return p.GetVersionPrefix()
 	}
 	return p.GetLanguagePrefix()
 }
 
+// GetLanguagePrefix returns the path prefix for the current language and
+// version, e.g. "v2/de".
 func (p *Paths) GetLanguagePrefix() string {
+	return path.Join(p.GetVersionPrefix(), p.getLanguagePrefix())
+}
+
+// GetVersionPrefix returns the path prefix for the current content version,
+// e.g. "v2", or an empty string if none is needed.
+func (p *Paths) GetVersionPrefix() string {
+	if p.Version == nil {
+		return ""
+	}
+	if p.Version.Name == p.DefaultContentVersion && !p.defaultContentVersionInSubdir {
+		return ""
+	}
+	return p.Version.Name
+}
+
+func (p *Paths) getLanguagePrefix() string {
 	if !p.multilingual {
 		return ""
 	}
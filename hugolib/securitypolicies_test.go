@@ -170,6 +170,135 @@ urls="none"
 			})
 	})
 
+	c.Run("resources.GetRemote, denied maxBytes", func(c *qt.C) {
+		c.Parallel()
+		httpTestVariant(c, `{{ $json := resources.GetRemote "%[1]s/fruits.json" }}{{ $json.Content }}`, `(?s).*/fruits.json" exceeded the size limit of 10 bytes in policy "security\.http\.maxBytes".*`,
+			func(b *sitesBuilder) {
+				b.WithConfigFile("toml", `
+[security]
+[security.http]
+maxBytes=10
+`)
+			})
+	})
+
+	c.Run("resources.GetRemote, denied for module", func(c *qt.C) {
+		c.Parallel()
+		cb := func(b *sitesBuilder) {
+			b.WithConfigFile("toml", `
+theme = "mytheme"
+[security]
+[security.funcs]
+[[security.funcs.modules]]
+path = "my*"
+deny = ['^resources\.GetRemote$', 'getJSON']
+`)
+			b.WithSourceFile("themes/mytheme/layouts/partials/p.html", `{{ $json := resources.GetRemote "https://example.org/fruits.json" }}`)
+			b.WithTemplatesAdded("index.html", `{{ partial "p.html" . }}`)
+		}
+		testVariant(c, cb, `(?s).*"resources.GetRemote" is denied for module "mytheme" in policy "security\.funcs\.modules".*`)
+	})
+
+	c.Run("getJSON alias, denied for module", func(c *qt.C) {
+		c.Parallel()
+		cb := func(b *sitesBuilder) {
+			b.WithConfigFile("toml", `
+theme = "mytheme"
+[security]
+[security.funcs]
+[[security.funcs.modules]]
+path = "my*"
+deny = 'data\.GetJSON'
+`)
+			b.WithSourceFile("themes/mytheme/layouts/partials/p.html", `{{ $json := getJSON "https://example.org/fruits.json" }}`)
+			b.WithTemplatesAdded("index.html", `{{ partial "p.html" . }}`)
+		}
+		testVariant(c, cb, `(?s).*"data.GetJSON" is denied for module "mytheme" in policy "security\.funcs\.modules".*`)
+	})
+
+	c.Run("Module funcs, project OK", func(c *qt.C) {
+		c.Parallel()
+		cb := func(b *sitesBuilder) {
+			b.WithConfigFile("toml", `
+theme = "mytheme"
+[security]
+[security.funcs]
+[[security.funcs.modules]]
+path = "**"
+deny = '^os\.'
+`)
+			b.WithSourceFile("themes/mytheme/layouts/partials/p.html", `{{ "theme" | upper }}`)
+			b.WithTemplatesAdded("index.html", `{{ partial "p.html" . }}|{{ os.Getenv "HUGO_FOO" }}`)
+		}
+		testVariant(c, cb, "")
+	})
+
+	c.Run("os.ReadFile, denied", func(c *qt.C) {
+		c.Parallel()
+		cb := func(b *sitesBuilder) {
+			b.WithConfigFile("toml", `
+[security]
+[security.files]
+read = ["data/**"]
+`)
+			b.WithTemplatesAdded("index.html", `{{ os.ReadFile "config.toml" }}`)
+		}
+		testVariant(c, cb, `(?s).*"config.toml" is not whitelisted in policy "security\.files\.read".*`)
+	})
+
+	c.Run("os.ReadDir, denied", func(c *qt.C) {
+		c.Parallel()
+		cb := func(b *sitesBuilder) {
+			b.WithConfigFile("toml", `
+[security]
+[security.files]
+read = "none"
+`)
+			b.WithTemplatesAdded("index.html", `{{ os.ReadDir "." }}`)
+		}
+		testVariant(c, cb, `(?s).*"\." is not whitelisted in policy "security\.files\.read".*`)
+	})
+
+	c.Run("resources.Get outside mounts, default", func(c *qt.C) {
+		c.Parallel()
+		b := newTestSitesBuilder(c).WithWorkingDir("/mywork").WithConfigFile("toml", `
+workingDir = "/mywork"
+`)
+		b.WithSourceFile(".env", "SECRET=1", "notes/notes.txt", "Notes.")
+		b.WithTemplatesAdded("index.html", `{{ with resources.Get ".env" }}{{ .Content }}{{ end }}|{{ with resources.Get "notes/notes.txt" }}{{ .Content }}{{ end }}|{{ with resources.Get "config.toml" }}{{ .Content }}{{ end }}|`)
+		b.Build(BuildCfg{})
+		b.AssertFileContent("public/index.html", "|||")
+	})
+
+	c.Run("resources.Get outside mounts, denied", func(c *qt.C) {
+		c.Parallel()
+		cb := func(b *sitesBuilder) {
+			b.WithWorkingDir("/mywork").WithConfigFile("toml", `
+workingDir = "/mywork"
+[security]
+[security.files]
+read = ["data/**"]
+`)
+			b.WithSourceFile("secret/notes.txt", "Secret.")
+			b.WithTemplatesAdded("index.html", `{{ with resources.Get "secret/notes.txt" }}{{ .Content }}{{ end }}`)
+		}
+		testVariant(c, cb, `(?s).*"secret/notes.txt" is not whitelisted in policy "security\.files\.read".*`)
+	})
+
+	c.Run("resources.Get outside mounts, OK", func(c *qt.C) {
+		c.Parallel()
+		b := newTestSitesBuilder(c).WithWorkingDir("/mywork").WithConfigFile("toml", `
+workingDir = "/mywork"
+[security]
+[security.files]
+read = ["data/**", "notes/**"]
+`)
+		b.WithSourceFile("notes/notes.txt", "Notes.")
+		b.WithTemplatesAdded("index.html", `{{ with resources.Get "notes/notes.txt" }}{{ .Content }}{{ end }}|{{ resources.Get "notes/missing.txt" }}|`)
+		b.Build(BuildCfg{})
+		b.AssertFileContent("public/index.html", "Notes.||")
+	})
+
 	c.Run("getJSON, OK", func(c *qt.C) {
 		c.Parallel()
 		httpTestVariant(c, `{{ $json := getJSON "%[1]s/fruits.json" }}{{ $json.Content }}`, "", nil)
@@ -187,6 +316,18 @@ urls="none"
 			})
 	})
 
+	c.Run("getJSON, denied maxBytes", func(c *qt.C) {
+		c.Parallel()
+		httpTestVariant(c, `{{ $json := getJSON "%[1]s/fruits.json" }}{{ $json.Content }}`, `(?s).*exceeded the size limit of 10 bytes in policy "security\.http\.maxBytes".*`,
+			func(b *sitesBuilder) {
+				b.WithConfigFile("toml", `
+[security]
+[security.http]
+maxBytes=10
+`)
+			})
+	})
+
 	c.Run("getCSV, denied URL", func(c *qt.C) {
 		c.Parallel()
 		httpTestVariant(c, `{{ $d := getCSV ";" "%[1]s/cities.csv" }}{{ $d.Content }}`, `(?s).*is not whitelisted in policy "security\.http\.urls".*`,
@@ -724,6 +724,23 @@ func (s *SiteInfo) Language() *langs.Language {
 	return s.language
 }
 
+// Version returns the content version of this site, or nil if no versions
+// are configured.
+func (s *SiteInfo) Version() *langs.Version {
+	return s.s.PathSpec.Version
+}
+
+// Versions returns all the enabled content versions, sorted by weight.
+func (s *SiteInfo) Versions() langs.Versions {
+	var versions langs.Versions
+	for _, v := range s.s.PathSpec.Versions {
+		if !v.Disabled {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
 func (s *SiteInfo) Config() SiteConfig {
 	return s.s.siteConfigConfig
 }
@@ -1285,6 +1302,7 @@ func (s *SiteInfo) HomeAbsURL() string {
 	if s.IsMultiLingual() {
 		base = s.Language().Lang
 	}
+	base = path.Join(s.s.getVersionPathPrefix(true), base)
 	return s.owner.AbsURL(base, false)
 }
 
@@ -1315,7 +1333,11 @@ func (s *Site) initializeSiteInfo() error {
 
 	languagePrefix := ""
 	if s.multilingualEnabled() && (defaultContentInSubDir || lang.Lang != defaultContentLanguage) {
-		languagePrefix = "/" + lang.Lang
+		languagePrefix = lang.Lang
+	}
+	languagePrefix = path.Join(s.PathSpec.GetVersionPrefix(), languagePrefix)
+	if languagePrefix != "" {
+		languagePrefix = "/" + languagePrefix
 	}
 
 	uglyURLs := func(p page.Page) bool {
@@ -1572,17 +1594,38 @@ func (s *Site) assembleMenus() {
 	}
 }
 
-// get any language code to prefix the target file path with.
+// siteKey returns the key identifying a Site by its language and version.
+func siteKey(lang, version string) string {
+	return path.Join(version, lang)
+}
+
+func (s *Site) siteKey() string {
+	return siteKey(s.Lang(), s.PathSpec.VersionName())
+}
+
+// get any language code and version to prefix the target file path with.
 func (s *Site) getLanguageTargetPathLang(alwaysInSubDir bool) string {
 	if s.h.IsMultihost() {
-		return s.Language().Lang
+		return path.Join(s.Language().Lang, s.getVersionPathPrefix(alwaysInSubDir))
 	}
 
 	return s.getLanguagePermalinkLang(alwaysInSubDir)
 }
 
-// get any lanaguagecode to prefix the relative permalink with.
+// get any version and lanaguagecode to prefix the relative permalink with.
 func (s *Site) getLanguagePermalinkLang(alwaysInSubDir bool) string {
+	return path.Join(s.getVersionPathPrefix(alwaysInSubDir), s.getLanguagePathPrefix(alwaysInSubDir))
+}
+
+// get any version name to prefix the target path and permalink with.
+func (s *Site) getVersionPathPrefix(alwaysInSubDir bool) string {
+	if alwaysInSubDir && len(s.Info.Versions()) > 1 {
+		return s.PathSpec.VersionName()
+	}
+	return s.PathSpec.GetVersionPrefix()
+}
+
+func (s *Site) getLanguagePathPrefix(alwaysInSubDir bool) string {
 	if !s.Info.IsMultiLingual() || s.h.IsMultihost() {
 		return ""
 	}
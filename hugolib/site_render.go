@@ -378,9 +378,13 @@ func (s *Site) renderAliases() error {
 }
 
 // renderMainLanguageRedirect creates a redirect to the main language home,
-// depending on if it lives in sub folder (e.g. /en) or not.
+// depending on if it lives in sub folder (e.g. /en) or not, and the same for
+// the default content version (e.g. /v2/en).
 func (s *Site) renderMainLanguageRedirect() error {
-	if !s.h.multilingual.enabled() || s.h.IsMultihost() {
+	multilingual := s.h.multilingual.enabled()
+	versioned := s.PathSpec.Version != nil
+
+	if (!multilingual && !versioned) || s.h.IsMultihost() {
 		// No need for a redirect
 		return nil
 	}
@@ -388,16 +392,37 @@ func (s *Site) renderMainLanguageRedirect() error {
 	html, found := s.outputFormatsConfig.GetByName("HTML")
 	if found {
 		mainLang := s.h.multilingual.DefaultLang
-		if s.Info.defaultContentLanguageInSubdir {
-			mainLangURL := s.PathSpec.AbsURL(mainLang.Lang+"/", false)
+
+		// The path to the main language home in the default version, and
+		// where it would live if always published in sub folders.
+		var mainPath, fullPath string
+		if versioned {
+			fullPath = s.PathSpec.DefaultContentVersion
+			if s.Cfg.GetBool("defaultContentVersionInSubdir") {
+				mainPath = fullPath
+			}
+		}
+		if multilingual {
+			fullPath = path.Join(fullPath, mainLang.Lang)
+			if s.Info.defaultContentLanguageInSubdir {
+				mainPath = path.Join(mainPath, mainLang.Lang)
+			}
+		}
+
+		var mainLangURL string
+		if mainPath != "" {
+			mainLangURL = s.PathSpec.AbsURL(mainPath+"/", false)
 			s.Log.Debugf("Write redirect to main language %s: %s", mainLang, mainLangURL)
 			if err := s.publishDestAlias(true, "/", mainLangURL, html, nil); err != nil {
 				return err
 			}
 		} else {
-			mainLangURL := s.PathSpec.AbsURL("", false)
+			mainLangURL = s.PathSpec.AbsURL("", false)
+		}
+
+		if fullPath != mainPath {
 			s.Log.Debugf("Write redirect to main language %s: %s", mainLang, mainLangURL)
-			if err := s.publishDestAlias(true, mainLang.Lang, mainLangURL, html, nil); err != nil {
+			if err := s.publishDestAlias(true, fullPath, mainLangURL, html, nil); err != nil {
 				return err
 			}
 		}
@@ -14,11 +14,14 @@
 package hugolib
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -1042,6 +1045,112 @@ Some text.
 	}
 }
 
+func TestCSPCollector(t *testing.T) {
+	for _, minify := range []bool{false, true} {
+		t.Run(fmt.Sprintf("minify-%t", minify), func(t *testing.T) {
+			statsFilename := "hugo_csp.json"
+			defer os.Remove(statsFilename)
+
+			b := newTestSitesBuilder(t)
+			b.WithConfigFile("toml", fmt.Sprintf(`
+baseURL = "https://example.org/"
+minify = %t
+disableKinds = ["taxonomy", "term", "section", "RSS", "sitemap", "404"]
+
+[build.csp]
+  injectMeta = true
+  writeHeaders = true
+[build.csp.directives]
+  default-src = ["'self'"]
+  object-src = ["'none'"]
+
+`, minify))
+
+			b.WithTemplates(
+				"index.html", `<!DOCTYPE html><html><head><title>Home</title><script>console.log("home")</script></head><body><img src="https://images.example.com/a.png"></body></html>`,
+				"_default/single.html", `<!DOCTYPE html><html><head><title>{{ .Title }}</title><script src="https://cdn.example.com/a.js"></script></head><body><a href="https://example.org/">Home</a></body></html>`,
+			)
+
+			b.WithContent("p1.md", "---\ntitle: P1\n---")
+
+			b.Build(BuildCfg{})
+
+			sum := sha256.Sum256([]byte(`console.log("home")`))
+			homeHash := "'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'"
+			homePolicy := fmt.Sprintf("default-src 'self'; img-src 'self' https://images.example.com; object-src 'none'; script-src 'self' %s", homeHash)
+			p1Policy := "default-src 'self'; object-src 'none'; script-src 'self' https://cdn.example.com"
+
+			var stats publisher.CSPStats
+			b.Assert(json.Unmarshal([]byte(b.FileContent(statsFilename)), &stats), qt.IsNil)
+			b.Assert(stats.Directives, qt.DeepEquals, publisher.CSPSources{
+				"img-src":    {"https://images.example.com"},
+				"script-src": {homeHash, "https://cdn.example.com"},
+			})
+			b.Assert(stats.Pages, qt.DeepEquals, map[string]string{
+				"/index.html":    homePolicy,
+				"/p1/index.html": p1Policy,
+			})
+
+			b.AssertFileContent("public/index.html", fmt.Sprintf(`<head><meta http-equiv="Content-Security-Policy" content="%s">`, homePolicy))
+			b.AssertFileContent("public/p1/index.html", fmt.Sprintf(`<meta http-equiv="Content-Security-Policy" content="%s">`, p1Policy))
+			b.AssertFileContent("public/_headers", fmt.Sprintf(`
+/
+  Content-Security-Policy: %s
+/p1/
+  Content-Security-Policy: %s
+`, homePolicy, p1Policy))
+		})
+	}
+}
+
+func TestCSPCollectorHighlight(t *testing.T) {
+	t.Parallel()
+
+	files := `
+-- config.toml --
+baseURL = "https://example.org/"
+disableKinds = ["taxonomy", "term", "section", "RSS", "sitemap", "404"]
+[markup.highlight]
+noClasses = true
+[build.csp]
+injectMeta = true
+-- content/p1.md --
+---
+title: P1
+---
+
+§§§go
+func main() {}
+§§§
+
+-- layouts/_default/single.html --
+<!DOCTYPE html><html><head><title>{{ .Title }}</title></head><body><button onclick="alert(1)">B</button>{{ .Content }}</body></html>
+`
+
+	b := NewIntegrationTestBuilder(
+		IntegrationTestConfig{
+			T:           t,
+			TxtarString: strings.ReplaceAll(files, "§§§", "```"),
+		},
+	).Build()
+
+	content := b.FileContent("public/p1/index.html")
+
+	hash := func(s string) string {
+		sum := sha256.Sum256([]byte(s))
+		return "'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'"
+	}
+
+	b.Assert(content, qt.Contains, "script-src 'self' 'unsafe-hashes' "+hash("alert(1)"))
+	b.Assert(content, qt.Contains, "style-src 'self' 'unsafe-hashes' ")
+
+	styles := regexp.MustCompile(`style="([^"]+)"`).FindAllStringSubmatch(content, -1)
+	b.Assert(len(styles) > 2, qt.IsTrue)
+	for _, m := range styles {
+		b.Assert(content, qt.Contains, hash(m[1]))
+	}
+}
+
 func TestClassCollectorStress(t *testing.T) {
 	statsFilename := "hugo_stats.json"
 	defer os.Remove(statsFilename)
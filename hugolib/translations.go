@@ -14,17 +14,35 @@
 package hugolib
 
 import (
+	"sort"
+
 	"github.com/gohugoio/hugo/resources/page"
 )
 
+// translationsKey returns the key grouping p with its translations, the pages
+// with the same translation key in the same version.
+func translationsKey(s *Site, p page.Page) string {
+	return s.PathSpec.VersionName() + "/" + p.TranslationKey()
+}
+
+// versionsKey returns the key grouping p with its versions, the pages with
+// the same translation key in the same language.
+func versionsKey(s *Site, p page.Page) string {
+	return s.Lang() + "/" + p.TranslationKey()
+}
+
 func pagesToTranslationsMap(sites []*Site) map[string]page.Pages {
+	return groupPagesBy(sites, translationsKey)
+}
+
+func groupPagesBy(sites []*Site, key func(s *Site, p page.Page) string) map[string]page.Pages {
 	out := make(map[string]page.Pages)
 
 	for _, s := range sites {
 		s.pageMap.pageTrees.Walk(func(ss string, n *contentNode) bool {
 			p := n.p
 			// TranslationKey is implemented for all page types.
-			base := p.TranslationKey()
+			base := key(s, p)
 
 			pageTranslations, found := out[base]
 			if !found {
@@ -45,7 +63,7 @@ func assignTranslationsToPages(allTranslations map[string]page.Pages, sites []*S
 	for _, s := range sites {
 		s.pageMap.pageTrees.Walk(func(ss string, n *contentNode) bool {
 			p := n.p
-			base := p.TranslationKey()
+			base := translationsKey(s, p)
 			translations, found := allTranslations[base]
 			if !found {
 				return false
@@ -55,3 +73,82 @@ func assignTranslationsToPages(allTranslations map[string]page.Pages, sites []*S
 		})
 	}
 }
+
+func assignVersionsToPages(sites []*Site) {
+	allVersions := groupPagesBy(sites, versionsKey)
+
+	weights := make(map[string]int)
+	for i, v := range sites[0].Info.Versions() {
+		weights[v.Name] = i
+	}
+	versionWeight := func(p page.Page) int {
+		if v := p.Site().Version(); v != nil {
+			return weights[v.Name]
+		}
+		return 0
+	}
+
+	for _, versions := range allVersions {
+		sort.SliceStable(versions, func(i, j int) bool {
+			return versionWeight(versions[i]) < versionWeight(versions[j])
+		})
+	}
+
+	for _, s := range sites {
+		s.pageMap.pageTrees.Walk(func(ss string, n *contentNode) bool {
+			p := n.p
+			versions, found := allVersions[versionsKey(s, p)]
+			if !found {
+				return false
+			}
+			p.setVersions(versions)
+			return false
+		})
+	}
+}
+
+// MissingTranslations returns, per language, the content pages in other
+// languages of the same version without a translation in that language. The page in the
+// default content language is preferred when more than one translation exists.
+func (h *HugoSites) MissingTranslations() map[string]page.Pages {
+	out := make(map[string]page.Pages)
+	defaultLang := h.Cfg.GetString("defaultContentLanguage")
+
+	for _, translations := range pagesToTranslationsMap(h.Sites) {
+		var (
+			source page.Page
+			langs  = make(map[string]bool)
+		)
+		for _, p := range translations {
+			if p.File().IsZero() {
+				continue
+			}
+			langs[p.Lang()] = true
+			if source == nil || p.Lang() == defaultLang {
+				source = p
+			}
+		}
+		if source == nil {
+			continue
+		}
+
+		for _, s := range h.Sites {
+			lang := s.Lang()
+			if s.Info.Version() != source.Site().Version() {
+				// Translations are only tracked within a version.
+				continue
+			}
+			if !langs[lang] {
+				out[lang] = append(out[lang], source)
+			}
+		}
+	}
+
+	for _, pages := range out {
+		sort.Slice(pages, func(i, j int) bool {
+			return pages[i].File().Path() < pages[j].File().Path()
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestMissingTranslations(t *testing.T) {
+	t.Parallel()
+
+	files := `
+-- config.toml --
+defaultContentLanguage = "en"
+disableKinds = ["taxonomy", "term", "RSS", "sitemap"]
+[languages]
+[languages.en]
+weight = 1
+[languages.nb]
+weight = 2
+[languages.sv]
+weight = 3
+contentDir = "content_sv"
+-- content/p1.md --
+---
+title: "P1"
+---
+-- content/p1.nb.md --
+---
+title: "P1 nb"
+---
+-- content/p2.nb.md --
+---
+title: "P2 nb"
+---
+-- content/s1/_index.md --
+---
+title: "S1"
+---
+-- content_sv/p1.md --
+---
+title: "P1 sv"
+---
+-- layouts/_default/single.html --
+{{ .Title }}
+-- layouts/_default/list.html --
+{{ .Title }}
+`
+
+	b := NewIntegrationTestBuilder(
+		IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+		},
+	).Build()
+
+	paths := func(lang string) []string {
+		var paths []string
+		for _, p := range b.H.MissingTranslations()[lang] {
+			paths = append(paths, p.Lang()+":"+p.File().Path())
+		}
+		return paths
+	}
+
+	b.Assert(paths("en"), qt.DeepEquals, []string{"nb:p2.nb.md"})
+	b.Assert(paths("nb"), qt.DeepEquals, []string{"en:s1/_index.md"})
+	b.Assert(paths("sv"), qt.DeepEquals, []string{"nb:p2.nb.md", "en:s1/_index.md"})
+}
@@ -0,0 +1,173 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestVersions(t *testing.T) {
+	t.Parallel()
+
+	files := `
+-- config.toml --
+baseURL = "https://example.org/"
+defaultContentLanguage = "en"
+disableKinds = ["taxonomy", "term", "RSS"]
+[languages]
+[languages.en]
+weight = 1
+[languages.de]
+weight = 2
+[versions]
+[versions.v1]
+title = "Version 1"
+weight = 2
+contentDir = "content/v1"
+[versions.v2]
+title = "Version 2"
+weight = 1
+contentDir = "content/v2"
+-- content/v1/docs/intro.md --
+---
+title: "Intro v1"
+---
+-- content/v1/docs/old.md --
+---
+title: "Old v1"
+---
+-- content/v2/docs/intro.md --
+---
+title: "Intro v2"
+---
+-- content/v2/docs/intro.de.md --
+---
+title: "Intro v2 de"
+---
+-- content/v2/docs/new.md --
+---
+title: "New v2"
+---
+-- layouts/_default/single.html --
+{{ .Title }}|{{ .Site.Version.Name }}|{{ .Permalink }}|Versions: {{ range .Versions }}{{ .Site.Version.Name }}:{{ .RelPermalink }}|{{ end }}Translations: {{ range .Translations }}{{ .RelPermalink }}|{{ end }}
+Ref: {{ ref . (dict "path" "docs/intro" "lang" "en" "version" "v1") }}
+-- layouts/_default/list.html --
+{{ .Title }}|{{ .Site.Version.Name }}|{{ range .Pages }}{{ .Title }}|{{ end }}
+-- layouts/index.html --
+Version: {{ site.Version.Name }}|{{ site.Version.Title }}|{{ site.Language.Lang }}
+Versions: {{ range site.Versions }}{{ .Name }}|{{ end }}
+GetPage: {{ with site.GetPage "docs/new" }}{{ .Title }}{{ else }}none{{ end }}|{{ with site.GetPage "docs/intro" }}{{ .Title }}{{ end }}
+Pages: {{ range site.RegularPages }}{{ .Title }}|{{ end }}
+`
+
+	b := NewIntegrationTestBuilder(
+		IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+		},
+	).Build()
+
+	b.AssertFileContent("public/index.html",
+		"Version: v2|Version 2|en",
+		"Versions: v2|v1|",
+		"GetPage: New v2|Intro v2",
+		"Pages: Intro v2|New v2|",
+	)
+	b.AssertFileContent("public/v1/index.html",
+		"Version: v1|Version 1|en",
+		"GetPage: none|Intro v1",
+		"Pages: Intro v1|Old v1|",
+	)
+	b.AssertFileContent("public/de/index.html", "Version: v2|Version 2|de", "Pages: Intro v2 de|")
+	b.AssertFileContent("public/v1/de/index.html", "Version: v1|Version 1|de", "GetPage: none|")
+
+	b.AssertFileContent("public/docs/intro/index.html",
+		"Intro v2|v2|https://example.org/docs/intro/|Versions: v1:/v1/docs/intro/|Translations: /de/docs/intro/|",
+		"Ref: https://example.org/v1/docs/intro/",
+	)
+	b.AssertFileContent("public/v1/docs/intro/index.html",
+		"Intro v1|v1|https://example.org/v1/docs/intro/|Versions: v2:/docs/intro/|Translations: \n",
+	)
+	b.AssertFileContent("public/de/docs/intro/index.html", "Intro v2 de|v2|https://example.org/de/docs/intro/|Versions: Translations: /docs/intro/|")
+	b.AssertFileContent("public/v1/docs/index.html", "Docs|v1|Intro v1|Old v1|")
+	b.AssertDestinationExists("public/v1/docs/new/index.html", false)
+	b.AssertDestinationExists("public/docs/old/index.html", false)
+
+	// One sitemap per language and version.
+	b.AssertFileContent("public/sitemap.xml",
+		"<loc>https://example.org/v2/en/sitemap.xml</loc>",
+		"<loc>https://example.org/v2/de/sitemap.xml</loc>",
+		"<loc>https://example.org/v1/en/sitemap.xml</loc>",
+		"<loc>https://example.org/v1/de/sitemap.xml</loc>",
+	)
+	b.AssertFileContent("public/v1/en/sitemap.xml", "<loc>https://example.org/v1/docs/intro/</loc>")
+	b.AssertFileContent("public/v2/en/sitemap.xml", "<loc>https://example.org/docs/new/</loc>")
+
+	b.Assert(len(b.H.Sites), qt.Equals, 4)
+	b.Assert(len(b.H.multilingual.Languages), qt.Equals, 2)
+}
+
+func TestVersionsDefaultInSubdir(t *testing.T) {
+	t.Parallel()
+
+	files := `
+-- config.toml --
+baseURL = "https://example.org/"
+defaultContentVersion = "v1"
+defaultContentVersionInSubdir = true
+disableKinds = ["taxonomy", "term", "RSS", "sitemap"]
+[versions]
+[versions.v1]
+[versions.v2]
+[versions.v3]
+disabled = true
+[module]
+[[module.mounts]]
+source = "docs/v1"
+target = "content"
+version = "v1"
+[[module.mounts]]
+source = "docs/v2"
+target = "content"
+version = "v2"
+-- docs/v1/p1.md --
+---
+title: "P1 v1"
+---
+-- docs/v2/p1.md --
+---
+title: "P1 v2"
+---
+-- layouts/_default/single.html --
+{{ .Title }}|{{ .RelPermalink }}|{{ range .AllVersions }}{{ .Site.Version.Name }}|{{ end }}
+-- layouts/index.html --
+Home: {{ site.Version.Name }}|{{ .RelPermalink }}|{{ range site.Versions }}{{ .Name }}|{{ end }}
+`
+
+	b := NewIntegrationTestBuilder(
+		IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+		},
+	).Build()
+
+	b.AssertFileContent("public/v1/index.html", "Home: v1|/v1/|v1|v2|")
+	b.AssertFileContent("public/v2/index.html", "Home: v2|/v2/|v1|v2|")
+	b.AssertFileContent("public/v1/p1/index.html", "P1 v1|/v1/p1/|v1|v2|")
+	b.AssertFileContent("public/v2/p1/index.html", "P1 v2|/v2/p1/|v1|v2|")
+	b.AssertFileContent("public/index.html", `<meta http-equiv="refresh" content="0; url=https://example.org/v1/">`)
+	b.AssertDestinationExists("public/v3/index.html", false)
+}
@@ -0,0 +1,260 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cldr provides locale-aware formatting of relative times, lists and
+// units based on data from the Unicode CLDR.
+package cldr
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gohugoio/locales"
+	translators "github.com/gohugoio/localescompressed"
+)
+
+// Regenerate data.go from the CLDR JSON data, see gen/main.go.
+//go:generate go run ./gen -version 44.1.0
+
+const (
+	// StyleLong is the long style, e.g. "3 kilometers" or "in 3 hours".
+	StyleLong = "long"
+
+	// StyleShort is the short style, e.g. "3 km" or "in 3 hr.".
+	StyleShort = "short"
+)
+
+const (
+	// ListAnd is the list type for conjunctions, e.g. "A, B, and C".
+	ListAnd = "and"
+
+	// ListOr is the list type for disjunctions, e.g. "A, B, or C".
+	ListOr = "or"
+)
+
+// The units supported by FormatRelativeTime, from largest to smallest.
+var relativeTimeUnits = []struct {
+	name string
+	d    time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"week", 7 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// Formatter formats relative times, lists and units for a locale.
+type Formatter struct {
+	translator locales.Translator
+	data       *localeData
+	hasData    bool
+}
+
+// New returns a new Formatter for the locale of translator.
+// Locales without CLDR data fall back to the base language, e.g. "de" for
+// "de_AT", and then to English, see HasData.
+func New(translator locales.Translator) *Formatter {
+	if translator == nil {
+		translator = translators.GetTranslator("en")
+	}
+	data, hasData := dataFor(translator.Locale())
+	return &Formatter{
+		translator: translator,
+		data:       data,
+		hasData:    hasData,
+	}
+}
+
+// dataFor returns the CLDR data for locale and whether it was found.
+// If not, the English data is returned.
+func dataFor(locale string) (*localeData, bool) {
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	for {
+		if d, found := localeDatas[locale]; found {
+			return d, true
+		}
+		i := strings.LastIndexByte(locale, '-')
+		if i == -1 {
+			return localeDatas["en"], false
+		}
+		locale = locale[:i]
+	}
+}
+
+// HasData reports whether there is CLDR data for the locale or its base
+// language. If not, relative times, lists and units are formatted in English.
+func (f *Formatter) HasData() bool {
+	return f.hasData
+}
+
+// Locale returns the locale of the CLDR data used, e.g. "en".
+func (f *Formatter) Locale() string {
+	return f.data.locale
+}
+
+// PluralCategory returns the CLDR cardinal plural category of n with v visible
+// fraction digits, e.g. "one" or "other".
+func (f *Formatter) PluralCategory(n float64, v uint64) string {
+	return pluralCategory(f.translator.CardinalPluralRule(n, v))
+}
+
+// OrdinalCategory returns the CLDR ordinal plural category of n, e.g. "one",
+// "two", "few" or "other" in English for 1st, 2nd, 3rd and 4th.
+func (f *Formatter) OrdinalCategory(n float64) string {
+	return pluralCategory(f.translator.OrdinalPluralRule(n, 0))
+}
+
+func pluralCategory(r locales.PluralRule) string {
+	if r == locales.PluralRuleUnknown {
+		return "other"
+	}
+	return strings.ToLower(r.String())
+}
+
+// RelativeTimeUnit returns d expressed in the largest unit that fits,
+// rounded to the nearest integer, e.g. -3, "day" for 3 days ago.
+func RelativeTimeUnit(d time.Duration) (float64, string) {
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+	for _, u := range relativeTimeUnits {
+		if abs >= u.d || u.name == "second" {
+			return math.Round(float64(d) / float64(u.d)), u.name
+		}
+	}
+	panic("unreachable")
+}
+
+// FormatRelativeTime formats value with v visible fraction digits in the given unit
+// relative to now, e.g. "3 days ago" for -3 days or "in 1 hour" for 1 hour.
+// Valid units are year, month, week, day, hour, minute and second, optionally
+// in plural. Style is one of long (default) and short.
+func (f *Formatter) FormatRelativeTime(value float64, v uint64, unit, style string) (string, error) {
+	unit = strings.TrimSuffix(strings.ToLower(unit), "s")
+	style, err := checkStyle(style, StyleLong)
+	if err != nil {
+		return "", err
+	}
+
+	var patterns relativeTimePatterns
+	found := false
+	if style == StyleShort {
+		patterns, found = f.data.relativeTimeShort[unit]
+	}
+	if !found {
+		patterns, found = f.data.relativeTime[unit]
+	}
+	if !found {
+		return "", fmt.Errorf("unsupported relative time unit %q", unit)
+	}
+
+	p := patterns.future
+	if value < 0 {
+		p = patterns.past
+	}
+
+	return f.formatPlural(p, math.Abs(value), v), nil
+}
+
+// FormatUnit formats value with v visible fraction digits in the given unit,
+// e.g. "3 km" or "3 kilometers".
+// Style is one of short (default) and long.
+func (f *Formatter) FormatUnit(value float64, v uint64, unit, style string) (string, error) {
+	unit = strings.ToLower(unit)
+	style, err := checkStyle(style, StyleShort)
+	if err != nil {
+		return "", err
+	}
+
+	var patterns pluralPatterns
+	found := false
+	if style == StyleShort {
+		if patterns, found = f.data.unitsShort[unit]; !found {
+			if s, ok := defaultShortUnits[unit]; ok {
+				patterns, found = pluralPatterns{"other": s}, true
+			}
+		}
+	}
+	if !found {
+		patterns, found = f.data.units[unit]
+	}
+	if !found {
+		return "", fmt.Errorf("unsupported unit %q", unit)
+	}
+
+	return f.formatPlural(patterns, value, v), nil
+}
+
+// FormatList joins items as a list of the given type, one of and (default) and
+// or, e.g. "A, B, and C".
+func (f *Formatter) FormatList(items []string, typ string) (string, error) {
+	if typ == "" {
+		typ = ListAnd
+	}
+	p, found := f.data.lists[typ]
+	if !found {
+		return "", fmt.Errorf("unsupported list type %q", typ)
+	}
+
+	switch len(items) {
+	case 0:
+		return "", nil
+	case 1:
+		return items[0], nil
+	case 2:
+		return format(p.two, items[0], items[1]), nil
+	}
+
+	n := len(items)
+	s := format(p.end, items[n-2], items[n-1])
+	for i := n - 3; i > 0; i-- {
+		s = format(p.middle, items[i], s)
+	}
+	return format(p.start, items[0], s), nil
+}
+
+func (f *Formatter) formatPlural(patterns pluralPatterns, value float64, v uint64) string {
+	p, found := patterns[f.PluralCategory(value, v)]
+	if !found {
+		p = patterns["other"]
+	}
+	return format(p, f.translator.FmtNumber(value, v))
+}
+
+func checkStyle(style, defaultStyle string) (string, error) {
+	switch style {
+	case "":
+		return defaultStyle, nil
+	case StyleLong, StyleShort:
+		return style, nil
+	case "narrow":
+		return StyleShort, nil
+	default:
+		return "", fmt.Errorf("unsupported style %q, must be one of long and short", style)
+	}
+}
+
+// format replaces the placeholders {0}, {1} etc. in pattern with args.
+func format(pattern string, args ...string) string {
+	oldnew := make([]string, 0, len(args)*2)
+	for i, arg := range args {
+		oldnew = append(oldnew, fmt.Sprintf("{%d}", i), arg)
+	}
+	return strings.NewReplacer(oldnew...).Replace(pattern)
+}
@@ -0,0 +1,182 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cldr
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	translators "github.com/gohugoio/localescompressed"
+)
+
+func TestLocale(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		lang    string
+		expect  string
+		hasData bool
+	}{
+		{"en", "en", true},
+		{"en-GB", "en", true},
+		{"de-AT", "de", true},
+		{"nb-NO", "nb", true},
+		{"nn", "en", false},
+		{"pt-BR", "pt", true},
+		{"fi", "en", false},
+	} {
+		f := New(translators.GetTranslator(test.lang))
+		c.Assert(f.Locale(), qt.Equals, test.expect, qt.Commentf(test.lang))
+		c.Assert(f.HasData(), qt.Equals, test.hasData, qt.Commentf(test.lang))
+	}
+
+	c.Assert(New(nil).Locale(), qt.Equals, "en")
+	c.Assert(New(nil).HasData(), qt.IsTrue)
+}
+
+func TestFormatRelativeTime(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		lang   string
+		value  float64
+		unit   string
+		style  string
+		expect string
+	}{
+		{"en", -3, "day", "", "3 days ago"},
+		{"en", -1, "days", "", "1 day ago"},
+		{"en", 1, "hour", "", "in 1 hour"},
+		{"en", 2, "hour", "short", "in 2 hr."},
+		{"en", 2, "day", "short", "in 2 days"},
+		{"en", 1500, "year", "", "in 1,500 years"},
+		{"de", -3, "day", "", "vor 3 Tagen"},
+		{"de", 1, "year", "", "in 1 Jahr"},
+		{"fr", -1, "month", "", "il y a 1 mois"},
+		{"nb", -2, "week", "long", "for 2 uker siden"},
+		{"ja", 5, "minute", "", "5 分後"},
+	} {
+		f := New(translators.GetTranslator(test.lang))
+		got, err := f.FormatRelativeTime(test.value, 0, test.unit, test.style)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, test.expect)
+	}
+
+	f := New(nil)
+	_, err := f.FormatRelativeTime(1, 0, "decade", "")
+	c.Assert(err, qt.ErrorMatches, `unsupported relative time unit "decade"`)
+	_, err = f.FormatRelativeTime(1, 0, "day", "tiny")
+	c.Assert(err, qt.ErrorMatches, `unsupported style "tiny".*`)
+}
+
+func TestRelativeTimeUnit(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		d     time.Duration
+		value float64
+		unit  string
+	}{
+		{0, 0, "second"},
+		{-30 * time.Second, -30, "second"},
+		{90 * time.Second, 2, "minute"},
+		{-3 * time.Hour, -3, "hour"},
+		{-50 * time.Hour, -2, "day"},
+		{15 * 24 * time.Hour, 2, "week"},
+		{-45 * 24 * time.Hour, -2, "month"},
+		{800 * 24 * time.Hour, 2, "year"},
+	} {
+		value, unit := RelativeTimeUnit(test.d)
+		c.Assert(value, qt.Equals, test.value, qt.Commentf(test.d.String()))
+		c.Assert(unit, qt.Equals, test.unit, qt.Commentf(test.d.String()))
+	}
+}
+
+func TestFormatList(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		lang   string
+		items  []string
+		typ    string
+		expect string
+	}{
+		{"en", nil, "", ""},
+		{"en", []string{"A"}, "", "A"},
+		{"en", []string{"A", "B"}, "", "A and B"},
+		{"en", []string{"A", "B", "C"}, "", "A, B, and C"},
+		{"en", []string{"A", "B", "C", "D"}, "or", "A, B, C, or D"},
+		{"de", []string{"A", "B", "C"}, "and", "A, B und C"},
+		{"zh", []string{"A", "B", "C"}, "", "A、B和C"},
+		{"en", []string{"{1}", "B"}, "", "{1} and B"},
+	} {
+		f := New(translators.GetTranslator(test.lang))
+		got, err := f.FormatList(test.items, test.typ)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, test.expect)
+	}
+
+	_, err := New(nil).FormatList([]string{"A"}, "xor")
+	c.Assert(err, qt.ErrorMatches, `unsupported list type "xor"`)
+}
+
+func TestFormatUnit(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		lang   string
+		value  float64
+		v      uint64
+		unit   string
+		style  string
+		expect string
+	}{
+		{"en", 5, 0, "kilometer", "", "5 km"},
+		{"en", 1, 0, "kilometer", "long", "1 kilometer"},
+		{"en", 1, 1, "kilometer", "long", "1.0 kilometers"},
+		{"en", 2, 0, "foot", "long", "2 feet"},
+		{"en", 3, 0, "hour", "short", "3 hr"},
+		{"en", 21.5, 1, "celsius", "", "21.5°C"},
+		{"de", 21.5, 1, "celsius", "", "21,5 °C"},
+		{"de", 2, 0, "day", "long", "2 Tage"},
+		{"fr", 1024, 0, "megabyte", "", "1\u202f024 Mo"},
+		{"ja", 3, 0, "celsius", "long", "摂氏 3 度"},
+	} {
+		f := New(translators.GetTranslator(test.lang))
+		got, err := f.FormatUnit(test.value, test.v, test.unit, test.style)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, test.expect)
+	}
+
+	_, err := New(nil).FormatUnit(1, 0, "parsec", "")
+	c.Assert(err, qt.ErrorMatches, `unsupported unit "parsec"`)
+}
+
+func TestPluralCategory(t *testing.T) {
+	c := qt.New(t)
+
+	en := New(translators.GetTranslator("en"))
+	c.Assert(en.PluralCategory(1, 0), qt.Equals, "one")
+	c.Assert(en.PluralCategory(1, 1), qt.Equals, "other")
+	c.Assert(en.PluralCategory(2, 0), qt.Equals, "other")
+	c.Assert(en.OrdinalCategory(1), qt.Equals, "one")
+	c.Assert(en.OrdinalCategory(2), qt.Equals, "two")
+	c.Assert(en.OrdinalCategory(3), qt.Equals, "few")
+	c.Assert(en.OrdinalCategory(11), qt.Equals, "other")
+
+	pl := New(translators.GetTranslator("pl"))
+	c.Assert(pl.PluralCategory(3, 0), qt.Equals, "few")
+	c.Assert(pl.PluralCategory(5, 0), qt.Equals, "many")
+}
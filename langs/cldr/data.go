@@ -0,0 +1,851 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cldr
+
+// The data below is derived from the Unicode CLDR (https://cldr.unicode.org/),
+// see https://www.unicode.org/license.html.
+
+// pluralPatterns maps CLDR plural categories to patterns with {0} as the
+// placeholder for the number. A missing category falls back to "other".
+type pluralPatterns map[string]string
+
+type relativeTimePatterns struct {
+	future pluralPatterns
+	past   pluralPatterns
+}
+
+// listPatterns are the patterns used to join two items, {0} and {1}, at the
+// start, in the middle and at the end of lists with three or more items, and
+// in lists with two items.
+type listPatterns struct {
+	start  string
+	middle string
+	end    string
+	two    string
+}
+
+type localeData struct {
+	locale string
+
+	relativeTime      map[string]relativeTimePatterns
+	relativeTimeShort map[string]relativeTimePatterns
+
+	lists map[string]listPatterns
+
+	units map[string]pluralPatterns
+
+	// Short units falling back to defaultShortUnits and then to units.
+	unitsShort map[string]pluralPatterns
+}
+
+func other(s string) pluralPatterns {
+	return pluralPatterns{"other": s}
+}
+
+func oneOther(one, other string) pluralPatterns {
+	return pluralPatterns{"one": one, "other": other}
+}
+
+func rel(future, past pluralPatterns) relativeTimePatterns {
+	return relativeTimePatterns{future: future, past: past}
+}
+
+// commaList returns list patterns for languages using commas with a
+// conjunction or disjunction before the last item, e.g. "{0} and {1}".
+func commaList(end string) listPatterns {
+	return listPatterns{start: "{0}, {1}", middle: "{0}, {1}", end: end, two: end}
+}
+
+// Short symbols shared by most languages.
+var defaultShortUnits = map[string]string{
+	"kilometer":          "{0} km",
+	"meter":              "{0} m",
+	"centimeter":         "{0} cm",
+	"millimeter":         "{0} mm",
+	"mile":               "{0} mi",
+	"foot":               "{0} ft",
+	"inch":               "{0} in",
+	"kilogram":           "{0} kg",
+	"gram":               "{0} g",
+	"pound":              "{0} lb",
+	"liter":              "{0} l",
+	"celsius":            "{0} °C",
+	"fahrenheit":         "{0} °F",
+	"kilometer-per-hour": "{0} km/h",
+	"mile-per-hour":      "{0} mph",
+	"kilobyte":           "{0} kB",
+	"megabyte":           "{0} MB",
+	"gigabyte":           "{0} GB",
+}
+
+var localeDatas = map[string]*localeData{
+	"en": {
+		locale: "en",
+		relativeTime: map[string]relativeTimePatterns{
+			"year":   rel(oneOther("in {0} year", "in {0} years"), oneOther("{0} year ago", "{0} years ago")),
+			"month":  rel(oneOther("in {0} month", "in {0} months"), oneOther("{0} month ago", "{0} months ago")),
+			"week":   rel(oneOther("in {0} week", "in {0} weeks"), oneOther("{0} week ago", "{0} weeks ago")),
+			"day":    rel(oneOther("in {0} day", "in {0} days"), oneOther("{0} day ago", "{0} days ago")),
+			"hour":   rel(oneOther("in {0} hour", "in {0} hours"), oneOther("{0} hour ago", "{0} hours ago")),
+			"minute": rel(oneOther("in {0} minute", "in {0} minutes"), oneOther("{0} minute ago", "{0} minutes ago")),
+			"second": rel(oneOther("in {0} second", "in {0} seconds"), oneOther("{0} second ago", "{0} seconds ago")),
+		},
+		relativeTimeShort: map[string]relativeTimePatterns{
+			"year":   rel(other("in {0} yr."), other("{0} yr. ago")),
+			"month":  rel(other("in {0} mo."), other("{0} mo. ago")),
+			"week":   rel(other("in {0} wk."), other("{0} wk. ago")),
+			"hour":   rel(other("in {0} hr."), other("{0} hr. ago")),
+			"minute": rel(other("in {0} min."), other("{0} min. ago")),
+			"second": rel(other("in {0} sec."), other("{0} sec. ago")),
+		},
+		lists: map[string]listPatterns{
+			ListAnd: {start: "{0}, {1}", middle: "{0}, {1}", end: "{0}, and {1}", two: "{0} and {1}"},
+			ListOr:  {start: "{0}, {1}", middle: "{0}, {1}", end: "{0}, or {1}", two: "{0} or {1}"},
+		},
+		units: map[string]pluralPatterns{
+			"kilometer":          oneOther("{0} kilometer", "{0} kilometers"),
+			"meter":              oneOther("{0} meter", "{0} meters"),
+			"centimeter":         oneOther("{0} centimeter", "{0} centimeters"),
+			"millimeter":         oneOther("{0} millimeter", "{0} millimeters"),
+			"mile":               oneOther("{0} mile", "{0} miles"),
+			"foot":               oneOther("{0} foot", "{0} feet"),
+			"inch":               oneOther("{0} inch", "{0} inches"),
+			"kilogram":           oneOther("{0} kilogram", "{0} kilograms"),
+			"gram":               oneOther("{0} gram", "{0} grams"),
+			"pound":              oneOther("{0} pound", "{0} pounds"),
+			"liter":              oneOther("{0} liter", "{0} liters"),
+			"celsius":            oneOther("{0} degree Celsius", "{0} degrees Celsius"),
+			"fahrenheit":         oneOther("{0} degree Fahrenheit", "{0} degrees Fahrenheit"),
+			"kilometer-per-hour": oneOther("{0} kilometer per hour", "{0} kilometers per hour"),
+			"mile-per-hour":      oneOther("{0} mile per hour", "{0} miles per hour"),
+			"byte":               oneOther("{0} byte", "{0} bytes"),
+			"kilobyte":           oneOther("{0} kilobyte", "{0} kilobytes"),
+			"megabyte":           oneOther("{0} megabyte", "{0} megabytes"),
+			"gigabyte":           oneOther("{0} gigabyte", "{0} gigabytes"),
+			"year":               oneOther("{0} year", "{0} years"),
+			"month":              oneOther("{0} month", "{0} months"),
+			"week":               oneOther("{0} week", "{0} weeks"),
+			"day":                oneOther("{0} day", "{0} days"),
+			"hour":               oneOther("{0} hour", "{0} hours"),
+			"minute":             oneOther("{0} minute", "{0} minutes"),
+			"second":             oneOther("{0} second", "{0} seconds"),
+		},
+		unitsShort: map[string]pluralPatterns{
+			"liter":      other("{0} L"),
+			"celsius":    other("{0}°C"),
+			"fahrenheit": other("{0}°F"),
+			"byte":       other("{0} byte"),
+			"year":       oneOther("{0} yr", "{0} yrs"),
+			"month":      oneOther("{0} mth", "{0} mths"),
+			"week":       oneOther("{0} wk", "{0} wks"),
+			"day":        oneOther("{0} day", "{0} days"),
+			"hour":       other("{0} hr"),
+			"minute":     other("{0} min"),
+			"second":     other("{0} sec"),
+		},
+	},
+	"de": {
+		locale: "de",
+		relativeTime: map[string]relativeTimePatterns{
+			"year":   rel(oneOther("in {0} Jahr", "in {0} Jahren"), oneOther("vor {0} Jahr", "vor {0} Jahren")),
+			"month":  rel(oneOther("in {0} Monat", "in {0} Monaten"), oneOther("vor {0} Monat", "vor {0} Monaten")),
+			"week":   rel(oneOther("in {0} Woche", "in {0} Wochen"), oneOther("vor {0} Woche", "vor {0} Wochen")),
+			"day":    rel(oneOther("in {0} Tag", "in {0} Tagen"), oneOther("vor {0} Tag", "vor {0} Tagen")),
+			"hour":   rel(oneOther("in {0} Stunde", "in {0} Stunden"), oneOther("vor {0} Stunde", "vor {0} Stunden")),
+			"minute": rel(oneOther("in {0} Minute", "in {0} Minuten"), oneOther("vor {0} Minute", "vor {0} Minuten")),
+			"second": rel(oneOther("in {0} Sekunde", "in {0} Sekunden"), oneOther("vor {0} Sekunde", "vor {0} Sekunden")),
+		},
+		relativeTimeShort: map[string]relativeTimePatterns{
+			"year":   rel(other("in {0} J."), other("vor {0} J.")),
+			"hour":   rel(other("in {0} Std."), other("vor {0} Std.")),
+			"minute": rel(other("in {0} Min."), other("vor {0} Min.")),
+			"second": rel(other("in {0} Sek."), other("vor {0} Sek.")),
+		},
+		lists: map[string]listPatterns{
+			ListAnd: commaList("{0} und {1}"),
+			ListOr:  commaList("{0} oder {1}"),
+		},
+		units: map[string]pluralPatterns{
+			"kilometer":          other("{0} Kilometer"),
+			"meter":              other("{0} Meter"),
+			"centimeter":         other("{0} Zentimeter"),
+			"millimeter":         other("{0} Millimeter"),
+			"mile":               oneOther("{0} Meile", "{0} Meilen"),
+			"foot":               other("{0} Fuß"),
+			"inch":               other("{0} Zoll"),
+			"kilogram":           other("{0} Kilogramm"),
+			"gram":               other("{0} Gramm"),
+			"pound":              other("{0} Pfund"),
+			"liter":              other("{0} Liter"),
+			"celsius":            other("{0} Grad Celsius"),
+			"fahrenheit":         other("{0} Grad Fahrenheit"),
+			"kilometer-per-hour": other("{0} Kilometer pro Stunde"),
+			"mile-per-hour":      oneOther("{0} Meile pro Stunde", "{0} Meilen pro Stunde"),
+			"byte":               other("{0} Byte"),
+			"kilobyte":           other("{0} Kilobyte"),
+			"megabyte":           other("{0} Megabyte"),
+			"gigabyte":           other("{0} Gigabyte"),
+			"year":               oneOther("{0} Jahr", "{0} Jahre"),
+			"month":              oneOther("{0} Monat", "{0} Monate"),
+			"week":               oneOther("{0} Woche", "{0} Wochen"),
+			"day":                oneOther("{0} Tag", "{0} Tage"),
+			"hour":               oneOther("{0} Stunde", "{0} Stunden"),
+			"minute":             oneOther("{0} Minute", "{0} Minuten"),
+			"second":             oneOther("{0} Sekunde", "{0} Sekunden"),
+		},
+		unitsShort: map[string]pluralPatterns{
+			"mile-per-hour": other("{0} mi/h"),
+			"byte":          other("{0} Byte"),
+			"year":          other("{0} J."),
+			"month":         other("{0} Mon."),
+			"week":          other("{0} Wo."),
+			"day":           other("{0} Tg."),
+			"hour":          other("{0} Std."),
+			"minute":        other("{0} Min."),
+			"second":        other("{0} Sek."),
+		},
+	},
+	"fr": {
+		locale: "fr",
+		relativeTime: map[string]relativeTimePatterns{
+			"year":   rel(oneOther("dans {0} an", "dans {0} ans"), oneOther("il y a {0} an", "il y a {0} ans")),
+			"month":  rel(other("dans {0} mois"), other("il y a {0} mois")),
+			"week":   rel(oneOther("dans {0} semaine", "dans {0} semaines"), oneOther("il y a {0} semaine", "il y a {0} semaines")),
+			"day":    rel(oneOther("dans {0} jour", "dans {0} jours"), oneOther("il y a {0} jour", "il y a {0} jours")),
+			"hour":   rel(oneOther("dans {0} heure", "dans {0} heures"), oneOther("il y a {0} heure", "il y a {0} heures")),
+			"minute": rel(oneOther("dans {0} minute", "dans {0} minutes"), oneOther("il y a {0} minute", "il y a {0} minutes")),
+			"second": rel(oneOther("dans {0} seconde", "dans {0} secondes"), oneOther("il y a {0} seconde", "il y a {0} secondes")),
+		},
+		relativeTimeShort: map[string]relativeTimePatterns{
+			"year":   rel(other("dans {0} a"), other("il y a {0} a")),
+			"month":  rel(other("dans {0} m."), other("il y a {0} m.")),
+			"week":   rel(other("dans {0} sem."), other("il y a {0} sem.")),
+			"day":    rel(other("dans {0} j"), other("il y a {0} j")),
+			"hour":   rel(other("dans {0} h"), other("il y a {0} h")),
+			"minute": rel(other("dans {0} min"), other("il y a {0} min")),
+			"second": rel(other("dans {0} s"), other("il y a {0} s")),
+		},
+		lists: map[string]listPatterns{
+			ListAnd: commaList("{0} et {1}"),
+			ListOr:  commaList("{0} ou {1}"),
+		},
+		units: map[string]pluralPatterns{
+			"kilometer":          oneOther("{0} kilomètre", "{0} kilomètres"),
+			"meter":              oneOther("{0} mètre", "{0} mètres"),
+			"centimeter":         oneOther("{0} centimètre", "{0} centimètres"),
+			"millimeter":         oneOther("{0} millimètre", "{0} millimètres"),
+			"mile":               oneOther("{0} mille", "{0} milles"),
+			"foot":               oneOther("{0} pied", "{0} pieds"),
+			"inch":               oneOther("{0} pouce", "{0} pouces"),
+			"kilogram":           oneOther("{0} kilogramme", "{0} kilogrammes"),
+			"gram":               oneOther("{0} gramme", "{0} grammes"),
+			"pound":              oneOther("{0} livre", "{0} livres"),
+			"liter":              oneOther("{0} litre", "{0} litres"),
+			"celsius":            oneOther("{0} degré Celsius", "{0} degrés Celsius"),
+			"fahrenheit":         oneOther("{0} degré Fahrenheit", "{0} degrés Fahrenheit"),
+			"kilometer-per-hour": oneOther("{0} kilomètre à l’heure", "{0} kilomètres à l’heure"),
+			"mile-per-hour":      oneOther("{0} mille à l’heure", "{0} milles à l’heure"),
+			"byte":               oneOther("{0} octet", "{0} octets"),
+			"kilobyte":           oneOther("{0} kilooctet", "{0} kilooctets"),
+			"megabyte":           oneOther("{0} mégaoctet", "{0} mégaoctets"),
+			"gigabyte":           oneOther("{0} gigaoctet", "{0} gigaoctets"),
+			"year":               oneOther("{0} an", "{0} ans"),
+			"month":              other("{0} mois"),
+			"week":               oneOther("{0} semaine", "{0} semaines"),
+			"day":                oneOther("{0} jour", "{0} jours"),
+			"hour":               oneOther("{0} heure", "{0} heures"),
+			"minute":             oneOther("{0} minute", "{0} minutes"),
+			"second":             oneOther("{0} seconde", "{0} secondes"),
+		},
+		unitsShort: map[string]pluralPatterns{
+			"foot":          other("{0} pi"),
+			"inch":          other("{0} po"),
+			"mile-per-hour": other("{0} mi/h"),
+			"byte":          other("{0} o"),
+			"kilobyte":      other("{0} ko"),
+			"megabyte":      other("{0} Mo"),
+			"gigabyte":      other("{0} Go"),
+			"year":          oneOther("{0} an", "{0} ans"),
+			"month":         other("{0} m."),
+			"week":          other("{0} sem."),
+			"day":           other("{0} j"),
+			"hour":          other("{0} h"),
+			"minute":        other("{0} min"),
+			"second":        other("{0} s"),
+		},
+	},
+	"es": {
+		locale: "es",
+		relativeTime: map[string]relativeTimePatterns{
+			"year":   rel(oneOther("dentro de {0} año", "dentro de {0} años"), oneOther("hace {0} año", "hace {0} años")),
+			"month":  rel(oneOther("dentro de {0} mes", "dentro de {0} meses"), oneOther("hace {0} mes", "hace {0} meses")),
+			"week":   rel(oneOther("dentro de {0} semana", "dentro de {0} semanas"), oneOther("hace {0} semana", "hace {0} semanas")),
+			"day":    rel(oneOther("dentro de {0} día", "dentro de {0} días"), oneOther("hace {0} día", "hace {0} días")),
+			"hour":   rel(oneOther("dentro de {0} hora", "dentro de {0} horas"), oneOther("hace {0} hora", "hace {0} horas")),
+			"minute": rel(oneOther("dentro de {0} minuto", "dentro de {0} minutos"), oneOther("hace {0} minuto", "hace {0} minutos")),
+			"second": rel(oneOther("dentro de {0} segundo", "dentro de {0} segundos"), oneOther("hace {0} segundo", "hace {0} segundos")),
+		},
+		relativeTimeShort: map[string]relativeTimePatterns{
+			"year":   rel(other("dentro de {0} a"), other("hace {0} a")),
+			"month":  rel(other("dentro de {0} m"), other("hace {0} m")),
+			"week":   rel(other("dentro de {0} sem."), other("hace {0} sem.")),
+			"day":    rel(other("dentro de {0} d"), other("hace {0} d")),
+			"hour":   rel(other("dentro de {0} h"), other("hace {0} h")),
+			"minute": rel(other("dentro de {0} min"), other("hace {0} min")),
+			"second": rel(other("dentro de {0} s"), other("hace {0} s")),
+		},
+		lists: map[string]listPatterns{
+			ListAnd: commaList("{0} y {1}"),
+			ListOr:  commaList("{0} o {1}"),
+		},
+		units: map[string]pluralPatterns{
+			"kilometer":          oneOther("{0} kilómetro", "{0} kilómetros"),
+			"meter":              oneOther("{0} metro", "{0} metros"),
+			"centimeter":         oneOther("{0} centímetro", "{0} centímetros"),
+			"millimeter":         oneOther("{0} milímetro", "{0} milímetros"),
+			"mile":               oneOther("{0} milla", "{0} millas"),
+			"foot":               oneOther("{0} pie", "{0} pies"),
+			"inch":               oneOther("{0} pulgada", "{0} pulgadas"),
+			"kilogram":           oneOther("{0} kilogramo", "{0} kilogramos"),
+			"gram":               oneOther("{0} gramo", "{0} gramos"),
+			"pound":              oneOther("{0} libra", "{0} libras"),
+			"liter":              oneOther("{0} litro", "{0} litros"),
+			"celsius":            oneOther("{0} grado Celsius", "{0} grados Celsius"),
+			"fahrenheit":         oneOther("{0} grado Fahrenheit", "{0} grados Fahrenheit"),
+			"kilometer-per-hour": oneOther("{0} kilómetro por hora", "{0} kilómetros por hora"),
+			"mile-per-hour":      oneOther("{0} milla por hora", "{0} millas por hora"),
+			"byte":               oneOther("{0} byte", "{0} bytes"),
+			"kilobyte":           oneOther("{0} kilobyte", "{0} kilobytes"),
+			"megabyte":           oneOther("{0} megabyte", "{0} megabytes"),
+			"gigabyte":           oneOther("{0} gigabyte", "{0} gigabytes"),
+			"year":               oneOther("{0} año", "{0} años"),
+			"month":              oneOther("{0} mes", "{0} meses"),
+			"week":               oneOther("{0} semana", "{0} semanas"),
+			"day":                oneOther("{0} día", "{0} días"),
+			"hour":               oneOther("{0} hora", "{0} horas"),
+			"minute":             oneOther("{0} minuto", "{0} minutos"),
+			"second":             oneOther("{0} segundo", "{0} segundos"),
+		},
+		unitsShort: map[string]pluralPatterns{
+			"mile-per-hour": other("{0} mi/h"),
+			"byte":          other("{0} B"),
+			"year":          other("{0} a"),
+			"month":         other("{0} m"),
+			"week":          other("{0} sem."),
+			"day":           other("{0} d"),
+			"hour":          other("{0} h"),
+			"minute":        other("{0} min"),
+			"second":        other("{0} s"),
+		},
+	},
+	"it": {
+		locale: "it",
+		relativeTime: map[string]relativeTimePatterns{
+			"year":   rel(oneOther("tra {0} anno", "tra {0} anni"), oneOther("{0} anno fa", "{0} anni fa")),
+			"month":  rel(oneOther("tra {0} mese", "tra {0} mesi"), oneOther("{0} mese fa", "{0} mesi fa")),
+			"week":   rel(oneOther("tra {0} settimana", "tra {0} settimane"), oneOther("{0} settimana fa", "{0} settimane fa")),
+			"day":    rel(oneOther("tra {0} giorno", "tra {0} giorni"), oneOther("{0} giorno fa", "{0} giorni fa")),
+			"hour":   rel(oneOther("tra {0} ora", "tra {0} ore"), oneOther("{0} ora fa", "{0} ore fa")),
+			"minute": rel(oneOther("tra {0} minuto", "tra {0} minuti"), oneOther("{0} minuto fa", "{0} minuti fa")),
+			"second": rel(oneOther("tra {0} secondo", "tra {0} secondi"), oneOther("{0} secondo fa", "{0} secondi fa")),
+		},
+		relativeTimeShort: map[string]relativeTimePatterns{
+			"year":   rel(other("tra {0} a"), other("{0} a fa")),
+			"month":  rel(other("tra {0} m"), other("{0} m fa")),
+			"week":   rel(other("tra {0} sett."), other("{0} sett. fa")),
+			"day":    rel(other("tra {0} g"), other("{0} g fa")),
+			"hour":   rel(other("tra {0} h"), other("{0} h fa")),
+			"minute": rel(other("tra {0} min"), other("{0} min fa")),
+			"second": rel(other("tra {0} s"), other("{0} s fa")),
+		},
+		lists: map[string]listPatterns{
+			ListAnd: commaList("{0} e {1}"),
+			ListOr:  commaList("{0} o {1}"),
+		},
+		units: map[string]pluralPatterns{
+			"kilometer":          oneOther("{0} chilometro", "{0} chilometri"),
+			"meter":              oneOther("{0} metro", "{0} metri"),
+			"centimeter":         oneOther("{0} centimetro", "{0} centimetri"),
+			"millimeter":         oneOther("{0} millimetro", "{0} millimetri"),
+			"mile":               oneOther("{0} miglio", "{0} miglia"),
+			"foot":               oneOther("{0} piede", "{0} piedi"),
+			"inch":               oneOther("{0} pollice", "{0} pollici"),
+			"kilogram":           oneOther("{0} chilogrammo", "{0} chilogrammi"),
+			"gram":               oneOther("{0} grammo", "{0} grammi"),
+			"pound":              oneOther("{0} libbra", "{0} libbre"),
+			"liter":              oneOther("{0} litro", "{0} litri"),
+			"celsius":            oneOther("{0} grado Celsius", "{0} gradi Celsius"),
+			"fahrenheit":         oneOther("{0} grado Fahrenheit", "{0} gradi Fahrenheit"),
+			"kilometer-per-hour": oneOther("{0} chilometro orario", "{0} chilometri orari"),
+			"mile-per-hour":      oneOther("{0} miglio orario", "{0} miglia orarie"),
+			"byte":               other("{0} byte"),
+			"kilobyte":           other("{0} kilobyte"),
+			"megabyte":           other("{0} megabyte"),
+			"gigabyte":           other("{0} gigabyte"),
+			"year":               oneOther("{0} anno", "{0} anni"),
+			"month":              oneOther("{0} mese", "{0} mesi"),
+			"week":               oneOther("{0} settimana", "{0} settimane"),
+			"day":                oneOther("{0} giorno", "{0} giorni"),
+			"hour":               oneOther("{0} ora", "{0} ore"),
+			"minute":             oneOther("{0} minuto", "{0} minuti"),
+			"second":             oneOther("{0} secondo", "{0} secondi"),
+		},
+		unitsShort: map[string]pluralPatterns{
+			"byte":   other("{0} byte"),
+			"year":   oneOther("{0} anno", "{0} anni"),
+			"month":  oneOther("{0} mese", "{0} mesi"),
+			"week":   other("{0} sett."),
+			"day":    other("{0} g"),
+			"hour":   other("{0} h"),
+			"minute": other("{0} min"),
+			"second": other("{0} s"),
+		},
+	},
+	"nl": {
+		locale: "nl",
+		relativeTime: map[string]relativeTimePatterns{
+			"year":   rel(other("over {0} jaar"), other("{0} jaar geleden")),
+			"month":  rel(oneOther("over {0} maand", "over {0} maanden"), oneOther("{0} maand geleden", "{0} maanden geleden")),
+			"week":   rel(oneOther("over {0} week", "over {0} weken"), oneOther("{0} week geleden", "{0} weken geleden")),
+			"day":    rel(oneOther("over {0} dag", "over {0} dagen"), oneOther("{0} dag geleden", "{0} dagen geleden")),
+			"hour":   rel(other("over {0} uur"), other("{0} uur geleden")),
+			"minute": rel(oneOther("over {0} minuut", "over {0} minuten"), oneOther("{0} minuut geleden", "{0} minuten geleden")),
+			"second": rel(oneOther("over {0} seconde", "over {0} seconden"), oneOther("{0} seconde geleden", "{0} seconden geleden")),
+		},
+		relativeTimeShort: map[string]relativeTimePatterns{
+			"year":   rel(other("over {0} jr."), other("{0} jr. geleden")),
+			"month":  rel(other("over {0} mnd."), other("{0} mnd. geleden")),
+			"week":   rel(other("over {0} wk."), other("{0} wk. geleden")),
+			"minute": rel(other("over {0} min."), other("{0} min. geleden")),
+			"second": rel(other("over {0} sec."), other("{0} sec. geleden")),
+		},
+		lists: map[string]listPatterns{
+			ListAnd: commaList("{0} en {1}"),
+			ListOr:  commaList("{0} of {1}"),
+		},
+		units: map[string]pluralPatterns{
+			"kilometer":          other("{0} kilometer"),
+			"meter":              other("{0} meter"),
+			"centimeter":         other("{0} centimeter"),
+			"millimeter":         other("{0} millimeter"),
+			"mile":               other("{0} mijl"),
+			"foot":               other("{0} voet"),
+			"inch":               other("{0} inch"),
+			"kilogram":           other("{0} kilogram"),
+			"gram":               other("{0} gram"),
+			"pound":              other("{0} pond"),
+			"liter":              other("{0} liter"),
+			"celsius":            oneOther("{0} graad Celsius", "{0} graden Celsius"),
+			"fahrenheit":         oneOther("{0} graad Fahrenheit", "{0} graden Fahrenheit"),
+			"kilometer-per-hour": other("{0} kilometer per uur"),
+			"mile-per-hour":      other("{0} mijl per uur"),
+			"byte":               other("{0} byte"),
+			"kilobyte":           other("{0} kilobyte"),
+			"megabyte":           other("{0} megabyte"),
+			"gigabyte":           other("{0} gigabyte"),
+			"year":               other("{0} jaar"),
+			"month":              oneOther("{0} maand", "{0} maanden"),
+			"week":               oneOther("{0} week", "{0} weken"),
+			"day":                oneOther("{0} dag", "{0} dagen"),
+			"hour":               other("{0} uur"),
+			"minute":             oneOther("{0} minuut", "{0} minuten"),
+			"second":             oneOther("{0} seconde", "{0} seconden"),
+		},
+		unitsShort: map[string]pluralPatterns{
+			"kilometer-per-hour": other("{0} km/u"),
+			"mile-per-hour":      other("{0} mi/u"),
+			"byte":               other("{0} byte"),
+			"year":               other("{0} jr"),
+			"month":              other("{0} mnd"),
+			"week":               other("{0} wk"),
+			"hour":               other("{0} uur"),
+			"minute":             other("{0} min"),
+			"second":             other("{0} sec"),
+		},
+	},
+	"pt": {
+		locale: "pt",
+		relativeTime: map[string]relativeTimePatterns{
+			"year":   rel(oneOther("em {0} ano", "em {0} anos"), oneOther("há {0} ano", "há {0} anos")),
+			"month":  rel(oneOther("em {0} mês", "em {0} meses"), oneOther("há {0} mês", "há {0} meses")),
+			"week":   rel(oneOther("em {0} semana", "em {0} semanas"), oneOther("há {0} semana", "há {0} semanas")),
+			"day":    rel(oneOther("em {0} dia", "em {0} dias"), oneOther("há {0} dia", "há {0} dias")),
+			"hour":   rel(oneOther("em {0} hora", "em {0} horas"), oneOther("há {0} hora", "há {0} horas")),
+			"minute": rel(oneOther("em {0} minuto", "em {0} minutos"), oneOther("há {0} minuto", "há {0} minutos")),
+			"second": rel(oneOther("em {0} segundo", "em {0} segundos"), oneOther("há {0} segundo", "há {0} segundos")),
+		},
+		relativeTimeShort: map[string]relativeTimePatterns{
+			"week":   rel(other("em {0} sem."), other("há {0} sem.")),
+			"hour":   rel(other("em {0} h"), other("há {0} h")),
+			"minute": rel(other("em {0} min."), other("há {0} min.")),
+			"second": rel(other("em {0} seg."), other("há {0} seg.")),
+		},
+		lists: map[string]listPatterns{
+			ListAnd: commaList("{0} e {1}"),
+			ListOr:  commaList("{0} ou {1}"),
+		},
+		units: map[string]pluralPatterns{
+			"kilometer":          oneOther("{0} quilômetro", "{0} quilômetros"),
+			"meter":              oneOther("{0} metro", "{0} metros"),
+			"centimeter":         oneOther("{0} centímetro", "{0} centímetros"),
+			"millimeter":         oneOther("{0} milímetro", "{0} milímetros"),
+			"mile":               oneOther("{0} milha", "{0} milhas"),
+			"foot":               oneOther("{0} pé", "{0} pés"),
+			"inch":               oneOther("{0} polegada", "{0} polegadas"),
+			"kilogram":           oneOther("{0} quilograma", "{0} quilogramas"),
+			"gram":               oneOther("{0} grama", "{0} gramas"),
+			"pound":              oneOther("{0} libra", "{0} libras"),
+			"liter":              oneOther("{0} litro", "{0} litros"),
+			"celsius":            oneOther("{0} grau Celsius", "{0} graus Celsius"),
+			"fahrenheit":         oneOther("{0} grau Fahrenheit", "{0} graus Fahrenheit"),
+			"kilometer-per-hour": oneOther("{0} quilômetro por hora", "{0} quilômetros por hora"),
+			"mile-per-hour":      oneOther("{0} milha por hora", "{0} milhas por hora"),
+			"byte":               oneOther("{0} byte", "{0} bytes"),
+			"kilobyte":           oneOther("{0} kilobyte", "{0} kilobytes"),
+			"megabyte":           oneOther("{0} megabyte", "{0} megabytes"),
+			"gigabyte":           oneOther("{0} gigabyte", "{0} gigabytes"),
+			"year":               oneOther("{0} ano", "{0} anos"),
+			"month":              oneOther("{0} mês", "{0} meses"),
+			"week":               oneOther("{0} semana", "{0} semanas"),
+			"day":                oneOther("{0} dia", "{0} dias"),
+			"hour":               oneOther("{0} hora", "{0} horas"),
+			"minute":             oneOther("{0} minuto", "{0} minutos"),
+			"second":             oneOther("{0} segundo", "{0} segundos"),
+		},
+		unitsShort: map[string]pluralPatterns{
+			"mile-per-hour": other("{0} mi/h"),
+			"byte":          other("{0} byte"),
+			"year":          oneOther("{0} ano", "{0} anos"),
+			"month":         oneOther("{0} mês", "{0} meses"),
+			"week":          other("{0} sem."),
+			"day":           oneOther("{0} dia", "{0} dias"),
+			"hour":          other("{0} h"),
+			"minute":        other("{0} min"),
+			"second":        other("{0} s"),
+		},
+	},
+	"nb": {
+		locale: "nb",
+		relativeTime: map[string]relativeTimePatterns{
+			"year":   rel(other("om {0} år"), other("for {0} år siden")),
+			"month":  rel(oneOther("om {0} måned", "om {0} måneder"), oneOther("for {0} måned siden", "for {0} måneder siden")),
+			"week":   rel(oneOther("om {0} uke", "om {0} uker"), oneOther("for {0} uke siden", "for {0} uker siden")),
+			"day":    rel(other("om {0} døgn"), other("for {0} døgn siden")),
+			"hour":   rel(oneOther("om {0} time", "om {0} timer"), oneOther("for {0} time siden", "for {0} timer siden")),
+			"minute": rel(oneOther("om {0} minutt", "om {0} minutter"), oneOther("for {0} minutt siden", "for {0} minutter siden")),
+			"second": rel(oneOther("om {0} sekund", "om {0} sekunder"), oneOther("for {0} sekund siden", "for {0} sekunder siden")),
+		},
+		relativeTimeShort: map[string]relativeTimePatterns{
+			"month":  rel(other("om {0} md."), other("for {0} md. siden")),
+			"week":   rel(other("om {0} u."), other("for {0} u. siden")),
+			"day":    rel(other("om {0} d."), other("for {0} d. siden")),
+			"hour":   rel(other("om {0} t"), other("for {0} t siden")),
+			"minute": rel(other("om {0} min"), other("for {0} min siden")),
+			"second": rel(other("om {0} sek"), other("for {0} sek siden")),
+		},
+		lists: map[string]listPatterns{
+			ListAnd: commaList("{0} og {1}"),
+			ListOr:  commaList("{0} eller {1}"),
+		},
+		units: map[string]pluralPatterns{
+			"kilometer":          other("{0} kilometer"),
+			"meter":              other("{0} meter"),
+			"centimeter":         other("{0} centimeter"),
+			"millimeter":         other("{0} millimeter"),
+			"mile":               oneOther("{0} engelsk mil", "{0} engelske mil"),
+			"foot":               other("{0} fot"),
+			"inch":               oneOther("{0} tomme", "{0} tommer"),
+			"kilogram":           other("{0} kilogram"),
+			"gram":               other("{0} gram"),
+			"pound":              other("{0} pund"),
+			"liter":              other("{0} liter"),
+			"celsius":            oneOther("{0} grad celsius", "{0} grader celsius"),
+			"fahrenheit":         oneOther("{0} grad fahrenheit", "{0} grader fahrenheit"),
+			"kilometer-per-hour": other("{0} kilometer per time"),
+			"mile-per-hour":      oneOther("{0} engelsk mil per time", "{0} engelske mil per time"),
+			"byte":               other("{0} byte"),
+			"kilobyte":           other("{0} kilobyte"),
+			"megabyte":           other("{0} megabyte"),
+			"gigabyte":           other("{0} gigabyte"),
+			"year":               other("{0} år"),
+			"month":              oneOther("{0} måned", "{0} måneder"),
+			"week":               oneOther("{0} uke", "{0} uker"),
+			"day":                other("{0} døgn"),
+			"hour":               oneOther("{0} time", "{0} timer"),
+			"minute":             oneOther("{0} minutt", "{0} minutter"),
+			"second":             oneOther("{0} sekund", "{0} sekunder"),
+		},
+		unitsShort: map[string]pluralPatterns{
+			"byte":   other("{0} B"),
+			"year":   other("{0} år"),
+			"month":  other("{0} md."),
+			"week":   other("{0} u."),
+			"day":    other("{0} d."),
+			"hour":   other("{0} t"),
+			"minute": other("{0} min"),
+			"second": other("{0} s"),
+		},
+	},
+	"sv": {
+		locale: "sv",
+		relativeTime: map[string]relativeTimePatterns{
+			"year":   rel(other("om {0} år"), other("för {0} år sedan")),
+			"month":  rel(oneOther("om {0} månad", "om {0} månader"), oneOther("för {0} månad sedan", "för {0} månader sedan")),
+			"week":   rel(oneOther("om {0} vecka", "om {0} veckor"), oneOther("för {0} vecka sedan", "för {0} veckor sedan")),
+			"day":    rel(oneOther("om {0} dag", "om {0} dagar"), oneOther("för {0} dag sedan", "för {0} dagar sedan")),
+			"hour":   rel(oneOther("om {0} timme", "om {0} timmar"), oneOther("för {0} timme sedan", "för {0} timmar sedan")),
+			"minute": rel(oneOther("om {0} minut", "om {0} minuter"), oneOther("för {0} minut sedan", "för {0} minuter sedan")),
+			"second": rel(oneOther("om {0} sekund", "om {0} sekunder"), oneOther("för {0} sekund sedan", "för {0} sekunder sedan")),
+		},
+		relativeTimeShort: map[string]relativeTimePatterns{
+			"month":  rel(other("om {0} mån."), other("för {0} mån. sedan")),
+			"week":   rel(other("om {0} v."), other("för {0} v. sedan")),
+			"day":    rel(other("om {0} d"), other("för {0} d sedan")),
+			"hour":   rel(other("om {0} tim"), other("för {0} tim sedan")),
+			"minute": rel(other("om {0} min"), other("för {0} min sedan")),
+			"second": rel(other("om {0} s"), other("för {0} s sedan")),
+		},
+		lists: map[string]listPatterns{
+			ListAnd: commaList("{0} och {1}"),
+			ListOr:  commaList("{0} eller {1}"),
+		},
+		units: map[string]pluralPatterns{
+			"kilometer":          other("{0} kilometer"),
+			"meter":              other("{0} meter"),
+			"centimeter":         other("{0} centimeter"),
+			"millimeter":         other("{0} millimeter"),
+			"mile":               oneOther("{0} engelsk mil", "{0} engelska mil"),
+			"foot":               other("{0} fot"),
+			"inch":               other("{0} tum"),
+			"kilogram":           other("{0} kilogram"),
+			"gram":               other("{0} gram"),
+			"pound":              other("{0} pund"),
+			"liter":              other("{0} liter"),
+			"celsius":            oneOther("{0} grad Celsius", "{0} grader Celsius"),
+			"fahrenheit":         oneOther("{0} grad Fahrenheit", "{0} grader Fahrenheit"),
+			"kilometer-per-hour": other("{0} kilometer per timme"),
+			"mile-per-hour":      oneOther("{0} engelsk mil per timme", "{0} engelska mil per timme"),
+			"byte":               other("{0} byte"),
+			"kilobyte":           other("{0} kilobyte"),
+			"megabyte":           other("{0} megabyte"),
+			"gigabyte":           other("{0} gigabyte"),
+			"year":               other("{0} år"),
+			"month":              oneOther("{0} månad", "{0} månader"),
+			"week":               oneOther("{0} vecka", "{0} veckor"),
+			"day":                other("{0} dygn"),
+			"hour":               oneOther("{0} timme", "{0} timmar"),
+			"minute":             oneOther("{0} minut", "{0} minuter"),
+			"second":             oneOther("{0} sekund", "{0} sekunder"),
+		},
+		unitsShort: map[string]pluralPatterns{
+			"byte":   other("{0} byte"),
+			"year":   other("{0} år"),
+			"month":  other("{0} mån"),
+			"week":   other("{0} v"),
+			"day":    other("{0} d"),
+			"hour":   other("{0} tim"),
+			"minute": other("{0} min"),
+			"second": other("{0} s"),
+		},
+	},
+	"da": {
+		locale: "da",
+		relativeTime: map[string]relativeTimePatterns{
+			"year":   rel(other("om {0} år"), other("for {0} år siden")),
+			"month":  rel(oneOther("om {0} måned", "om {0} måneder"), oneOther("for {0} måned siden", "for {0} måneder siden")),
+			"week":   rel(oneOther("om {0} uge", "om {0} uger"), oneOther("for {0} uge siden", "for {0} uger siden")),
+			"day":    rel(oneOther("om {0} dag", "om {0} dage"), oneOther("for {0} dag siden", "for {0} dage siden")),
+			"hour":   rel(oneOther("om {0} time", "om {0} timer"), oneOther("for {0} time siden", "for {0} timer siden")),
+			"minute": rel(oneOther("om {0} minut", "om {0} minutter"), oneOther("for {0} minut siden", "for {0} minutter siden")),
+			"second": rel(oneOther("om {0} sekund", "om {0} sekunder"), oneOther("for {0} sekund siden", "for {0} sekunder siden")),
+		},
+		relativeTimeShort: map[string]relativeTimePatterns{
+			"month":  rel(other("om {0} md."), other("for {0} md. siden")),
+			"hour":   rel(other("om {0} t."), other("for {0} t. siden")),
+			"minute": rel(other("om {0} min."), other("for {0} min. siden")),
+			"second": rel(other("om {0} sek."), other("for {0} sek. siden")),
+		},
+		lists: map[string]listPatterns{
+			ListAnd: commaList("{0} og {1}"),
+			ListOr:  commaList("{0} eller {1}"),
+		},
+		units: map[string]pluralPatterns{
+			"kilometer":          other("{0} kilometer"),
+			"meter":              other("{0} meter"),
+			"centimeter":         other("{0} centimeter"),
+			"millimeter":         other("{0} millimeter"),
+			"mile":               oneOther("{0} engelsk mil", "{0} engelske mil"),
+			"foot":               other("{0} fod"),
+			"inch":               oneOther("{0} tomme", "{0} tommer"),
+			"kilogram":           other("{0} kilogram"),
+			"gram":               other("{0} gram"),
+			"pound":              other("{0} pund"),
+			"liter":              other("{0} liter"),
+			"celsius":            oneOther("{0} grad celsius", "{0} grader celsius"),
+			"fahrenheit":         oneOther("{0} grad fahrenheit", "{0} grader fahrenheit"),
+			"kilometer-per-hour": other("{0} kilometer i timen"),
+			"mile-per-hour":      oneOther("{0} engelsk mil i timen", "{0} engelske mil i timen"),
+			"byte":               other("{0} byte"),
+			"kilobyte":           other("{0} kilobyte"),
+			"megabyte":           other("{0} megabyte"),
+			"gigabyte":           other("{0} gigabyte"),
+			"year":               other("{0} år"),
+			"month":              oneOther("{0} måned", "{0} måneder"),
+			"week":               oneOther("{0} uge", "{0} uger"),
+			"day":                oneOther("{0} dag", "{0} dage"),
+			"hour":               oneOther("{0} time", "{0} timer"),
+			"minute":             oneOther("{0} minut", "{0} minutter"),
+			"second":             oneOther("{0} sekund", "{0} sekunder"),
+		},
+		unitsShort: map[string]pluralPatterns{
+			"byte":   other("{0} byte"),
+			"year":   other("{0} år"),
+			"month":  other("{0} md."),
+			"week":   oneOther("{0} uge", "{0} uger"),
+			"day":    oneOther("{0} dag", "{0} dage"),
+			"hour":   other("{0} t"),
+			"minute": other("{0} min."),
+			"second": other("{0} sek."),
+		},
+	},
+	"ja": {
+		locale: "ja",
+		relativeTime: map[string]relativeTimePatterns{
+			"year":   rel(other("{0} 年後"), other("{0} 年前")),
+			"month":  rel(other("{0} か月後"), other("{0} か月前")),
+			"week":   rel(other("{0} 週間後"), other("{0} 週間前")),
+			"day":    rel(other("{0} 日後"), other("{0} 日前")),
+			"hour":   rel(other("{0} 時間後"), other("{0} 時間前")),
+			"minute": rel(other("{0} 分後"), other("{0} 分前")),
+			"second": rel(other("{0} 秒後"), other("{0} 秒前")),
+		},
+		lists: map[string]listPatterns{
+			ListAnd: {start: "{0}、{1}", middle: "{0}、{1}", end: "{0}、{1}", two: "{0}、{1}"},
+			ListOr:  {start: "{0}、{1}", middle: "{0}、{1}", end: "{0}、または{1}", two: "{0}または{1}"},
+		},
+		units: map[string]pluralPatterns{
+			"kilometer":          other("{0} キロメートル"),
+			"meter":              other("{0} メートル"),
+			"centimeter":         other("{0} センチメートル"),
+			"millimeter":         other("{0} ミリメートル"),
+			"mile":               other("{0} マイル"),
+			"foot":               other("{0} フィート"),
+			"inch":               other("{0} インチ"),
+			"kilogram":           other("{0} キログラム"),
+			"gram":               other("{0} グラム"),
+			"pound":              other("{0} ポンド"),
+			"liter":              other("{0} リットル"),
+			"celsius":            other("摂氏 {0} 度"),
+			"fahrenheit":         other("華氏 {0} 度"),
+			"kilometer-per-hour": other("時速 {0} キロメートル"),
+			"mile-per-hour":      other("時速 {0} マイル"),
+			"byte":               other("{0} バイト"),
+			"kilobyte":           other("{0} キロバイト"),
+			"megabyte":           other("{0} メガバイト"),
+			"gigabyte":           other("{0} ギガバイト"),
+			"year":               other("{0} 年"),
+			"month":              other("{0} か月"),
+			"week":               other("{0} 週間"),
+			"day":                other("{0} 日"),
+			"hour":               other("{0} 時間"),
+			"minute":             other("{0} 分"),
+			"second":             other("{0} 秒"),
+		},
+		unitsShort: map[string]pluralPatterns{
+			"liter":      other("{0} L"),
+			"celsius":    other("{0}°C"),
+			"fahrenheit": other("{0}°F"),
+			"byte":       other("{0} byte"),
+			"year":       other("{0} 年"),
+			"month":      other("{0} か月"),
+			"week":       other("{0} 週"),
+			"day":        other("{0} 日"),
+			"hour":       other("{0} 時間"),
+			"minute":     other("{0} 分"),
+			"second":     other("{0} 秒"),
+		},
+	},
+	"zh": {
+		locale: "zh",
+		relativeTime: map[string]relativeTimePatterns{
+			"year":   rel(other("{0}年后"), other("{0}年前")),
+			"month":  rel(other("{0}个月后"), other("{0}个月前")),
+			"week":   rel(other("{0}周后"), other("{0}周前")),
+			"day":    rel(other("{0}天后"), other("{0}天前")),
+			"hour":   rel(other("{0}小时后"), other("{0}小时前")),
+			"minute": rel(other("{0}分钟后"), other("{0}分钟前")),
+			"second": rel(other("{0}秒钟后"), other("{0}秒钟前")),
+		},
+		relativeTimeShort: map[string]relativeTimePatterns{
+			"second": rel(other("{0}秒后"), other("{0}秒前")),
+		},
+		lists: map[string]listPatterns{
+			ListAnd: {start: "{0}、{1}", middle: "{0}、{1}", end: "{0}和{1}", two: "{0}和{1}"},
+			ListOr:  {start: "{0}、{1}", middle: "{0}、{1}", end: "{0}或{1}", two: "{0}或{1}"},
+		},
+		units: map[string]pluralPatterns{
+			"kilometer":          other("{0}公里"),
+			"meter":              other("{0}米"),
+			"centimeter":         other("{0}厘米"),
+			"millimeter":         other("{0}毫米"),
+			"mile":               other("{0}英里"),
+			"foot":               other("{0}英尺"),
+			"inch":               other("{0}英寸"),
+			"kilogram":           other("{0}千克"),
+			"gram":               other("{0}克"),
+			"pound":              other("{0}磅"),
+			"liter":              other("{0}升"),
+			"celsius":            other("{0}摄氏度"),
+			"fahrenheit":         other("{0}华氏度"),
+			"kilometer-per-hour": other("每小时{0}公里"),
+			"mile-per-hour":      other("每小时{0}英里"),
+			"byte":               other("{0}字节"),
+			"kilobyte":           other("{0}千字节"),
+			"megabyte":           other("{0}兆字节"),
+			"gigabyte":           other("{0}吉字节"),
+			"year":               other("{0}年"),
+			"month":              other("{0}个月"),
+			"week":               other("{0}周"),
+			"day":                other("{0}天"),
+			"hour":               other("{0}小时"),
+			"minute":             other("{0}分钟"),
+			"second":             other("{0}秒钟"),
+		},
+		unitsShort: map[string]pluralPatterns{
+			"kilometer":          other("{0}公里"),
+			"meter":              other("{0}米"),
+			"centimeter":         other("{0}厘米"),
+			"millimeter":         other("{0}毫米"),
+			"mile":               other("{0}英里"),
+			"foot":               other("{0}英尺"),
+			"inch":               other("{0}英寸"),
+			"kilogram":           other("{0}公斤"),
+			"gram":               other("{0}克"),
+			"pound":              other("{0}磅"),
+			"liter":              other("{0}升"),
+			"celsius":            other("{0}°C"),
+			"fahrenheit":         other("{0}°F"),
+			"kilometer-per-hour": other("{0}公里/小时"),
+			"mile-per-hour":      other("{0}英里/小时"),
+			"byte":               other("{0} byte"),
+			"year":               other("{0}年"),
+			"month":              other("{0}个月"),
+			"week":               other("{0}周"),
+			"day":                other("{0}天"),
+			"hour":               other("{0}小时"),
+			"minute":             other("{0}分钟"),
+			"second":             other("{0}秒"),
+		},
+	},
+}
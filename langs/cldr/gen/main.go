@@ -0,0 +1,533 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command gen generates the CLDR data in langs/cldr/data.go from a release of
+// the CLDR JSON data, see https://github.com/unicode-org/cldr-json.
+//
+// It is run with go generate in langs/cldr, which downloads the release set
+// in the go:generate directive. Use -dir to read an extracted release instead.
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	translators "github.com/gohugoio/localescompressed"
+)
+
+const releaseURL = "https://github.com/unicode-org/cldr-json/releases/download/%[1]s/cldr-%[1]s-json-full.zip"
+
+func main() {
+	var (
+		version = flag.String("version", "", "the CLDR JSON release to download, e.g. 44.1.0")
+		dir     = flag.String("dir", "", "a directory with an extracted CLDR JSON release, used instead of downloading it")
+		out     = flag.String("out", "data.go", "the file to write")
+	)
+	flag.Parse()
+
+	fsys, err := openRelease(*dir, *version)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	b, err := generate(fsys, *version)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*out, b, 0666); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func openRelease(dir, version string) (fs.FS, error) {
+	if dir != "" {
+		return os.DirFS(dir), nil
+	}
+	if version == "" {
+		return nil, errors.New("either -version or -dir must be set")
+	}
+
+	u := fmt.Sprintf(releaseURL, version)
+	log.Printf("Downloading %s", u)
+	resp, err := http.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", u, resp.Status)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return zip.NewReader(bytes.NewReader(b), int64(len(b)))
+}
+
+// The CLDR JSON packages and files we read, per locale.
+const (
+	datesPackage = "cldr-dates-full"
+	miscPackage  = "cldr-misc-full"
+	unitsPackage = "cldr-units-full"
+
+	dateFieldsFile   = "dateFields.json"
+	listPatternsFile = "listPatterns.json"
+	unitsFile        = "units.json"
+)
+
+// The relative time units, see relativeTimeUnits in cldr.go.
+var relativeTimeUnits = []string{"year", "month", "week", "day", "hour", "minute", "second"}
+
+// The units supported by FormatUnit mapped to their CLDR keys.
+var units = []struct {
+	name string
+	key  string
+}{
+	{"kilometer", "length-kilometer"},
+	{"meter", "length-meter"},
+	{"centimeter", "length-centimeter"},
+	{"millimeter", "length-millimeter"},
+	{"mile", "length-mile"},
+	{"foot", "length-foot"},
+	{"inch", "length-inch"},
+	{"kilogram", "mass-kilogram"},
+	{"gram", "mass-gram"},
+	{"pound", "mass-pound"},
+	{"liter", "volume-liter"},
+	{"celsius", "temperature-celsius"},
+	{"fahrenheit", "temperature-fahrenheit"},
+	{"kilometer-per-hour", "speed-kilometer-per-hour"},
+	{"mile-per-hour", "speed-mile-per-hour"},
+	{"byte", "digital-byte"},
+	{"kilobyte", "digital-kilobyte"},
+	{"megabyte", "digital-megabyte"},
+	{"gigabyte", "digital-gigabyte"},
+	{"year", "duration-year"},
+	{"month", "duration-month"},
+	{"week", "duration-week"},
+	{"day", "duration-day"},
+	{"hour", "duration-hour"},
+	{"minute", "duration-minute"},
+	{"second", "duration-second"},
+}
+
+// The list types supported by FormatList mapped to their CLDR keys.
+var lists = []struct {
+	name string
+	key  string
+}{
+	{"ListAnd", "listPattern-type-standard"},
+	{"ListOr", "listPattern-type-or"},
+}
+
+// Short symbols shared by most languages, used when a locale has none.
+var defaultShortUnits = map[string]string{
+	"kilometer":          "{0} km",
+	"meter":              "{0} m",
+	"centimeter":         "{0} cm",
+	"millimeter":         "{0} mm",
+	"mile":               "{0} mi",
+	"foot":               "{0} ft",
+	"inch":               "{0} in",
+	"kilogram":           "{0} kg",
+	"gram":               "{0} g",
+	"pound":              "{0} lb",
+	"liter":              "{0} l",
+	"celsius":            "{0} °C",
+	"fahrenheit":         "{0} °F",
+	"kilometer-per-hour": "{0} km/h",
+	"mile-per-hour":      "{0} mph",
+	"kilobyte":           "{0} kB",
+	"megabyte":           "{0} MB",
+	"gigabyte":           "{0} GB",
+}
+
+var pluralCategories = []string{"zero", "one", "two", "few", "many", "other"}
+
+type pluralPatterns map[string]string
+
+type relativeTimePatterns struct {
+	future pluralPatterns
+	past   pluralPatterns
+}
+
+type listPatterns struct {
+	start  string
+	middle string
+	end    string
+	two    string
+}
+
+type localeData struct {
+	relativeTime      map[string]relativeTimePatterns
+	relativeTimeShort map[string]relativeTimePatterns
+	lists             map[string]listPatterns
+	units             map[string]pluralPatterns
+	unitsShort        map[string]pluralPatterns
+}
+
+// generate returns the Go source of data.go from the CLDR JSON release in fsys.
+// The version recorded is read from cldr-core/package.json, if found.
+func generate(fsys fs.FS, version string) ([]byte, error) {
+	// Locale => file name => path.
+	files := make(map[string]map[string]string)
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if strings.HasSuffix(p, "cldr-core/package.json") {
+			v, err := readVersion(fsys, p)
+			if err != nil {
+				return err
+			}
+			version = v
+			return nil
+		}
+		// E.g. cldr-json/cldr-dates-full/main/de-AT/dateFields.json.
+		parts := strings.Split(p, "/")
+		n := len(parts)
+		if n < 4 || parts[n-3] != "main" {
+			return nil
+		}
+		switch parts[n-4] + "/" + parts[n-1] {
+		case datesPackage + "/" + dateFieldsFile, miscPackage + "/" + listPatternsFile, unitsPackage + "/" + unitsFile:
+		default:
+			return nil
+		}
+		locale := parts[n-2]
+		if files[locale] == nil {
+			files[locale] = make(map[string]string)
+		}
+		files[locale][parts[n-1]] = p
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if version == "" {
+		return nil, errors.New("no CLDR version found")
+	}
+
+	var locales []string
+	for locale := range files {
+		if translators.GetTranslator(strings.ReplaceAll(locale, "-", "_")) == nil {
+			// Not supported by Hugo.
+			continue
+		}
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	datas := make(map[string]*localeData)
+	for _, locale := range locales {
+		d, err := readLocale(fsys, locale, files[locale])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", locale, err)
+		}
+		datas[locale] = d
+	}
+
+	if _, found := datas["en"]; !found {
+		return nil, errors.New("no data found for en")
+	}
+
+	// Skip the locales with the same data as their base language, e.g. de-AT,
+	// as Hugo falls back to that.
+	var keep []string
+	for _, locale := range locales {
+		if i := strings.LastIndexByte(locale, '-'); i != -1 {
+			if parent, found := datas[locale[:i]]; found && reflect.DeepEqual(parent, datas[locale]) {
+				continue
+			}
+		}
+		keep = append(keep, locale)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, header, version)
+	buf.WriteString("\n// Short symbols shared by most languages.\nvar defaultShortUnits = map[string]string{\n")
+	for _, u := range units {
+		if s, found := defaultShortUnits[u.name]; found {
+			fmt.Fprintf(&buf, "%q: %q,\n", u.name, s)
+		}
+	}
+	buf.WriteString("}\n")
+
+	fmt.Fprintf(&buf, "\n// cldrVersion is the version of the CLDR data below.\nconst cldrVersion = %q\n", version)
+
+	buf.WriteString("\nvar localeDatas = map[string]*localeData{\n")
+	for _, locale := range keep {
+		writeLocale(&buf, locale, datas[locale])
+	}
+	buf.WriteString("}\n")
+
+	b, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the generated source: %w", err)
+	}
+	return b, nil
+}
+
+func readVersion(fsys fs.FS, filename string) (string, error) {
+	var pkg struct {
+		Version string `json:"version"`
+	}
+	b, err := fs.ReadFile(fsys, filename)
+	if err != nil {
+		return "", err
+	}
+	if err := json.Unmarshal(b, &pkg); err != nil {
+		return "", fmt.Errorf("failed to decode %s: %w", filename, err)
+	}
+	return pkg.Version, nil
+}
+
+// readJSON decodes the JSON file filename in fsys and returns the value at
+// the given path below main/<locale>, nil if not found.
+func readJSON(fsys fs.FS, filename, locale string, keys ...string) (map[string]any, error) {
+	if filename == "" {
+		return nil, nil
+	}
+	b, err := fs.ReadFile(fsys, filename)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", filename, err)
+	}
+	return lookup(m, append([]string{"main", locale}, keys...)...), nil
+}
+
+func lookup(m map[string]any, keys ...string) map[string]any {
+	for _, k := range keys {
+		v, ok := m[k].(map[string]any)
+		if !ok {
+			return nil
+		}
+		m = v
+	}
+	return m
+}
+
+// plurals returns the patterns in m with keys on the form prefix + category,
+// e.g. "unitPattern-count-one".
+func plurals(m map[string]any, prefix string) pluralPatterns {
+	p := make(pluralPatterns)
+	for _, category := range pluralCategories {
+		if s, ok := m[prefix+category].(string); ok {
+			p[category] = s
+		}
+	}
+	if p["other"] == "" {
+		return nil
+	}
+	return p
+}
+
+func readLocale(fsys fs.FS, locale string, files map[string]string) (*localeData, error) {
+	d := &localeData{
+		relativeTime:      make(map[string]relativeTimePatterns),
+		relativeTimeShort: make(map[string]relativeTimePatterns),
+		lists:             make(map[string]listPatterns),
+		units:             make(map[string]pluralPatterns),
+		unitsShort:        make(map[string]pluralPatterns),
+	}
+
+	fields, err := readJSON(fsys, files[dateFieldsFile], locale, "dates", "fields")
+	if err != nil {
+		return nil, err
+	}
+	relativeTime := func(key string) (relativeTimePatterns, bool) {
+		m := lookup(fields, key)
+		future := plurals(lookup(m, "relativeTime-type-future"), "relativeTimePattern-count-")
+		past := plurals(lookup(m, "relativeTime-type-past"), "relativeTimePattern-count-")
+		return relativeTimePatterns{future: future, past: past}, future != nil && past != nil
+	}
+	for _, unit := range relativeTimeUnits {
+		long, found := relativeTime(unit)
+		if !found {
+			continue
+		}
+		d.relativeTime[unit] = long
+		if short, found := relativeTime(unit + "-short"); found && !reflect.DeepEqual(short, long) {
+			d.relativeTimeShort[unit] = short
+		}
+	}
+
+	patterns, err := readJSON(fsys, files[listPatternsFile], locale, "listPatterns")
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lists {
+		m := lookup(patterns, l.key)
+		p := listPatterns{}
+		p.start, _ = m["start"].(string)
+		p.middle, _ = m["middle"].(string)
+		p.end, _ = m["end"].(string)
+		p.two, _ = m["2"].(string)
+		if p.start != "" && p.middle != "" && p.end != "" && p.two != "" {
+			d.lists[l.name] = p
+		}
+	}
+
+	long, err := readJSON(fsys, files[unitsFile], locale, "units", "long")
+	if err != nil {
+		return nil, err
+	}
+	short, err := readJSON(fsys, files[unitsFile], locale, "units", "short")
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range units {
+		if p := plurals(lookup(long, u.key), "unitPattern-count-"); p != nil {
+			d.units[u.name] = p
+		}
+		if p := plurals(lookup(short, u.key), "unitPattern-count-"); p != nil {
+			if len(p) == 1 && p["other"] == defaultShortUnits[u.name] {
+				continue
+			}
+			d.unitsShort[u.name] = p
+		}
+	}
+
+	return d, nil
+}
+
+func writeLocale(w io.Writer, locale string, d *localeData) {
+	fmt.Fprintf(w, "%q: {\nlocale: %q,\n", strings.ToLower(locale), locale)
+
+	writeRelativeTime := func(field string, m map[string]relativeTimePatterns) {
+		if len(m) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "%s: map[string]relativeTimePatterns{\n", field)
+		for _, unit := range relativeTimeUnits {
+			if p, found := m[unit]; found {
+				fmt.Fprintf(w, "%q: {future: %s, past: %s},\n", unit, pluralsSource(p.future), pluralsSource(p.past))
+			}
+		}
+		w.Write([]byte("},\n"))
+	}
+	writeRelativeTime("relativeTime", d.relativeTime)
+	writeRelativeTime("relativeTimeShort", d.relativeTimeShort)
+
+	if len(d.lists) > 0 {
+		w.Write([]byte("lists: map[string]listPatterns{\n"))
+		for _, l := range lists {
+			if p, found := d.lists[l.name]; found {
+				fmt.Fprintf(w, "%s: {start: %q, middle: %q, end: %q, two: %q},\n", l.name, p.start, p.middle, p.end, p.two)
+			}
+		}
+		w.Write([]byte("},\n"))
+	}
+
+	writeUnits := func(field string, m map[string]pluralPatterns) {
+		if len(m) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "%s: map[string]pluralPatterns{\n", field)
+		for _, u := range units {
+			if p, found := m[u.name]; found {
+				fmt.Fprintf(w, "%q: %s,\n", u.name, pluralsSource(p))
+			}
+		}
+		w.Write([]byte("},\n"))
+	}
+	writeUnits("units", d.units)
+	writeUnits("unitsShort", d.unitsShort)
+
+	w.Write([]byte("},\n"))
+}
+
+func pluralsSource(p pluralPatterns) string {
+	var parts []string
+	for _, category := range pluralCategories {
+		if s, found := p[category]; found {
+			parts = append(parts, fmt.Sprintf("%q: %q", category, s))
+		}
+	}
+	return "pluralPatterns{" + strings.Join(parts, ", ") + "}"
+}
+
+const header = `// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file is autogenerated by gen/main.go from CLDR %s.
+
+package cldr
+
+// The data below is derived from the Unicode CLDR (https://cldr.unicode.org/),
+// see https://www.unicode.org/license.html.
+
+// pluralPatterns maps CLDR plural categories to patterns with {0} as the
+// placeholder for the number. A missing category falls back to "other".
+type pluralPatterns map[string]string
+
+type relativeTimePatterns struct {
+	future pluralPatterns
+	past   pluralPatterns
+}
+
+// listPatterns are the patterns used to join two items, {0} and {1}, at the
+// start, in the middle and at the end of lists with three or more items, and
+// in lists with two items.
+type listPatterns struct {
+	start  string
+	middle string
+	end    string
+	two    string
+}
+
+type localeData struct {
+	locale string
+
+	relativeTime      map[string]relativeTimePatterns
+	relativeTimeShort map[string]relativeTimePatterns
+
+	lists map[string]listPatterns
+
+	units map[string]pluralPatterns
+
+	// Short units falling back to defaultShortUnits and then to units.
+	unitsShort map[string]pluralPatterns
+}
+`
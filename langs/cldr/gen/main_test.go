@@ -0,0 +1,48 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestGenerate(t *testing.T) {
+	c := qt.New(t)
+
+	b, err := generate(os.DirFS("testdata"), "")
+	c.Assert(err, qt.IsNil)
+	s := string(b)
+
+	c.Assert(s, qt.Contains, "// This file is autogenerated by gen/main.go from CLDR 1.0.0.")
+	c.Assert(s, qt.Contains, `const cldrVersion = "1.0.0"`)
+
+	c.Assert(s, qt.Contains, `"year": {future: pluralPatterns{"one": "in {0} year", "other": "in {0} years"}, past: pluralPatterns{"one": "{0} year ago", "other": "{0} years ago"}},`)
+	// Short patterns equal to the long are skipped.
+	c.Assert(s, qt.Contains, "relativeTimeShort: map[string]relativeTimePatterns{\n\t\t\t\"year\": {future: pluralPatterns{\"other\": \"in {0} yr.\"}, past: pluralPatterns{\"other\": \"{0} yr. ago\"}},\n\t\t},")
+	c.Assert(s, qt.Contains, `ListAnd: {start: "{0}, {1}", middle: "{0}, {1}", end: "{0}, and {1}", two: "{0} and {1}"},`)
+	// Short units equal to defaultShortUnits are skipped.
+	c.Assert(s, qt.Contains, "unitsShort: map[string]pluralPatterns{\n\t\t\t\"day\": pluralPatterns{\"one\": \"{0} day\", \"other\": \"{0} days\"},\n\t\t},")
+
+	c.Assert(s, qt.Contains, `"day": {future: pluralPatterns{"one": "через {0} день", "few": "через {0} дня", "many": "через {0} дней", "other": "через {0} дня"}`)
+	c.Assert(s, qt.Contains, `"kilometer": pluralPatterns{"one": "{0} километр", "few": "{0} километра", "many": "{0} километров", "other": "{0} километра"},`)
+	c.Assert(s, qt.Contains, `"kilometer": pluralPatterns{"other": "{0} км"},`)
+
+	// The same as en.
+	c.Assert(s, qt.Not(qt.Contains), `"en-gb"`)
+	// Not supported by Hugo.
+	c.Assert(s, qt.Not(qt.Contains), `"xx"`)
+}
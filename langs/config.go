@@ -167,9 +167,67 @@ func LoadLanguageSettings(cfg config.Provider, oldLangs Languages) (c LanguagesC
 		}
 	}
 
+	if err := resolveFallbackChains(c.Languages, defaultLang); err != nil {
+		return c, err
+	}
+
+	if err := loadVersionSettings(cfg); err != nil {
+		return c, err
+	}
+
 	return c, nil
 }
 
+// resolveFallbackChains resolves the fallbacks of each language into a chain,
+// following the fallbacks of the fallbacks and ending with defaultLang.
+// Disabled languages are skipped.
+func resolveFallbackChains(languages Languages, defaultLang string) error {
+	byLang := make(map[string]*Language)
+	for _, l := range languages {
+		byLang[l.Lang] = l
+	}
+
+	for _, l := range languages {
+		if len(l.Fallbacks) == 0 {
+			continue
+		}
+
+		seen := map[string]bool{l.Lang: true}
+		var chain []string
+		var walk func(fallbacks []string) error
+		walk = func(fallbacks []string) error {
+			for _, fallback := range fallbacks {
+				fl, found := byLang[fallback]
+				if !found {
+					return fmt.Errorf("language %q: fallback language %q is not defined", l.Lang, fallback)
+				}
+				if seen[fallback] {
+					continue
+				}
+				seen[fallback] = true
+				if !fl.Disabled {
+					chain = append(chain, fallback)
+				}
+				if err := walk(fl.Fallbacks); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		if err := walk(l.Fallbacks); err != nil {
+			return err
+		}
+		if !seen[defaultLang] {
+			chain = append(chain, defaultLang)
+		}
+
+		l.fallbackChain = chain
+	}
+
+	return nil
+}
+
 func toSortedLanguages(cfg config.Provider, l map[string]any) (Languages, error) {
 	languages := make(Languages, len(l))
 	i := 0
@@ -196,6 +254,10 @@ func toSortedLanguages(cfg config.Provider, l map[string]any) (Languages, error)
 				language.ContentDir = filepath.Clean(cast.ToString(v))
 			case "disabled":
 				language.Disabled = cast.ToBool(v)
+			case "fallbacks":
+				for _, fallback := range cast.ToStringSlice(v) {
+					language.Fallbacks = append(language.Fallbacks, strings.ToLower(fallback))
+				}
 			case "params":
 				m := maps.ToStringMap(v)
 				// Needed for case insensitive fetching of params values
@@ -0,0 +1,282 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gohugoio/go-i18n/v2/i18n"
+	"github.com/gohugoio/hugo/parser"
+	"github.com/gohugoio/hugo/parser/metadecoders"
+	"github.com/spf13/afero"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+const (
+	// UnitGroupI18n is the group of the translation strings in /i18n.
+	UnitGroupI18n = "i18n"
+
+	// UnitGroupContent is the group of front matter fields and content segments.
+	UnitGroupContent = "content"
+)
+
+// The CLDR plural categories in the order used in exchange files.
+var pluralCategories = []string{"zero", "one", "two", "few", "many", "other"}
+
+// Document is a set of translation units for a language, as exchanged with
+// translators in e.g. XLIFF or PO files.
+type Document struct {
+	SourceLang string
+	TargetLang string
+	Units      []Unit
+}
+
+// Unit is a translatable string.
+type Unit struct {
+	// Either UnitGroupI18n or UnitGroupContent.
+	Group string
+
+	// The translation ID, or for content, the content file path relative to
+	// the content dir followed by a fragment, e.g. "posts/p1.md#title".
+	ID string
+
+	Description string
+
+	// The text per plural category, e.g. "one" and "other".
+	// Strings without plural forms have "other" only.
+	Source map[string]string
+	Target map[string]string
+
+	// Whether the target needs review, e.g. because the source has changed
+	// since it was translated.
+	NeedsReview bool
+}
+
+// IsPlural reports whether u has plural forms.
+func (u Unit) IsPlural() bool {
+	return len(u.Source) > 1
+}
+
+// PluralCategories returns the CLDR plural categories used by lang in the
+// order they appear in exchange files, e.g. "one", "other" for English.
+func PluralCategories(lang string) []string {
+	tag := language.Make(lang)
+	forms := make(map[plural.Form]bool)
+	for i := 0; i < 1000; i++ {
+		forms[plural.Cardinal.MatchPlural(tag, i, 0, 0, 0, 0)] = true
+	}
+	// Some languages have a separate category for decimals, e.g. 1.5.
+	forms[plural.Cardinal.MatchPlural(tag, 1, 1, 1, 5, 5)] = true
+
+	var categories []string
+	for _, c := range pluralCategories {
+		if forms[pluralForm(c)] {
+			categories = append(categories, c)
+		}
+	}
+	return categories
+}
+
+func pluralForm(category string) plural.Form {
+	switch category {
+	case "zero":
+		return plural.Zero
+	case "one":
+		return plural.One
+	case "two":
+		return plural.Two
+	case "few":
+		return plural.Few
+	case "many":
+		return plural.Many
+	default:
+		return plural.Other
+	}
+}
+
+func messageForms(m *i18n.Message) map[string]string {
+	forms := make(map[string]string)
+	for c, v := range map[string]string{
+		"zero":  m.Zero,
+		"one":   m.One,
+		"two":   m.Two,
+		"few":   m.Few,
+		"many":  m.Many,
+		"other": m.Other,
+	} {
+		if v != "" {
+			forms[c] = v
+		}
+	}
+	if len(forms) == 0 {
+		forms["other"] = ""
+	}
+	return forms
+}
+
+// ExportUnits returns the translation strings in defaultLang as units with the
+// current translations in lang as targets.
+func (tp *TranslationProvider) ExportUnits(defaultLang, lang string) []Unit {
+	defaultMessages := tp.messages[strings.ToLower(defaultLang)]
+	messages := tp.messages[strings.ToLower(lang)]
+
+	ids := make([]string, 0, len(defaultMessages))
+	for id := range defaultMessages {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	units := make([]Unit, len(ids))
+	for i, id := range ids {
+		dm := defaultMessages[id]
+		u := Unit{
+			Group:       UnitGroupI18n,
+			ID:          id,
+			Description: dm.Description,
+			Source:      messageForms(dm),
+			Target:      make(map[string]string),
+		}
+		if m, found := messages[id]; found {
+			u.Target = messageForms(m)
+			u.NeedsReview = m.Hash != "" && m.Hash != MessageHash(dm)
+		}
+		units[i] = u
+	}
+
+	return units
+}
+
+// ImportUnits writes the translated i18n units to the translation file for
+// lang in the project's i18n dir, creating dir/<lang>.toml if no such file exists.
+// Units without a target or in need of review are skipped.
+// The hash of the imported translations is set to the current hash of the
+// string in defaultLang. It returns the filename written to and the number of imported units.
+func (tp *TranslationProvider) ImportUnits(fs afero.Fs, dir, defaultLang, lang string, units []Unit) (string, int, error) {
+	lang = strings.ToLower(lang)
+	defaultMessages := tp.messages[strings.ToLower(defaultLang)]
+
+	filename := tp.projectFiles[lang]
+	if filename == "" {
+		filename = filepath.Join(dir, lang+".toml")
+	}
+
+	b, err := afero.ReadFile(fs, filename)
+	if err != nil && !os.IsNotExist(err) {
+		return "", 0, err
+	}
+
+	ext := strings.TrimPrefix(filepath.Ext(filename), ".")
+
+	var (
+		poDoc    Document
+		m        map[string]any
+		imported int
+	)
+
+	if ext == "po" {
+		if len(b) > 0 {
+			if poDoc, err = ReadPO(bytes.NewReader(b)); err != nil {
+				return "", 0, fmt.Errorf("failed to read %q: %w", filename, err)
+			}
+		}
+		poDoc.TargetLang = lang
+	} else {
+		format := metadecoders.FormatFromString(ext)
+		if format == "" {
+			return "", 0, fmt.Errorf("unsupported translation file format %q", ext)
+		}
+		m = make(map[string]any)
+		if len(b) > 0 {
+			if m, err = metadecoders.Default.UnmarshalToMap(b, format); err != nil {
+				return "", 0, fmt.Errorf("failed to read %q, note that only the map format is supported: %w", filename, err)
+			}
+		}
+	}
+
+	for _, u := range units {
+		if u.Group != UnitGroupI18n || u.NeedsReview || !hasTarget(u) {
+			continue
+		}
+		imported++
+
+		var hash string
+		if dm, found := defaultMessages[u.ID]; found {
+			hash = MessageHash(dm)
+		}
+
+		if m != nil {
+			entry := make(map[string]any)
+			for c, v := range u.Target {
+				if v != "" {
+					entry[c] = v
+				}
+			}
+			if u.Description != "" {
+				entry["description"] = u.Description
+			}
+			if hash != "" {
+				entry["hash"] = hash
+			}
+			m[u.ID] = entry
+			continue
+		}
+
+		found := false
+		for i, pu := range poDoc.Units {
+			if pu.Group == u.Group && pu.ID == u.ID {
+				poDoc.Units[i] = u
+				found = true
+				break
+			}
+		}
+		if !found {
+			poDoc.Units = append(poDoc.Units, u)
+		}
+	}
+
+	if imported == 0 {
+		return "", 0, nil
+	}
+
+	var buf bytes.Buffer
+	if m != nil {
+		err = parser.InterfaceToConfig(m, metadecoders.FormatFromString(ext), &buf)
+	} else {
+		err = WritePO(&buf, poDoc)
+	}
+	if err != nil {
+		return "", 0, err
+	}
+
+	if err := fs.MkdirAll(filepath.Dir(filename), 0777); err != nil {
+		return "", 0, err
+	}
+
+	return filename, imported, afero.WriteFile(fs, filename, buf.Bytes(), 0666)
+}
+
+func hasTarget(u Unit) bool {
+	for _, v := range u.Target {
+		if v != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/go-i18n/v2/i18n"
+	"github.com/spf13/afero"
+)
+
+func TestPluralCategories(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(PluralCategories("en"), qt.DeepEquals, []string{"one", "other"})
+	c.Assert(PluralCategories("ja"), qt.DeepEquals, []string{"other"})
+	c.Assert(PluralCategories("pl"), qt.DeepEquals, []string{"one", "few", "many", "other"})
+	c.Assert(PluralCategories("ar"), qt.DeepEquals, []string{"zero", "one", "two", "few", "many", "other"})
+}
+
+func TestExchangeRoundTrip(t *testing.T) {
+	doc := Document{
+		SourceLang: "en",
+		TargetLang: "de",
+		Units: []Unit{
+			{
+				Group:       UnitGroupI18n,
+				ID:          "hello",
+				Description: "Greeting on the home page.",
+				Source:      map[string]string{"other": "Hello \"World\""},
+				Target:      map[string]string{"other": "Hallo \"Welt\""},
+			},
+			{
+				Group:       UnitGroupI18n,
+				ID:          "minutes",
+				Source:      map[string]string{"one": "One minute", "other": "{{ .Count }} minutes"},
+				Target:      map[string]string{"one": "Eine Minute", "other": "{{ .Count }} Minuten"},
+				NeedsReview: true,
+			},
+			{
+				Group:  UnitGroupContent,
+				ID:     "posts/p1.md#content.1",
+				Source: map[string]string{"other": "First line.\nSecond line.\n"},
+				Target: map[string]string{"other": ""},
+			},
+		},
+	}
+
+	for _, test := range []struct {
+		name  string
+		write func(io.Writer, Document) error
+		read  func(io.Reader) (Document, error)
+	}{
+		{"PO", WritePO, ReadPO},
+		{"XLIFF", WriteXLIFF, ReadXLIFF},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			c := qt.New(t)
+
+			var buf bytes.Buffer
+			c.Assert(test.write(&buf, doc), qt.IsNil)
+
+			got, err := test.read(&buf)
+			c.Assert(err, qt.IsNil)
+			c.Assert(got, qt.DeepEquals, doc)
+		})
+	}
+}
+
+func TestReadPO(t *testing.T) {
+	c := qt.New(t)
+
+	doc, err := ReadPO(bytes.NewBufferString(`
+msgid ""
+msgstr ""
+"Language: pl\n"
+"X-Hugo-Plural-Categories: one few many other\n"
+
+# A translator comment.
+msgid "Hello"
+msgstr "Cześć"
+
+msgctxt "minutes"
+msgid "One minute"
+msgid_plural "{{ .Count }} minutes"
+msgstr[0] "Minuta"
+msgstr[1] ""
+"{{ .Count }} minuty"
+msgstr[2] "{{ .Count }} minut"
+`))
+	c.Assert(err, qt.IsNil)
+	c.Assert(doc.TargetLang, qt.Equals, "pl")
+	c.Assert(doc.Units, qt.HasLen, 2)
+	c.Assert(doc.Units[0].ID, qt.Equals, "Hello")
+	c.Assert(doc.Units[0].Target, qt.DeepEquals, map[string]string{"other": "Cześć"})
+	c.Assert(doc.Units[1].Target, qt.DeepEquals, map[string]string{"one": "Minuta", "few": "{{ .Count }} minuty", "many": "{{ .Count }} minut"})
+
+	// Poedit does not write the plural categories.
+	doc, err = ReadPO(bytes.NewBufferString(`
+msgid ""
+msgstr ""
+"Language: pl\n"
+"Plural-Forms: nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<12 || n%100>14) ? 1 : 2);\n"
+
+msgid "One minute"
+msgid_plural "{{ .Count }} minutes"
+msgstr[0] "Minuta"
+msgstr[1] "{{ .Count }} minuty"
+msgstr[2] "{{ .Count }} minut"
+`))
+	c.Assert(err, qt.IsNil)
+	c.Assert(doc.Units[0].Target, qt.DeepEquals, map[string]string{"one": "Minuta", "few": "{{ .Count }} minuty", "many": "{{ .Count }} minut"})
+
+	doc, err = ReadPO(bytes.NewBufferString(`
+msgid ""
+msgstr ""
+"Language: ja\n"
+"Plural-Forms: nplurals=1; plural=0;\n"
+
+msgid "One minute"
+msgid_plural "{{ .Count }} minutes"
+msgstr[0] "{{ .Count }} 分"
+`))
+	c.Assert(err, qt.IsNil)
+	c.Assert(doc.Units[0].Target, qt.DeepEquals, map[string]string{"other": "{{ .Count }} 分"})
+
+	_, err = ReadPO(bytes.NewBufferString("msgid \"a\"\nmsgfoo \"b\"\n"))
+	c.Assert(err, qt.ErrorMatches, `line 2: unknown keyword "msgfoo"`)
+}
+
+func TestImportUnits(t *testing.T) {
+	c := qt.New(t)
+	v := getConfig()
+
+	tp := prepareTranslationProvider(t, i18nTest{
+		data: map[string][]byte{
+			"en.toml": []byte(`
+[hello]
+other = "Hello"
+[bye]
+other = "Bye"
+`),
+			"de.yaml": []byte(`
+hello:
+  other: "Hallo"
+  hash: sha1-outdated
+`),
+		},
+	}, v)
+
+	units := tp.ExportUnits("en", "de")
+	c.Assert(units, qt.HasLen, 2)
+	c.Assert(units[0].ID, qt.Equals, "bye")
+	c.Assert(units[0].Target, qt.DeepEquals, map[string]string{})
+	c.Assert(units[1].ID, qt.Equals, "hello")
+	c.Assert(units[1].NeedsReview, qt.IsTrue)
+
+	units[0].Target = map[string]string{"other": "Tschüss"}
+
+	fs := afero.NewMemMapFs()
+	filename, n, err := tp.ImportUnits(fs, "i18n", "en", "de", units)
+	c.Assert(err, qt.IsNil)
+	c.Assert(n, qt.Equals, 1)
+
+	b, err := afero.ReadFile(fs, filename)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Contains, "bye:\n  hash: "+MessageHash(&i18n.Message{Other: "Bye"})+"\n  other: Tschüss\n")
+
+	_, n, err = tp.ImportUnits(fs, "i18n", "en", "fr", units[1:])
+	c.Assert(err, qt.IsNil)
+	c.Assert(n, qt.Equals, 0)
+	exists, _ := afero.Exists(fs, filepath.Join("i18n", "fr.toml"))
+	c.Assert(exists, qt.IsFalse)
+}
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/spf13/cast"
 
@@ -24,8 +25,10 @@ import (
 	"github.com/gohugoio/hugo/common/loggers"
 	"github.com/gohugoio/hugo/config"
 	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/langs"
 
 	"github.com/gohugoio/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
 )
 
 type translateFunc func(translationID string, templateData any) string
@@ -37,21 +40,29 @@ type Translator struct {
 	translateFuncs map[string]translateFunc
 	cfg            config.Provider
 	logger         loggers.Logger
+
+	// The translation IDs used since the Translator was created.
+	used *sync.Map
 }
 
 // NewTranslator creates a new Translator for the given language bundle and configuration.
 func NewTranslator(b *i18n.Bundle, cfg config.Provider, logger loggers.Logger) Translator {
-	t := Translator{cfg: cfg, logger: logger, translateFuncs: make(map[string]translateFunc)}
+	t := Translator{cfg: cfg, logger: logger, translateFuncs: make(map[string]translateFunc), used: &sync.Map{}}
 	t.initFuncs(b)
 	return t
 }
 
-// Func gets the translate func for the given language, or for the default
-// configured language if not found.
+// Func gets the translate func for the given language, or for the first
+// language in its fallback chain or the default configured language if not found.
 func (t Translator) Func(lang string) translateFunc {
 	if f, ok := t.translateFuncs[lang]; ok {
 		return f
 	}
+	for _, fallback := range t.fallbackChains()[lang] {
+		if f, ok := t.translateFuncs[fallback]; ok {
+			return f
+		}
+	}
 	t.logger.Infof("Translation func for language %v not found, use default.", lang)
 	if f, ok := t.translateFuncs[t.cfg.GetString("defaultContentLanguage")]; ok {
 		return f
@@ -59,19 +70,45 @@ func (t Translator) Func(lang string) translateFunc {
 
 	t.logger.Infoln("i18n not initialized; if you need string translations, check that you have a bundle in /i18n that matches the site language or the default language.")
 	return func(translationID string, args any) string {
+		t.used.LoadOrStore(translationID, true)
 		return ""
 	}
 }
 
+// fallbackChains returns the configured fallback chains keyed by language.
+func (t Translator) fallbackChains() map[string][]string {
+	chains := make(map[string][]string)
+	if languages, ok := t.cfg.Get("languagesSorted").(langs.Languages); ok {
+		for _, l := range languages {
+			if chain := langs.GetFallbackChain(l); chain != nil {
+				chains[l.Lang] = chain
+			}
+		}
+	}
+	return chains
+}
+
 func (t Translator) initFuncs(bndl *i18n.Bundle) {
 	enableMissingTranslationPlaceholders := t.cfg.GetBool("enableMissingTranslationPlaceholders")
+	fallbackChains := t.fallbackChains()
+
+	localizers := make(map[string]*i18n.Localizer)
+	tags := make(map[string]language.Tag)
+	for _, lang := range bndl.LanguageTags() {
+		localizers[langKey(lang)] = i18n.NewLocalizer(bndl, lang.String())
+		tags[langKey(lang)] = lang
+	}
+
 	for _, lang := range bndl.LanguageTags() {
 		currentLang := lang
 		currentLangStr := currentLang.String()
 		// This may be pt-BR; make it case insensitive.
-		currentLangKey := strings.ToLower(strings.TrimPrefix(currentLangStr, artificialLangTagPrefix))
-		localizer := i18n.NewLocalizer(bndl, currentLangStr)
+		currentLangKey := langKey(currentLang)
+		localizer := localizers[currentLangKey]
+		fallbacks := fallbackChains[currentLangKey]
 		t.translateFuncs[currentLangKey] = func(translationID string, templateData any) string {
+			t.used.LoadOrStore(translationID, true)
+
 			pluralCount := getPluralCount(templateData)
 
 			if templateData != nil {
@@ -84,25 +121,22 @@ func (t Translator) initFuncs(bndl *i18n.Bundle) {
 				}
 			}
 
-			translated, translatedLang, err := localizer.LocalizeWithTag(&i18n.LocalizeConfig{
+			lc := &i18n.LocalizeConfig{
 				MessageID:    translationID,
 				TemplateData: templateData,
 				PluralCount:  pluralCount,
-			})
-
-			sameLang := currentLang == translatedLang
+			}
 
-			if err == nil && sameLang {
+			translated, found, err := localize(localizer, currentLang, lc)
+			if found {
 				return translated
 			}
 
-			if err != nil && sameLang && translated != "" {
-				// See #8492
-				// TODO(bep) this needs to be improved/fixed upstream,
-				// but currently we get an error even if the fallback to
-				// "other" succeeds.
-				if fmt.Sprintf("%T", err) == "i18n.pluralFormNotFoundError" {
-					return translated
+			for _, fallback := range fallbacks {
+				if l, ok := localizers[fallback]; ok {
+					if s, found, _ := localize(l, tags[fallback], lc); found {
+						return s
+					}
 				}
 			}
 
@@ -123,6 +157,30 @@ func (t Translator) initFuncs(bndl *i18n.Bundle) {
 	}
 }
 
+// localize translates lc using localizer and reports whether the translation
+// was found in the language tag and not in the bundle's default language.
+func localize(localizer *i18n.Localizer, tag language.Tag, lc *i18n.LocalizeConfig) (string, bool, error) {
+	translated, translatedLang, err := localizer.LocalizeWithTag(lc)
+
+	sameLang := tag == translatedLang
+
+	if err == nil && sameLang {
+		return translated, true, nil
+	}
+
+	if err != nil && sameLang && translated != "" {
+		// See #8492
+		// TODO(bep) this needs to be improved/fixed upstream,
+		// but currently we get an error even if the fallback to
+		// "other" succeeds.
+		if fmt.Sprintf("%T", err) == "i18n.pluralFormNotFoundError" {
+			return translated, true, nil
+		}
+	}
+
+	return translated, false, err
+}
+
 // intCount wraps the Count method.
 type intCount int
 
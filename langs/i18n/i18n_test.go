@@ -18,6 +18,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/gohugoio/go-i18n/v2/i18n"
 	"github.com/gohugoio/hugo/common/types"
 
 	"github.com/gohugoio/hugo/modules"
@@ -534,6 +535,73 @@ func TestI18nTranslate(t *testing.T) {
 	}
 }
 
+func TestI18nStatus(t *testing.T) {
+	c := qt.New(t)
+	v := getConfig()
+
+	hello := &i18n.Message{Other: "Hello"}
+
+	tp := prepareTranslationProvider(t, i18nTest{
+		data: map[string][]byte{
+			"en.toml": []byte(`
+[hello]
+other = "Hello"
+[bye]
+other = "Bye"
+[unused]
+other = "Unused"
+`),
+			"de.toml": []byte(fmt.Sprintf(`
+[hello]
+other = "Hallo"
+hash = "sha1-outdated"
+[unused]
+other = "Unbenutzt"
+hash = %q
+`, MessageHash(&i18n.Message{Other: "Unused"}))),
+			"nb.yaml": []byte(`
+hello:
+  other: "Hei"
+  hash: ` + MessageHash(hello) + `
+`),
+		},
+	}, v)
+
+	for _, lang := range []string{"en", "de", "nb"} {
+		f := tp.t.Func(lang)
+		f("hello", nil)
+		f("bye", nil)
+		f("notdefined", nil)
+	}
+
+	statuses := tp.Status("en", []string{"en", "de", "nb"})
+	c.Assert(statuses, qt.DeepEquals, []Status{
+		{Lang: "en", Missing: []string{"notdefined"}, Unused: []string{"unused"}, Outdated: []string{}},
+		{Lang: "de", Missing: []string{"bye", "notdefined"}, Unused: []string{"unused"}, Outdated: []string{"hello"}},
+		{Lang: "nb", Missing: []string{"bye", "notdefined", "unused"}, Unused: []string{}, Outdated: []string{}},
+	})
+
+	fs := afero.NewMemMapFs()
+	for _, st := range statuses[1:] {
+		_, err := tp.ScaffoldMissing(fs, "i18n", "en", st)
+		c.Assert(err, qt.IsNil)
+	}
+
+	de, err := afero.ReadFile(fs, filepath.Join("i18n", "de.toml"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(de), qt.Contains, "# Needs translation from \"en\".\n[bye]\nhash = '"+MessageHash(&i18n.Message{Other: "Bye"})+"'\nother = 'Bye'\n")
+	c.Assert(string(de), qt.Contains, "[notdefined]\nother = ''\n")
+
+	nb, err := afero.ReadFile(fs, filepath.Join("i18n", "nb.yaml"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(nb), qt.Contains, "bye:\n  hash: "+MessageHash(&i18n.Message{Other: "Bye"})+"\n  other: Bye\n")
+
+	_, err = tp.ScaffoldMissing(fs, "i18n", "en", Status{Lang: "fr"})
+	c.Assert(err, qt.IsNil)
+	exists, _ := afero.Exists(fs, filepath.Join("i18n", "fr.toml"))
+	c.Assert(exists, qt.IsFalse)
+}
+
 func BenchmarkI18nTranslate(b *testing.B) {
 	v := getConfig()
 	for _, test := range i18nTests {
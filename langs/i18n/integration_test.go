@@ -55,3 +55,58 @@ l1: {{ i18n "l1"  }}|l2: {{ i18n "l2"  }}|l3: {{ i18n "l3"  }}
 l1: l1main|l2: l2main|l3: l3theme
 	`)
 }
+
+func TestI18nFromPO(t *testing.T) {
+	t.Parallel()
+
+	files := `
+-- config.toml --
+defaultContentLanguage = "en"
+[languages]
+[languages.en]
+weight = 1
+[languages.de]
+weight = 2
+-- i18n/en.toml --
+[hello]
+other = 'Hello'
+[bye]
+other = 'Bye'
+[minutes]
+one = 'One minute'
+other = '{{ .Count }} minutes'
+-- i18n/de.po --
+msgid ""
+msgstr ""
+"Language: de\n"
+"X-Hugo-Plural-Categories: one other\n"
+
+msgctxt "hello"
+msgid "Hello"
+msgstr "Hallo"
+
+#, fuzzy
+msgctxt "bye"
+msgid "Bye"
+msgstr "Tschüss"
+
+msgctxt "minutes"
+msgid "One minute"
+msgid_plural "{{ .Count }} minutes"
+msgstr[0] "Eine Minute"
+msgstr[1] "{{ .Count }} Minuten"
+-- layouts/index.html --
+hello: {{ i18n "hello" }}|bye: {{ i18n "bye" }}|minutes: {{ i18n "minutes" 1 }}/{{ i18n "minutes" 3 }}
+`
+
+	b := hugolib.NewIntegrationTestBuilder(
+		hugolib.IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+		},
+	).Build()
+
+	b.AssertFileContent("public/de/index.html", `
+hello: Hallo|bye: Bye|minutes: Eine Minute/3 Minuten
+	`)
+}
@@ -0,0 +1,338 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The PO header listing the plural categories of the msgstr[n] entries, e.g. "one other".
+const poPluralCategoriesHeader = "X-Hugo-Plural-Categories"
+
+var (
+	poEscaper   = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	poUnescaper = strings.NewReplacer(`\\`, `\`, `\"`, `"`, `\n`, "\n", `\t`, "\t", `\r`, "\r")
+)
+
+// WritePO writes doc as a gettext PO file.
+//
+// The translation ID is stored in msgctxt, prefixed with "content:" for
+// content units, and the source text in msgid.
+// For strings with plural forms, msgid and msgid_plural hold the "one" and
+// "other" forms of the source and msgstr[n] the target forms in the order of
+// the plural categories of the target language, see PluralCategories.
+// Translations in need of review are marked as fuzzy.
+func WritePO(w io.Writer, doc Document) error {
+	categories := PluralCategories(doc.TargetLang)
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, `msgid ""`)
+	fmt.Fprintln(bw, `msgstr ""`)
+	fmt.Fprintf(bw, "\"Language: %s\\n\"\n", doc.TargetLang)
+	fmt.Fprintln(bw, `"MIME-Version: 1.0\n"`)
+	fmt.Fprintln(bw, `"Content-Type: text/plain; charset=UTF-8\n"`)
+	fmt.Fprintln(bw, `"Content-Transfer-Encoding: 8bit\n"`)
+	if len(categories) == 2 && categories[0] == "one" {
+		fmt.Fprintln(bw, `"Plural-Forms: nplurals=2; plural=(n != 1);\n"`)
+	}
+	fmt.Fprintf(bw, "\"%s: %s\\n\"\n", poPluralCategoriesHeader, strings.Join(categories, " "))
+	if doc.SourceLang != "" {
+		fmt.Fprintf(bw, "\"X-Source-Language: %s\\n\"\n", doc.SourceLang)
+	}
+
+	for _, u := range doc.Units {
+		fmt.Fprintln(bw)
+		if u.Description != "" {
+			for _, line := range strings.Split(u.Description, "\n") {
+				fmt.Fprintf(bw, "#. %s\n", line)
+			}
+		}
+		if u.NeedsReview {
+			fmt.Fprintln(bw, "#, fuzzy")
+		}
+		ctxt := u.ID
+		if u.Group != "" && u.Group != UnitGroupI18n {
+			ctxt = u.Group + ":" + u.ID
+		}
+		writePOString(bw, "msgctxt", ctxt)
+
+		if !u.IsPlural() {
+			writePOString(bw, "msgid", u.Source["other"])
+			writePOString(bw, "msgstr", u.Target["other"])
+			continue
+		}
+
+		singular := u.Source["one"]
+		if singular == "" {
+			singular = u.Source["other"]
+		}
+		writePOString(bw, "msgid", singular)
+		writePOString(bw, "msgid_plural", u.Source["other"])
+		for i, c := range categories {
+			writePOString(bw, fmt.Sprintf("msgstr[%d]", i), u.Target[c])
+		}
+	}
+
+	return bw.Flush()
+}
+
+func writePOString(w io.Writer, keyword, s string) {
+	if !strings.Contains(strings.TrimSuffix(s, "\n"), "\n") {
+		fmt.Fprintf(w, "%s \"%s\"\n", keyword, poEscaper.Replace(s))
+		return
+	}
+	// Split multiline strings after each newline.
+	fmt.Fprintf(w, "%s \"\"\n", keyword)
+	lines := strings.SplitAfter(s, "\n")
+	for _, line := range lines {
+		if line != "" {
+			fmt.Fprintf(w, "\"%s\"\n", poEscaper.Replace(line))
+		}
+	}
+}
+
+type poEntry struct {
+	comments  []string
+	flags     []string
+	ctxt      *string
+	id        *string
+	idPlural  *string
+	str       map[int]*string
+	lastField *string
+}
+
+// ReadPO reads a gettext PO file as written by WritePO.
+// Entries without a msgctxt use the msgid as the translation ID.
+// The msgstr[n] entries are mapped to the plural categories in the
+// X-Hugo-Plural-Categories header or, for files from other tools, e.g. Poedit,
+// to the plural categories of the language in the Language header.
+func ReadPO(r io.Reader) (Document, error) {
+	var (
+		doc        Document
+		entries    []*poEntry
+		cur        = &poEntry{str: make(map[int]*string)}
+		lineNumber int
+	)
+
+	flush := func() {
+		if cur.id != nil {
+			entries = append(entries, cur)
+		}
+		cur = &poEntry{str: make(map[int]*string)}
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for sc.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			if cur.id != nil {
+				flush()
+			}
+			switch {
+			case strings.HasPrefix(line, "#."):
+				cur.comments = append(cur.comments, strings.TrimSpace(line[2:]))
+			case strings.HasPrefix(line, "#,"):
+				for _, flag := range strings.Split(line[2:], ",") {
+					cur.flags = append(cur.flags, strings.TrimSpace(flag))
+				}
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, `"`) {
+			if cur.lastField == nil {
+				return doc, fmt.Errorf("line %d: unexpected string", lineNumber)
+			}
+			s, err := unquotePO(line)
+			if err != nil {
+				return doc, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			*cur.lastField += s
+			continue
+		}
+
+		i := strings.IndexByte(line, ' ')
+		if i == -1 {
+			return doc, fmt.Errorf("line %d: invalid line %q", lineNumber, line)
+		}
+		keyword, rest := line[:i], strings.TrimSpace(line[i+1:])
+		s, err := unquotePO(rest)
+		if err != nil {
+			return doc, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		switch {
+		case keyword == "msgctxt":
+			if cur.id != nil {
+				flush()
+			}
+			cur.ctxt = &s
+			cur.lastField = cur.ctxt
+		case keyword == "msgid":
+			if cur.id != nil {
+				flush()
+			}
+			cur.id = &s
+			cur.lastField = cur.id
+		case keyword == "msgid_plural":
+			cur.idPlural = &s
+			cur.lastField = cur.idPlural
+		case keyword == "msgstr":
+			cur.str[0] = &s
+			cur.lastField = &s
+		case strings.HasPrefix(keyword, "msgstr["):
+			n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(keyword, "msgstr["), "]"))
+			if err != nil {
+				return doc, fmt.Errorf("line %d: invalid keyword %q", lineNumber, keyword)
+			}
+			cur.str[n] = &s
+			cur.lastField = &s
+		default:
+			return doc, fmt.Errorf("line %d: unknown keyword %q", lineNumber, keyword)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return doc, err
+	}
+	flush()
+
+	var categories []string
+
+	for _, e := range entries {
+		if *e.id == "" && e.ctxt == nil {
+			// The header.
+			for _, line := range strings.Split(e.msgstr(0), "\n") {
+				k, v, found := strings.Cut(line, ":")
+				if !found {
+					continue
+				}
+				v = strings.TrimSpace(v)
+				switch strings.TrimSpace(k) {
+				case "Language":
+					doc.TargetLang = v
+				case "X-Source-Language":
+					doc.SourceLang = v
+				case poPluralCategoriesHeader:
+					categories = strings.Fields(v)
+				}
+			}
+			continue
+		}
+
+		if categories == nil {
+			if doc.TargetLang != "" {
+				categories = PluralCategories(doc.TargetLang)
+			} else {
+				categories = []string{"one", "other"}
+			}
+		}
+
+		u := Unit{
+			Group:       UnitGroupI18n,
+			ID:          *e.id,
+			Description: strings.Join(e.comments, "\n"),
+			Source:      make(map[string]string),
+			Target:      make(map[string]string),
+		}
+		if e.ctxt != nil {
+			u.ID = *e.ctxt
+			if rest := strings.TrimPrefix(u.ID, UnitGroupContent+":"); rest != u.ID {
+				u.Group, u.ID = UnitGroupContent, rest
+			}
+		}
+		for _, flag := range e.flags {
+			if flag == "fuzzy" {
+				u.NeedsReview = true
+			}
+		}
+
+		if e.idPlural == nil {
+			u.Source["other"] = *e.id
+			u.Target["other"] = e.msgstr(0)
+		} else {
+			u.Source["one"] = *e.id
+			u.Source["other"] = *e.idPlural
+			for n, s := range e.str {
+				if n < len(categories) {
+					u.Target[categories[n]] = *s
+				}
+			}
+		}
+
+		doc.Units = append(doc.Units, u)
+	}
+
+	return doc, nil
+}
+
+func (e *poEntry) msgstr(n int) string {
+	if s := e.str[n]; s != nil {
+		return *s
+	}
+	return ""
+}
+
+func unquotePO(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", errors.New("expected a quoted string")
+	}
+	return poUnescaper.Replace(s[1 : len(s)-1]), nil
+}
+
+// unmarshalPO is registered with the go-i18n bundle to load PO files in /i18n.
+// Fuzzy and untranslated entries are skipped.
+func unmarshalPO(b []byte, v any) error {
+	doc, err := ReadPO(bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	m := make(map[string]any)
+	for _, u := range doc.Units {
+		if u.Group != UnitGroupI18n || u.NeedsReview || !hasTarget(u) {
+			continue
+		}
+		entry := make(map[string]any)
+		for c, s := range u.Target {
+			if s != "" {
+				entry[c] = s
+			}
+		}
+		if u.Description != "" {
+			entry["description"] = u.Description
+		}
+		m[u.ID] = entry
+	}
+
+	vp, ok := v.(*any)
+	if !ok {
+		return fmt.Errorf("unmarshalPO: unsupported target %T", v)
+	}
+	*vp = m
+
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gohugoio/go-i18n/v2/i18n"
+	toml "github.com/pelletier/go-toml/v2"
+	"github.com/spf13/afero"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Status holds the i18n coverage for a language compared to the
+// default content language.
+type Status struct {
+	Lang string `json:"lang"`
+
+	// Translation IDs defined in the default language or used in the
+	// templates without a translation in this language.
+	Missing []string `json:"missing"`
+
+	// Translation IDs defined in this language, but not used in any template
+	// since the translations were loaded.
+	Unused []string `json:"unused"`
+
+	// Translation IDs with a hash that does not match the current
+	// translation in the default language, see MessageHash.
+	Outdated []string `json:"outdated"`
+}
+
+// MessageHash returns the hash used to detect outdated translations of m,
+// "sha1-" followed by the SHA-1 of the "other" form.
+// A translation with a hash that does not match the hash of the message in
+// the default content language is considered outdated.
+func MessageHash(m *i18n.Message) string {
+	sum := sha1.Sum([]byte(m.Other))
+	return "sha1-" + hex.EncodeToString(sum[:])
+}
+
+// Status returns the i18n coverage for the given languages compared to defaultLang.
+// The unused IDs are only meaningful after the site has been rendered.
+func (tp *TranslationProvider) Status(defaultLang string, langs []string) []Status {
+	defaultLang = strings.ToLower(defaultLang)
+	defaultMessages := tp.messages[defaultLang]
+
+	used := make(map[string]bool)
+	if tp.t.used != nil {
+		tp.t.used.Range(func(k, v any) bool {
+			used[k.(string)] = true
+			return true
+		})
+	}
+
+	expected := make(map[string]bool)
+	for id := range defaultMessages {
+		expected[id] = true
+	}
+	for id := range used {
+		expected[id] = true
+	}
+
+	var statuses []Status
+	for _, lang := range langs {
+		lang = strings.ToLower(lang)
+		messages := tp.messages[lang]
+		st := Status{Lang: lang, Missing: []string{}, Unused: []string{}, Outdated: []string{}}
+
+		for id := range expected {
+			if _, found := messages[id]; !found {
+				st.Missing = append(st.Missing, id)
+			}
+		}
+
+		for id, m := range messages {
+			if !used[id] {
+				st.Unused = append(st.Unused, id)
+			}
+			if lang == defaultLang || m.Hash == "" {
+				continue
+			}
+			if dm, found := defaultMessages[id]; found && m.Hash != MessageHash(dm) {
+				st.Outdated = append(st.Outdated, id)
+			}
+		}
+
+		sort.Strings(st.Missing)
+		sort.Strings(st.Unused)
+		sort.Strings(st.Outdated)
+
+		statuses = append(statuses, st)
+	}
+
+	return statuses
+}
+
+// ScaffoldMissing adds the missing translation IDs in st to the translation
+// file for the language in the project's i18n dir, creating dir/<lang>.toml if
+// no such file exists.
+// The new translations are copies of the default language with a hash set, so
+// they will be reported as outdated when the default language changes.
+// It returns the filename written to, or an empty string if nothing was missing.
+func (tp *TranslationProvider) ScaffoldMissing(fs afero.Fs, dir, defaultLang string, st Status) (string, error) {
+	if len(st.Missing) == 0 {
+		return "", nil
+	}
+
+	defaultMessages := tp.messages[strings.ToLower(defaultLang)]
+
+	entries := make(map[string]map[string]string)
+	for _, id := range st.Missing {
+		entry := map[string]string{"other": ""}
+		if dm, found := defaultMessages[id]; found {
+			for form, v := range map[string]string{
+				"zero":        dm.Zero,
+				"one":         dm.One,
+				"two":         dm.Two,
+				"few":         dm.Few,
+				"many":        dm.Many,
+				"other":       dm.Other,
+				"description": dm.Description,
+			} {
+				if v != "" {
+					entry[form] = v
+				}
+			}
+			entry["hash"] = MessageHash(dm)
+		}
+		entries[id] = entry
+	}
+
+	filename := tp.projectFiles[st.Lang]
+	if filename == "" {
+		filename = filepath.Join(dir, st.Lang+".toml")
+	}
+
+	var (
+		b   []byte
+		err error
+	)
+	switch ext := strings.TrimPrefix(filepath.Ext(filename), "."); ext {
+	case "toml":
+		b, err = toml.Marshal(entries)
+	case "yaml", "yml":
+		b, err = yaml.Marshal(entries)
+	default:
+		return "", fmt.Errorf("scaffolding of %q files not supported, add the missing translations to %q manually", ext, filename)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if existing, err := afero.ReadFile(fs, filename); err == nil {
+		buf.Write(existing)
+		if len(existing) > 0 && !bytes.HasSuffix(existing, []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+	fmt.Fprintf(&buf, "\n# Needs translation from %q.\n", defaultLang)
+	buf.Write(b)
+
+	if err := fs.MkdirAll(filepath.Dir(filename), 0777); err != nil {
+		return "", err
+	}
+
+	return filename, afero.WriteFile(fs, filename, buf.Bytes(), 0666)
+}
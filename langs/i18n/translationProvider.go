@@ -16,6 +16,7 @@ package i18n
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/gohugoio/hugo/common/paths"
@@ -37,6 +38,12 @@ import (
 // of bundles etc.
 type TranslationProvider struct {
 	t Translator
+
+	// The messages per language, e.g. "pt-br", and message ID.
+	messages map[string]map[string]*i18n.Message
+
+	// The translation file per language in the project's i18n dir.
+	projectFiles map[string]string
 }
 
 // NewTranslationProvider creates a new translation provider.
@@ -53,6 +60,11 @@ func (tp *TranslationProvider) Update(d *deps.Deps) error {
 	bundle.RegisterUnmarshalFunc("yaml", yaml.Unmarshal)
 	bundle.RegisterUnmarshalFunc("yml", yaml.Unmarshal)
 	bundle.RegisterUnmarshalFunc("json", json.Unmarshal)
+	bundle.RegisterUnmarshalFunc("po", unmarshalPO)
+
+	tp.messages = make(map[string]map[string]*i18n.Message)
+	tp.projectFiles = make(map[string]string)
+	projectDir := d.PathSpec.AbsPathify(d.Cfg.GetString("i18nDir"))
 
 	// The source dirs are ordered so the most important comes first. Since this is a
 	// last key win situation, we have to reverse the iteration order.
@@ -65,9 +77,11 @@ func (tp *TranslationProvider) Update(d *deps.Deps) error {
 			return err
 		}
 		for _, file := range files {
-			if err := addTranslationFile(bundle, file); err != nil {
+			mf, err := addTranslationFile(bundle, file)
+			if err != nil {
 				return err
 			}
+			tp.addMessageFile(mf, file, projectDir)
 		}
 	}
 
@@ -80,10 +94,10 @@ func (tp *TranslationProvider) Update(d *deps.Deps) error {
 
 const artificialLangTagPrefix = "art-x-"
 
-func addTranslationFile(bundle *i18n.Bundle, r source.File) error {
+func addTranslationFile(bundle *i18n.Bundle, r source.File) (*i18n.MessageFile, error) {
 	f, err := r.FileInfo().Meta().Open()
 	if err != nil {
-		return fmt.Errorf("failed to open translations file %q:: %w", r.LogicalName(), err)
+		return nil, fmt.Errorf("failed to open translations file %q:: %w", r.LogicalName(), err)
 	}
 
 	b := helpers.ReaderToBytes(f)
@@ -96,25 +110,46 @@ func addTranslationFile(bundle *i18n.Bundle, r source.File) error {
 		try := artificialLangTagPrefix + lang
 		_, err = language.Parse(try)
 		if err != nil {
-			return fmt.Errorf("%q: %s", try, err)
+			return nil, fmt.Errorf("%q: %s", try, err)
 		}
 		name = artificialLangTagPrefix + name
 	}
 
-	_, err = bundle.ParseMessageFileBytes(b, name)
+	mf, err := bundle.ParseMessageFileBytes(b, name)
 	if err != nil {
 		if strings.Contains(err.Error(), "no plural rule") {
 			// https://github.com/gohugoio/hugo/issues/7798
 			name = artificialLangTagPrefix + name
-			_, err = bundle.ParseMessageFileBytes(b, name)
+			mf, err = bundle.ParseMessageFileBytes(b, name)
 			if err == nil {
-				return nil
+				return mf, nil
 			}
 		}
-		return errWithFileContext(fmt.Errorf("failed to load translations: %w", err), r)
+		return nil, errWithFileContext(fmt.Errorf("failed to load translations: %w", err), r)
 	}
 
-	return nil
+	return mf, nil
+}
+
+func (tp *TranslationProvider) addMessageFile(mf *i18n.MessageFile, r source.File, projectDir string) {
+	lang := langKey(mf.Tag)
+	messages, found := tp.messages[lang]
+	if !found {
+		messages = make(map[string]*i18n.Message)
+		tp.messages[lang] = messages
+	}
+	for _, m := range mf.Messages {
+		messages[m.ID] = m
+	}
+
+	if filename := r.FileInfo().Meta().Filename; strings.HasPrefix(filename, projectDir+string(filepath.Separator)) {
+		tp.projectFiles[lang] = filename
+	}
+}
+
+// langKey returns the key used for tag in the translation funcs, e.g. "pt-br".
+func langKey(tag language.Tag) string {
+	return strings.ToLower(strings.TrimPrefix(tag.String(), artificialLangTagPrefix))
 }
 
 // Clone sets the language func for the new language.
@@ -0,0 +1,182 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+const xliffNamespace = "urn:oasis:names:tc:xliff:document:2.0"
+
+type xliffDocument struct {
+	XMLName xml.Name    `xml:"urn:oasis:names:tc:xliff:document:2.0 xliff"`
+	Version string      `xml:"version,attr"`
+	SrcLang string      `xml:"srcLang,attr"`
+	TrgLang string      `xml:"trgLang,attr,omitempty"`
+	Files   []xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	ID    string      `xml:"id,attr"`
+	Units []xliffUnit `xml:"unit"`
+}
+
+type xliffUnit struct {
+	// Must be a NMTOKEN, so the translation ID is stored in Name.
+	ID       string         `xml:"id,attr"`
+	Name     string         `xml:"name,attr,omitempty"`
+	Notes    *xliffNotes    `xml:"notes,omitempty"`
+	Segments []xliffSegment `xml:"segment"`
+}
+
+type xliffNotes struct {
+	Notes []xliffNote `xml:"note"`
+}
+
+type xliffNote struct {
+	Category string `xml:"category,attr,omitempty"`
+	Text     string `xml:",chardata"`
+}
+
+type xliffSegment struct {
+	// The plural category for strings with plural forms.
+	ID     string `xml:"id,attr,omitempty"`
+	State  string `xml:"state,attr,omitempty"`
+	Source string `xml:"source"`
+	Target string `xml:"target,omitempty"`
+}
+
+// WriteXLIFF writes doc as an XLIFF 2.0 document with one file per unit group.
+//
+// Strings with plural forms get one segment per plural category of the
+// target language, see PluralCategories, with the category as segment id.
+// Segments without a target or in need of review have state "initial".
+func WriteXLIFF(w io.Writer, doc Document) error {
+	xdoc := xliffDocument{
+		Version: "2.0",
+		SrcLang: doc.SourceLang,
+		TrgLang: doc.TargetLang,
+	}
+
+	categories := PluralCategories(doc.TargetLang)
+
+	files := make(map[string]int)
+	for i, u := range doc.Units {
+		group := u.Group
+		if group == "" {
+			group = UnitGroupI18n
+		}
+		fi, found := files[group]
+		if !found {
+			fi = len(xdoc.Files)
+			files[group] = fi
+			xdoc.Files = append(xdoc.Files, xliffFile{ID: group})
+		}
+
+		xu := xliffUnit{
+			ID:   fmt.Sprintf("u%d", i+1),
+			Name: u.ID,
+		}
+		if u.Description != "" {
+			xu.Notes = &xliffNotes{Notes: []xliffNote{{Category: "description", Text: u.Description}}}
+		}
+
+		segment := func(id, source, target string) xliffSegment {
+			state := "translated"
+			if target == "" || u.NeedsReview {
+				state = "initial"
+			}
+			return xliffSegment{ID: id, State: state, Source: source, Target: target}
+		}
+
+		if !u.IsPlural() {
+			xu.Segments = []xliffSegment{segment("", u.Source["other"], u.Target["other"])}
+		} else {
+			for _, c := range categories {
+				source, found := u.Source[c]
+				if !found {
+					source = u.Source["other"]
+				}
+				xu.Segments = append(xu.Segments, segment(c, source, u.Target[c]))
+			}
+		}
+
+		xdoc.Files[fi].Units = append(xdoc.Files[fi].Units, xu)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(xdoc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ReadXLIFF reads an XLIFF 2.0 document as written by WriteXLIFF.
+// Segments with state "initial" are marked as in need of review.
+func ReadXLIFF(r io.Reader) (Document, error) {
+	var xdoc xliffDocument
+	if err := xml.NewDecoder(r).Decode(&xdoc); err != nil {
+		return Document{}, fmt.Errorf("failed to read XLIFF: %w", err)
+	}
+	if xdoc.XMLName.Space != xliffNamespace {
+		return Document{}, fmt.Errorf("unsupported XLIFF namespace %q", xdoc.XMLName.Space)
+	}
+
+	doc := Document{
+		SourceLang: xdoc.SrcLang,
+		TargetLang: xdoc.TrgLang,
+	}
+
+	for _, f := range xdoc.Files {
+		for _, xu := range f.Units {
+			u := Unit{
+				Group:  f.ID,
+				ID:     xu.Name,
+				Source: make(map[string]string),
+				Target: make(map[string]string),
+			}
+			if u.ID == "" {
+				u.ID = xu.ID
+			}
+			if xu.Notes != nil {
+				for _, n := range xu.Notes.Notes {
+					if n.Category == "description" {
+						u.Description = n.Text
+					}
+				}
+			}
+			for _, s := range xu.Segments {
+				category := s.ID
+				if len(xu.Segments) == 1 || category == "" {
+					category = "other"
+				}
+				u.Source[category] = s.Source
+				u.Target[category] = s.Target
+				if s.State == "initial" && s.Target != "" {
+					u.NeedsReview = true
+				}
+			}
+			doc.Units = append(doc.Units, u)
+		}
+	}
+
+	return doc, nil
+}
@@ -40,6 +40,8 @@ import (
 var globalOnlySettings = map[string]bool{
 	strings.ToLower("defaultContentLanguageInSubdir"): true,
 	strings.ToLower("defaultContentLanguage"):         true,
+	strings.ToLower("defaultContentVersionInSubdir"):  true,
+	strings.ToLower("defaultContentVersion"):          true,
 	strings.ToLower("multilingual"):                   true,
 	strings.ToLower("assetDir"):                       true,
 	strings.ToLower("resourceDir"):                    true,
@@ -54,9 +56,19 @@ type Language struct {
 	Title             string
 	Weight            int
 
+	// The name of the content version this language belongs to, if
+	// versions are configured. See Version.
+	// For internal use.
+	Version string
+
 	// For internal use.
 	Disabled bool
 
+	// The languages to fall back to for missing content and translation
+	// strings, most specific first, e.g. "pt" for "pt-br".
+	// The default content language is always the last resort.
+	Fallbacks []string
+
 	// If set per language, this tells Hugo that all content files without any
 	// language indicator (e.g. my-page.en.md) is in this language.
 	// This is usually a path relative to the working dir, but it can be an
@@ -91,6 +103,9 @@ type Language struct {
 	collator      *Collator
 	location      *time.Location
 
+	// Fallbacks resolved, see GetFallbackChain.
+	fallbackChain []string
+
 	// Error during initialization. Will fail the buld.
 	initErr error
 }
@@ -307,6 +322,13 @@ func GetCollator(l *Language) *Collator {
 	return l.collator
 }
 
+// GetFallbackChain returns the languages to fall back to for l, most specific
+// first and ending with the default content language.
+// It returns nil if no fallbacks are configured for l.
+func GetFallbackChain(l *Language) []string {
+	return l.fallbackChain
+}
+
 func (l *Language) loadLocation(tzStr string) error {
 	location, err := time.LoadLocation(tzStr)
 	if err != nil {
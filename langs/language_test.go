@@ -106,3 +106,55 @@ func BenchmarkCollator(b *testing.B) {
 	})
 
 }
+
+func TestResolveFallbackChains(t *testing.T) {
+	c := qt.New(t)
+
+	v := config.NewWithTestDefaults()
+	newLang := func(lang string, fallbacks ...string) *Language {
+		l := NewLanguage(lang, v)
+		l.Fallbacks = fallbacks
+		return l
+	}
+
+	en, de, deCH, deLI := newLang("en"), newLang("de"), newLang("de-ch", "de"), newLang("de-li", "de-ch", "de")
+	c.Assert(resolveFallbackChains(Languages{en, de, deCH, deLI}, "en"), qt.IsNil)
+	c.Assert(GetFallbackChain(en), qt.IsNil)
+	c.Assert(GetFallbackChain(de), qt.IsNil)
+	c.Assert(GetFallbackChain(deCH), qt.DeepEquals, []string{"de", "en"})
+	c.Assert(GetFallbackChain(deLI), qt.DeepEquals, []string{"de-ch", "de", "en"})
+
+	err := resolveFallbackChains(Languages{en, newLang("pt-br", "pt")}, "en")
+	c.Assert(err, qt.ErrorMatches, `language "pt-br": fallback language "pt" is not defined`)
+}
+
+func TestLoadVersionSettings(t *testing.T) {
+	c := qt.New(t)
+
+	v := config.NewWithTestDefaults()
+	c.Assert(loadVersionSettings(v), qt.IsNil)
+	c.Assert(GetVersions(v), qt.IsNil)
+
+	v.Set("versions", map[string]any{
+		"v1": map[string]any{"weight": 2, "title": "Version 1"},
+		"v2": map[string]any{"weight": 1, "contentDir": "content/v2"},
+		"v3": map[string]any{"disabled": true},
+	})
+	c.Assert(loadVersionSettings(v), qt.IsNil)
+	versions := GetVersions(v)
+	c.Assert(len(versions), qt.Equals, 3)
+	c.Assert(versions[0].Name, qt.Equals, "v2")
+	c.Assert(versions[0].ContentDir, qt.Equals, "content/v2")
+	c.Assert(versions[1].Title, qt.Equals, "Version 1")
+	c.Assert(versions.Get("v3").Disabled, qt.IsTrue)
+	c.Assert(v.GetString("defaultContentVersion"), qt.Equals, "v2")
+
+	v.Set("defaultContentVersion", "v4")
+	c.Assert(loadVersionSettings(v), qt.ErrorMatches, `.*"v4" for defaultContentVersion does not match any version definition`)
+	v.Set("defaultContentVersion", "v3")
+	c.Assert(loadVersionSettings(v), qt.ErrorMatches, `cannot disable default version "v3"`)
+
+	l := NewLanguage("en", v).ForVersion("v1")
+	c.Assert(l.Version, qt.Equals, "v1")
+	c.Assert(l.Lang, qt.Equals, "en")
+}
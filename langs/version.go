@@ -0,0 +1,183 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package langs
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gohugoio/hugo/common/maps"
+	"github.com/gohugoio/hugo/config"
+	"github.com/spf13/cast"
+)
+
+// Version is a version of the site content, e.g. "v1" or "v2" of the
+// documentation. Hugo builds one site per language and version.
+type Version struct {
+	// The name of the version, also used as the path prefix, e.g. "v2".
+	Name   string
+	Title  string
+	Weight int
+
+	// If set, all content files in this directory belong to this version.
+	// For internal use.
+	ContentDir string
+
+	// For internal use.
+	Disabled bool
+}
+
+// For internal use.
+func (v *Version) String() string {
+	return v.Name
+}
+
+// Versions is a sortable list of versions.
+type Versions []*Version
+
+func (v Versions) Len() int { return len(v) }
+func (v Versions) Less(i, j int) bool {
+	wi, wj := v[i].Weight, v[j].Weight
+
+	if wi == wj {
+		return v[i].Name < v[j].Name
+	}
+
+	// Versions without a weight go last.
+	if wi == 0 || wj == 0 {
+		return wj == 0
+	}
+
+	return wi < wj
+}
+
+func (v Versions) Swap(i, j int) { v[i], v[j] = v[j], v[i] }
+
+// Get returns the version with the given name, or nil if not found.
+func (v Versions) Get(name string) *Version {
+	for _, vv := range v {
+		if vv.Name == name {
+			return vv
+		}
+	}
+	return nil
+}
+
+// GetVersions returns the versions configured in cfg, sorted by weight.
+// It returns nil if no versions are configured.
+func GetVersions(cfg config.Provider) Versions {
+	if v, ok := cfg.Get("versionsSorted").(Versions); ok {
+		return v
+	}
+	return nil
+}
+
+// ForVersion creates a copy of l for the given version.
+func (l *Language) ForVersion(version string) *Language {
+	// Make sure the params are prepared before they're shared.
+	params := l.Params()
+
+	return &Language{
+		Lang:              l.Lang,
+		LanguageName:      l.LanguageName,
+		LanguageDirection: l.LanguageDirection,
+		Title:             l.Title,
+		Weight:            l.Weight,
+		Version:           version,
+		Disabled:          l.Disabled,
+		Fallbacks:         l.Fallbacks,
+		ContentDir:        l.ContentDir,
+		Cfg:               l.Cfg,
+		LocalCfg:          l.LocalCfg,
+		Provider:          l.Provider,
+		params:            params,
+		paramsSet:         true,
+		translator:        l.translator,
+		timeFormatter:     l.timeFormatter,
+		tag:               l.tag,
+		collator:          l.collator,
+		location:          l.location,
+		fallbackChain:     l.fallbackChain,
+		initErr:           l.initErr,
+	}
+}
+
+func loadVersionSettings(cfg config.Provider) error {
+	versionsFromConfig := cfg.GetParams("versions")
+	if len(versionsFromConfig) == 0 {
+		return nil
+	}
+
+	versions, err := toSortedVersions(versionsFromConfig)
+	if err != nil {
+		return fmt.Errorf("failed to parse versions config: %w", err)
+	}
+
+	defaultVersion := strings.ToLower(cfg.GetString("defaultContentVersion"))
+	if defaultVersion == "" {
+		for _, v := range versions {
+			if !v.Disabled {
+				defaultVersion = v.Name
+				break
+			}
+		}
+	}
+
+	v := versions.Get(defaultVersion)
+	if v == nil {
+		return fmt.Errorf("site config value %q for defaultContentVersion does not match any version definition", defaultVersion)
+	}
+	if v.Disabled {
+		return fmt.Errorf("cannot disable default version %q", defaultVersion)
+	}
+
+	cfg.Set("defaultContentVersion", defaultVersion)
+	cfg.Set("versionsSorted", versions)
+
+	return nil
+}
+
+func toSortedVersions(m map[string]any) (Versions, error) {
+	versions := make(Versions, 0, len(m))
+
+	for name, vConf := range m {
+		vm, err := maps.ToStringMapE(vConf)
+		if err != nil {
+			return nil, fmt.Errorf("version config is not a map: %T", vConf)
+		}
+
+		version := &Version{Name: name}
+
+		for k, v := range vm {
+			switch strings.ToLower(k) {
+			case "title":
+				version.Title = cast.ToString(v)
+			case "weight":
+				version.Weight = cast.ToInt(v)
+			case "contentdir":
+				version.ContentDir = filepath.Clean(cast.ToString(v))
+			case "disabled":
+				version.Disabled = cast.ToBool(v)
+			}
+		}
+
+		versions = append(versions, version)
+	}
+
+	sort.Sort(versions)
+
+	return versions, nil
+}
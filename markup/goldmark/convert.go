@@ -19,6 +19,7 @@ import (
 
 	"github.com/gohugoio/hugo/markup/goldmark/codeblocks"
 	"github.com/gohugoio/hugo/markup/goldmark/internal/extensions/attributes"
+	"github.com/gohugoio/hugo/markup/goldmark/internal/extensions/direction"
 	"github.com/gohugoio/hugo/markup/goldmark/internal/render"
 
 	"github.com/gohugoio/hugo/identity"
@@ -84,10 +85,15 @@ func newMarkdown(pcfg converter.ProviderConfig) goldmark.Markdown {
 		rendererOptions = append(rendererOptions, html.WithUnsafe())
 	}
 
+	var documentDirection string
+	if pcfg.Cfg != nil {
+		documentDirection = pcfg.Cfg.GetString("languageDirection")
+	}
+
 	var (
 		extensions = []goldmark.Extender{
 			newLinks(cfg),
-			newTocExtension(rendererOptions),
+			newTocExtension(rendererOptions, documentDirection),
 		}
 		parserOptions []parser.Option
 	)
@@ -136,6 +142,10 @@ func newMarkdown(pcfg converter.ProviderConfig) goldmark.Markdown {
 		extensions = append(extensions, attributes.New())
 	}
 
+	if documentDirection != "" || cfg.Parser.AutoDirection {
+		extensions = append(extensions, direction.New(documentDirection, cfg.Parser.AutoDirection))
+	}
+
 	md := goldmark.New(
 		goldmark.WithExtensions(
 			extensions...,
@@ -88,6 +88,10 @@ type Parser struct {
 
 	// Enables custom attributes.
 	Attribute ParserAttribute
+
+	// Enables detection of the text direction of blocks, setting the dir
+	// attribute on blocks with a direction different from their surroundings.
+	AutoDirection bool
 }
 
 type ParserAttribute struct {
@@ -575,3 +575,80 @@ a <!-- b --> c
 		"<li>This is a list item <!-- Comment: an innocent-looking comment --></li>",
 	)
 }
+
+func TestDirection(t *testing.T) {
+	t.Parallel()
+
+	files := `
+-- config.toml --
+disableKinds = ["taxonomy", "term", "RSS", "sitemap"]
+defaultContentLanguage = "ar"
+paginate = 1
+[languages.ar]
+languageDirection = "rtl"
+[markup.goldmark.parser]
+autoDirection = true
+[markup.goldmark.parser.attribute]
+block = true
+-- content/_index.md --
+---
+title: "الرئيسية"
+---
+-- content/p1.md --
+---
+title: "p1"
+---
+## مقدمة
+
+نص عربي مع كلمة Hugo.
+
+## Getting started
+
+Install Hugo with ` + "`go install`" + `.
+
+- عنصر
+- Item
+
+Forced paragraph.
+{dir="rtl"}
+
+123 نص[^1].
+
+[^1]: A footnote.
+-- content/p2.md --
+---
+title: "p2"
+---
+-- layouts/_default/single.html --
+{{ .TableOfContents }}|{{ .Content }}
+-- layouts/index.html --
+{{ range .Paginator.Pages }}{{ .Title }}{{ end }}
+{{ template "_internal/pagination.html" . }}
+Truncated: {{ "نص عربي طويل جدا للاختبار" | truncate 10 }}|
+`
+
+	b := hugolib.NewIntegrationTestBuilder(
+		hugolib.IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+		},
+	).Build()
+
+	b.AssertFileContent("public/p1/index.html",
+		`<nav id="TableOfContents" dir="rtl">`,
+		`<li dir="ltr"><a href="#getting-started">Getting started</a></li>`,
+		`<h2 id="مقدمة">مقدمة</h2>`,
+		`<p>نص عربي مع كلمة Hugo.</p>`,
+		`<h2 id="getting-started" dir="ltr">Getting started</h2>`,
+		`<p dir="ltr">Install Hugo with <code>go install</code>.</p>`,
+		`<li dir="ltr">Item</li>`,
+		`<p dir="rtl">Forced paragraph.</p>`,
+		`<div class="footnotes" role="doc-endnotes" dir="rtl">`,
+		`<li id="fn:1" dir="ltr">`,
+	)
+	b.AssertFileContent("public/p1/index.html", "<li>عنصر</li>", "<p>123 نص<sup")
+	b.AssertFileContent("public/index.html",
+		`<ul class="pagination pagination-default" dir="rtl">`,
+		"Truncated: نص عربي …|",
+	)
+}
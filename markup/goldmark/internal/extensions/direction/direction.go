@@ -0,0 +1,148 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package direction provides a Goldmark extension that sets the dir attribute
+// on blocks with a text direction different from their surroundings.
+package direction
+
+import (
+	"unicode/utf8"
+
+	htext "github.com/gohugoio/hugo/common/text"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+var attrDir = []byte("dir")
+
+// The block kinds that get a dir attribute when their direction is detected.
+var directionKinds = map[ast.NodeKind]bool{
+	ast.KindParagraph:              true,
+	ast.KindHeading:                true,
+	ast.KindBlockquote:             true,
+	ast.KindList:                   true,
+	ast.KindListItem:               true,
+	east.KindTableCell:             true,
+	east.KindFootnote:              true,
+	east.KindDefinitionTerm:        true,
+	east.KindDefinitionDescription: true,
+}
+
+// New returns a new direction extension.
+// The footnote list gets the document direction when set, and, if auto is
+// enabled, blocks whose first strong character has a direction other than
+// the inherited one get an explicit dir attribute.
+func New(documentDirection string, auto bool) goldmark.Extender {
+	return &directionExtension{documentDirection: documentDirection, auto: auto}
+}
+
+type directionExtension struct {
+	documentDirection string
+	auto              bool
+}
+
+func (e *directionExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithASTTransformers(
+			// After the attributes and footnote transformers, before the ToC.
+			util.Prioritized(&transformer{documentDirection: e.documentDirection, auto: e.auto}, 1000),
+		),
+	)
+}
+
+type transformer struct {
+	documentDirection string
+	auto              bool
+}
+
+func (t *transformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	inherited := t.documentDirection
+	if inherited == "" {
+		inherited = htext.DirectionLTR
+	}
+	t.walk(doc, inherited, reader.Source())
+}
+
+func (t *transformer) walk(n ast.Node, inherited string, src []byte) {
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		if c.Type() != ast.TypeBlock {
+			continue
+		}
+
+		dir := inherited
+
+		if v, found := c.Attribute(attrDir); found {
+			// Set by the user, e.g. {dir="rtl"}.
+			if d := attributeString(v); d == htext.DirectionLTR || d == htext.DirectionRTL {
+				dir = d
+			}
+		} else if c.Kind() == east.KindFootnoteList {
+			if t.documentDirection != "" {
+				c.SetAttribute(attrDir, []byte(t.documentDirection))
+			}
+		} else if t.auto && directionKinds[c.Kind()] {
+			if d := firstStrong(c, src); d != "" && d != inherited {
+				c.SetAttribute(attrDir, []byte(d))
+				dir = d
+			}
+		}
+
+		t.walk(c, dir, src)
+	}
+}
+
+func attributeString(v any) string {
+	switch vv := v.(type) {
+	case []byte:
+		return string(vv)
+	case string:
+		return vv
+	}
+	return ""
+}
+
+// firstStrong returns the direction of the first strong character in the
+// text of n, skipping code, raw HTML and URLs.
+func firstStrong(n ast.Node, src []byte) string {
+	var dir string
+	ast.Walk(n, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		var b []byte
+		switch nn := n.(type) {
+		case *ast.CodeSpan, *ast.CodeBlock, *ast.FencedCodeBlock, *ast.HTMLBlock, *ast.RawHTML, *ast.AutoLink, *ast.Image:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			b = nn.Segment.Value(src)
+		case *ast.String:
+			b = nn.Value
+		default:
+			return ast.WalkContinue, nil
+		}
+		for len(b) > 0 {
+			r, size := utf8.DecodeRune(b)
+			if d := htext.RuneDirection(r); d != "" {
+				dir = d
+				return ast.WalkStop, nil
+			}
+			b = b[size:]
+		}
+		return ast.WalkContinue, nil
+	})
+	return dir
+}
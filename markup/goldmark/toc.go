@@ -32,7 +32,8 @@ var (
 )
 
 type tocTransformer struct {
-	r renderer.Renderer
+	r                 renderer.Renderer
+	documentDirection string
 }
 
 func (t *tocTransformer) Transform(n *ast.Document, reader text.Reader, pc parser.Context) {
@@ -41,7 +42,7 @@ func (t *tocTransformer) Transform(n *ast.Document, reader text.Reader, pc parse
 	}
 
 	var (
-		toc         tableofcontents.Root
+		toc         = tableofcontents.Root{Direction: t.documentDirection}
 		tocHeading  tableofcontents.Heading
 		level       int
 		row         = -1
@@ -81,6 +82,11 @@ func (t *tocTransformer) Transform(n *ast.Document, reader text.Reader, pc parse
 			if found {
 				tocHeading.ID = string(id.([]byte))
 			}
+			if dir, found := heading.AttributeString("dir"); found {
+				if b, ok := dir.([]byte); ok {
+					tocHeading.Direction = string(b)
+				}
+			}
 		case
 			ast.KindCodeSpan,
 			ast.KindLink,
@@ -110,19 +116,23 @@ func (t *tocTransformer) Transform(n *ast.Document, reader text.Reader, pc parse
 }
 
 type tocExtension struct {
-	options []renderer.Option
+	options           []renderer.Option
+	documentDirection string
 }
 
-func newTocExtension(options []renderer.Option) goldmark.Extender {
+func newTocExtension(options []renderer.Option, documentDirection string) goldmark.Extender {
 	return &tocExtension{
-		options: options,
+		options:           options,
+		documentDirection: documentDirection,
 	}
 }
 
 func (e *tocExtension) Extend(m goldmark.Markdown) {
 	r := goldmark.DefaultRenderer()
 	r.AddOptions(e.options...)
+	// Run after the other transformers so the headings have their final attributes.
 	m.Parser().AddOptions(parser.WithASTTransformers(util.Prioritized(&tocTransformer{
-		r: r,
-	}, 10)))
+		r:                 r,
+		documentDirection: e.documentDirection,
+	}, 1001)))
 }
@@ -25,6 +25,9 @@ type Heading struct {
 	ID   string
 	Text string
 
+	// The text direction of the heading, "ltr" or "rtl", if set.
+	Direction string
+
 	Headings Headings
 }
 
@@ -37,6 +40,9 @@ func (h Heading) IsZero() bool {
 // data structure for the ToC.
 type Root struct {
 	Headings Headings
+
+	// The text direction of the document, "ltr" or "rtl", if set.
+	Direction string
 }
 
 // AddAt adds the heading into the given location.
@@ -69,6 +75,7 @@ func (toc Root) ToHTML(startLevel, stopLevel int, ordered bool) string {
 		startLevel: startLevel,
 		stopLevel:  stopLevel,
 		ordered:    ordered,
+		direction:  toc.Direction,
 	}
 	b.Build()
 	return b.s.String()
@@ -81,6 +88,7 @@ type tocBuilder struct {
 	startLevel int
 	stopLevel  int
 	ordered    bool
+	direction  string
 }
 
 func (b *tocBuilder) Build() {
@@ -88,15 +96,23 @@ func (b *tocBuilder) Build() {
 }
 
 func (b *tocBuilder) writeNav(h Headings) {
-	b.s.WriteString("<nav id=\"TableOfContents\">")
-	b.writeHeadings(1, 0, b.h)
+	b.s.WriteString("<nav id=\"TableOfContents\"")
+	if b.direction != "" {
+		b.s.WriteString(" dir=\"" + b.direction + "\"")
+	}
+	b.s.WriteString(">")
+	dir := b.direction
+	if dir == "" {
+		dir = "ltr"
+	}
+	b.writeHeadings(1, 0, dir, b.h)
 	b.s.WriteString("</nav>")
 }
 
-func (b *tocBuilder) writeHeadings(level, indent int, h Headings) {
+func (b *tocBuilder) writeHeadings(level, indent int, dir string, h Headings) {
 	if level < b.startLevel {
 		for _, h := range h {
-			b.writeHeadings(level+1, indent, h.Headings)
+			b.writeHeadings(level+1, indent, dir, h.Headings)
 		}
 		return
 	}
@@ -118,7 +134,7 @@ func (b *tocBuilder) writeHeadings(level, indent int, h Headings) {
 	}
 
 	for _, h := range h {
-		b.writeHeading(level+1, indent+2, h)
+		b.writeHeading(level+1, indent+2, dir, h)
 	}
 
 	if hasChildren {
@@ -133,13 +149,18 @@ func (b *tocBuilder) writeHeadings(level, indent int, h Headings) {
 	}
 }
 
-func (b *tocBuilder) writeHeading(level, indent int, h Heading) {
+func (b *tocBuilder) writeHeading(level, indent int, dir string, h Heading) {
 	b.indent(indent)
-	b.s.WriteString("<li>")
+	if h.Direction != "" && h.Direction != dir {
+		dir = h.Direction
+		b.s.WriteString("<li dir=\"" + dir + "\">")
+	} else {
+		b.s.WriteString("<li>")
+	}
 	if !h.IsZero() {
 		b.s.WriteString("<a href=\"#" + h.ID + "\">" + h.Text + "</a>")
 	}
-	b.writeHeadings(level, indent, h.Headings)
+	b.writeHeadings(level, indent, dir, h.Headings)
 	b.s.WriteString("</li>\n")
 }
 
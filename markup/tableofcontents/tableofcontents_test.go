@@ -153,3 +153,30 @@ func TestTocMissingParent(t *testing.T) {
   </ol>
 </nav>`, qt.Commentf(got))
 }
+
+func TestTocDirection(t *testing.T) {
+	c := qt.New(t)
+
+	toc := &Root{Direction: "rtl"}
+
+	toc.AddAt(Heading{Text: "مقدمة", ID: "h2-1"}, 0, 1)
+	toc.AddAt(Heading{Text: "Hugo", ID: "h3-1", Direction: "ltr"}, 0, 2)
+	toc.AddAt(Heading{Text: "Go", ID: "h4-1", Direction: "ltr"}, 0, 3)
+	toc.AddAt(Heading{Text: "خاتمة", ID: "h2-2", Direction: "rtl"}, 0, 1)
+
+	got := toc.ToHTML(2, -1, false)
+	c.Assert(got, qt.Equals, `<nav id="TableOfContents" dir="rtl">
+  <ul>
+    <li><a href="#h2-1">مقدمة</a>
+      <ul>
+        <li dir="ltr"><a href="#h3-1">Hugo</a>
+          <ul>
+            <li><a href="#h4-1">Go</a></li>
+          </ul>
+        </li>
+      </ul>
+    </li>
+    <li><a href="#h2-2">خاتمة</a></li>
+  </ul>
+</nav>`, qt.Commentf(got))
+}
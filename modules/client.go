@@ -184,9 +184,25 @@ func (c *Client) Tidy() error {
 		return nil
 	}
 
+	if err := c.tidyLockFile(tc.AllModules, coll.lock); err != nil {
+		return err
+	}
+
 	return c.tidy(tc.AllModules, false)
 }
 
+// tidyLockFile removes the modules no longer in use from hugo.lock.
+func (c *Client) tidyLockFile(mods Modules, lock *lockFile) error {
+	inUse := make(map[string]bool)
+	for _, m := range mods {
+		inUse[pathKey(m.Path())] = true
+	}
+	lock.retain(func(m *lockedModule) bool {
+		return inUse[pathKey(m.Path)]
+	})
+	return c.writeLockFile(lock)
+}
+
 // Vendor writes all the module dependencies to a _vendor folder.
 //
 // Unlike Go, we support it for any level.
@@ -233,7 +249,7 @@ func (c *Client) Vendor() error {
 			continue
 		}
 
-		if !t.IsGoMod() && !t.Vendor() {
+		if !t.IsGoMod() && !t.Vendor() && !isSourced(t) {
 			// We currently do not vendor components living in the
 			// theme directory, see https://github.com/gohugoio/hugo/issues/5993
 			continue
@@ -321,6 +337,10 @@ func (c *Client) Get(args ...string) error {
 
 		// We need to be explicit about the modules to get.
 		for _, m := range c.moduleConfig.Imports {
+			if m.Source != "" {
+				// Fetched by Hugo, see below.
+				continue
+			}
 			if !isProbablyModule(m.Path) {
 				// Skip themes/components stored below /themes etc.
 				// There may be false positives in the above, but those
@@ -342,12 +362,37 @@ func (c *Client) Get(args ...string) error {
 			}
 		}
 
-		return nil
+		return c.getSourced(update)
 	}
 
 	return c.get(args...)
 }
 
+// getSourced fetches the modules imported with a Source. If update is set,
+// the locked versions are ignored and the imports resolved again.
+func (c *Client) getSourced(update bool) error {
+	if update {
+		lock, err := readLockFile(c.fs, c.lockFilename())
+		if err != nil {
+			return err
+		}
+		lock.retain(func(m *lockedModule) bool {
+			for _, imp := range c.moduleConfig.Imports {
+				if imp.Source != "" && pathKey(imp.Path) == pathKey(m.Path) {
+					return false
+				}
+			}
+			return true
+		})
+		if err := c.writeLockFile(lock); err != nil {
+			return err
+		}
+	}
+
+	_, coll := c.collect(false)
+	return coll.err
+}
+
 func (c *Client) get(args ...string) error {
 	var hasD bool
 	for _, arg := range args {
@@ -386,6 +431,8 @@ var verifyErrorDirRe = regexp.MustCompile(`dir has been modified \((.*?)\)`)
 // Verify checks that the dependencies of the current module,
 // which are stored in a local downloaded source cache, have not been
 // modified since being downloaded.
+// Modules fetched from an Import Source are verified against the hashes
+// in hugo.lock.
 func (c *Client) Verify(clean bool) error {
 	// TODO(bep) add path to mod clean
 	err := c.runVerify()
@@ -437,13 +484,47 @@ func (c *Client) Clean(pattern string) error {
 			c.logger.Printf("hugo: cleaned module cache for %q", m.Path)
 		}
 	}
-	return err
+	if err != nil {
+		return err
+	}
+
+	lock, err := readLockFile(c.fs, c.lockFilename())
+	if err != nil {
+		return err
+	}
+
+	for _, m := range lock.Modules {
+		if g != nil && !g.Match(m.Path) {
+			continue
+		}
+		dir := filepath.Join(c.sourceCacheDir(), m.dirname())
+		if found, _ := afero.DirExists(c.fs, dir); !found {
+			continue
+		}
+		if _, err := hugofs.MakeReadableAndRemoveAllModulePkgDir(c.fs, dir); err != nil {
+			return err
+		}
+		c.logger.Printf("hugo: cleaned module cache for %q", m.Path)
+	}
+
+	return nil
 }
 
 func (c *Client) runVerify() error {
+	if err := c.verifyLockFile(); err != nil {
+		return err
+	}
+	if c.GoModulesFilename == "" {
+		return nil
+	}
 	return c.runGo(context.Background(), ioutil.Discard, "mod", "verify")
 }
 
+func isSourced(m Module) bool {
+	ma, ok := m.(*moduleAdapter)
+	return ok && ma.sourced
+}
+
 func isProbablyModule(path string) bool {
 	return module.CheckPath(path) == nil
 }
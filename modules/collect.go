@@ -171,6 +171,9 @@ type collected struct {
 	// Set if a Go modules enabled project.
 	gomods goModules
 
+	// The modules fetched from a Source, read from hugo.lock.
+	lock *lockFile
+
 	// Ordered list of collected modules, including Go Modules and theme
 	// components stored below /themes.
 	modules Modules
@@ -196,6 +199,12 @@ func (c *collector) initModules() error {
 		gomods:   goModules{},
 	}
 
+	lock, err := readLockFile(c.fs, c.lockFilename())
+	if err != nil {
+		return err
+	}
+	c.lock = lock
+
 	// If both these are true, we don't even need Go installed to build.
 	if c.ccfg.IgnoreVendor == nil && c.isVendored(c.ccfg.WorkingDir) {
 		return nil
@@ -225,6 +234,7 @@ func (c *collector) add(owner *moduleAdapter, moduleImport Import, disabled bool
 		moduleDir string
 		version   string
 		vendored  bool
+		sourced   bool
 	)
 
 	modulePath := moduleImport.Path
@@ -251,6 +261,16 @@ func (c *collector) add(owner *moduleAdapter, moduleImport Import, disabled bool
 		}
 	}
 
+	if moduleDir == "" && moduleImport.Source != "" {
+		var err error
+		moduleDir, version, err = c.resolveSource(moduleImport)
+		if err != nil {
+			c.err = err
+			return nil, nil
+		}
+		sourced = true
+	}
+
 	if moduleDir == "" {
 		var versionQuery string
 		mod = c.gomods.GetByPath(modulePath)
@@ -308,6 +328,7 @@ func (c *collector) add(owner *moduleAdapter, moduleImport Import, disabled bool
 	ma := &moduleAdapter{
 		dir:      moduleDir,
 		vendor:   vendored,
+		sourced:  sourced,
 		disabled: disabled,
 		gomod:    mod,
 		version:  version,
@@ -526,6 +547,10 @@ func (c *collector) collect() {
 
 	// Add the project mod on top.
 	c.modules = append(Modules{projectMod}, c.modules...)
+
+	if c.err == nil {
+		c.err = c.writeLockFile(c.lock)
+	}
 }
 
 func (c *collector) isVendored(dir string) bool {
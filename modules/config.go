@@ -14,6 +14,7 @@
 package modules
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -58,6 +59,24 @@ func ApplyProjectConfigDefaults(cfg config.Provider, mod Module) error {
 	// Map legacy directory config into the new module.
 	languages := cfg.Get("languagesSortedDefaultFirst").(langs.Languages)
 	isMultiHost := languages.IsMultihost()
+	versions := langs.GetVersions(cfg)
+
+	hasLanguageContentDir, hasVersionContentDir := false, false
+	for _, language := range languages {
+		if language.ContentDir != "" {
+			hasLanguageContentDir = true
+			break
+		}
+	}
+	for _, version := range versions {
+		if version.ContentDir != "" {
+			hasVersionContentDir = true
+			break
+		}
+	}
+	if hasLanguageContentDir && hasVersionContentDir {
+		return errors.New("contentDir cannot be set on both languages and versions; use module mounts with lang and version set instead")
+	}
 
 	// To bridge between old and new configuration format we need
 	// a way to make sure all of the core components are configured on
@@ -126,15 +145,8 @@ func ApplyProjectConfigDefaults(cfg config.Provider, mod Module) error {
 		if d.multilingual {
 			if d.component == files.ComponentFolderContent {
 				seen := make(map[string]bool)
-				hasContentDir := false
-				for _, language := range languages {
-					if language.ContentDir != "" {
-						hasContentDir = true
-						break
-					}
-				}
 
-				if hasContentDir {
+				if hasLanguageContentDir {
 					for _, language := range languages {
 						contentDir := language.ContentDir
 						if contentDir == "" {
@@ -146,6 +158,18 @@ func ApplyProjectConfigDefaults(cfg config.Provider, mod Module) error {
 						seen[contentDir] = true
 						mounts = append(mounts, Mount{Lang: language.Lang, Source: contentDir, Target: d.component})
 					}
+				} else if hasVersionContentDir {
+					for _, version := range versions {
+						contentDir := version.ContentDir
+						if contentDir == "" {
+							contentDir = files.ComponentFolderContent
+						}
+						if seen[contentDir] {
+							continue
+						}
+						seen[contentDir] = true
+						mounts = append(mounts, Mount{Version: version.Name, Source: contentDir, Target: d.component})
+					}
 				}
 
 				componentsConfigured[d.component] = len(seen) > 0
@@ -210,6 +234,16 @@ func decodeConfig(cfg config.Provider, pathReplacements map[string]string) (Conf
 			return c, err
 		}
 
+		for _, imp := range c.Imports {
+			if imp.Source == "" {
+				continue
+			}
+			// The path is used to name the module directory in the cache.
+			if err := checkSourcePath(imp.Path); err != nil {
+				return c, err
+			}
+		}
+
 		if c.replacementsMap == nil {
 
 			if len(c.Replacements) == 1 {
@@ -294,6 +328,12 @@ type Config struct {
 	// Configures GOPRIVATE.
 	Private string
 
+	// A local directory to fetch the modules imported with a Source from
+	// before trying the network, e.g. a copy of the pkg/hugo directory in the
+	// modules cache. Modules must be locked in hugo.lock to be fetched from
+	// the mirror.
+	Mirror string
+
 	// Set the workspace file to use, e.g. hugo.work.
 	// Enables Go "Workspace" mode.
 	// Requires Go 1.18+
@@ -305,7 +345,7 @@ type Config struct {
 // modules imports, e.g. github.com/bep/myshortcodes.
 func (c Config) hasModuleImport() bool {
 	for _, imp := range c.Imports {
-		if isProbablyModule(imp.Path) {
+		if imp.Source == "" && isProbablyModule(imp.Path) {
 			return true
 		}
 	}
@@ -375,6 +415,15 @@ type Import struct {
 	NoVendor            bool   // Never vendor this import (only allowed in main project).
 	Disable             bool   // Turn off this module.
 	Mounts              []Mount
+
+	// Fetch the module from this Git repository or zip/tar.gz archive
+	// (a URL or a filename relative to the project) instead of using Go
+	// Modules. The resolved version and content hash is stored in hugo.lock.
+	Source string
+
+	// The Git tag, branch or commit to check out when Source is a Git
+	// repository.
+	Version string
 }
 
 type Mount struct {
@@ -383,6 +432,8 @@ type Mount struct {
 
 	Lang string // any language code associated with this mount.
 
+	Version string // any content version associated with this mount.
+
 	// Include only files matching the given Glob patterns (string or slice).
 	IncludeFiles any
 
@@ -392,7 +443,7 @@ type Mount struct {
 
 // Used as key to remove duplicates.
 func (m Mount) key() string {
-	return strings.Join([]string{m.Lang, m.Source, m.Target}, "/")
+	return strings.Join([]string{m.Lang, m.Version, m.Source, m.Target}, "/")
 }
 
 func (m Mount) Component() string {
@@ -14,6 +14,7 @@
 package modules
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/gohugoio/hugo/common/hugo"
@@ -159,3 +160,31 @@ theme = ["a", "b"]
 	c.Assert(mcfg.Imports[0].Path, qt.Equals, "a")
 	c.Assert(mcfg.Imports[1].Path, qt.Equals, "b")
 }
+
+func TestDecodeConfigSourceImportPath(t *testing.T) {
+	c := qt.New(t)
+
+	for _, path := range []string{"/etc/mytheme", "example.org/../../mytheme", "..", `example.org\mytheme`} {
+		tomlConfig := fmt.Sprintf(`
+[module]
+[[module.imports]]
+path=%q
+source="https://example.org/mytheme.git"
+`, path)
+		cfg, err := config.FromConfigString(tomlConfig, "toml")
+		c.Assert(err, qt.IsNil)
+
+		_, err = DecodeConfig(cfg)
+		c.Assert(err, qt.ErrorMatches, `invalid module import: malformed import path.*`, qt.Commentf(path))
+	}
+
+	cfg, err := config.FromConfigString(`
+[module]
+[[module.imports]]
+path="example.org/mytheme"
+source="https://example.org/mytheme.git"
+`, "toml")
+	c.Assert(err, qt.IsNil)
+	_, err = DecodeConfig(cfg)
+	c.Assert(err, qt.IsNil)
+}
@@ -0,0 +1,223 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modules
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rogpeppe/go-internal/dirhash"
+	"github.com/rogpeppe/go-internal/module"
+	"github.com/spf13/afero"
+)
+
+const lockFilename = "hugo.lock"
+
+// lockFile holds the resolved versions and content hashes of the modules
+// fetched by Hugo itself, i.e. the imports with a Source set.
+type lockFile struct {
+	Modules []*lockedModule `json:"modules"`
+
+	dirty bool
+}
+
+// lockedModule is a module entry in hugo.lock.
+type lockedModule struct {
+	// The module path as used in the import.
+	Path string `json:"path"`
+
+	// The Git repository or archive URL.
+	Source string `json:"source"`
+
+	// The version requested, e.g. a Git tag or branch. May be empty.
+	Version string `json:"version,omitempty"`
+
+	// The resolved Git commit. Empty for archives.
+	Resolved string `json:"resolved,omitempty"`
+
+	// The content hash of the module files, e.g. "h1:...".
+	Hash string `json:"hash"`
+}
+
+// matches reports whether m was resolved from the given import.
+func (m *lockedModule) matches(imp Import) bool {
+	return m.Source == imp.Source && m.Version == imp.Version
+}
+
+// key returns a version identifying the resolved files of this module,
+// the commit for Git sources and derived from the content hash for archives.
+func (m *lockedModule) key() string {
+	if m.Resolved != "" {
+		return m.Resolved
+	}
+	h := sha256.Sum256([]byte(m.Hash))
+	return hex.EncodeToString(h[:8])
+}
+
+// version returns the version to report for this module.
+func (m *lockedModule) version() string {
+	if m.Version != "" {
+		return m.Version
+	}
+	key := m.key()
+	if len(key) > 12 {
+		key = key[:12]
+	}
+	return key
+}
+
+// validate returns an error if m cannot safely be used to name a directory
+// in the source cache, e.g. a path with ".." elements in a hand-edited
+// hugo.lock.
+func (m *lockedModule) validate() error {
+	if err := checkSourcePath(m.Path); err != nil {
+		return err
+	}
+	if m.Resolved != "" && !isCommitHash(m.Resolved) {
+		return fmt.Errorf("invalid resolved commit %q for module %q", m.Resolved, m.Path)
+	}
+	return nil
+}
+
+// checkSourcePath returns an error if path is not a valid import path for a
+// module with a Source, e.g. an absolute path or one with ".." elements.
+func checkSourcePath(path string) error {
+	if err := module.CheckImportPath(path); err != nil {
+		return fmt.Errorf("invalid module import: %w", err)
+	}
+	return nil
+}
+
+// isCommitHash reports whether s is a full hex encoded Git commit hash.
+func isCommitHash(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil && strings.ToLower(s) == s
+}
+
+// dirname returns the directory name, relative to the source cache or a
+// mirror, where this module is stored.
+func (m *lockedModule) dirname() string {
+	return filepath.FromSlash(m.Path) + "@" + m.key()
+}
+
+func (l *lockFile) get(path string) *lockedModule {
+	for _, m := range l.Modules {
+		if strings.EqualFold(m.Path, path) {
+			return m
+		}
+	}
+	return nil
+}
+
+func (l *lockFile) set(m *lockedModule) {
+	for i, mm := range l.Modules {
+		if strings.EqualFold(mm.Path, m.Path) {
+			if *mm != *m {
+				l.Modules[i] = m
+				l.dirty = true
+			}
+			return
+		}
+	}
+	l.Modules = append(l.Modules, m)
+	l.dirty = true
+}
+
+// retain removes all modules not matching keep.
+func (l *lockFile) retain(keep func(m *lockedModule) bool) {
+	var modules []*lockedModule
+	for _, m := range l.Modules {
+		if keep(m) {
+			modules = append(modules, m)
+		} else {
+			l.dirty = true
+		}
+	}
+	l.Modules = modules
+}
+
+func readLockFile(fs afero.Fs, filename string) (*lockFile, error) {
+	l := &lockFile{}
+	b, err := afero.ReadFile(fs, filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return l, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(b, l); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", lockFilename, err)
+	}
+	for _, m := range l.Modules {
+		if err := m.validate(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", lockFilename, err)
+		}
+	}
+	return l, nil
+}
+
+func (l *lockFile) write(fs afero.Fs, filename string) error {
+	sort.Slice(l.Modules, func(i, j int) bool {
+		return l.Modules[i].Path < l.Modules[j].Path
+	})
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(l); err != nil {
+		return err
+	}
+	if err := afero.WriteFile(fs, filename, b.Bytes(), 0666); err != nil {
+		return err
+	}
+	l.dirty = false
+	return nil
+}
+
+// hashDir calculates the "h1:" content hash of the files in dir, the same
+// hash as used in go.sum.
+func hashDir(fs afero.Fs, dir string) (string, error) {
+	var files []string
+	err := afero.Walk(fs, dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return dirhash.Hash1(files, func(name string) (io.ReadCloser, error) {
+		return fs.Open(filepath.Join(dir, filepath.FromSlash(name)))
+	})
+}
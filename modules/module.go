@@ -81,6 +81,7 @@ type moduleAdapter struct {
 	dir        string
 	version    string
 	vendor     bool
+	sourced    bool // Set if fetched from an Import Source.
 	disabled   bool
 	projectMod bool
 	owner      Module
@@ -174,6 +175,11 @@ func (m *moduleAdapter) Watch() bool {
 		return true
 	}
 
+	if m.sourced {
+		// Fetched into the modules cache.
+		return false
+	}
+
 	if !m.IsGoMod() {
 		// Module inside /themes
 		return true
@@ -0,0 +1,358 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modules
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rogpeppe/go-internal/semver"
+	"github.com/spf13/afero"
+)
+
+// OutdatedModule describes a module with a newer version available.
+type OutdatedModule struct {
+	// The module path.
+	Path string
+
+	// The version in use.
+	Version string
+
+	// The latest available version.
+	Latest string
+
+	// The Hugo version requirements of the latest version.
+	HugoVersion HugoVersion
+
+	// The mounts added and removed when upgrading to the latest version.
+	MountsAdded   []Mount
+	MountsRemoved []Mount
+}
+
+// Compatible reports whether the latest version works with the running
+// Hugo binary.
+func (m OutdatedModule) Compatible() bool {
+	return m.HugoVersion.IsValid()
+}
+
+// Outdated writes a list of the modules with newer versions available to w.
+// Go Modules are resolved using the configured module proxy, which may be a
+// file:// URL pointing to a local mirror. Modules imported from a Git Source
+// are checked against the semver tags in the repository.
+// If plan is set, the mount changes each upgrade would introduce are included.
+func (c *Client) Outdated(w io.Writer, plan bool) error {
+	mods, err := c.outdated()
+	if err != nil {
+		return err
+	}
+
+	if len(mods) == 0 {
+		fmt.Fprintln(w, "All modules are up to date.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODULE\tCURRENT\tLATEST\tHUGO")
+	for _, m := range mods {
+		status := "ok"
+		if !m.Compatible() {
+			status = fmt.Sprintf("incompatible (requires %s)", strings.TrimSpace(m.HugoVersion.String()))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", m.Path, m.Version, m.Latest, status)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if !plan {
+		return nil
+	}
+
+	for _, m := range mods {
+		fmt.Fprintf(w, "\n%s %s => %s\n", m.Path, m.Version, m.Latest)
+		if len(m.MountsAdded) == 0 && len(m.MountsRemoved) == 0 {
+			fmt.Fprintln(w, "  no mount changes")
+			continue
+		}
+		for _, mnt := range m.MountsRemoved {
+			fmt.Fprintf(w, "  - %s\n", mountString(mnt))
+		}
+		for _, mnt := range m.MountsAdded {
+			fmt.Fprintf(w, "  + %s\n", mountString(mnt))
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) outdated() ([]OutdatedModule, error) {
+	mc, coll := c.collect(true)
+	if coll.err != nil {
+		return nil, coll.err
+	}
+
+	updates, err := c.listGoModUpdates()
+	if err != nil {
+		return nil, err
+	}
+
+	var outdated []OutdatedModule
+
+	for _, m := range mc.AllModules {
+		if m.Owner() == nil || m.Vendor() || m.Replace() != nil {
+			continue
+		}
+
+		imp, found := findImport(m.Owner(), m.Path())
+		if !found {
+			continue
+		}
+
+		var (
+			latest string
+			dir    string
+			clean  func()
+		)
+
+		switch {
+		case isSourced(m):
+			if archiveSuffix(imp.Source) != "" {
+				// Archives are not versioned.
+				continue
+			}
+			latest, err = c.latestGitTag(imp.Source, imp.Version)
+			if err != nil {
+				return nil, err
+			}
+			if latest == "" {
+				continue
+			}
+			dir, clean, err = c.fetchGitTemp(imp.Source, latest)
+		case m.IsGoMod():
+			gm := updates.GetByPath(m.Path())
+			if gm == nil || gm.Update == nil {
+				continue
+			}
+			latest = gm.Update.Version
+			dir, err = c.downloadGoMod(m.Path(), latest)
+			clean = func() {}
+		default:
+			// Themes and components in the themes directory.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		upgraded, err := coll.loadModuleDir(m.Owner(), imp, dir)
+		clean()
+		if err != nil {
+			return nil, err
+		}
+
+		om := OutdatedModule{
+			Path:        m.Path(),
+			Version:     m.Version(),
+			Latest:      latest,
+			HugoVersion: upgraded.Config().HugoVersion,
+		}
+		om.MountsAdded, om.MountsRemoved = diffMounts(m.Mounts(), upgraded.Mounts())
+
+		outdated = append(outdated, om)
+	}
+
+	return outdated, nil
+}
+
+// loadModuleDir loads the config and mounts of the module in dir as imported
+// by imp, without adding it to the collected modules.
+func (c *collector) loadModuleDir(owner Module, imp Import, dir string) (Module, error) {
+	if !strings.HasSuffix(dir, fileSeparator) {
+		dir += fileSeparator
+	}
+
+	ma := &moduleAdapter{
+		path:  imp.Path,
+		dir:   dir,
+		owner: owner,
+	}
+
+	if !imp.IgnoreConfig {
+		if err := c.applyThemeConfig(ma); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.applyMounts(imp, ma); err != nil {
+		return nil, err
+	}
+
+	return ma, nil
+}
+
+func findImport(owner Module, path string) (Import, bool) {
+	for _, imp := range owner.Config().Imports {
+		if pathKey(imp.Path) == pathKey(path) {
+			return imp, true
+		}
+	}
+	return Import{}, false
+}
+
+func diffMounts(from, to []Mount) (added, removed []Mount) {
+	inFrom := make(map[string]bool)
+	for _, m := range from {
+		inFrom[m.key()] = true
+	}
+	inTo := make(map[string]bool)
+	for _, m := range to {
+		inTo[m.key()] = true
+		if !inFrom[m.key()] {
+			added = append(added, m)
+		}
+	}
+	for _, m := range from {
+		if !inTo[m.key()] {
+			removed = append(removed, m)
+		}
+	}
+	return
+}
+
+func mountString(m Mount) string {
+	s := filepath.ToSlash(m.Source) + " -> " + filepath.ToSlash(m.Target)
+	if m.Lang != "" {
+		s += " (" + m.Lang + ")"
+	}
+	return s
+}
+
+// latestGitTag returns the latest semver tag in the Git repository in source
+// newer than current, or an empty string if current is the latest or not a
+// semver version.
+func (c *Client) latestGitTag(source, current string) (string, error) {
+	if !semver.IsValid(current) {
+		return "", nil
+	}
+
+	if err := checkGitArg("source", source); err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	if err := c.runGit(c.ccfg.WorkingDir, &b, "ls-remote", "--tags", "--refs", "--", source); err != nil {
+		return "", err
+	}
+
+	var tags []string
+	scanner := bufio.NewScanner(&b)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		tag := strings.TrimPrefix(fields[1], "refs/tags/")
+		if !semver.IsValid(tag) {
+			continue
+		}
+		if semver.Prerelease(tag) != "" && semver.Prerelease(current) == "" {
+			// Only upgrade to a pre-release if already on one.
+			continue
+		}
+		tags = append(tags, tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return semver.Compare(tags[i], tags[j]) > 0
+	})
+
+	if len(tags) == 0 || semver.Compare(tags[0], current) <= 0 {
+		return "", nil
+	}
+
+	return tags[0], nil
+}
+
+// fetchGitTemp checks out ref of the Git repository in source into a
+// temporary directory. The returned func removes it.
+func (c *Client) fetchGitTemp(source, ref string) (string, func(), error) {
+	cacheDir := c.sourceCacheDir()
+	if err := c.fs.MkdirAll(cacheDir, 0777); err != nil {
+		return "", nil, err
+	}
+	dir, err := afero.TempDir(c.fs, cacheDir, "_tmp")
+	if err != nil {
+		return "", nil, err
+	}
+	clean := func() {
+		c.fs.RemoveAll(dir)
+	}
+	if _, err := c.fetchGit(source, ref, dir); err != nil {
+		clean()
+		return "", nil, err
+	}
+	return dir, clean, nil
+}
+
+// listGoModUpdates lists the Go Modules in use with any available update.
+func (c *Client) listGoModUpdates() (goModules, error) {
+	if c.GoModulesFilename == "" || !c.moduleConfig.hasModuleImport() {
+		return nil, nil
+	}
+
+	b := &bytes.Buffer{}
+	if err := c.runGo(context.Background(), b, "list", "-m", "-u", "-json", "all"); err != nil {
+		return nil, fmt.Errorf("failed to list module updates: %w", err)
+	}
+
+	var modules goModules
+	dec := json.NewDecoder(b)
+	for {
+		m := &goModule{}
+		if err := dec.Decode(m); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed to decode modules list: %w", err)
+		}
+		modules = append(modules, m)
+	}
+
+	return modules, nil
+}
+
+// downloadGoMod downloads the given version of a Go Module into the module
+// cache, without changing go.mod, and returns its directory.
+func (c *Client) downloadGoMod(path, version string) (string, error) {
+	b := &bytes.Buffer{}
+	if err := c.runGo(context.Background(), b, "mod", "download", "-json", path+"@"+version); err != nil {
+		return "", fmt.Errorf("failed to download module: %w", err)
+	}
+	var m goModule
+	if err := json.NewDecoder(b).Decode(&m); err != nil {
+		return "", fmt.Errorf("failed to decode module: %w", err)
+	}
+	if m.Dir == "" {
+		return "", fmt.Errorf("failed to download module %s@%s", path, version)
+	}
+	return m.Dir, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modules
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/common/hexec"
+	"github.com/gohugoio/hugo/htesting"
+	"github.com/gohugoio/hugo/hugofs"
+)
+
+func TestClientOutdated(t *testing.T) {
+	if !hexec.InPath("git") {
+		t.Skip("git not found")
+	}
+
+	c := qt.New(t)
+
+	workDir, clean, err := htesting.CreateTempDir(hugofs.Os, "hugo-modules-outdated-test")
+	c.Assert(err, qt.IsNil)
+	defer clean()
+
+	writeFile := func(filename, content string) {
+		c.Assert(os.MkdirAll(filepath.Dir(filename), 0777), qt.IsNil)
+		c.Assert(os.WriteFile(filename, []byte(content), 0666), qt.IsNil)
+	}
+
+	newRepo := func(name string) (string, func(args ...string)) {
+		dir := filepath.Join(workDir, "repos", name)
+		c.Assert(os.MkdirAll(dir, 0777), qt.IsNil)
+		git := newTestGitRunner(c, dir)
+		git("init", "--quiet")
+		return dir, git
+	}
+
+	// Upgrading mod1 adds an assets mount and requires a future Hugo version.
+	repo1, git1 := newRepo("mod1")
+	writeFile(filepath.Join(repo1, "layouts", "partials", "p.html"), "v1")
+	git1("add", "-A")
+	git1("commit", "--quiet", "-m", "v1")
+	git1("tag", "v1.0.0")
+	writeFile(filepath.Join(repo1, "assets", "main.css"), "body {}")
+	writeFile(filepath.Join(repo1, "config.toml"), "[module]\n[module.hugoVersion]\nmin = \"999.0.0\"\n")
+	git1("add", "-A")
+	git1("commit", "--quiet", "-m", "v1.1")
+	git1("tag", "v1.1.0")
+	git1("tag", "v2.0.0-beta1")
+
+	// mod2 is up to date.
+	repo2, git2 := newRepo("mod2")
+	writeFile(filepath.Join(repo2, "layouts", "partials", "p2.html"), "v1")
+	git2("add", "-A")
+	git2("commit", "--quiet", "-m", "v1")
+	git2("tag", "v1.0.0")
+
+	projectDir := filepath.Join(workDir, "project")
+	c.Assert(os.MkdirAll(filepath.Join(projectDir, "themes"), 0777), qt.IsNil)
+
+	mcfg := DefaultModuleConfig
+	mcfg.Imports = []Import{
+		{Path: "example.org/mod1", Source: repo1, Version: "v1.0.0"},
+		{Path: "example.org/mod2", Source: repo2, Version: "v1.0.0"},
+	}
+
+	client := NewClient(ClientConfig{
+		Fs:           hugofs.Os,
+		WorkingDir:   projectDir,
+		CacheDir:     filepath.Join(workDir, "cache"),
+		ThemesDir:    filepath.Join(projectDir, "themes"),
+		Exec:         newTestExec(),
+		ModuleConfig: mcfg,
+	})
+
+	mods, err := client.outdated()
+	c.Assert(err, qt.IsNil)
+	c.Assert(mods, qt.HasLen, 1)
+	m := mods[0]
+	c.Assert(m.Path, qt.Equals, "example.org/mod1")
+	c.Assert(m.Version, qt.Equals, "v1.0.0")
+	c.Assert(m.Latest, qt.Equals, "v1.1.0")
+	c.Assert(m.Compatible(), qt.IsFalse)
+	c.Assert(m.MountsAdded, qt.DeepEquals, []Mount{{Source: "assets", Target: "assets"}})
+	c.Assert(m.MountsRemoved, qt.HasLen, 0)
+
+	var b bytes.Buffer
+	c.Assert(client.Outdated(&b, true), qt.IsNil)
+	c.Assert(b.String(), qt.Equals, `MODULE            CURRENT  LATEST  HUGO
+example.org/mod1  v1.0.0   v1.1.0  incompatible (requires Min 999.0.0)
+
+example.org/mod1 v1.0.0 => v1.1.0
+  + assets -> assets
+`)
+
+	// The lock file is left untouched.
+	lock, err := readLockFile(hugofs.Os, filepath.Join(projectDir, lockFilename))
+	c.Assert(err, qt.IsNil)
+	c.Assert(lock.get("example.org/mod1").Version, qt.Equals, "v1.0.0")
+
+	_, err = client.latestGitTag("--upload-pack=touch "+filepath.Join(workDir, "pwned"), "v1.0.0")
+	c.Assert(err, qt.ErrorMatches, `invalid Git source "--upload-pack=.*": must not start with '-'`)
+	c.Assert(filepath.Join(workDir, "pwned"), qt.Not(qt.Satisfies), fileExists)
+}
+
+func TestDiffMounts(t *testing.T) {
+	c := qt.New(t)
+
+	from := []Mount{{Source: "layouts", Target: "layouts"}, {Source: "static", Target: "static"}}
+	to := []Mount{{Source: "layouts", Target: "layouts"}, {Source: "assets", Target: "assets", Lang: "en"}}
+
+	added, removed := diffMounts(from, to)
+	c.Assert(added, qt.DeepEquals, []Mount{{Source: "assets", Target: "assets", Lang: "en"}})
+	c.Assert(removed, qt.DeepEquals, []Mount{{Source: "static", Target: "static"}})
+	c.Assert(mountString(added[0]), qt.Equals, "assets -> assets (en)")
+}
@@ -0,0 +1,482 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modules
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gohugoio/hugo/common/collections"
+	"github.com/gohugoio/hugo/common/hexec"
+	"github.com/gohugoio/hugo/common/hugio"
+	"github.com/gohugoio/hugo/config"
+	"github.com/spf13/afero"
+)
+
+// The modules fetched by Hugo itself are stored below this directory in the
+// modules cache, next to the Go Modules in pkg/mod.
+const sourceCacheDirname = "pkg/hugo"
+
+var archiveSuffixes = []string{".zip", ".tar.gz", ".tgz"}
+
+// archiveSuffix returns the archive file suffix of source, e.g. ".zip",
+// or an empty string if source is not an archive.
+func archiveSuffix(source string) string {
+	source = strings.ToLower(source)
+	if isRemoteSource(source) {
+		// Ignore any query string.
+		source, _, _ = strings.Cut(source, "?")
+	}
+	for _, suffix := range archiveSuffixes {
+		if strings.HasSuffix(source, suffix) {
+			return suffix
+		}
+	}
+	return ""
+}
+
+func isRemoteSource(source string) bool {
+	return strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://")
+}
+
+func (c *Client) sourceCacheDir() string {
+	return filepath.Join(c.ccfg.CacheDir, filepath.FromSlash(sourceCacheDirname))
+}
+
+func (c *Client) mirrorDir() string {
+	mirror := c.moduleConfig.Mirror
+	if mirror == "" || filepath.IsAbs(mirror) {
+		return mirror
+	}
+	return filepath.Join(c.ccfg.WorkingDir, mirror)
+}
+
+func (c *Client) lockFilename() string {
+	return filepath.Join(c.ccfg.WorkingDir, lockFilename)
+}
+
+// resolveSource resolves a module imported from a Git repository or an
+// archive, fetching it into the modules cache if needed.
+// It returns the module directory and version.
+func (c *collector) resolveSource(imp Import) (string, string, error) {
+	if err := checkSourcePath(imp.Path); err != nil {
+		return "", "", err
+	}
+
+	locked := c.lock.get(imp.Path)
+	if locked != nil && !locked.matches(imp) {
+		// The import has changed since it was locked.
+		locked = nil
+	}
+
+	if locked != nil {
+		dir := filepath.Join(c.sourceCacheDir(), locked.dirname())
+		if found, _ := afero.DirExists(c.fs, dir); found {
+			return dir, locked.version(), nil
+		}
+	}
+
+	m, err := c.fetchSource(imp, locked)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch module %q from %q: %w", imp.Path, imp.Source, err)
+	}
+	c.lock.set(m)
+
+	return filepath.Join(c.sourceCacheDir(), m.dirname()), m.version(), nil
+}
+
+// fetchSource fetches the module imported by imp into the modules cache,
+// from the mirror if available, and verifies it against the locked hash,
+// if any.
+func (c *Client) fetchSource(imp Import, locked *lockedModule) (*lockedModule, error) {
+	cacheDir := c.sourceCacheDir()
+	if err := c.fs.MkdirAll(cacheDir, 0777); err != nil {
+		return nil, err
+	}
+	tempDir, err := afero.TempDir(c.fs, cacheDir, "_tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer c.fs.RemoveAll(tempDir)
+
+	m := &lockedModule{
+		Path:    imp.Path,
+		Source:  imp.Source,
+		Version: imp.Version,
+	}
+
+	fromMirror := false
+	if mirror := c.mirrorDir(); mirror != "" && locked != nil {
+		mirrored := filepath.Join(mirror, locked.dirname())
+		if found, _ := afero.DirExists(c.fs, mirrored); found {
+			if err := hugio.CopyDir(c.fs, mirrored, tempDir, nil); err != nil {
+				return nil, err
+			}
+			m.Resolved = locked.Resolved
+			fromMirror = true
+		}
+	}
+
+	if !fromMirror {
+		if archiveSuffix(imp.Source) != "" {
+			err = c.fetchArchive(imp.Source, tempDir)
+		} else {
+			ref := imp.Version
+			if locked != nil {
+				ref = locked.Resolved
+			}
+			m.Resolved, err = c.fetchGit(imp.Source, ref, tempDir)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m.Hash, err = hashDir(c.fs, tempDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if locked != nil && m.Hash != locked.Hash {
+		return nil, fmt.Errorf("checksum mismatch\n\tdownloaded: %s\n\t%s:  %s", m.Hash, lockFilename, locked.Hash)
+	}
+
+	dir := filepath.Join(cacheDir, m.dirname())
+	if err := c.fs.MkdirAll(filepath.Dir(dir), 0777); err != nil {
+		return nil, err
+	}
+	if err := c.fs.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := c.fs.Rename(tempDir, dir); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// fetchGit clones the Git repository in source into targetDir and checks
+// out ref, if set. It returns the resolved commit.
+func (c *Client) fetchGit(source, ref, targetDir string) (string, error) {
+	if err := checkGitArg("source", source); err != nil {
+		return "", err
+	}
+	if err := checkGitArg("version", ref); err != nil {
+		return "", err
+	}
+
+	if err := c.runGit(c.ccfg.WorkingDir, io.Discard, "clone", "--quiet", "--", source, targetDir); err != nil {
+		return "", err
+	}
+	if ref != "" {
+		if err := c.runGit(targetDir, io.Discard, "-c", "advice.detachedHead=false", "checkout", "--quiet", ref, "--"); err != nil {
+			return "", err
+		}
+	}
+
+	var b bytes.Buffer
+	if err := c.runGit(targetDir, &b, "rev-parse", "HEAD"); err != nil {
+		return "", err
+	}
+
+	// We only need the files.
+	if err := c.fs.RemoveAll(filepath.Join(targetDir, ".git")); err != nil {
+		return "", err
+	}
+
+	commit := strings.TrimSpace(b.String())
+	if !isCommitHash(commit) {
+		return "", fmt.Errorf("unexpected commit %q from git rev-parse", commit)
+	}
+
+	return commit, nil
+}
+
+// checkGitArg returns an error if the positional argument s would be read
+// as an option by git, e.g. a source set to "--upload-pack=<cmd>" in the
+// config of an imported theme.
+func checkGitArg(name, s string) error {
+	if strings.HasPrefix(s, "-") {
+		return fmt.Errorf("invalid Git %s %q: must not start with '-'", name, s)
+	}
+	return nil
+}
+
+func (c *Client) runGit(dir string, stdout io.Writer, args ...string) error {
+	var env []string
+	config.SetEnvVars(&env, "GIT_TERMINAL_PROMPT", "0")
+
+	stderr := new(bytes.Buffer)
+
+	argsv := collections.StringSliceToInterfaceSlice(args)
+	argsv = append(argsv, hexec.WithEnviron(env))
+	argsv = append(argsv, hexec.WithStderr(stderr))
+	argsv = append(argsv, hexec.WithStdout(stdout))
+	argsv = append(argsv, hexec.WithDir(dir))
+	argsv = append(argsv, hexec.WithContext(context.Background()))
+
+	cmd, err := c.ccfg.Exec.New("git", argsv...)
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.Run(); err != nil {
+		if hexec.IsNotFound(err) {
+			return errors.New("git is needed to fetch modules from a Git repository; install it or fetch the modules from a mirror")
+		}
+		if stderr.Len() > 0 {
+			return fmt.Errorf("git %s failed: %s", args[0], strings.TrimSpace(stderr.String()))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// fetchArchive extracts the zip or tar.gz archive in source, a URL or a
+// filename relative to the project, into targetDir.
+// Any top level directory shared by all files in the archive is removed.
+func (c *Client) fetchArchive(source, targetDir string) error {
+	var b []byte
+	if isRemoteSource(source) {
+		sec := c.ccfg.Exec.Sec()
+		if err := sec.CheckAllowedHTTPURL(source); err != nil {
+			return err
+		}
+		if err := sec.CheckAllowedHTTPMethod("GET"); err != nil {
+			return err
+		}
+
+		client := &http.Client{Timeout: sec.HTTPTimeout(5 * time.Minute)}
+		resp, err := client.Get(source)
+		if err != nil {
+			return sec.CheckHTTPError(source, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("failed to download archive: %s", resp.Status)
+		}
+		if b, err = io.ReadAll(sec.LimitHTTPBody(source, resp.Body)); err != nil {
+			return sec.CheckHTTPError(source, err)
+		}
+	} else {
+		filename := source
+		if !filepath.IsAbs(filename) {
+			filename = filepath.Join(c.ccfg.WorkingDir, filename)
+		}
+		var err error
+		if b, err = afero.ReadFile(c.fs, filename); err != nil {
+			return err
+		}
+	}
+
+	var files []archiveFile
+	var err error
+	if archiveSuffix(source) == ".zip" {
+		files, err = readZip(b)
+	} else {
+		files, err = readTarGz(b)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	prefix := commonArchiveDir(files)
+
+	for _, f := range files {
+		name := strings.TrimPrefix(f.name, prefix)
+		if name == "" {
+			continue
+		}
+		filename := filepath.Join(targetDir, filepath.FromSlash(name))
+		if err := c.fs.MkdirAll(filepath.Dir(filename), 0777); err != nil {
+			return err
+		}
+		if err := afero.WriteFile(c.fs, filename, f.data, 0666); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type archiveFile struct {
+	name string
+	data []byte
+}
+
+func cleanArchiveName(name string) (string, error) {
+	name = path.Clean(strings.ReplaceAll(name, "\\", "/"))
+	if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return "", fmt.Errorf("invalid file name %q in archive", name)
+	}
+	return name, nil
+}
+
+// The maximum decompressed size of a single file and of all files in a
+// module archive, to guard against decompression bombs.
+var (
+	maxArchiveFileSize int64 = 100 << 20
+	maxArchiveSize     int64 = 500 << 20
+)
+
+// archiveReader reads the files of an archive, enforcing the size limits.
+type archiveReader struct {
+	size int64
+}
+
+func (ar *archiveReader) read(name string, r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxArchiveFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxArchiveFileSize {
+		return nil, fmt.Errorf("archive file %q exceeds the maximum size of %d bytes", name, maxArchiveFileSize)
+	}
+	ar.size += int64(len(data))
+	if ar.size > maxArchiveSize {
+		return nil, fmt.Errorf("archive exceeds the maximum decompressed size of %d bytes", maxArchiveSize)
+	}
+	return data, nil
+}
+
+func readZip(b []byte) ([]archiveFile, error) {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, err
+	}
+	var ar archiveReader
+	var files []archiveFile
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() {
+			continue
+		}
+		name, err := cleanArchiveName(zf.Name)
+		if err != nil {
+			return nil, err
+		}
+		f, err := zf.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := ar.read(name, f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, archiveFile{name: name, data: data})
+	}
+	return files, nil
+}
+
+func readTarGz(b []byte) ([]archiveFile, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+	var ar archiveReader
+	var files []archiveFile
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			// Skip directories, symlinks etc.
+			continue
+		}
+		name, err := cleanArchiveName(hdr.Name)
+		if err != nil {
+			return nil, err
+		}
+		data, err := ar.read(name, tr)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, archiveFile{name: name, data: data})
+	}
+	return files, nil
+}
+
+// commonArchiveDir returns the top level directory, with a trailing slash,
+// shared by all files, e.g. "mytheme-1.0.0/" in archives created by GitHub.
+func commonArchiveDir(files []archiveFile) string {
+	var prefix string
+	for i, f := range files {
+		dir, _, found := strings.Cut(f.name, "/")
+		if !found {
+			return ""
+		}
+		if i == 0 {
+			prefix = dir + "/"
+		} else if !strings.HasPrefix(f.name, prefix) {
+			return ""
+		}
+	}
+	return prefix
+}
+
+// verifyLockFile checks that the modules in hugo.lock stored in the modules
+// cache have not been modified since being fetched.
+func (c *Client) verifyLockFile() error {
+	lock, err := readLockFile(c.fs, c.lockFilename())
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	for _, m := range lock.Modules {
+		dir := filepath.Join(c.sourceCacheDir(), m.dirname())
+		if found, _ := afero.DirExists(c.fs, dir); !found {
+			// Not fetched.
+			continue
+		}
+		hash, err := hashDir(c.fs, dir)
+		if err != nil {
+			return err
+		}
+		if hash != m.Hash {
+			errs = append(errs, fmt.Sprintf("%s %s: dir has been modified (%s)", m.Path, m.version(), dir))
+		}
+	}
+
+	if errs != nil {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+func (c *Client) writeLockFile(lock *lockFile) error {
+	if lock == nil || !lock.dirty {
+		return nil
+	}
+	return lock.write(c.fs, c.lockFilename())
+}
@@ -0,0 +1,410 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modules
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/common/hexec"
+	"github.com/gohugoio/hugo/common/hugio"
+	"github.com/gohugoio/hugo/config/security"
+	"github.com/gohugoio/hugo/htesting"
+	"github.com/gohugoio/hugo/hugofs"
+	"github.com/spf13/afero"
+)
+
+func TestClientSource(t *testing.T) {
+	if !hexec.InPath("git") {
+		t.Skip("git not found")
+	}
+
+	c := qt.New(t)
+
+	workDir, clean, err := htesting.CreateTempDir(hugofs.Os, "hugo-modules-source-test")
+	c.Assert(err, qt.IsNil)
+	defer clean()
+
+	writeFile := func(filename, content string) {
+		c.Assert(os.MkdirAll(filepath.Dir(filename), 0777), qt.IsNil)
+		c.Assert(os.WriteFile(filename, []byte(content), 0666), qt.IsNil)
+	}
+
+	// A Git repository with two tagged versions.
+	repoDir := filepath.Join(workDir, "repos", "gitmod")
+	git := newTestGitRunner(c, repoDir)
+	writeFile(filepath.Join(repoDir, "layouts", "partials", "git.html"), "v1")
+	git("init", "--quiet")
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "v1")
+	git("tag", "v1.0.0")
+	writeFile(filepath.Join(repoDir, "layouts", "partials", "git.html"), "v2")
+	git("commit", "--quiet", "-am", "v2")
+	git("tag", "v2.0.0")
+
+	// A zip archive with a top level directory.
+	var zipb bytes.Buffer
+	zw := zip.NewWriter(&zipb)
+	for name, content := range map[string]string{
+		"zipmod-1.0/layouts/partials/zip.html": "zip",
+		"zipmod-1.0/config.toml":               "[params]\nzip = true\n",
+	} {
+		w, err := zw.Create(name)
+		c.Assert(err, qt.IsNil)
+		w.Write([]byte(content))
+	}
+	c.Assert(zw.Close(), qt.IsNil)
+	writeFile(filepath.Join(workDir, "repos", "zipmod.zip"), zipb.String())
+
+	projectDir := filepath.Join(workDir, "project")
+	c.Assert(os.MkdirAll(filepath.Join(projectDir, "themes"), 0777), qt.IsNil)
+
+	newClient := func(cacheDir, mirror string, imports ...Import) *Client {
+		mcfg := DefaultModuleConfig
+		mcfg.Mirror = mirror
+		mcfg.Imports = imports
+		return NewClient(ClientConfig{
+			Fs:           hugofs.Os,
+			WorkingDir:   projectDir,
+			CacheDir:     cacheDir,
+			ThemesDir:    filepath.Join(projectDir, "themes"),
+			Exec:         newTestExec(),
+			ModuleConfig: mcfg,
+		})
+	}
+
+	gitImport := Import{Path: "example.org/gitmod", Source: repoDir, Version: "v1.0.0"}
+	zipImport := Import{Path: "example.org/zipmod", Source: "../repos/zipmod.zip"}
+	cacheDir := filepath.Join(workDir, "cache")
+
+	client := newClient(cacheDir, "", gitImport, zipImport)
+	mc, err := client.Collect()
+	c.Assert(err, qt.IsNil)
+	c.Assert(len(mc.AllModules), qt.Equals, 3)
+
+	gitMod, zipMod := mc.AllModules[1], mc.AllModules[2]
+	c.Assert(gitMod.Path(), qt.Equals, "example.org/gitmod")
+	c.Assert(gitMod.Version(), qt.Equals, "v1.0.0")
+	c.Assert(gitMod.IsGoMod(), qt.IsFalse)
+	c.Assert(gitMod.Watch(), qt.IsFalse)
+	b, err := os.ReadFile(filepath.Join(gitMod.Dir(), "layouts", "partials", "git.html"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(b), qt.Equals, "v1")
+	c.Assert(filepath.Join(gitMod.Dir(), ".git"), qt.Not(qt.Satisfies), dirExists)
+
+	c.Assert(filepath.Join(zipMod.Dir(), "layouts", "partials", "zip.html"), qt.Satisfies, fileExists)
+	c.Assert(zipMod.Cfg().GetBool("params.zip"), qt.IsTrue)
+
+	lock, err := readLockFile(hugofs.Os, filepath.Join(projectDir, lockFilename))
+	c.Assert(err, qt.IsNil)
+	c.Assert(len(lock.Modules), qt.Equals, 2)
+	lockedGit := lock.get("example.org/gitmod")
+	c.Assert(lockedGit.Version, qt.Equals, "v1.0.0")
+	c.Assert(lockedGit.Resolved, qt.HasLen, 40)
+	c.Assert(lockedGit.Hash, qt.Matches, `h1:.*=`)
+	c.Assert(lock.get("example.org/zipmod").Resolved, qt.Equals, "")
+
+	c.Assert(client.Verify(false), qt.IsNil)
+
+	c.Run("Git not allowed", func(c *qt.C) {
+		mcfg := DefaultModuleConfig
+		mcfg.Imports = []Import{gitImport}
+		client := NewClient(ClientConfig{
+			Fs:           hugofs.Os,
+			WorkingDir:   projectDir,
+			CacheDir:     filepath.Join(workDir, "cache-notallowed"),
+			ThemesDir:    filepath.Join(projectDir, "themes"),
+			Exec:         hexec.New(security.DefaultConfig),
+			ModuleConfig: mcfg,
+		})
+		_, err := client.Collect()
+		c.Assert(err, qt.ErrorMatches, `(?s).*access denied: "git" is not whitelisted in policy "security.exec.allow".*`)
+	})
+
+	c.Run("Locked", func(c *qt.C) {
+		// Move the tag, the locked commit should still be used.
+		git("tag", "-f", "v1.0.0", "v2.0.0")
+		defer git("tag", "-f", "v1.0.0", "v2.0.0^")
+
+		client := newClient(filepath.Join(workDir, "cache2"), "", gitImport)
+		mc, err := client.Collect()
+		c.Assert(err, qt.IsNil)
+		b, err := os.ReadFile(filepath.Join(mc.AllModules[1].Dir(), "layouts", "partials", "git.html"))
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(b), qt.Equals, "v1")
+	})
+
+	c.Run("Mirror", func(c *qt.C) {
+		mirror := filepath.Join(workDir, "mirror")
+		c.Assert(hugio.CopyDir(hugofs.Os, filepath.Join(cacheDir, "pkg", "hugo"), mirror, nil), qt.IsNil)
+
+		// The sources are not available.
+		gitImport, zipImport := gitImport, zipImport
+		gitImport.Source = "https://example.org/notfound.git"
+		zipImport.Source = "https://example.org/notfound.zip"
+		// Changing the Source would invalidate the lock, so keep it in sync.
+		lock, err := readLockFile(hugofs.Os, filepath.Join(projectDir, lockFilename))
+		c.Assert(err, qt.IsNil)
+		lock.get(gitImport.Path).Source = gitImport.Source
+		lock.get(zipImport.Path).Source = zipImport.Source
+		c.Assert(lock.write(hugofs.Os, filepath.Join(projectDir, lockFilename)), qt.IsNil)
+
+		client := newClient(filepath.Join(workDir, "cache3"), "../mirror", gitImport, zipImport)
+		mc, err := client.Collect()
+		c.Assert(err, qt.IsNil)
+		c.Assert(filepath.Join(mc.AllModules[1].Dir(), "layouts", "partials", "git.html"), qt.Satisfies, fileExists)
+		c.Assert(filepath.Join(mc.AllModules[2].Dir(), "layouts", "partials", "zip.html"), qt.Satisfies, fileExists)
+
+		// A tampered mirror.
+		c.Assert(os.WriteFile(filepath.Join(mirror, lock.get(gitImport.Path).dirname(), "layouts", "partials", "git.html"), []byte("tampered"), 0666), qt.IsNil)
+		client = newClient(filepath.Join(workDir, "cache4"), "../mirror", gitImport, zipImport)
+		_, err = client.Collect()
+		c.Assert(err, qt.ErrorMatches, `(?s).*checksum mismatch.*`)
+
+		// Restore the lock file.
+		lock.get(gitImport.Path).Source = repoDir
+		lock.get(zipImport.Path).Source = "../repos/zipmod.zip"
+		c.Assert(lock.write(hugofs.Os, filepath.Join(projectDir, lockFilename)), qt.IsNil)
+	})
+
+	c.Run("Verify", func(c *qt.C) {
+		filename := filepath.Join(gitMod.Dir(), "layouts", "partials", "git.html")
+		c.Assert(os.WriteFile(filename, []byte("modified"), 0666), qt.IsNil)
+
+		client := newClient(cacheDir, "", gitImport, zipImport)
+		err := client.Verify(false)
+		c.Assert(err, qt.ErrorMatches, `example.org/gitmod v1.0.0: dir has been modified.*`)
+		c.Assert(client.Verify(true), qt.IsNil)
+		c.Assert(filename, qt.Not(qt.Satisfies), fileExists)
+
+		// Fetch it again.
+		_, err = client.Collect()
+		c.Assert(err, qt.IsNil)
+		c.Assert(filename, qt.Satisfies, fileExists)
+		c.Assert(client.Verify(false), qt.IsNil)
+	})
+
+	c.Run("Update and tidy", func(c *qt.C) {
+		gitImport := gitImport
+		gitImport.Version = "v2.0.0"
+		client := newClient(cacheDir, "", gitImport)
+		mc, err := client.Collect()
+		c.Assert(err, qt.IsNil)
+		b, err := os.ReadFile(filepath.Join(mc.AllModules[1].Dir(), "layouts", "partials", "git.html"))
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(b), qt.Equals, "v2")
+
+		c.Assert(client.Tidy(), qt.IsNil)
+		lock, err := readLockFile(hugofs.Os, filepath.Join(projectDir, lockFilename))
+		c.Assert(err, qt.IsNil)
+		c.Assert(len(lock.Modules), qt.Equals, 1)
+		c.Assert(lock.Modules[0].Version, qt.Equals, "v2.0.0")
+	})
+}
+
+func TestClientSourceArchiveHTTPPolicy(t *testing.T) {
+	c := qt.New(t)
+
+	workDir, clean, err := htesting.CreateTempDir(hugofs.Os, "hugo-modules-source-test")
+	c.Assert(err, qt.IsNil)
+	defer clean()
+
+	sec := security.DefaultConfig
+	sec.HTTP.URLs = security.NewWhitelist("none")
+
+	mcfg := DefaultModuleConfig
+	mcfg.Imports = []Import{{Path: "example.org/zipmod", Source: "https://example.org/zipmod.zip"}}
+	client := NewClient(ClientConfig{
+		Fs:           hugofs.Os,
+		WorkingDir:   workDir,
+		CacheDir:     filepath.Join(workDir, "cache"),
+		ThemesDir:    filepath.Join(workDir, "themes"),
+		Exec:         hexec.New(sec),
+		ModuleConfig: mcfg,
+	})
+
+	_, err = client.Collect()
+	c.Assert(err, qt.ErrorMatches, `(?s).*access denied: "https://example.org/zipmod.zip" is not whitelisted in policy "security.http.urls".*`)
+}
+
+func TestClientSourceGitOptionInjection(t *testing.T) {
+	c := qt.New(t)
+
+	workDir, clean, err := htesting.CreateTempDir(hugofs.Os, "hugo-modules-source-test")
+	c.Assert(err, qt.IsNil)
+	defer clean()
+
+	pwned := filepath.Join(workDir, "pwned")
+
+	for _, imp := range []Import{
+		{Path: "example.org/source", Source: "--upload-pack=touch " + pwned},
+		{Path: "example.org/version", Source: "https://example.org/theme.git", Version: "--orphan=" + pwned},
+	} {
+		mcfg := DefaultModuleConfig
+		mcfg.Imports = []Import{imp}
+		client := NewClient(ClientConfig{
+			Fs:           hugofs.Os,
+			WorkingDir:   workDir,
+			CacheDir:     filepath.Join(workDir, "cache"),
+			ThemesDir:    filepath.Join(workDir, "themes"),
+			Exec:         newTestExec(),
+			ModuleConfig: mcfg,
+		})
+
+		_, err := client.Collect()
+		c.Assert(err, qt.ErrorMatches, `(?s).*invalid Git (source|version) "--.*": must not start with '-'.*`)
+		c.Assert(pwned, qt.Not(qt.Satisfies), fileExists)
+	}
+}
+
+func TestCommonArchiveDir(t *testing.T) {
+	c := qt.New(t)
+
+	files := func(names ...string) []archiveFile {
+		var files []archiveFile
+		for _, name := range names {
+			files = append(files, archiveFile{name: name})
+		}
+		return files
+	}
+
+	c.Assert(commonArchiveDir(files("a/b.txt", "a/c/d.txt")), qt.Equals, "a/")
+	c.Assert(commonArchiveDir(files("a/b.txt", "ab/c.txt")), qt.Equals, "")
+	c.Assert(commonArchiveDir(files("a/b.txt", "c.txt")), qt.Equals, "")
+	c.Assert(commonArchiveDir(nil), qt.Equals, "")
+
+	_, err := cleanArchiveName("../foo.txt")
+	c.Assert(err, qt.Not(qt.IsNil))
+	name, err := cleanArchiveName("a/./b/../c.txt")
+	c.Assert(err, qt.IsNil)
+	c.Assert(name, qt.Equals, "a/c.txt")
+
+	c.Assert(archiveSuffix("https://example.org/theme.tar.gz?token=foo"), qt.Equals, ".tar.gz")
+	c.Assert(archiveSuffix("../theme.ZIP"), qt.Equals, ".zip")
+	c.Assert(archiveSuffix("https://github.com/gohugoio/theme.git"), qt.Equals, "")
+}
+
+// newTestExec returns an Exec allowing git, which is needed to fetch
+// modules from Git.
+func newTestExec() *hexec.Exec {
+	sec := security.DefaultConfig
+	sec.Exec.Allow = security.NewWhitelist("^git$")
+	return hexec.New(sec)
+}
+
+func newTestGitRunner(c *qt.C, dir string) func(args ...string) {
+	return func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Hugo", "-c", "user.email=hugo@example.org"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		c.Assert(err, qt.IsNil, qt.Commentf(string(out)))
+	}
+}
+
+func fileExists(filename string) bool {
+	found, _ := afero.Exists(hugofs.Os, filename)
+	return found
+}
+
+func dirExists(filename string) bool {
+	found, _ := afero.DirExists(hugofs.Os, filename)
+	return found
+}
+
+func TestReadLockFileInvalid(t *testing.T) {
+	c := qt.New(t)
+
+	fs := afero.NewMemMapFs()
+	for _, test := range []struct {
+		m      lockedModule
+		expect string
+	}{
+		{lockedModule{Path: "../../mytheme", Source: "https://example.org/mytheme.git"}, `.*malformed import path.*`},
+		{lockedModule{Path: "/mytheme", Source: "https://example.org/mytheme.git"}, `.*malformed import path.*`},
+		{lockedModule{Path: "example.org/mytheme", Source: "https://example.org/mytheme.git", Resolved: "../../foo"}, `.*invalid resolved commit.*`},
+	} {
+		l := &lockFile{Modules: []*lockedModule{&test.m}}
+		c.Assert(l.write(fs, lockFilename), qt.IsNil)
+		_, err := readLockFile(fs, lockFilename)
+		c.Assert(err, qt.ErrorMatches, test.expect)
+	}
+
+	l := &lockFile{Modules: []*lockedModule{{Path: "example.org/mytheme", Source: "https://example.org/mytheme.git", Resolved: "3e1c8f61c18a6c2f4ad0e3d2d4d0a16c2a0a5f7e"}}}
+	c.Assert(l.write(fs, lockFilename), qt.IsNil)
+	_, err := readLockFile(fs, lockFilename)
+	c.Assert(err, qt.IsNil)
+}
+
+func TestReadArchiveSizeLimits(t *testing.T) {
+	c := qt.New(t)
+
+	defer func(fileSize, size int64) {
+		maxArchiveFileSize, maxArchiveSize = fileSize, size
+	}(maxArchiveFileSize, maxArchiveSize)
+	maxArchiveFileSize, maxArchiveSize = 1000, 2500
+
+	newZip := func(sizes ...int) []byte {
+		var b bytes.Buffer
+		zw := zip.NewWriter(&b)
+		for i, size := range sizes {
+			w, err := zw.Create(fmt.Sprintf("mod/f%d.txt", i))
+			c.Assert(err, qt.IsNil)
+			w.Write(make([]byte, size))
+		}
+		c.Assert(zw.Close(), qt.IsNil)
+		return b.Bytes()
+	}
+
+	newTarGz := func(sizes ...int) []byte {
+		var b bytes.Buffer
+		gw := gzip.NewWriter(&b)
+		tw := tar.NewWriter(gw)
+		for i, size := range sizes {
+			c.Assert(tw.WriteHeader(&tar.Header{Name: fmt.Sprintf("mod/f%d.txt", i), Mode: 0666, Size: int64(size), Typeflag: tar.TypeReg}), qt.IsNil)
+			tw.Write(make([]byte, size))
+		}
+		c.Assert(tw.Close(), qt.IsNil)
+		c.Assert(gw.Close(), qt.IsNil)
+		return b.Bytes()
+	}
+
+	for _, test := range []struct {
+		name string
+		pack func(sizes ...int) []byte
+		read func(b []byte) ([]archiveFile, error)
+	}{
+		{"zip", newZip, readZip},
+		{"tar.gz", newTarGz, readTarGz},
+	} {
+		c.Run(test.name, func(c *qt.C) {
+			files, err := test.read(test.pack(1000, 1000))
+			c.Assert(err, qt.IsNil)
+			c.Assert(files, qt.HasLen, 2)
+
+			_, err = test.read(test.pack(1001))
+			c.Assert(err, qt.ErrorMatches, `archive file "mod/f0.txt" exceeds the maximum size of 1000 bytes`)
+
+			_, err = test.read(test.pack(1000, 1000, 1000))
+			c.Assert(err, qt.ErrorMatches, `archive exceeds the maximum decompressed size of 2500 bytes`)
+		})
+	}
+}
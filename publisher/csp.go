@@ -0,0 +1,389 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publisher
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+
+	"github.com/gohugoio/hugo/config"
+)
+
+var (
+	cspHeadRe           = regexp.MustCompile(`(?i)<head(\s[^>]*)?>`)
+	cspInlineAttrRe     = regexp.MustCompile(`(?i)\s(style|on[a-z]+)\s*=`)
+	cspAttrValueEscaper = strings.NewReplacer(`&`, "&amp;", `"`, "&quot;")
+)
+
+// CSPSources maps a Content Security Policy directive, e.g. "script-src",
+// to its sources.
+type CSPSources map[string][]string
+
+func (s CSPSources) add(directive, source string) {
+	for _, v := range s[directive] {
+		if v == source {
+			return
+		}
+	}
+	s[directive] = append(s[directive], source)
+}
+
+// Merge adds the sources in other not already in s.
+func (s CSPSources) Merge(other CSPSources) {
+	for directive, sources := range other {
+		for _, source := range sources {
+			s.add(directive, source)
+		}
+	}
+}
+
+// Policy returns the policy with the sources in s added to the base policy.
+// Directives not in base will be allowed from 'self' in addition to the collected sources.
+func (s CSPSources) Policy(base map[string][]string) string {
+	merged := make(CSPSources)
+	for directive, sources := range base {
+		merged[directive] = append([]string(nil), sources...)
+	}
+	for directive, sources := range s {
+		if _, found := merged[directive]; !found {
+			merged[directive] = []string{"'self'"}
+		}
+		for _, source := range sources {
+			merged.add(directive, source)
+		}
+	}
+
+	directives := make([]string, 0, len(merged))
+	for directive := range merged {
+		directives = append(directives, directive)
+	}
+	sort.Strings(directives)
+
+	var sb strings.Builder
+	for i, directive := range directives {
+		if i > 0 {
+			sb.WriteString("; ")
+		}
+		sb.WriteString(directive)
+		for _, source := range merged[directive] {
+			sb.WriteByte(' ')
+			sb.WriteString(source)
+		}
+	}
+
+	return sb.String()
+}
+
+func (s CSPSources) sort() {
+	for _, sources := range s {
+		sort.Strings(sources)
+	}
+}
+
+// CSPStats holds the Content Security Policy sources collected from the published HTML.
+type CSPStats struct {
+	// The sources collected from all pages.
+	Directives CSPSources `json:"directives"`
+
+	// The base policy with the sources from all pages added.
+	Policy string `json:"policy"`
+
+	// The policy per page, keyed by its slash separated publish path, e.g. "/posts/p1/index.html".
+	Pages map[string]string `json:"pages"`
+}
+
+type cspCollector struct {
+	cfg config.CSP
+
+	// The scheme and host of the site's baseURL. Sources from this origin
+	// are covered by 'self'.
+	selfOrigin string
+
+	mu    sync.Mutex
+	pages map[string]CSPSources
+}
+
+func newCSPCollector(cfg config.CSP, baseURL string) *cspCollector {
+	c := &cspCollector{
+		cfg:   cfg,
+		pages: make(map[string]CSPSources),
+	}
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		c.selfOrigin = u.Scheme + "://" + u.Host
+	}
+	return c
+}
+
+func (c *cspCollector) setPage(targetPath string, sources CSPSources) {
+	c.mu.Lock()
+	c.pages[path.Join("/", strings.ReplaceAll(targetPath, "\\", "/"))] = sources
+	c.mu.Unlock()
+}
+
+func (c *cspCollector) getCSPStats() *CSPStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := &CSPStats{
+		Directives: make(CSPSources),
+		Pages:      make(map[string]string),
+	}
+	for p, sources := range c.pages {
+		stats.Directives.Merge(sources)
+		stats.Pages[p] = sources.Policy(c.cfg.Directives)
+	}
+	stats.Directives.sort()
+	stats.Policy = stats.Directives.Policy(c.cfg.Directives)
+
+	return stats
+}
+
+// addElement adds any external origins and inline style attributes and
+// event handlers in the start tag el to sources.
+// It reports whether the element's inline content should be hashed.
+func (c *cspCollector) addElement(sources CSPSources, el []byte) bool {
+	tagName := strings.ToLower(parseStartTag(string(el)))
+
+	var hasOrigins bool
+	switch tagName {
+	case "script", "style", "link", "img", "source", "iframe", "video", "audio", "embed", "object", "form":
+		hasOrigins = true
+	}
+
+	if !hasOrigins && !cspInlineAttrRe.Match(el) {
+		return false
+	}
+
+	attrs := make(map[string]string)
+	z := html.NewTokenizer(bytes.NewReader(el))
+	if tt := z.Next(); tt == html.StartTagToken || tt == html.SelfClosingTagToken {
+		for {
+			k, v, more := z.TagAttr()
+			attrs[string(k)] = string(v)
+			c.addInlineAttr(sources, string(k), string(v))
+			if !more {
+				break
+			}
+		}
+	}
+
+	if !hasOrigins {
+		return false
+	}
+
+	addOrigin := func(directive, s string) {
+		if origin := c.origin(s); origin != "" {
+			sources.add(directive, origin)
+		}
+	}
+
+	switch tagName {
+	case "script":
+		if src, found := attrs["src"]; found {
+			addOrigin("script-src", src)
+			return false
+		}
+		switch strings.ToLower(attrs["type"]) {
+		case "", "module", "text/javascript", "application/javascript":
+			return true
+		}
+		// E.g. JSON-LD, which is not executed.
+		return false
+	case "style":
+		return true
+	case "link":
+		href := attrs["href"]
+		for _, rel := range strings.Fields(strings.ToLower(attrs["rel"])) {
+			switch rel {
+			case "stylesheet":
+				addOrigin("style-src", href)
+			case "icon", "apple-touch-icon":
+				addOrigin("img-src", href)
+			case "manifest":
+				addOrigin("manifest-src", href)
+			case "modulepreload":
+				addOrigin("script-src", href)
+			case "preload":
+				switch attrs["as"] {
+				case "script":
+					addOrigin("script-src", href)
+				case "style":
+					addOrigin("style-src", href)
+				case "font":
+					addOrigin("font-src", href)
+				case "image":
+					addOrigin("img-src", href)
+				}
+			}
+		}
+	case "img":
+		addOrigin("img-src", attrs["src"])
+		for _, src := range parseSrcset(attrs["srcset"]) {
+			addOrigin("img-src", src)
+		}
+	case "source":
+		addOrigin("media-src", attrs["src"])
+		for _, src := range parseSrcset(attrs["srcset"]) {
+			addOrigin("img-src", src)
+		}
+	case "iframe":
+		addOrigin("frame-src", attrs["src"])
+	case "video", "audio":
+		addOrigin("media-src", attrs["src"])
+		addOrigin("img-src", attrs["poster"])
+	case "embed":
+		addOrigin("object-src", attrs["src"])
+	case "object":
+		addOrigin("object-src", attrs["data"])
+	case "form":
+		addOrigin("form-action", attrs["action"])
+	}
+
+	return false
+}
+
+// addInline adds the hash of the inline content of a script or style element to sources.
+func (c *cspCollector) addInline(sources CSPSources, tagName string, content []byte) {
+	hash := cspHash(content)
+
+	if strings.EqualFold(tagName, "style") {
+		sources.add("style-src", hash)
+	} else {
+		sources.add("script-src", hash)
+	}
+}
+
+// addInlineAttr adds the hash of a style attribute or an event handler
+// attribute, e.g. onclick, to sources. Browsers only allow these by hash
+// when 'unsafe-hashes' is also set, which does not allow them otherwise.
+func (c *cspCollector) addInlineAttr(sources CSPSources, name, value string) {
+	var directive string
+	switch {
+	case name == "style":
+		directive = "style-src"
+	case len(name) > 2 && strings.HasPrefix(name, "on"):
+		directive = "script-src"
+	default:
+		return
+	}
+
+	if strings.TrimSpace(value) == "" {
+		return
+	}
+
+	sources.add(directive, "'unsafe-hashes'")
+	sources.add(directive, cspHash([]byte(value)))
+}
+
+// addInlineAttrs adds the style attributes and event handlers of the
+// elements in the HTML fragment b to sources.
+func (c *cspCollector) addInlineAttrs(sources CSPSources, b []byte) {
+	if !cspInlineAttrRe.Match(b) {
+		return
+	}
+
+	z := html.NewTokenizer(bytes.NewReader(b))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if _, hasAttr := z.TagName(); !hasAttr {
+				continue
+			}
+			for {
+				k, v, more := z.TagAttr()
+				c.addInlineAttr(sources, string(k), string(v))
+				if !more {
+					break
+				}
+			}
+		}
+	}
+}
+
+// cspHash returns the sha256 hash source expression of the inline content b.
+func cspHash(b []byte) string {
+	// The HTML parser normalizes newlines before the browser computes the hash.
+	b = bytes.ReplaceAll(b, []byte("\r\n"), []byte("\n"))
+	sum := sha256.Sum256(b)
+	return "'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'"
+}
+
+// origin returns the origin of the absolute URL s, or an empty string if s
+// is relative or from the site's own origin.
+func (c *cspCollector) origin(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	scheme := u.Scheme
+	if scheme == "" {
+		// Protocol relative URL.
+		scheme = "https"
+	}
+	if scheme != "http" && scheme != "https" {
+		return ""
+	}
+	origin := scheme + "://" + u.Host
+	if origin == c.selfOrigin {
+		return ""
+	}
+	return origin
+}
+
+// injectCSPMeta injects a Content-Security-Policy meta tag with policy into
+// the head element in b. b is returned unchanged if no head element is found.
+func injectCSPMeta(b []byte, policy string) []byte {
+	loc := cspHeadRe.FindIndex(b)
+	if loc == nil {
+		return b
+	}
+
+	meta := `<meta http-equiv="Content-Security-Policy" content="` + cspAttrValueEscaper.Replace(policy) + `">`
+
+	result := make([]byte, 0, len(b)+len(meta))
+	result = append(result, b[:loc[1]]...)
+	result = append(result, meta...)
+	result = append(result, b[loc[1]:]...)
+
+	return result
+}
+
+// parseSrcset returns the URLs in an img or source srcset attribute.
+func parseSrcset(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var urls []string
+	for _, candidate := range strings.Split(s, ",") {
+		if fields := strings.Fields(candidate); len(fields) > 0 {
+			urls = append(urls, fields[0])
+		}
+	}
+	return urls
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publisher
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+
+	"github.com/gohugoio/hugo/config"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestCSPCollector(t *testing.T) {
+	c := qt.New(t)
+
+	hash := func(s string) string {
+		sum := sha256.Sum256([]byte(s))
+		return "'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'"
+	}
+
+	collect := func(s string) CSPSources {
+		w := newHTMLElementsCollectorWriter(nil)
+		w.cspCollector = newCSPCollector(config.CSP{}, "https://example.org/docs/")
+		w.csp = make(CSPSources)
+		// Write in small chunks to make sure the state survives across writes.
+		for i := 0; i < len(s); i += 7 {
+			end := i + 7
+			if end > len(s) {
+				end = len(s)
+			}
+			w.Write([]byte(s[i:end]))
+		}
+		return w.csp
+	}
+
+	for _, test := range []struct {
+		name   string
+		html   string
+		expect CSPSources
+	}{
+		{"inline script", `<script>console.log("a");</script>`, CSPSources{"script-src": {hash(`console.log("a");`)}}},
+		{"inline script uppercase", `<SCRIPT>a()</SCRIPT>`, CSPSources{"script-src": {hash(`a()`)}}},
+		{"inline module", `<script type="module">a()</script><script type=module>a()</script>`, CSPSources{"script-src": {hash(`a()`)}}},
+		{"inline style", `<style>body { color: red; }</style>`, CSPSources{"style-src": {hash(`body { color: red; }`)}}},
+		{"json-ld", `<script type="application/ld+json">{}</script>`, CSPSources{}},
+		{"crlf", "<script>a()\r\nb()</script>", CSPSources{"script-src": {hash("a()\nb()")}}},
+		{"external script", `<script src="https://cdn.example.com/a.js?v=1"></script>`, CSPSources{"script-src": {"https://cdn.example.com"}}},
+		{"relative script", `<script src="/a.js"></script><script src="https://example.org/b.js"></script>`, CSPSources{}},
+		{"protocol relative", `<img src="//images.example.com/a.png">`, CSPSources{"img-src": {"https://images.example.com"}}},
+		{"stylesheet", `<link rel="stylesheet" href="https://fonts.googleapis.com/css?family=Foo">`, CSPSources{"style-src": {"https://fonts.googleapis.com"}}},
+		{"preload font", `<link rel=preload as=font href="https://fonts.gstatic.com/a.woff2" crossorigin>`, CSPSources{"font-src": {"https://fonts.gstatic.com"}}},
+		{"srcset", `<img src="a.jpg" srcset="https://a.example.com/a.jpg 1x, https://b.example.com/b.jpg 2x">`, CSPSources{"img-src": {"https://a.example.com", "https://b.example.com"}}},
+		{"iframe", `<iframe src="https://www.youtube.com/embed/abc"></iframe>`, CSPSources{"frame-src": {"https://www.youtube.com"}}},
+		{"pre", `<pre><script>a()</script></pre>`, CSPSources{}},
+		{"style attribute", `<div style="color: red">a</div><p style="">b</p>`, CSPSources{"style-src": {"'unsafe-hashes'", hash(`color: red`)}}},
+		{"event handler", `<button onclick="a(&quot;b&quot;)">a</button>`, CSPSources{"script-src": {"'unsafe-hashes'", hash(`a("b")`)}}},
+		{"highlighted code", `<div class="highlight"><pre tabindex="0" style="color:#f8f8f2"><code><span style="display:flex;"><span style="color:#f92672">func</span></span></code></pre></div>`, CSPSources{"style-src": {"'unsafe-hashes'", hash(`color:#f8f8f2`), hash(`display:flex;`), hash(`color:#f92672`)}}},
+	} {
+		c.Run(test.name, func(c *qt.C) {
+			c.Assert(collect(test.html), qt.DeepEquals, test.expect)
+		})
+	}
+}
+
+func TestCSPSourcesPolicy(t *testing.T) {
+	c := qt.New(t)
+
+	sources := CSPSources{
+		"script-src": {"'sha256-abc'", "https://cdn.example.com"},
+		"img-src":    {"https://images.example.com"},
+	}
+
+	c.Assert(sources.Policy(config.DefaultCSPDirectives), qt.Equals,
+		"default-src 'self'; img-src 'self' https://images.example.com; script-src 'self' 'sha256-abc' https://cdn.example.com")
+	c.Assert(sources.Policy(map[string][]string{"script-src": {"'none'"}}), qt.Equals,
+		"img-src 'self' https://images.example.com; script-src 'none' 'sha256-abc' https://cdn.example.com")
+	c.Assert(CSPSources{}.Policy(config.DefaultCSPDirectives), qt.Equals, "default-src 'self'")
+}
+
+func TestInjectCSPMeta(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(string(injectCSPMeta([]byte(`<html><head lang="en"><title>T</title></head></html>`), `script-src 'self'`)), qt.Equals,
+		`<html><head lang="en"><meta http-equiv="Content-Security-Policy" content="script-src 'self'"><title>T</title></head></html>`)
+	c.Assert(string(injectCSPMeta([]byte(`<HTML><HEAD></HEAD></HTML>`), `a`)), qt.Equals,
+		`<HTML><HEAD><meta http-equiv="Content-Security-Policy" content="a"></HEAD></HTML>`)
+	c.Assert(string(injectCSPMeta([]byte(`<header></header>`), `a`)), qt.Equals, `<header></header>`)
+}
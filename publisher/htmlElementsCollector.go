@@ -133,6 +133,11 @@ func (c *htmlElementsCollector) getHTMLElements() HTMLElements {
 type htmlElementsCollectorWriter struct {
 	collector *htmlElementsCollector
 
+	// Set when collecting Content Security Policy sources.
+	cspCollector *cspCollector
+	csp          CSPSources
+	cspHashInner bool // Whether to hash the inner content of the current script or style.
+
 	r     rune   // Current rune
 	width int    // The width in bytes of r
 	input []byte // The current slice written to Write
@@ -231,6 +236,14 @@ func (w *htmlElementsCollectorWriter) lexElementInside(resolve htmlCollectorStat
 				w.buff.Reset()
 			}()
 
+			if w.cspCollector != nil {
+				w.cspHashInner = w.cspCollector.addElement(w.csp, b)
+			}
+
+			if w.collector == nil {
+				return resolve
+			}
+
 			// First check if we have processed this element before.
 			w.collector.mu.RLock()
 
@@ -312,11 +325,25 @@ func htmlLexElementStart(w *htmlElementsCollectorWriter) htmlCollectorStateFunc
 						if w.r != '>' {
 							return false
 						}
-						m := endTagRe.FindSubmatch(w.buff.Bytes())
+						b := w.buff.Bytes()
+						m := endTagRe.FindSubmatch(b)
 						if m == nil {
 							return false
 						}
-						return bytes.EqualFold(m[1], tagNameCopy)
+						if !bytes.EqualFold(m[1], tagNameCopy) {
+							return false
+						}
+						if w.cspCollector != nil {
+							inner := b[:len(b)-len(m[0])]
+							if w.cspHashInner {
+								w.cspCollector.addInline(w.csp, string(tagNameCopy), inner)
+								w.cspHashInner = false
+							} else if bytes.EqualFold(tagNameCopy, []byte("pre")) {
+								// E.g. highlighted code with inline styles.
+								w.cspCollector.addInlineAttrs(w.csp, inner)
+							}
+						}
+						return true
 					},
 					htmlLexStart,
 				))
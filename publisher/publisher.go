@@ -14,6 +14,7 @@
 package publisher
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -74,6 +75,7 @@ type DestinationPublisher struct {
 	fs                    afero.Fs
 	min                   minifiers.Client
 	htmlElementsCollector *htmlElementsCollector
+	cspCollector          *cspCollector
 }
 
 // NewDestinationPublisher creates a new DestinationPublisher.
@@ -84,7 +86,11 @@ func NewDestinationPublisher(rs *resources.Spec, outputFormats output.Formats, m
 	if rs.BuildConfig.WriteStats {
 		classCollector = newHTMLElementsCollector()
 	}
-	pub = DestinationPublisher{fs: fs, htmlElementsCollector: classCollector}
+	var cspCollector *cspCollector
+	if rs.BuildConfig.CSP.Enable {
+		cspCollector = newCSPCollector(rs.BuildConfig.CSP, cfg.GetString("baseURL"))
+	}
+	pub = DestinationPublisher{fs: fs, htmlElementsCollector: classCollector, cspCollector: cspCollector}
 	pub.min, err = minifiers.New(mediaTypes, outputFormats, cfg)
 	return
 }
@@ -112,6 +118,31 @@ func (p DestinationPublisher) Publish(d Descriptor) error {
 		src = b
 	}
 
+	collectElements := p.htmlElementsCollector != nil && d.OutputFormat.IsHTML
+
+	if p.cspCollector != nil && d.OutputFormat.IsHTML {
+		// We need the policy before writing to be able to inject it,
+		// so collect everything up front.
+		b := bp.GetBuffer()
+		defer bp.PutBuffer(b)
+		if _, err := b.ReadFrom(src); err != nil {
+			return err
+		}
+
+		cw := newHTMLElementsCollectorWriter(p.htmlElementsCollector)
+		cw.cspCollector = p.cspCollector
+		cw.csp = make(CSPSources)
+		cw.Write(b.Bytes())
+		p.cspCollector.setPage(d.TargetPath, cw.csp)
+		collectElements = false
+
+		if p.cspCollector.cfg.InjectMeta {
+			src = bytes.NewReader(injectCSPMeta(b.Bytes(), cw.csp.Policy(p.cspCollector.cfg.Directives)))
+		} else {
+			src = b
+		}
+	}
+
 	f, err := helpers.OpenFileForWriting(p.fs, d.TargetPath)
 	if err != nil {
 		return err
@@ -120,7 +151,7 @@ func (p DestinationPublisher) Publish(d Descriptor) error {
 
 	var w io.Writer = f
 
-	if p.htmlElementsCollector != nil && d.OutputFormat.IsHTML {
+	if collectElements {
 		w = io.MultiWriter(w, newHTMLElementsCollectorWriter(p.htmlElementsCollector))
 	}
 
@@ -133,17 +164,20 @@ func (p DestinationPublisher) Publish(d Descriptor) error {
 }
 
 func (p DestinationPublisher) PublishStats() PublishStats {
-	if p.htmlElementsCollector == nil {
-		return PublishStats{}
+	var stats PublishStats
+	if p.htmlElementsCollector != nil {
+		stats.HTMLElements = p.htmlElementsCollector.getHTMLElements()
 	}
-
-	return PublishStats{
-		HTMLElements: p.htmlElementsCollector.getHTMLElements(),
+	if p.cspCollector != nil {
+		stats.CSP = p.cspCollector.getCSPStats()
 	}
+
+	return stats
 }
 
 type PublishStats struct {
 	HTMLElements HTMLElements `json:"htmlElements"`
+	CSP          *CSPStats    `json:"csp,omitempty"`
 }
 
 // Publisher publishes a result file.
@@ -123,6 +123,18 @@ func (e *errorResource) Exif() *exif.ExifInfo {
 	panic(e.ResourceError)
 }
 
+func (e *errorResource) IPTC() *exif.IPTCInfo {
+	panic(e.ResourceError)
+}
+
+func (e *errorResource) XMP() *exif.XMPInfo {
+	panic(e.ResourceError)
+}
+
+func (e *errorResource) Colors() ([]images.DominantColor, error) {
+	panic(e.ResourceError)
+}
+
 func (e *errorResource) DecodeImage() (image.Image, error) {
 	panic(e.ResourceError)
 }
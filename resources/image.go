@@ -15,6 +15,7 @@ package resources
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
@@ -24,6 +25,7 @@ import (
 	_ "image/png"
 	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"path"
 	"path/filepath"
@@ -60,22 +62,52 @@ type imageResource struct {
 	// original (first).
 	root *imageResource
 
+	// Set when the Exif orientation of the root has been applied to this
+	// image, e.g. by AutoOrient.
+	oriented bool
+
 	metaInit    sync.Once
 	metaInitErr error
 	meta        *imageMeta
 
+	publishInit sync.Once
+	publishErr  error
+
+	colorsInit sync.Once
+	colorsErr  error
+	colors     []images.DominantColor
+
 	baseResource
 }
 
 type imageMeta struct {
 	Exif *exif.ExifInfo
+	IPTC *exif.IPTCInfo
+	XMP  *exif.XMPInfo
 }
 
 func (i *imageResource) Exif() *exif.ExifInfo {
-	return i.root.getExif()
+	if m := i.root.getMeta(); m != nil {
+		return m.Exif
+	}
+	return nil
 }
 
-func (i *imageResource) getExif() *exif.ExifInfo {
+func (i *imageResource) IPTC() *exif.IPTCInfo {
+	if m := i.root.getMeta(); m != nil {
+		return m.IPTC
+	}
+	return nil
+}
+
+func (i *imageResource) XMP() *exif.XMPInfo {
+	if m := i.root.getMeta(); m != nil {
+		return m.XMP
+	}
+	return nil
+}
+
+func (i *imageResource) getMeta() *imageMeta {
 	i.metaInit.Do(func() {
 		supportsExif := i.Format == images.JPEG || i.Format == images.TIFF
 		if !supportsExif {
@@ -108,13 +140,22 @@ func (i *imageResource) getExif() *exif.ExifInfo {
 			}
 			defer f.Close()
 
-			x, err := i.getSpec().imaging.DecodeExif(f)
+			i.meta = &imageMeta{}
+
+			i.meta.Exif, err = i.getSpec().imaging.DecodeExif(f)
 			if err != nil {
 				i.getSpec().Logger.Warnf("Unable to decode Exif metadata from image: %s", i.Key())
-				return nil
 			}
 
-			i.meta = &imageMeta{Exif: x}
+			if i.Format == images.JPEG {
+				if _, err = f.Seek(0, 0); err != nil {
+					return err
+				}
+				i.meta.IPTC, i.meta.XMP, err = i.getSpec().imaging.DecodeMetadata(f)
+				if err != nil {
+					i.getSpec().Logger.Warnf("Unable to decode IPTC/XMP metadata from image: %s", i.Key())
+				}
+			}
 
 			// Also write it to cache
 			enc := json.NewEncoder(w)
@@ -128,11 +169,74 @@ func (i *imageResource) getExif() *exif.ExifInfo {
 		panic(fmt.Sprintf("metadata init failed: %s", i.metaInitErr))
 	}
 
-	if i.meta == nil {
-		return nil
+	return i.meta
+}
+
+// Colors returns the dominant colors in this image, the most dominant first.
+func (i *imageResource) Colors() ([]images.DominantColor, error) {
+	i.colorsInit.Do(func() {
+		key := i.getImageColorsCacheTargetPath()
+
+		read := func(info filecache.ItemInfo, r io.ReadSeeker) error {
+			return json.NewDecoder(r).Decode(&i.colors)
+		}
+
+		create := func(info filecache.ItemInfo, w io.WriteCloser) (err error) {
+			defer w.Close()
+			img, err := i.DecodeImage()
+			if err != nil {
+				return err
+			}
+			i.colors = images.DominantColors(img, images.DefaultDominantColors)
+			return json.NewEncoder(w).Encode(i.colors)
+		}
+
+		_, i.colorsErr = i.getSpec().imageCache.fileCache.ReadOrCreate(key, read, create)
+		if i.colorsErr != nil {
+			i.colorsErr = fmt.Errorf("image %q: failed to extract colors: %w", i.Key(), i.colorsErr)
+		}
+	})
+
+	return i.colors, i.colorsErr
+}
+
+// Publish publishes the image to the target destinations. If configured, the
+// metadata policy is applied to JPEG images.
+func (i *imageResource) Publish() error {
+	policy := i.Proc.Cfg.MetadataPolicy
+	if !i.Proc.Cfg.Cfg.Metadata.Originals || policy == exif.PolicyKeep || i.Format != images.JPEG {
+		return i.baseResource.Publish()
+	}
+
+	i.publishInit.Do(func() {
+		i.publishErr = i.publishWithMetadataPolicy(policy)
+	})
+
+	return i.publishErr
+}
+
+func (i *imageResource) publishWithMetadataPolicy(policy exif.Policy) error {
+	fr, err := i.ReadSeekCloser()
+	if err != nil {
+		return err
+	}
+	defer fr.Close()
+
+	m, err := exif.ReadJPEGMetadata(fr)
+	if err != nil {
+		return fmt.Errorf("image %q: failed to read metadata: %w", i.Key(), err)
+	}
+	if _, err := fr.Seek(0, 0); err != nil {
+		return err
+	}
+
+	fw, err := helpers.OpenFilesForWriting(i.getSpec().BaseFs.PublishFs, i.getTargetFilenames()...)
+	if err != nil {
+		return err
 	}
+	defer fw.Close()
 
-	return i.meta.Exif
+	return m.Filter(policy).WriteJPEG(fw, fr)
 }
 
 // Clone is for internal use.
@@ -140,6 +244,7 @@ func (i *imageResource) Clone() resource.Resource {
 	gr := i.baseResource.Clone().(baseResource)
 	return &imageResource{
 		root:         i.root,
+		oriented:     i.oriented,
 		Image:        i.WithSpec(gr),
 		baseResource: gr,
 	}
@@ -149,6 +254,7 @@ func (i *imageResource) cloneTo(targetPath string) resource.Resource {
 	gr := i.baseResource.cloneTo(targetPath).(baseResource)
 	return &imageResource{
 		root:         i.root,
+		oriented:     i.oriented,
 		Image:        i.WithSpec(gr),
 		baseResource: gr,
 	}
@@ -170,6 +276,7 @@ func (i *imageResource) cloneWithUpdates(u *transformationUpdate) (baseResource,
 
 	return &imageResource{
 		root:         i.root,
+		oriented:     i.oriented,
 		Image:        img,
 		baseResource: base,
 	}, nil
@@ -252,11 +359,29 @@ func (i *imageResource) Filter(filters ...any) (images.ImageResource, error) {
 	var gfilters []gift.Filter
 
 	for _, f := range filters {
-		gfilters = append(gfilters, images.ToFilters(f)...)
+		for _, gf := range images.ToFilters(f) {
+			if op, ok := images.UnwrapFilter(gf).(images.ImageFilterFromOrientationProvider); ok {
+				if i.oriented {
+					// The Exif data is read from the root, so don't apply it twice.
+					continue
+				}
+				if of := op.AutoOrient(i.Exif()); of != nil {
+					gfilters = append(gfilters, of)
+					conf.Oriented = true
+				}
+				continue
+			}
+			gfilters = append(gfilters, gf)
+		}
+	}
+
+	if len(gfilters) == 0 {
+		// E.g. AutoOrient on an image that's already upright.
+		return i, nil
 	}
 
 	conf.Key = helpers.HashString(gfilters)
-	conf.TargetFormat = i.Format
+	conf.TargetFormat = i.Format.DefaultTargetFormat()
 
 	return i.doWithImageConfig(conf, func(src image.Image) (image.Image, error) {
 		return i.Proc.Filter(src, gfilters...)
@@ -279,10 +404,18 @@ func (i *imageResource) doWithImageConfig(conf images.ImageConfig, f func(src im
 			<-imageProcSem
 		}()
 
-		errOp := conf.Action
-		errPath := i.getSourceFilename()
+		var (
+			errOp   = conf.Action
+			errPath = i.getSourceFilename()
+			err     error
+		)
 
-		src, err := i.DecodeImage()
+		var src image.Image
+		if i.Format.IsVector() {
+			src, err = i.rasterize(conf)
+		} else {
+			src, err = i.DecodeImage()
+		}
 		if err != nil {
 			return nil, nil, &os.PathError{Op: errOp, Path: errPath, Err: err}
 		}
@@ -328,6 +461,13 @@ func (i *imageResource) doWithImageConfig(conf images.ImageConfig, f func(src im
 		ci.Format = conf.TargetFormat
 		ci.setMediaType(conf.TargetFormat.MediaType())
 
+		if conf.TargetFormat == images.JPEG && conf.Metadata != exif.PolicyStrip && i.Format == images.JPEG {
+			ci.Metadata, err = i.metadataForEncode(conf, converted.Bounds())
+			if err != nil {
+				return nil, nil, &os.PathError{Op: errOp, Path: errPath, Err: err}
+			}
+		}
+
 		return ci, converted, nil
 	})
 	if err != nil {
@@ -338,6 +478,30 @@ func (i *imageResource) doWithImageConfig(conf images.ImageConfig, f func(src im
 	return img, nil
 }
 
+// metadataForEncode reads the metadata from i and applies the metadata policy
+// in conf. We read it from i and not the root to preserve any changes
+// made when processing i, e.g. the orientation.
+func (i *imageResource) metadataForEncode(conf images.ImageConfig, bounds image.Rectangle) (*exif.Metadata, error) {
+	f, err := i.ReadSeekCloser()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	m, err := exif.ReadJPEGMetadata(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata: %w", err)
+	}
+
+	m = m.Filter(conf.Metadata)
+	if conf.Oriented {
+		m.SetOrientation(1)
+	}
+	m.SetDimensions(bounds.Dx(), bounds.Dy())
+
+	return m, nil
+}
+
 func (i *imageResource) decodeImageConfig(action, spec string) (images.ImageConfig, error) {
 	conf, err := images.DecodeImageConfig(action, spec, i.Proc.Cfg, i.Format)
 	if err != nil {
@@ -372,10 +536,35 @@ func (i *imageResource) DecodeImage() (image.Image, error) {
 		}
 		return &giphy{gif: g, Image: g.Image[0]}, nil
 	}
+	if i.Format == images.SVG {
+		return i.Proc.DecodeSVG(f, 0, 0)
+	}
 	img, _, err := image.Decode(f)
 	return img, err
 }
 
+// rasterize renders a vector image at a size large enough to cover the
+// dimensions in conf, so any resizing that follows is a downscale.
+func (i *imageResource) rasterize(conf images.ImageConfig) (image.Image, error) {
+	w, h := i.Width(), i.Height()
+	if w == 0 || h == 0 {
+		return nil, errors.New("image has no intrinsic size")
+	}
+
+	scale := math.Max(float64(conf.Width)/float64(w), float64(conf.Height)/float64(h))
+	if scale > 1 {
+		w, h = int(math.Ceil(float64(w)*scale)), int(math.Ceil(float64(h)*scale))
+	}
+
+	f, err := i.ReadSeekCloser()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image for decode: %w", err)
+	}
+	defer f.Close()
+
+	return i.Proc.DecodeSVG(f, w, h)
+}
+
 func (i *imageResource) clone(img image.Image) *imageResource {
 	spec := i.baseResource.Clone().(baseResource)
 
@@ -389,6 +578,7 @@ func (i *imageResource) clone(img image.Image) *imageResource {
 	return &imageResource{
 		Image:        image,
 		root:         i.root,
+		oriented:     i.oriented,
 		baseResource: spec,
 	}
 }
@@ -398,7 +588,7 @@ func (i *imageResource) setBasePath(conf images.ImageConfig) {
 }
 
 func (i *imageResource) getImageMetaCacheTargetPath() string {
-	const imageMetaVersionNumber = 1 // Increment to invalidate the meta cache
+	const imageMetaVersionNumber = 2 // Increment to invalidate the meta cache
 
 	cfgHash := i.getSpec().imaging.Cfg.CfgHash
 	df := i.getResourcePaths().relTargetDirFile
@@ -412,6 +602,12 @@ func (i *imageResource) getImageMetaCacheTargetPath() string {
 	return p
 }
 
+func (i *imageResource) getImageColorsCacheTargetPath() string {
+	const imageColorsVersionNumber = 1 // Increment to invalidate the colors cache
+
+	return fmt.Sprintf("%s_colors%d.json", strings.TrimSuffix(i.getImageMetaCacheTargetPath(), ".json"), imageColorsVersionNumber)
+}
+
 func (i *imageResource) relTargetPathFromConfig(conf images.ImageConfig) dirFile {
 	p1, p2 := paths.FileAndExt(i.getResourcePaths().relTargetDirFile.file)
 	if conf.TargetFormat != i.Format {
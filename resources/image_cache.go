@@ -149,6 +149,7 @@ func (c *imageCache) getOrCreate(
 
 	// The file is now stored in this cache.
 	img.setSourceFs(c.fileCache.Fs)
+	img.oriented = parent.oriented || conf.Oriented
 
 	c.mu.Lock()
 	if cachedImage, found = c.store[memKey]; found {
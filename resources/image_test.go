@@ -42,6 +42,7 @@ import (
 
 	"github.com/gohugoio/hugo/media"
 	"github.com/gohugoio/hugo/resources/images"
+	"github.com/gohugoio/hugo/resources/images/exif"
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/gohugoio/hugo/htesting/hqt"
@@ -470,6 +471,109 @@ func TestImageExif(t *testing.T) {
 	getAndCheckExif(c, image)
 }
 
+func TestImageMetadataPolicy(t *testing.T) {
+	c := qt.New(t)
+
+	decodeExif := func(c *qt.C, fs afero.Fs, filename string) *exif.ExifInfo {
+		f, err := fs.Open(filepath.Clean(filename))
+		c.Assert(err, qt.IsNil)
+		defer f.Close()
+		d, err := exif.NewDecoder()
+		c.Assert(err, qt.IsNil)
+		x, err := d.Decode(f)
+		c.Assert(err, qt.IsNil)
+		return x
+	}
+
+	c.Run("Default", func(c *qt.C) {
+		spec := newTestResourceSpec(specDescriptor{c: c})
+		img := fetchImageForSpec(spec, c, "sunset.jpg")
+		resized, err := img.Resize("300x")
+		c.Assert(err, qt.IsNil)
+		c.Assert(img.RelPermalink(), qt.Equals, "/a/sunset.jpg")
+
+		c.Assert(decodeExif(c, spec.BaseFs.PublishFs, resized.RelPermalink()), qt.IsNil)
+		c.Assert(decodeExif(c, spec.BaseFs.PublishFs, "a/sunset.jpg").Lat, qt.Not(qt.Equals), float64(0))
+	})
+
+	c.Run("Strip GPS", func(c *qt.C) {
+		spec := newTestResourceSpec(specDescriptor{c: c, imaging: map[string]any{
+			"metadata": map[string]any{"policy": "stripGPS", "originals": true},
+		}})
+		img := fetchImageForSpec(spec, c, "sunset.jpg")
+		resized, err := img.Resize("300x")
+		c.Assert(err, qt.IsNil)
+		c.Assert(resized.RelPermalink(), qt.Equals, "/a/sunset_hu59e56ffff1bc1d8d122b1403d34e039f_90587_300x0_resize_q68_linear_m2.jpg")
+		c.Assert(img.RelPermalink(), qt.Equals, "/a/sunset.jpg")
+
+		for _, filename := range []string{resized.RelPermalink(), "a/sunset.jpg"} {
+			x := decodeExif(c, spec.BaseFs.PublishFs, filename)
+			c.Assert(x, qt.Not(qt.IsNil))
+			c.Assert(x.Lat, qt.Equals, float64(0))
+			c.Assert(x.Date.Format("2006-01-02"), qt.Equals, "2017-10-27")
+			c.Assert(x.Tags["LensModel"], qt.Equals, "smc PENTAX-DA* 16-50mm F2.8 ED AL [IF] SDM")
+		}
+
+		assertImageFile(c, spec.BaseFs.PublishFs, "a/sunset.jpg", 900, 562)
+	})
+
+	c.Run("Copyright", func(c *qt.C) {
+		spec := newTestResourceSpec(specDescriptor{c: c, imaging: map[string]any{
+			"metadata": map[string]any{"policy": "copyright"},
+		}})
+		img := fetchImageForSpec(spec, c, "sunset.jpg")
+		resized, err := img.Resize("300x")
+		c.Assert(err, qt.IsNil)
+		c.Assert(img.RelPermalink(), qt.Equals, "/a/sunset.jpg")
+
+		x := decodeExif(c, spec.BaseFs.PublishFs, resized.RelPermalink())
+		c.Assert(x.Lat, qt.Equals, float64(0))
+		c.Assert(x.Tags["LensModel"], qt.IsNil)
+		c.Assert(x.Tags["Artist"], qt.Equals, "bjorn.erik.pedersen@gmail.com")
+
+		// Originals are left untouched by default.
+		c.Assert(decodeExif(c, spec.BaseFs.PublishFs, "a/sunset.jpg").Lat, qt.Not(qt.Equals), float64(0))
+	})
+
+	c.Run("Invalid", func(c *qt.C) {
+		_, err := images.DecodeConfig(map[string]any{
+			"metadata": map[string]any{"policy": "foo"},
+		})
+		c.Assert(err, qt.ErrorMatches, ".*invalid metadata policy.*")
+	})
+}
+
+func TestImageIPTCAndXMP(t *testing.T) {
+	c := qt.New(t)
+	spec := newTestResourceSpec(specDescriptor{c: c})
+
+	for i := 0; i < 2; i++ {
+		// The second time the metadata is read from the file cache.
+		img := fetchImageForSpec(spec, c, "sunset.jpg")
+
+		iptc := img.IPTC()
+		c.Assert(iptc, qt.Not(qt.IsNil))
+		c.Assert(iptc.Creator, qt.Equals, "bjorn.erik.pedersen@gmail.com")
+		c.Assert(iptc.Keywords, qt.DeepEquals, []string{"Malaga", "Torremolinos"})
+		c.Assert(iptc.Date.Format("2006-01-02"), qt.Equals, "2017-10-27")
+		c.Assert(iptc.Tags["City"], qt.Equals, "Benalmádena")
+
+		xmp := img.XMP()
+		c.Assert(xmp, qt.Not(qt.IsNil))
+		c.Assert(xmp.Creator, qt.DeepEquals, []string{"bjorn.erik.pedersen@gmail.com"})
+		c.Assert(xmp.Keywords, qt.DeepEquals, []string{"Malaga", "Torremolinos"})
+		c.Assert(xmp.Rating, qt.Equals, 4)
+		c.Assert(xmp.Tags["CreatorTool"], qt.Equals, "Adobe Photoshop Lightroom 6.12 (Macintosh)")
+
+		resized, err := img.Resize("300x")
+		c.Assert(err, qt.IsNil)
+		c.Assert(resized.XMP(), qt.DeepEquals, xmp)
+	}
+
+	png := fetchImageForSpec(spec, c, "gohugoio.png")
+	c.Assert(png.IPTC(), qt.IsNil)
+}
+
 func BenchmarkImageExif(b *testing.B) {
 	getImages := func(c *qt.C, b *testing.B, fs afero.Fs) []images.ImageResource {
 		spec := newTestResourceSpec(specDescriptor{fs: fs, c: c})
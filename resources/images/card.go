@@ -0,0 +1,439 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"strings"
+
+	"github.com/disintegration/gift"
+	"github.com/gohugoio/hugo/common/hugio"
+	"github.com/gohugoio/hugo/common/maps"
+	"github.com/gohugoio/hugo/resources/resource"
+	"github.com/spf13/cast"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+var _ gift.Filter = (*cardFilter)(nil)
+
+const (
+	defaultCardWidth      = 1200
+	defaultCardHeight     = 630
+	defaultCardLineHeight = 1.2
+	defaultCardEllipsis   = "…"
+)
+
+// CardLayout describes a social card, e.g. an Open Graph image, made up of a
+// background image with text boxes and images drawn on top.
+type CardLayout struct {
+	// The card size in pixels. The background is filled to this size.
+	Width  int
+	Height int
+
+	// The anchor used when filling the background, default "center".
+	Anchor string
+
+	texts  []cardText
+	images []cardImage
+
+	// Hashable representation of the layout used to build the cache key.
+	opts maps.Params
+}
+
+type cardText struct {
+	text       string
+	x, y       int
+	width      int
+	height     int
+	size       float64
+	color      color.Color
+	lineHeight float64
+	align      string
+	valign     string
+	maxLines   int
+	ellipsis   string
+	fontSource hugio.ReadSeekCloserProvider
+}
+
+type cardImage struct {
+	src           ImageSource
+	x, y          int
+	width, height int
+	fit           string
+	radius        roundedCornersFilter
+}
+
+// DecodeCardLayout decodes a card layout from a map, typically created with
+// dict in a template:
+//
+//	width, height: the card size, default 1200x630.
+//	anchor: the background fill anchor, default "center".
+//	texts: a slice of text boxes with the options
+//	  text: the text to draw.
+//	  x, y: the top left corner of the box.
+//	  width: the box width, used for wrapping and alignment. Default is the
+//	         card width minus x.
+//	  height: the box height, used for vertical alignment.
+//	  font: a font resource. Default is Go Regular.
+//	  size: the font size in pixels, default 20.
+//	  color: the text color as hex, default "#ffffff".
+//	  lineHeight: the line height relative to the font size, default 1.2.
+//	  align: left, center or right.
+//	  valign: top, middle or bottom.
+//	  maxLines: the maximum number of lines, 0 means no limit.
+//	  ellipsis: appended to truncated text, default "…".
+//	images: a slice of image slots with the options
+//	  image: an image resource.
+//	  x, y, width, height: the slot's position and size.
+//	  fit: cover (default), contain or stretch.
+//	  radius: an optional corner radius in pixels or percent, e.g. "50%".
+func DecodeCardLayout(v any) (*CardLayout, error) {
+	m, ok := maps.ToParamsAndPrepare(v)
+	if !ok {
+		return nil, fmt.Errorf("invalid card layout: cannot convert %T to a map", v)
+	}
+
+	l := &CardLayout{
+		Width:  defaultCardWidth,
+		Height: defaultCardHeight,
+		Anchor: "center",
+		opts:   maps.Params{},
+	}
+
+	for k, v := range m {
+		switch k {
+		case "width":
+			l.Width = cast.ToInt(v)
+		case "height":
+			l.Height = cast.ToInt(v)
+		case "anchor":
+			l.Anchor = cast.ToString(v)
+		case "texts", "images":
+		default:
+			return nil, fmt.Errorf("unknown card layout option %q", k)
+		}
+	}
+
+	if l.Width <= 0 || l.Height <= 0 {
+		return nil, errors.New("card width and height must be positive")
+	}
+
+	l.opts["width"], l.opts["height"], l.opts["anchor"] = l.Width, l.Height, l.Anchor
+
+	texts, err := toParamsSlice(m["texts"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid card texts: %w", err)
+	}
+	var textOpts []maps.Params
+	for _, tm := range texts {
+		t, opts, err := decodeCardText(l, tm)
+		if err != nil {
+			return nil, err
+		}
+		l.texts = append(l.texts, t)
+		textOpts = append(textOpts, opts)
+	}
+	l.opts["texts"] = textOpts
+
+	imgs, err := toParamsSlice(m["images"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid card images: %w", err)
+	}
+	var imageOpts []maps.Params
+	for _, im := range imgs {
+		ci, opts, err := decodeCardImage(im)
+		if err != nil {
+			return nil, err
+		}
+		l.images = append(l.images, ci)
+		imageOpts = append(imageOpts, opts)
+	}
+	l.opts["images"] = imageOpts
+
+	return l, nil
+}
+
+// Filter returns the filter drawing the card's texts and images on the
+// background.
+func (l *CardLayout) Filter() gift.Filter {
+	return filter{
+		Options: newFilterOpts("card", l.opts),
+		Filter:  cardFilter{layout: l},
+	}
+}
+
+func toParamsSlice(v any) ([]maps.Params, error) {
+	switch vv := v.(type) {
+	case nil:
+		return nil, nil
+	case []maps.Params:
+		return vv, nil
+	}
+	var result []maps.Params
+	for _, vv := range cast.ToSlice(v) {
+		m, ok := maps.ToParamsAndPrepare(vv)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert %T to a map", vv)
+		}
+		result = append(result, m)
+	}
+	if result == nil {
+		return nil, fmt.Errorf("expected a slice of maps, got %T", v)
+	}
+	return result, nil
+}
+
+func decodeCardText(l *CardLayout, m maps.Params) (cardText, maps.Params, error) {
+	t := cardText{
+		size:       20,
+		color:      color.White,
+		lineHeight: defaultCardLineHeight,
+		align:      "left",
+		valign:     "top",
+		ellipsis:   defaultCardEllipsis,
+	}
+	opts := maps.Params{}
+	var hasWidth bool
+
+	for k, v := range m {
+		opts[k] = v
+		switch k {
+		case "text":
+			t.text = cast.ToString(v)
+		case "x":
+			t.x = cast.ToInt(v)
+		case "y":
+			t.y = cast.ToInt(v)
+		case "width":
+			t.width = cast.ToInt(v)
+			hasWidth = true
+		case "height":
+			t.height = cast.ToInt(v)
+		case "size":
+			t.size = cast.ToFloat64(v)
+		case "color":
+			c, err := hexStringToColor(cast.ToString(v))
+			if err != nil {
+				return t, nil, err
+			}
+			t.color = c
+		case "lineheight":
+			t.lineHeight = cast.ToFloat64(v)
+		case "align":
+			t.align = strings.ToLower(cast.ToString(v))
+		case "valign":
+			t.valign = strings.ToLower(cast.ToString(v))
+		case "maxlines":
+			t.maxLines = cast.ToInt(v)
+		case "ellipsis":
+			t.ellipsis = cast.ToString(v)
+		case "font":
+			if err, ok := v.(error); ok {
+				return t, nil, fmt.Errorf("invalid font source: %s", err)
+			}
+			fontSource, ok1 := v.(hugio.ReadSeekCloserProvider)
+			identifier, ok2 := v.(resource.Identifier)
+			if !(ok1 && ok2) {
+				return t, nil, fmt.Errorf("invalid text font source: %T", v)
+			}
+			t.fontSource = fontSource
+			opts[k] = identifier.Key()
+		default:
+			return t, nil, fmt.Errorf("unknown card text option %q", k)
+		}
+	}
+
+	if !hasWidth {
+		t.width = l.Width - t.x
+	}
+	if t.size <= 0 {
+		return t, nil, errors.New("card text size must be positive")
+	}
+	switch t.align {
+	case "left", "center", "right":
+	default:
+		return t, nil, fmt.Errorf("invalid card text align %q", t.align)
+	}
+	switch t.valign {
+	case "top", "middle", "bottom":
+	default:
+		return t, nil, fmt.Errorf("invalid card text valign %q", t.valign)
+	}
+
+	return t, opts, nil
+}
+
+func decodeCardImage(m maps.Params) (cardImage, maps.Params, error) {
+	ci := cardImage{fit: "cover"}
+	opts := maps.Params{}
+
+	for k, v := range m {
+		opts[k] = v
+		switch k {
+		case "image":
+			src, ok := v.(ImageSource)
+			if !ok {
+				return ci, nil, fmt.Errorf("invalid card image %T", v)
+			}
+			ci.src = src
+			opts[k] = src.Key()
+		case "x":
+			ci.x = cast.ToInt(v)
+		case "y":
+			ci.y = cast.ToInt(v)
+		case "width":
+			ci.width = cast.ToInt(v)
+		case "height":
+			ci.height = cast.ToInt(v)
+		case "fit":
+			ci.fit = strings.ToLower(cast.ToString(v))
+		case "radius":
+			rf := roundedCornersFilter{}
+			if s, ok := v.(string); ok && strings.HasSuffix(s, "%") {
+				rf.percent = true
+				v = strings.TrimSuffix(s, "%")
+			}
+			rf.radius = cast.ToFloat64(v)
+			ci.radius = rf
+		default:
+			return ci, nil, fmt.Errorf("unknown card image option %q", k)
+		}
+	}
+
+	if ci.src == nil {
+		return ci, nil, errors.New("card image slot needs an image")
+	}
+	if ci.width <= 0 || ci.height <= 0 {
+		return ci, nil, errors.New("card image width and height must be positive")
+	}
+	switch ci.fit {
+	case "cover", "contain", "stretch":
+	default:
+		return ci, nil, fmt.Errorf("invalid card image fit %q", ci.fit)
+	}
+
+	return ci, opts, nil
+}
+
+type cardFilter struct {
+	layout *CardLayout
+}
+
+func (f cardFilter) Draw(dst draw.Image, src image.Image, options *gift.Options) {
+	gift.New().Draw(dst, src)
+
+	for _, ci := range f.layout.images {
+		if err := ci.draw(dst); err != nil {
+			panic(err)
+		}
+	}
+
+	for _, t := range f.layout.texts {
+		if err := t.draw(dst); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (f cardFilter) Bounds(srcBounds image.Rectangle) image.Rectangle {
+	return image.Rect(0, 0, srcBounds.Dx(), srcBounds.Dy())
+}
+
+func (ci cardImage) draw(dst draw.Image) error {
+	img, err := ci.src.DecodeImage()
+	if err != nil {
+		return fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	var filters []gift.Filter
+	switch ci.fit {
+	case "cover":
+		filters = append(filters, gift.ResizeToFill(ci.width, ci.height, gift.LanczosResampling, gift.CenterAnchor))
+	case "contain":
+		filters = append(filters, gift.ResizeToFit(ci.width, ci.height, gift.LanczosResampling))
+	case "stretch":
+		filters = append(filters, gift.Resize(ci.width, ci.height, gift.LanczosResampling))
+	}
+	if ci.radius.radius > 0 {
+		filters = append(filters, ci.radius)
+	}
+
+	g := gift.New(filters...)
+	scaled := image.NewNRGBA(g.Bounds(img.Bounds()))
+	g.Draw(scaled, img)
+
+	// Center within the slot, relevant for contain.
+	sb := scaled.Bounds()
+	pt := image.Pt(ci.x+(ci.width-sb.Dx())/2, ci.y+(ci.height-sb.Dy())/2)
+	gift.New().DrawAt(dst, scaled, dst.Bounds().Min.Add(pt), gift.OverOperator)
+
+	return nil
+}
+
+func (t cardText) draw(dst draw.Image) error {
+	face, err := loadFontFace(t.fontSource, t.size)
+	if err != nil {
+		return err
+	}
+	defer face.Close()
+
+	lines := wrapText(face, t.text, t.width)
+	lines = truncateLines(face, lines, t.maxLines, t.width, t.ellipsis)
+
+	m := face.Metrics()
+	lineAdvance := int(math.Round(t.size * t.lineHeight))
+	// Center the glyphs vertically within the line box.
+	halfLeading := (lineAdvance - (m.Ascent + m.Descent).Ceil()) / 2
+
+	textHeight := lineAdvance * len(lines)
+	y := t.y
+	if t.height > 0 {
+		switch t.valign {
+		case "middle":
+			y += (t.height - textHeight) / 2
+		case "bottom":
+			y += t.height - textHeight
+		}
+	}
+
+	d := font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(t.color),
+		Face: face,
+	}
+
+	b := dst.Bounds()
+	for i, line := range lines {
+		x := t.x
+		if t.width > 0 {
+			switch t.align {
+			case "center":
+				x += (t.width - measureText(face, line)) / 2
+			case "right":
+				x += t.width - measureText(face, line)
+			}
+		}
+		baseline := y + i*lineAdvance + halfLeading + m.Ascent.Ceil()
+		d.Dot = fixed.P(b.Min.X+x, b.Min.Y+baseline)
+		d.DrawString(line)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/disintegration/gift"
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/helpers"
+)
+
+func TestWrapText(t *testing.T) {
+	c := qt.New(t)
+
+	face, err := loadFontFace(nil, 20)
+	c.Assert(err, qt.IsNil)
+
+	word := measureText(face, "word")
+	c.Assert(word > 0, qt.IsTrue)
+
+	lines := wrapText(face, "word word word\nword", word*2+measureText(face, " "))
+	c.Assert(lines, qt.DeepEquals, []string{"word word", "word", "word"})
+
+	lines = wrapText(face, "word word word", 0)
+	c.Assert(lines, qt.DeepEquals, []string{"word word word"})
+
+	// Words longer than the max width are broken.
+	lines = wrapText(face, "wordword", word)
+	c.Assert(lines, qt.DeepEquals, []string{"word", "word"})
+
+	lines = truncateLines(face, []string{"word word", "word", "word"}, 2, word*2, "…")
+	c.Assert(lines, qt.HasLen, 2)
+	c.Assert(strings.HasSuffix(lines[1], "…"), qt.IsTrue)
+	c.Assert(measureText(face, lines[1]) <= word*2, qt.IsTrue)
+
+	c.Assert(truncateLines(face, []string{"a", "b"}, 0, 100, "…"), qt.DeepEquals, []string{"a", "b"})
+}
+
+func TestDecodeCardLayout(t *testing.T) {
+	c := qt.New(t)
+
+	l, err := DecodeCardLayout(map[string]any{
+		"width": 600,
+		"texts": []any{
+			map[string]any{"text": "Hello", "x": 10, "size": 30, "maxLines": 2, "align": "center"},
+		},
+		"images": []any{
+			map[string]any{"image": testImageSource{key: "logo"}, "width": 10, "height": 10, "radius": "50%"},
+		},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(l.Width, qt.Equals, 600)
+	c.Assert(l.Height, qt.Equals, defaultCardHeight)
+	c.Assert(l.texts, qt.HasLen, 1)
+	c.Assert(l.texts[0].width, qt.Equals, 590)
+	c.Assert(l.texts[0].maxLines, qt.Equals, 2)
+	c.Assert(l.images[0].radius.percent, qt.IsTrue)
+
+	l2, err := DecodeCardLayout(map[string]any{
+		"width": 600,
+		"texts": []any{map[string]any{"text": "Hello!", "x": 10, "size": 30}},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(helpers.HashString(l.Filter()), qt.Not(qt.Equals), helpers.HashString(l2.Filter()))
+
+	for _, test := range []struct {
+		layout map[string]any
+		err    string
+	}{
+		{map[string]any{"foo": 1}, ".*unknown card layout option.*"},
+		{map[string]any{"width": -1}, ".*must be positive.*"},
+		{map[string]any{"texts": []any{map[string]any{"align": "justify"}}}, ".*invalid card text align.*"},
+		{map[string]any{"texts": []any{map[string]any{"font": "foo"}}}, ".*invalid text font source.*"},
+		{map[string]any{"images": []any{map[string]any{"width": 10, "height": 10}}}, ".*needs an image.*"},
+		{map[string]any{"images": []any{map[string]any{"image": testImageSource{}, "width": 10, "height": 10, "fit": "foo"}}}, ".*invalid card image fit.*"},
+	} {
+		_, err := DecodeCardLayout(test.layout)
+		c.Assert(err, qt.ErrorMatches, test.err)
+	}
+}
+
+func TestCardFilter(t *testing.T) {
+	c := qt.New(t)
+
+	red := color.NRGBA{R: 255, A: 255}
+	l, err := DecodeCardLayout(map[string]any{
+		"width":  200,
+		"height": 100,
+		"texts": []any{
+			map[string]any{"text": "Hugo Hugo Hugo Hugo Hugo Hugo", "x": 10, "y": 10, "width": 100, "size": 20, "color": "#00ff00", "maxLines": 2},
+		},
+		"images": []any{
+			map[string]any{"image": testImageSource{key: "red", img: uniformImage(4, 4, red)}, "x": 150, "y": 50, "width": 40, "height": 40},
+		},
+	})
+	c.Assert(err, qt.IsNil)
+
+	src := uniformImage(200, 100, color.NRGBA{A: 255})
+	g := gift.New(l.Filter())
+	dst := image.NewNRGBA(g.Bounds(src.Bounds()))
+	g.Draw(dst, src)
+
+	c.Assert(dst.NRGBAAt(170, 70), qt.Equals, red)
+	c.Assert(dst.NRGBAAt(5, 5), qt.Equals, color.NRGBA{A: 255})
+
+	// Text is drawn within its box on two lines only.
+	var green, below int
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 200; x++ {
+			if dst.NRGBAAt(x, y).G > 128 && dst.NRGBAAt(x, y).R < 128 {
+				green++
+				if x > 110 || y > 10+2*24+5 {
+					below++
+				}
+			}
+		}
+	}
+	c.Assert(green > 0, qt.IsTrue)
+	c.Assert(below, qt.Equals, 0)
+}
@@ -17,6 +17,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"image/color"
+	"math"
 	"strings"
 )
 
@@ -45,16 +46,56 @@ func ReplaceColorInPalette(c color.Color, p color.Palette) {
 	p[p.Index(c)] = c
 }
 
+// ColorFromHexString parses s, a hex color code with an optional leading #,
+// e.g. "#fff", "ff0000" or "#ff000080".
+func ColorFromHexString(s string) (color.Color, error) {
+	return hexStringToColor(s)
+}
+
+// ColorToHexString returns c as a lowercase hex color code with a leading #.
+// The alpha channel is only included if c is not opaque.
+func ColorToHexString(c color.Color) string {
+	nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if nc.A == 0xff {
+		return fmt.Sprintf("#%02x%02x%02x", nc.R, nc.G, nc.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", nc.R, nc.G, nc.B, nc.A)
+}
+
+// RelativeLuminance returns the relative luminance of c as defined in WCAG 2,
+// from 0 (black) to 1 (white). The alpha channel is ignored.
+func RelativeLuminance(c color.Color) float64 {
+	nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+	linear := func(v uint8) float64 {
+		f := float64(v) / 255
+		if f <= 0.03928 {
+			return f / 12.92
+		}
+		return math.Pow((f+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(nc.R) + 0.7152*linear(nc.G) + 0.0722*linear(nc.B)
+}
+
+// ContrastRatio returns the contrast ratio between c1 and c2 as defined in
+// WCAG 2, from 1 (no contrast) to 21 (black on white).
+func ContrastRatio(c1, c2 color.Color) float64 {
+	l1, l2 := RelativeLuminance(c1), RelativeLuminance(c2)
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
 func hexStringToColor(s string) (color.Color, error) {
 	s = strings.TrimPrefix(s, "#")
 
-	if len(s) != 3 && len(s) != 6 {
+	if len(s) != 3 && len(s) != 4 && len(s) != 6 && len(s) != 8 {
 		return nil, fmt.Errorf("invalid color code: %q", s)
 	}
 
 	s = strings.ToLower(s)
 
-	if len(s) == 3 {
+	if len(s) == 3 || len(s) == 4 {
 		var v string
 		for _, r := range s {
 			v += string(r) + string(r)
@@ -71,6 +112,15 @@ func hexStringToColor(s string) (color.Color, error) {
 		return color.Black, nil
 	}
 
+	if len(s) == 8 {
+		// Color with alpha channel.
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+		return color.NRGBA{b[0], b[1], b[2], b[3]}, nil
+	}
+
 	// Set Alfa to white.
 	s += "ff"
 
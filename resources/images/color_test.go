@@ -15,6 +15,7 @@ package images
 
 import (
 	"image/color"
+	"math"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
@@ -40,6 +41,8 @@ func TestHexStringToColor(t *testing.T) {
 		{"#000", color.Black},
 		{"#4287f5", color.RGBA{R: 0x42, G: 0x87, B: 0xf5, A: 0xff}},
 		{"777", color.RGBA{R: 0x77, G: 0x77, B: 0x77, A: 0xff}},
+		{"#4287f580", color.NRGBA{R: 0x42, G: 0x87, B: 0xf5, A: 0x80}},
+		{"0000", color.NRGBA{}},
 	} {
 
 		test := test
@@ -87,3 +90,26 @@ func TestReplaceColorInPalette(t *testing.T) {
 	c.Assert(palette, qt.HasLen, 2)
 	c.Assert(palette[0], qt.Equals, offWhite)
 }
+
+func TestLuminanceAndContrastRatio(t *testing.T) {
+	c := qt.New(t)
+
+	round := func(f float64) float64 {
+		return math.Round(f*100) / 100
+	}
+
+	c.Assert(RelativeLuminance(color.White), qt.Equals, 1.0)
+	c.Assert(RelativeLuminance(color.Black), qt.Equals, 0.0)
+	c.Assert(round(RelativeLuminance(color.RGBA{R: 255, A: 255})), qt.Equals, 0.21)
+
+	c.Assert(round(ContrastRatio(color.Black, color.White)), qt.Equals, 21.0)
+	c.Assert(round(ContrastRatio(color.White, color.Black)), qt.Equals, 21.0)
+	c.Assert(ContrastRatio(color.White, color.White), qt.Equals, 1.0)
+
+	gray, err := ColorFromHexString("#767676")
+	c.Assert(err, qt.IsNil)
+	c.Assert(round(ContrastRatio(color.White, gray)), qt.Equals, 4.54)
+
+	c.Assert(ColorToHexString(gray), qt.Equals, "#767676")
+	c.Assert(ColorToHexString(color.NRGBA{R: 255, A: 128}), qt.Equals, "#ff000080")
+}
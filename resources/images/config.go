@@ -21,6 +21,7 @@ import (
 
 	"github.com/gohugoio/hugo/helpers"
 	"github.com/gohugoio/hugo/media"
+	"github.com/gohugoio/hugo/resources/images/exif"
 
 	"errors"
 
@@ -44,6 +45,7 @@ var (
 		".bmp":  BMP,
 		".gif":  GIF,
 		".webp": WEBP,
+		".svg":  SVG,
 	}
 
 	imageFormatsBySubType = map[string]Format{
@@ -53,6 +55,7 @@ var (
 		media.BMPType.SubType:  BMP,
 		media.GIFType.SubType:  GIF,
 		media.WEBPType.SubType: WEBP,
+		media.SVGType.SubType:  SVG,
 	}
 
 	// Add or increment if changes to an image format's processing requires
@@ -171,6 +174,11 @@ func DecodeConfig(m map[string]any) (ImagingConfig, error) {
 	}
 	i.ResampleFilter = filter
 
+	i.MetadataPolicy, err = exif.ParsePolicy(i.Cfg.Metadata.Policy)
+	if err != nil {
+		return i, err
+	}
+
 	if strings.TrimSpace(i.Cfg.Exif.IncludeFields) == "" && strings.TrimSpace(i.Cfg.Exif.ExcludeFields) == "" {
 		// Don't change this for no good reason. Please don't.
 		i.Cfg.Exif.ExcludeFields = "GPS|Exif|Exposure[M|P|B]|Contrast|Resolution|Sharp|JPEG|Metering|Sensing|Saturation|ColorSpace|Flash|WhiteBalance"
@@ -280,9 +288,13 @@ func DecodeImageConfig(action, config string, defaults ImagingConfig, sourceForm
 		c.Anchor = defaults.Anchor
 	}
 
+	if c.TargetFormat == SVG {
+		return c, errors.New("SVG is not supported as a target format")
+	}
+
 	// default to the source format
 	if c.TargetFormat == 0 {
-		c.TargetFormat = sourceFormat
+		c.TargetFormat = sourceFormat.DefaultTargetFormat()
 	}
 
 	if c.Quality <= 0 && c.TargetFormat.RequiresDefaultQuality() {
@@ -340,11 +352,17 @@ type ImageConfig struct {
 
 	Anchor    gift.Anchor
 	AnchorStr string
+
+	// The metadata policy to apply when encoding to JPEG.
+	Metadata exif.Policy
+
+	// Set when the Exif orientation has been applied to the image pixels.
+	Oriented bool
 }
 
 func (i ImageConfig) GetKey(format Format) string {
 	if i.Key != "" {
-		return i.Action + "_" + i.Key
+		return i.Action + "_" + i.Key + i.metadataKey()
 	}
 
 	k := strconv.Itoa(i.Width) + "x" + strconv.Itoa(i.Height)
@@ -385,7 +403,14 @@ func (i ImageConfig) GetKey(format Format) string {
 		k += "_" + strconv.Itoa(mainImageVersionNumber)
 	}
 
-	return k
+	return k + i.metadataKey()
+}
+
+func (i ImageConfig) metadataKey() string {
+	if i.TargetFormat != JPEG || i.Metadata == exif.PolicyStrip {
+		return ""
+	}
+	return "_m" + strconv.Itoa(int(i.Metadata))
 }
 
 type ImagingConfig struct {
@@ -393,6 +418,7 @@ type ImagingConfig struct {
 	Hint           webpoptions.EncodingPreset
 	ResampleFilter gift.Resampling
 	Anchor         gift.Anchor
+	MetadataPolicy exif.Policy
 
 	// Config as provided by the user.
 	Cfg Imaging
@@ -423,6 +449,11 @@ type Imaging struct {
 	BgColor string
 
 	Exif ExifConfig
+
+	// Controls the metadata written to processed images.
+	Metadata MetadataConfig
+
+	SVG SVGConfig
 }
 
 func (cfg *Imaging) init() error {
@@ -460,3 +491,33 @@ type ExifConfig struct {
 	// .Long and .Lat. Set this to true to turn it off.
 	DisableLatLong bool
 }
+
+// MetadataConfig configures what Exif, IPTC and XMP metadata to write to
+// processed JPEG images.
+type MetadataConfig struct {
+	// One of "strip" (default), "keep", "stripGPS" or "copyright".
+	// Note that thumbnails and maker notes are never written.
+	Policy string
+
+	// Apply the policy above to original JPEG images when published as-is.
+	// Default is false, which publishes the original file unchanged.
+	// Has no effect when Policy is "keep".
+	Originals bool
+}
+
+// SVGConfig holds the limits applied when rasterizing SVG images.
+// Zero values means use the defaults.
+type SVGConfig struct {
+	// Maximum size of the SVG source in bytes. Default is 10 MiB.
+	MaxBytes int64
+
+	// Maximum number of elements, including elements instantiated through
+	// <use> references. Default is 50000.
+	MaxElements int
+
+	// Maximum element nesting depth. Default is 64.
+	MaxDepth int
+
+	// Maximum number of pixels in a rasterized image. Default is 25 megapixels.
+	MaxPixels int
+}
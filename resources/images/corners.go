@@ -0,0 +1,94 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/disintegration/gift"
+)
+
+var _ gift.Filter = (*roundedCornersFilter)(nil)
+
+type roundedCornersFilter struct {
+	radius float64
+
+	// If set, radius is a percentage of the shortest side.
+	percent bool
+}
+
+// cornerSamples is the number of samples per axis used to anti-alias the corners.
+const cornerSamples = 4
+
+func (f roundedCornersFilter) Draw(dst draw.Image, src image.Image, options *gift.Options) {
+	gift.New().Draw(dst, src)
+
+	b := dst.Bounds()
+	w, h := float64(b.Dx()), float64(b.Dy())
+
+	r := f.radius
+	if f.percent {
+		r = math.Min(w, h) * r / 100
+	}
+	r = math.Min(r, math.Min(w, h)/2)
+	if r <= 0 {
+		return
+	}
+
+	rc := int(math.Ceil(r))
+	for y := 0; y < b.Dy(); y++ {
+		if y >= rc && y < b.Dy()-rc {
+			continue
+		}
+		for x := 0; x < b.Dx(); x++ {
+			if x >= rc && x < b.Dx()-rc {
+				continue
+			}
+			coverage := cornerCoverage(float64(x), float64(y), w, h, r)
+			if coverage >= 1 {
+				continue
+			}
+			c := color.NRGBAModel.Convert(dst.At(b.Min.X+x, b.Min.Y+y)).(color.NRGBA)
+			c.A = uint8(math.Round(float64(c.A) * coverage))
+			dst.Set(b.Min.X+x, b.Min.Y+y, c)
+		}
+	}
+}
+
+// cornerCoverage returns the fraction of the pixel at x, y inside a rounded
+// rectangle of size w x h with corner radius r.
+func cornerCoverage(x, y, w, h, r float64) float64 {
+	var inside int
+	for sy := 0; sy < cornerSamples; sy++ {
+		for sx := 0; sx < cornerSamples; sx++ {
+			px := x + (float64(sx)+0.5)/cornerSamples
+			py := y + (float64(sy)+0.5)/cornerSamples
+
+			// Distance to the nearest corner circle's center, if in a corner.
+			cx := math.Max(r-px, px-(w-r))
+			cy := math.Max(r-py, py-(h-r))
+			if cx <= 0 || cy <= 0 || cx*cx+cy*cy <= r*r {
+				inside++
+			}
+		}
+	}
+	return float64(inside) / (cornerSamples * cornerSamples)
+}
+
+func (f roundedCornersFilter) Bounds(srcBounds image.Rectangle) image.Rectangle {
+	return image.Rect(0, 0, srcBounds.Dx(), srcBounds.Dy())
+}
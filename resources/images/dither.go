@@ -0,0 +1,211 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"strings"
+
+	"github.com/disintegration/gift"
+)
+
+var (
+	_ gift.Filter     = (*ditherFilter)(nil)
+	_ paletteProvider = (*ditherFilter)(nil)
+)
+
+// paletteProvider is implemented by filters producing images with a fixed
+// set of colors. The result of such a filter, if last in the chain, is
+// stored as a paletted image.
+type paletteProvider interface {
+	Palette() color.Palette
+}
+
+// ditherWeight is one cell in an error diffusion matrix.
+type ditherWeight struct {
+	dx, dy int
+	w      float32
+}
+
+var ditherMatrices = map[string][]ditherWeight{
+	strings.ToLower("FloydSteinberg"): {
+		{1, 0, 7.0 / 16}, {-1, 1, 3.0 / 16}, {0, 1, 5.0 / 16}, {1, 1, 1.0 / 16},
+	},
+	strings.ToLower("Atkinson"): {
+		{1, 0, 1.0 / 8}, {2, 0, 1.0 / 8}, {-1, 1, 1.0 / 8}, {0, 1, 1.0 / 8}, {1, 1, 1.0 / 8}, {0, 2, 1.0 / 8},
+	},
+	strings.ToLower("Burkes"): {
+		{1, 0, 8.0 / 32}, {2, 0, 4.0 / 32},
+		{-2, 1, 2.0 / 32}, {-1, 1, 4.0 / 32}, {0, 1, 8.0 / 32}, {1, 1, 4.0 / 32}, {2, 1, 2.0 / 32},
+	},
+	strings.ToLower("JarvisJudiceNinke"): {
+		{1, 0, 7.0 / 48}, {2, 0, 5.0 / 48},
+		{-2, 1, 3.0 / 48}, {-1, 1, 5.0 / 48}, {0, 1, 7.0 / 48}, {1, 1, 5.0 / 48}, {2, 1, 3.0 / 48},
+		{-2, 2, 1.0 / 48}, {-1, 2, 3.0 / 48}, {0, 2, 5.0 / 48}, {1, 2, 3.0 / 48}, {2, 2, 1.0 / 48},
+	},
+	strings.ToLower("Sierra"): {
+		{1, 0, 5.0 / 32}, {2, 0, 3.0 / 32},
+		{-2, 1, 2.0 / 32}, {-1, 1, 4.0 / 32}, {0, 1, 5.0 / 32}, {1, 1, 4.0 / 32}, {2, 1, 2.0 / 32},
+		{-1, 2, 2.0 / 32}, {0, 2, 3.0 / 32}, {1, 2, 2.0 / 32},
+	},
+	strings.ToLower("SierraLite"): {
+		{1, 0, 2.0 / 4}, {-1, 1, 1.0 / 4}, {0, 1, 1.0 / 4},
+	},
+	strings.ToLower("Stucki"): {
+		{1, 0, 8.0 / 42}, {2, 0, 4.0 / 42},
+		{-2, 1, 2.0 / 42}, {-1, 1, 4.0 / 42}, {0, 1, 8.0 / 42}, {1, 1, 4.0 / 42}, {2, 1, 2.0 / 42},
+		{-2, 2, 1.0 / 42}, {-1, 2, 2.0 / 42}, {0, 2, 4.0 / 42}, {1, 2, 2.0 / 42}, {2, 2, 1.0 / 42},
+	},
+}
+
+// bayer4 is the 4x4 Bayer threshold matrix used for ordered dithering.
+var bayer4 = [4][4]float32{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+const (
+	ditherMethodBayer = "bayer"
+
+	// Pixels with alpha below this threshold are made fully transparent and
+	// excluded from the error diffusion.
+	ditherAlphaThreshold = 128
+)
+
+type ditherFilter struct {
+	colors     []color.NRGBA
+	method     string
+	serpentine bool
+	strength   float32
+}
+
+// Palette returns the colors of the dithered image, including the
+// transparent color used for transparent source pixels.
+func (f ditherFilter) Palette() color.Palette {
+	p := make(color.Palette, 0, len(f.colors)+1)
+	for _, c := range f.colors {
+		p = append(p, c)
+	}
+	return append(p, color.NRGBA{})
+}
+
+func (f ditherFilter) Draw(dst draw.Image, src image.Image, options *gift.Options) {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	// Work in non-premultiplied float space.
+	pix := make([][3]float32, w*h)
+	transparent := make([]bool, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.NRGBAModel.Convert(src.At(b.Min.X+x, b.Min.Y+y)).(color.NRGBA)
+			i := y*w + x
+			pix[i] = [3]float32{float32(c.R), float32(c.G), float32(c.B)}
+			transparent[i] = c.A < ditherAlphaThreshold
+		}
+	}
+
+	db := dst.Bounds()
+	set := func(x, y int, c color.NRGBA) {
+		dst.Set(db.Min.X+x, db.Min.Y+y, c)
+	}
+
+	if f.method == ditherMethodBayer {
+		// Spread the threshold over the average distance between colors.
+		spread := 255 / float32(math.Cbrt(float64(len(f.colors))))
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				i := y*w + x
+				if transparent[i] {
+					set(x, y, color.NRGBA{})
+					continue
+				}
+				t := ((bayer4[y%4][x%4]+0.5)/16 - 0.5) * spread * f.strength
+				p := pix[i]
+				set(x, y, f.nearest([3]float32{p[0] + t, p[1] + t, p[2] + t}))
+			}
+		}
+		return
+	}
+
+	matrix := ditherMatrices[f.method]
+	for y := 0; y < h; y++ {
+		reverse := f.serpentine && y%2 == 1
+		for xi := 0; xi < w; xi++ {
+			x := xi
+			if reverse {
+				x = w - 1 - xi
+			}
+			i := y*w + x
+			if transparent[i] {
+				set(x, y, color.NRGBA{})
+				continue
+			}
+
+			old := pix[i]
+			c := f.nearest(old)
+			set(x, y, c)
+
+			qerr := [3]float32{
+				(old[0] - float32(c.R)) * f.strength,
+				(old[1] - float32(c.G)) * f.strength,
+				(old[2] - float32(c.B)) * f.strength,
+			}
+
+			for _, m := range matrix {
+				dx := m.dx
+				if reverse {
+					dx = -dx
+				}
+				nx, ny := x+dx, y+m.dy
+				if nx < 0 || nx >= w || ny >= h {
+					continue
+				}
+				j := ny*w + nx
+				if transparent[j] {
+					continue
+				}
+				pix[j][0] += qerr[0] * m.w
+				pix[j][1] += qerr[1] * m.w
+				pix[j][2] += qerr[2] * m.w
+			}
+		}
+	}
+}
+
+// nearest returns the palette color closest to p in RGB space.
+func (f ditherFilter) nearest(p [3]float32) color.NRGBA {
+	var (
+		best     color.NRGBA
+		bestDist float32 = math.MaxFloat32
+	)
+	for _, c := range f.colors {
+		dr, dg, db := p[0]-float32(c.R), p[1]-float32(c.G), p[2]-float32(c.B)
+		d := dr*dr + dg*dg + db*db
+		if d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	best.A = 255
+	return best
+}
+
+func (f ditherFilter) Bounds(srcBounds image.Rectangle) image.Rectangle {
+	return image.Rect(0, 0, srcBounds.Dx(), srcBounds.Dy())
+}
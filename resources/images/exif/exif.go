@@ -142,7 +142,7 @@ func (d *Decoder) Decode(r io.Reader) (ex *ExifInfo, err error) {
 		lat, long, _ = x.LatLong()
 	}
 
-	walker := &exifWalker{x: x, vals: make(map[string]any), includeMatcher: d.includeFieldsRe, excludeMatcher: d.excludeFieldsrRe}
+	walker := &exifWalker{x: x, vals: make(map[string]any), d: d}
 	if err = x.Walk(walker); err != nil {
 		return
 	}
@@ -152,6 +152,41 @@ func (d *Decoder) Decode(r io.Reader) (ex *ExifInfo, err error) {
 	return
 }
 
+// DecodeMetadata decodes the IPTC and XMP metadata from the JPEG image in r.
+// Both return values may be nil if not found.
+func (d *Decoder) DecodeMetadata(r io.Reader) (iptc *IPTCInfo, xmp *XMPInfo, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("IPTC/XMP failed: %v", r)
+		}
+	}()
+
+	m, err := ReadJPEGMetadata(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if m.iptc != nil {
+		iptc = d.decodeIPTC(m.iptc)
+	}
+
+	if m.xmp != nil {
+		xmp, err = d.decodeXMP(m.xmp)
+	}
+
+	return
+}
+
+func (d *Decoder) includeTag(name string) bool {
+	if d.excludeFieldsrRe != nil && d.excludeFieldsrRe.MatchString(name) {
+		return false
+	}
+	if d.includeFieldsRe != nil && !d.includeFieldsRe.MatchString(name) {
+		return false
+	}
+	return true
+}
+
 func decodeTag(x *_exif.Exif, f _exif.FieldName, t *tiff.Tag) (any, error) {
 	switch t.Format() {
 	case tiff.StringVal, tiff.UndefVal:
@@ -210,18 +245,14 @@ func tryParseDate(x *_exif.Exif, s string) (time.Time, error) {
 }
 
 type exifWalker struct {
-	x              *_exif.Exif
-	vals           map[string]any
-	includeMatcher *regexp.Regexp
-	excludeMatcher *regexp.Regexp
+	x    *_exif.Exif
+	vals map[string]any
+	d    *Decoder
 }
 
 func (e *exifWalker) Walk(f _exif.FieldName, tag *tiff.Tag) error {
 	name := string(f)
-	if e.excludeMatcher != nil && e.excludeMatcher.MatchString(name) {
-		return nil
-	}
-	if e.includeMatcher != nil && !e.includeMatcher.MatchString(name) {
+	if !e.d.includeTag(name) {
 		return nil
 	}
 	val, err := decodeTag(e.x, f, tag)
@@ -0,0 +1,308 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// IPTCInfo holds the decoded IPTC (IIM) data for an Image.
+type IPTCInfo struct {
+	// The object name, often used as the image title.
+	Title string
+
+	// A short synopsis of the image.
+	Headline string
+
+	// The caption or description of the image.
+	Caption string
+
+	// The (first) creator of the image (By-line).
+	Creator string
+
+	// The provider of the image.
+	Credit string
+
+	// The copyright notice.
+	Copyright string
+
+	// The keywords.
+	Keywords []string
+
+	// The date (and time, if set) the image was created.
+	Date time.Time
+
+	// A collection of the available IPTC datasets for this Image.
+	// Repeatable datasets, e.g. Keywords, are represented as slices.
+	Tags Tags
+}
+
+const (
+	iptcResourceID = 0x0404
+
+	iptcCodedCharacterSet = 90 // Record 1.
+
+	iptcObjectName      = 5
+	iptcKeywords        = 25
+	iptcDateCreated     = 55
+	iptcTimeCreated     = 60
+	iptcByline          = 80
+	iptcHeadline        = 105
+	iptcCredit          = 110
+	iptcCopyrightNotice = 116
+	iptcCaption         = 120
+)
+
+type iptcDataset struct {
+	name       string
+	repeatable bool
+}
+
+// The record 2 (application record) datasets we decode.
+var iptcDatasets = map[byte]iptcDataset{
+	iptcObjectName:      {"ObjectName", false},
+	10:                  {"Urgency", false},
+	15:                  {"Category", false},
+	20:                  {"SupplementalCategories", true},
+	iptcKeywords:        {"Keywords", true},
+	40:                  {"SpecialInstructions", false},
+	iptcDateCreated:     {"DateCreated", false},
+	iptcTimeCreated:     {"TimeCreated", false},
+	iptcByline:          {"Byline", true},
+	85:                  {"BylineTitle", true},
+	90:                  {"City", false},
+	92:                  {"SubLocation", false},
+	95:                  {"ProvinceState", false},
+	100:                 {"CountryCode", false},
+	101:                 {"Country", false},
+	103:                 {"TransmissionReference", false},
+	iptcHeadline:        {"Headline", false},
+	iptcCredit:          {"Credit", false},
+	115:                 {"Source", false},
+	iptcCopyrightNotice: {"CopyrightNotice", false},
+	118:                 {"Contact", true},
+	iptcCaption:         {"Caption", false},
+	122:                 {"WriterEditor", true},
+}
+
+type iptcRecord struct {
+	record  byte
+	dataset byte
+	data    []byte
+}
+
+// findIPTCResource returns the IPTC-IIM data from the Photoshop image
+// resource blocks in b, or nil if not found.
+func findIPTCResource(b []byte) []byte {
+	for len(b) >= 12 && bytes.HasPrefix(b, []byte("8BIM")) {
+		id := binary.BigEndian.Uint16(b[4:])
+		// Pascal string, padded to an even size.
+		nameLen := int(b[6]) + 1
+		if nameLen%2 == 1 {
+			nameLen++
+		}
+		pos := 6 + nameLen
+		if pos+4 > len(b) {
+			return nil
+		}
+		size := int(binary.BigEndian.Uint32(b[pos:]))
+		pos += 4
+		if size < 0 || pos+size > len(b) {
+			return nil
+		}
+		if id == iptcResourceID {
+			return append([]byte(nil), b[pos:pos+size]...)
+		}
+		pos += size
+		if size%2 == 1 {
+			pos++
+		}
+		if pos > len(b) {
+			return nil
+		}
+		b = b[pos:]
+	}
+	return nil
+}
+
+// appendIPTCResource appends the IPTC-IIM data in iptc as a Photoshop image
+// resource block to b.
+func appendIPTCResource(b, iptc []byte) []byte {
+	b = append(b, "8BIM"...)
+	b = append(b, byte(iptcResourceID>>8), byte(iptcResourceID&0xFF))
+	b = append(b, 0, 0) // Empty name, padded.
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(iptc)))
+	b = append(b, size[:]...)
+	b = append(b, iptc...)
+	if len(iptc)%2 == 1 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+func parseIPTC(b []byte) []iptcRecord {
+	var records []iptcRecord
+	for len(b) >= 5 && b[0] == 0x1C {
+		r := iptcRecord{record: b[1], dataset: b[2]}
+		size := int(binary.BigEndian.Uint16(b[3:]))
+		pos := 5
+		if size&0x8000 != 0 {
+			// Extended dataset, the size is stored in the following n bytes.
+			n := size & 0x7FFF
+			if n > 4 || pos+n > len(b) {
+				break
+			}
+			size = 0
+			for _, c := range b[pos : pos+n] {
+				size = size<<8 | int(c)
+			}
+			pos += n
+		}
+		if pos+size > len(b) {
+			break
+		}
+		r.data = b[pos : pos+size]
+		records = append(records, r)
+		b = b[pos+size:]
+	}
+	return records
+}
+
+func encodeIPTC(records []iptcRecord) []byte {
+	var b []byte
+	for _, r := range records {
+		if len(r.data) > 0x7FFF {
+			continue
+		}
+		b = append(b, 0x1C, r.record, r.dataset)
+		b = append(b, byte(len(r.data)>>8), byte(len(r.data)))
+		b = append(b, r.data...)
+	}
+	return b
+}
+
+func filterIPTC(b []byte, keep func(record, dataset byte) bool) []byte {
+	var records []iptcRecord
+	hasApplicationRecord := false
+	for _, r := range parseIPTC(b) {
+		if keep(r.record, r.dataset) {
+			records = append(records, r)
+			hasApplicationRecord = hasApplicationRecord || r.record == 2
+		}
+	}
+	if !hasApplicationRecord {
+		return nil
+	}
+	return encodeIPTC(records)
+}
+
+func (d *Decoder) decodeIPTC(b []byte) *IPTCInfo {
+	records := parseIPTC(b)
+	if len(records) == 0 {
+		return nil
+	}
+
+	isUTF8 := false
+	for _, r := range records {
+		if r.record == 1 && r.dataset == iptcCodedCharacterSet {
+			// ESC % G
+			isUTF8 = bytes.Equal(r.data, []byte("\x1b%G"))
+		}
+	}
+
+	toString := func(b []byte) string {
+		b = bytes.TrimRight(b, "\x00")
+		if isUTF8 || utf8.Valid(b) {
+			return strings.TrimSpace(string(b))
+		}
+		// Assume ISO-8859-1.
+		runes := make([]rune, len(b))
+		for i, c := range b {
+			runes[i] = rune(c)
+		}
+		return strings.TrimSpace(string(runes))
+	}
+
+	x := &IPTCInfo{Tags: make(Tags)}
+	var dateStr, timeStr string
+
+	for _, r := range records {
+		if r.record != 2 {
+			continue
+		}
+		ds, found := iptcDatasets[r.dataset]
+		if !found {
+			continue
+		}
+		s := toString(r.data)
+
+		switch r.dataset {
+		case iptcObjectName:
+			x.Title = s
+		case iptcHeadline:
+			x.Headline = s
+		case iptcCaption:
+			x.Caption = s
+		case iptcByline:
+			if x.Creator == "" {
+				x.Creator = s
+			}
+		case iptcCredit:
+			x.Credit = s
+		case iptcCopyrightNotice:
+			x.Copyright = s
+		case iptcKeywords:
+			x.Keywords = append(x.Keywords, s)
+		case iptcDateCreated:
+			dateStr = s
+		case iptcTimeCreated:
+			timeStr = s
+		}
+
+		if !d.includeTag(ds.name) {
+			continue
+		}
+
+		if ds.repeatable {
+			v, _ := x.Tags[ds.name].([]any)
+			x.Tags[ds.name] = append(v, s)
+		} else {
+			x.Tags[ds.name] = s
+		}
+	}
+
+	if dateStr != "" && !d.noDate {
+		x.Date = parseIPTCDate(dateStr, timeStr)
+	}
+
+	return x
+}
+
+// parseIPTCDate parses the IPTC date (CCYYMMDD) and time (HHMMSS±HHMM).
+func parseIPTCDate(dateStr, timeStr string) time.Time {
+	if timeStr != "" {
+		for _, layout := range []string{"20060102150405-0700", "20060102150405"} {
+			if t, err := time.ParseInLocation(layout, dateStr+timeStr, time.Local); err == nil {
+				return t
+			}
+		}
+	}
+	t, _ := time.ParseInLocation("20060102", dateStr, time.Local)
+	return t
+}
@@ -0,0 +1,344 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exif
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Policy decides which metadata to keep when writing an image.
+type Policy int
+
+const (
+	// PolicyStrip removes all Exif, IPTC and XMP metadata.
+	PolicyStrip Policy = iota
+
+	// PolicyKeep keeps all Exif, IPTC and XMP metadata.
+	// Thumbnails and maker notes are always removed.
+	PolicyKeep
+
+	// PolicyStripGPS keeps all metadata except the GPS location.
+	PolicyStripGPS
+
+	// PolicyCopyright keeps the Exif Artist, Copyright and Orientation tags and
+	// the IPTC By-line, Credit and Copyright Notice. XMP is removed.
+	PolicyCopyright
+)
+
+var policyNames = map[Policy]string{
+	PolicyStrip:     "strip",
+	PolicyKeep:      "keep",
+	PolicyStripGPS:  "stripGPS",
+	PolicyCopyright: "copyright",
+}
+
+// ParsePolicy parses s into a Policy. The empty string means PolicyStrip.
+func ParsePolicy(s string) (Policy, error) {
+	if s == "" {
+		return PolicyStrip, nil
+	}
+	for p, name := range policyNames {
+		if strings.EqualFold(s, name) {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid metadata policy %q, must be one of strip, keep, stripGPS or copyright", s)
+}
+
+func (p Policy) String() string {
+	return policyNames[p]
+}
+
+const (
+	markerSOI   = 0xD8
+	markerEOI   = 0xD9
+	markerSOS   = 0xDA
+	markerAPP0  = 0xE0
+	markerAPP1  = 0xE1
+	markerAPP13 = 0xED
+
+	// Max payload size of a JPEG segment.
+	maxSegmentSize = 0xFFFF - 2
+)
+
+var (
+	exifHeader      = []byte("Exif\x00\x00")
+	xmpHeader       = []byte("http://ns.adobe.com/xap/1.0/\x00")
+	photoshopHeader = []byte("Photoshop 3.0\x00")
+
+	errNotJPEG = errors.New("not a JPEG image")
+)
+
+// Metadata holds the Exif, IPTC and XMP metadata of a JPEG image.
+// A nil *Metadata is valid and represents no metadata.
+type Metadata struct {
+	exif *tiffData
+	iptc []byte // IPTC-IIM records.
+	xmp  []byte // The XMP packet.
+}
+
+// ReadJPEGMetadata reads the Exif, IPTC and XMP metadata from the JPEG image in r.
+func ReadJPEGMetadata(r io.Reader) (*Metadata, error) {
+	m := &Metadata{}
+	err := walkJPEG(r, func(marker byte, payload []byte) error {
+		switch {
+		case marker == markerAPP1 && bytes.HasPrefix(payload, exifHeader) && m.exif == nil:
+			t, err := parseTIFF(payload[len(exifHeader):])
+			if err != nil {
+				return fmt.Errorf("failed to parse Exif: %w", err)
+			}
+			m.exif = t
+		case marker == markerAPP1 && bytes.HasPrefix(payload, xmpHeader) && m.xmp == nil:
+			m.xmp = append([]byte(nil), payload[len(xmpHeader):]...)
+		case marker == markerAPP13 && bytes.HasPrefix(payload, photoshopHeader) && m.iptc == nil:
+			m.iptc = findIPTCResource(payload[len(photoshopHeader):])
+		}
+		return nil
+	}, nil)
+
+	return m, err
+}
+
+// Filter returns a copy of m with only the metadata allowed by p.
+func (m *Metadata) Filter(p Policy) *Metadata {
+	if m == nil || p == PolicyStrip {
+		return nil
+	}
+
+	m2 := &Metadata{}
+
+	switch p {
+	case PolicyKeep:
+		m2.exif = m.exif.clone()
+		m2.iptc = m.iptc
+		m2.xmp = m.xmp
+	case PolicyStripGPS:
+		m2.exif = m.exif.clone()
+		if m2.exif != nil {
+			m2.exif.gps = nil
+		}
+		m2.iptc = m.iptc
+		m2.xmp = stripXMPGPS(m.xmp)
+	case PolicyCopyright:
+		if m.exif != nil {
+			m2.exif = &tiffData{
+				order: m.exif.order,
+				ifd0: m.exif.ifd0.filter(func(tag uint16) bool {
+					return tag == tagArtist || tag == tagCopyright || tag == tagOrientation
+				}),
+			}
+		}
+		m2.iptc = filterIPTC(m.iptc, func(record, dataset byte) bool {
+			return record == 1 && dataset == iptcCodedCharacterSet ||
+				record == 2 && (dataset == iptcByline || dataset == iptcCredit || dataset == iptcCopyrightNotice)
+		})
+	}
+
+	return m2
+}
+
+// SetOrientation sets the Exif orientation if set.
+// This is used when the orientation has been applied to the image pixels.
+func (m *Metadata) SetOrientation(v int) {
+	if m == nil || m.exif == nil {
+		return
+	}
+	m.exif.ifd0.setInt(m.exif.order, tagOrientation, v)
+}
+
+// SetDimensions updates the Exif pixel dimensions if set.
+func (m *Metadata) SetDimensions(width, height int) {
+	if m == nil || m.exif == nil {
+		return
+	}
+	m.exif.exif.setInt(m.exif.order, tagPixelXDimension, width)
+	m.exif.exif.setInt(m.exif.order, tagPixelYDimension, height)
+}
+
+// WriteJPEG copies the JPEG image in r to w, replacing its Exif, IPTC and XMP
+// metadata with m. Any other segment, e.g. ICC color profiles, is kept.
+func (m *Metadata) WriteJPEG(w io.Writer, r io.Reader) error {
+	segments := m.segments()
+
+	bw := bufio.NewWriter(w)
+	bw.Write([]byte{0xFF, markerSOI})
+
+	written := false
+	writeMetadata := func() {
+		if written {
+			return
+		}
+		written = true
+		for _, s := range segments {
+			writeSegment(bw, s.marker, s.payload)
+		}
+	}
+
+	err := walkJPEG(r, func(marker byte, payload []byte) error {
+		if marker != markerAPP0 {
+			// JFIF requires the APP0 segment to come first.
+			writeMetadata()
+		}
+		switch {
+		case marker == markerAPP1 && (bytes.HasPrefix(payload, exifHeader) || bytes.HasPrefix(payload, xmpHeader)):
+			return nil
+		case marker == markerAPP13 && bytes.HasPrefix(payload, photoshopHeader):
+			return nil
+		}
+		writeSegment(bw, marker, payload)
+		return nil
+	}, func(marker byte, payload []byte, rest io.Reader) error {
+		writeMetadata()
+		writeSegment(bw, marker, payload)
+		_, err := io.Copy(bw, rest)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+type segment struct {
+	marker  byte
+	payload []byte
+}
+
+func (m *Metadata) segments() []segment {
+	if m == nil {
+		return nil
+	}
+
+	var segments []segment
+
+	if m.exif != nil {
+		b := m.exif.encode()
+		if len(b)+len(exifHeader) <= maxSegmentSize {
+			segments = append(segments, segment{markerAPP1, append(append([]byte(nil), exifHeader...), b...)})
+		}
+	}
+
+	if len(m.xmp) > 0 && len(m.xmp)+len(xmpHeader) <= maxSegmentSize {
+		segments = append(segments, segment{markerAPP1, append(append([]byte(nil), xmpHeader...), m.xmp...)})
+	}
+
+	if len(m.iptc) > 0 {
+		payload := append([]byte(nil), photoshopHeader...)
+		payload = appendIPTCResource(payload, m.iptc)
+		if len(payload) <= maxSegmentSize {
+			segments = append(segments, segment{markerAPP13, payload})
+		}
+	}
+
+	return segments
+}
+
+func writeSegment(w io.Writer, marker byte, payload []byte) {
+	w.Write([]byte{0xFF, marker})
+	if payload == nil && (marker >= 0xD0 && marker <= markerEOI || marker == 0x01) {
+		// Standalone marker.
+		return
+	}
+	var length [2]byte
+	binary.BigEndian.PutUint16(length[:], uint16(len(payload)+2))
+	w.Write(length[:])
+	w.Write(payload)
+}
+
+// walkJPEG calls onSegment for each segment in the JPEG image in r up until the
+// start of scan, and then onSOS with the start of scan segment and the reader
+// positioned at the compressed image data.
+func walkJPEG(r io.Reader, onSegment func(marker byte, payload []byte) error, onSOS func(marker byte, payload []byte, rest io.Reader) error) error {
+	br := bufio.NewReader(r)
+
+	var soi [2]byte
+	if _, err := io.ReadFull(br, soi[:]); err != nil || soi[0] != 0xFF || soi[1] != markerSOI {
+		return errNotJPEG
+	}
+
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return fmt.Errorf("failed to read JPEG marker: %w", err)
+		}
+		if b != 0xFF {
+			return fmt.Errorf("invalid JPEG marker %#x", b)
+		}
+
+		marker, err := br.ReadByte()
+		for err == nil && marker == 0xFF {
+			// Fill bytes.
+			marker, err = br.ReadByte()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read JPEG marker: %w", err)
+		}
+
+		if marker >= 0xD0 && marker <= 0xD7 || marker == 0x01 {
+			if err := onSegment(marker, nil); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if marker == markerEOI {
+			if onSOS != nil {
+				return onSOS(marker, nil, br)
+			}
+			return nil
+		}
+
+		var length [2]byte
+		if _, err := io.ReadFull(br, length[:]); err != nil {
+			return fmt.Errorf("failed to read JPEG segment: %w", err)
+		}
+		n := int(binary.BigEndian.Uint16(length[:])) - 2
+		if n < 0 {
+			return fmt.Errorf("invalid JPEG segment length %d", n)
+		}
+		payload := make([]byte, n)
+		if _, err := io.ReadFull(br, payload); err != nil {
+			return fmt.Errorf("failed to read JPEG segment: %w", err)
+		}
+
+		if marker == markerSOS {
+			if onSOS != nil {
+				return onSOS(marker, payload, br)
+			}
+			return nil
+		}
+
+		if err := onSegment(marker, payload); err != nil {
+			return err
+		}
+	}
+}
+
+var xmpGPSRe = regexp.MustCompile(`(?s)\s+exif:GPS\w+\s*=\s*"[^"]*"|\s+exif:GPS\w+\s*=\s*'[^']*'|<exif:GPS\w+\s*/>|<exif:GPS\w+[\s>].*?</exif:GPS\w+>`)
+
+// stripXMPGPS removes the GPS properties from the XMP packet in b.
+func stripXMPGPS(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return xmpGPSRe.ReplaceAll(b, nil)
+}
@@ -0,0 +1,246 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParsePolicy(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range []struct {
+		in   string
+		want Policy
+	}{
+		{"", PolicyStrip},
+		{"strip", PolicyStrip},
+		{"keep", PolicyKeep},
+		{"stripgps", PolicyStripGPS},
+		{"stripGPS", PolicyStripGPS},
+		{"Copyright", PolicyCopyright},
+	} {
+		p, err := ParsePolicy(test.in)
+		c.Assert(err, qt.IsNil)
+		c.Assert(p, qt.Equals, test.want)
+	}
+
+	_, err := ParsePolicy("foo")
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Assert(PolicyStripGPS.String(), qt.Equals, "stripGPS")
+}
+
+func TestMetadataFilter(t *testing.T) {
+	c := qt.New(t)
+
+	b, err := os.ReadFile(filepath.FromSlash("../../testdata/sunset.jpg"))
+	c.Assert(err, qt.IsNil)
+
+	m, err := ReadJPEGMetadata(bytes.NewReader(b))
+	c.Assert(err, qt.IsNil)
+
+	d, err := NewDecoder()
+	c.Assert(err, qt.IsNil)
+
+	write := func(c *qt.C, p Policy) []byte {
+		var buf bytes.Buffer
+		c.Assert(m.Filter(p).WriteJPEG(&buf, bytes.NewReader(b)), qt.IsNil)
+		// Make sure it's still a valid JPEG.
+		_, err := jpeg.Decode(bytes.NewReader(buf.Bytes()))
+		c.Assert(err, qt.IsNil)
+		return buf.Bytes()
+	}
+
+	decode := func(c *qt.C, b []byte) (*ExifInfo, *IPTCInfo, *XMPInfo) {
+		x, err := d.Decode(bytes.NewReader(b))
+		c.Assert(err, qt.IsNil)
+		iptc, xmp, err := d.DecodeMetadata(bytes.NewReader(b))
+		c.Assert(err, qt.IsNil)
+		return x, iptc, xmp
+	}
+
+	c.Run("Keep", func(c *qt.C) {
+		x, iptc, xmp := decode(c, write(c, PolicyKeep))
+		c.Assert(x.Lat, qt.Equals, float64(36.59744166666667))
+		c.Assert(x.Tags["LensModel"], qt.Equals, "smc PENTAX-DA* 16-50mm F2.8 ED AL [IF] SDM")
+		c.Assert(iptc.Keywords, qt.DeepEquals, []string{"Malaga", "Torremolinos"})
+		c.Assert(xmp.Rating, qt.Equals, 4)
+	})
+
+	c.Run("Strip GPS", func(c *qt.C) {
+		x, iptc, xmp := decode(c, write(c, PolicyStripGPS))
+		c.Assert(x.Lat, qt.Equals, float64(0))
+		c.Assert(x.Long, qt.Equals, float64(0))
+		c.Assert(x.Date.Format("2006-01-02"), qt.Equals, "2017-10-27")
+		c.Assert(x.Tags["LensModel"], qt.Equals, "smc PENTAX-DA* 16-50mm F2.8 ED AL [IF] SDM")
+		c.Assert(iptc.Keywords, qt.DeepEquals, []string{"Malaga", "Torremolinos"})
+		c.Assert(xmp.Rating, qt.Equals, 4)
+	})
+
+	c.Run("Copyright", func(c *qt.C) {
+		x, iptc, xmp := decode(c, write(c, PolicyCopyright))
+		c.Assert(x.Lat, qt.Equals, float64(0))
+		c.Assert(x.Tags["LensModel"], qt.IsNil)
+		c.Assert(x.Tags["Artist"], qt.Equals, "bjorn.erik.pedersen@gmail.com")
+		c.Assert(iptc.Creator, qt.Equals, "bjorn.erik.pedersen@gmail.com")
+		c.Assert(iptc.Keywords, qt.IsNil)
+		c.Assert(xmp, qt.IsNil)
+	})
+
+	c.Run("Strip", func(c *qt.C) {
+		out := write(c, PolicyStrip)
+		x, iptc, xmp := decode(c, out)
+		c.Assert(x, qt.IsNil)
+		c.Assert(iptc, qt.IsNil)
+		c.Assert(xmp, qt.IsNil)
+		c.Assert(len(out) < len(b), qt.IsTrue)
+	})
+}
+
+func TestMetadataSetOrientationAndDimensions(t *testing.T) {
+	c := qt.New(t)
+
+	var src bytes.Buffer
+	c.Assert(jpeg.Encode(&src, image.NewGray(image.Rect(0, 0, 4, 2)), nil), qt.IsNil)
+
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		m := &Metadata{
+			exif: &tiffData{order: order},
+		}
+		short := func(v uint16) []byte {
+			b := make([]byte, 2)
+			m.exif.order.PutUint16(b, v)
+			return b
+		}
+		m.exif.ifd0 = tiffIFD{
+			{tag: tagOrientation, typ: typeShort, count: 1, val: short(6)},
+			{tag: tagArtist, typ: 2, count: 5, val: []byte("Hugo\x00")},
+		}
+		m.exif.exif = tiffIFD{
+			{tag: tagPixelXDimension, typ: typeShort, count: 1, val: short(4)},
+			{tag: tagPixelYDimension, typ: typeShort, count: 1, val: short(2)},
+		}
+
+		m.SetOrientation(1)
+		m.SetDimensions(100000, 2)
+
+		var dst bytes.Buffer
+		c.Assert(m.WriteJPEG(&dst, bytes.NewReader(src.Bytes())), qt.IsNil)
+
+		d, err := NewDecoder()
+		c.Assert(err, qt.IsNil)
+		x, err := d.Decode(bytes.NewReader(dst.Bytes()))
+		c.Assert(err, qt.IsNil)
+		c.Assert(x.Tags["Orientation"], qt.Equals, 1)
+		c.Assert(x.Tags["Artist"], qt.Equals, "Hugo")
+		c.Assert(x.Tags["PixelXDimension"], qt.Equals, 100000)
+		c.Assert(x.Tags["PixelYDimension"], qt.Equals, 2)
+	}
+}
+
+func TestDecodeXMP(t *testing.T) {
+	c := qt.New(t)
+
+	packet := []byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:dc="http://purl.org/dc/elements/1.1/"
+    xmlns:xmp="http://ns.adobe.com/xap/1.0/"
+    xmlns:exif="http://ns.adobe.com/exif/1.0/"
+    xmlns:crs="http://ns.adobe.com/camera-raw-settings/1.0/"
+    xmlns:Iptc4xmpCore="http://iptc.org/std/Iptc4xmpCore/1.0/xmlns/"
+   xmp:Rating="3"
+   xmp:CreateDate="2021-06-01T10:00:00+02:00"
+   exif:GPSLatitude="36,35.8465N"
+   crs:Version="9.12">
+   <exif:GPSLongitude>4,30.5076W</exif:GPSLongitude>
+   <dc:title>
+    <rdf:Alt>
+     <rdf:li xml:lang="nb-NO">Solnedgang</rdf:li>
+     <rdf:li xml:lang="x-default">Sunset</rdf:li>
+    </rdf:Alt>
+   </dc:title>
+   <dc:subject>
+    <rdf:Bag>
+     <rdf:li>Sea</rdf:li>
+     <rdf:li>Sun</rdf:li>
+    </rdf:Bag>
+   </dc:subject>
+   <dc:rights>© Hugo</dc:rights>
+   <Iptc4xmpCore:CreatorContactInfo rdf:parseType="Resource">
+    <Iptc4xmpCore:CiEmailWork>hugo@example.org</Iptc4xmpCore:CiEmailWork>
+   </Iptc4xmpCore:CreatorContactInfo>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>`)
+
+	d, err := NewDecoder()
+	c.Assert(err, qt.IsNil)
+	x, err := d.decodeXMP(packet)
+	c.Assert(err, qt.IsNil)
+	c.Assert(x.Title, qt.Equals, "Sunset")
+	c.Assert(x.Keywords, qt.DeepEquals, []string{"Sea", "Sun"})
+	c.Assert(x.Rights, qt.Equals, "© Hugo")
+	c.Assert(x.Rating, qt.Equals, 3)
+	c.Assert(x.Date.Format("2006-01-02 15:04 -0700"), qt.Equals, "2021-06-01 10:00 +0200")
+	c.Assert(x.Tags["subject"], qt.DeepEquals, []any{"Sea", "Sun"})
+	c.Assert(x.Tags["GPSLatitude"], qt.Equals, "36,35.8465N")
+	c.Assert(x.Tags["GPSLongitude"], qt.Equals, "4,30.5076W")
+	c.Assert(x.Tags["Version"], qt.IsNil)
+	c.Assert(x.Tags["CreatorContactInfo"], qt.IsNil)
+
+	x, err = d.decodeXMP(stripXMPGPS(packet))
+	c.Assert(err, qt.IsNil)
+	c.Assert(x.Tags["GPSLatitude"], qt.IsNil)
+	c.Assert(x.Tags["GPSLongitude"], qt.IsNil)
+	c.Assert(x.Title, qt.Equals, "Sunset")
+}
+
+func TestDecodeIPTC(t *testing.T) {
+	c := qt.New(t)
+
+	records := encodeIPTC([]iptcRecord{
+		{1, iptcCodedCharacterSet, []byte("\x1b%G")},
+		{2, iptcObjectName, []byte("Sunset")},
+		{2, iptcKeywords, []byte("Sea")},
+		{2, iptcKeywords, []byte("Sun")},
+		{2, iptcByline, []byte("Bjørn")},
+		{2, iptcCaption, []byte("The sun sets.")},
+		{2, iptcDateCreated, []byte("20210601")},
+		{2, iptcTimeCreated, []byte("100000+0200")},
+	})
+
+	// Round trip through a Photoshop image resource block.
+	block := appendIPTCResource([]byte("8BIM\x04\x0c\x00\x00\x00\x00\x00\x01x\x00"), records)
+	c.Assert(findIPTCResource(block), qt.DeepEquals, records)
+
+	d, err := NewDecoder(ExcludeFields("Caption"))
+	c.Assert(err, qt.IsNil)
+	x := d.decodeIPTC(records)
+	c.Assert(x.Title, qt.Equals, "Sunset")
+	c.Assert(x.Creator, qt.Equals, "Bjørn")
+	c.Assert(x.Caption, qt.Equals, "The sun sets.")
+	c.Assert(x.Keywords, qt.DeepEquals, []string{"Sea", "Sun"})
+	c.Assert(x.Date.Format("2006-01-02 15:04 -0700"), qt.Equals, "2021-06-01 10:00 +0200")
+	c.Assert(x.Tags["Keywords"], qt.DeepEquals, []any{"Sea", "Sun"})
+	c.Assert(x.Tags["Caption"], qt.IsNil)
+}
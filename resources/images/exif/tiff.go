@@ -0,0 +1,262 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exif
+
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
+)
+
+const (
+	tagOrientation     = 0x0112
+	tagArtist          = 0x013B
+	tagCopyright       = 0x8298
+	tagExifIFD         = 0x8769
+	tagGPSIFD          = 0x8825
+	tagInteropIFD      = 0xA005
+	tagMakerNote       = 0x927C
+	tagPixelXDimension = 0xA002
+	tagPixelYDimension = 0xA003
+
+	typeShort = 3
+	typeLong  = 4
+	typeIFD   = 13
+)
+
+// Size in bytes of the TIFF data types, indexed by type.
+var tiffTypeSizes = [...]uint32{0, 1, 1, 2, 4, 8, 1, 1, 2, 4, 8, 4, 8, 4}
+
+var errInvalidTIFF = errors.New("invalid TIFF data")
+
+type tiffEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	val   []byte
+}
+
+type tiffIFD []tiffEntry
+
+func (ifd tiffIFD) filter(keep func(tag uint16) bool) tiffIFD {
+	var filtered tiffIFD
+	for _, e := range ifd {
+		if keep(e.tag) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// setInt sets the value of the SHORT or LONG entry with the given tag, if found.
+func (ifd tiffIFD) setInt(order binary.ByteOrder, tag uint16, v int) {
+	for i, e := range ifd {
+		if e.tag != tag {
+			continue
+		}
+		if v <= 0xFFFF && e.typ == typeShort {
+			e.val = make([]byte, 2)
+			order.PutUint16(e.val, uint16(v))
+		} else {
+			e.typ = typeLong
+			e.val = make([]byte, 4)
+			order.PutUint32(e.val, uint32(v))
+		}
+		e.count = 1
+		ifd[i] = e
+	}
+}
+
+// tiffData holds the parts of an Exif TIFF structure we preserve when
+// rewriting metadata: IFD0 and the Exif and GPS sub IFDs.
+// Thumbnails (IFD1), the interoperability IFD and maker notes are dropped, the
+// last because they often contain absolute offsets we cannot preserve.
+type tiffData struct {
+	order binary.ByteOrder
+	ifd0  tiffIFD
+	exif  tiffIFD
+	gps   tiffIFD
+}
+
+func (t *tiffData) clone() *tiffData {
+	if t == nil {
+		return nil
+	}
+	return &tiffData{
+		order: t.order,
+		ifd0:  append(tiffIFD(nil), t.ifd0...),
+		exif:  append(tiffIFD(nil), t.exif...),
+		gps:   append(tiffIFD(nil), t.gps...),
+	}
+}
+
+func parseTIFF(b []byte) (*tiffData, error) {
+	if len(b) < 8 {
+		return nil, errInvalidTIFF
+	}
+
+	t := &tiffData{}
+	switch string(b[:2]) {
+	case "II":
+		t.order = binary.LittleEndian
+	case "MM":
+		t.order = binary.BigEndian
+	default:
+		return nil, errInvalidTIFF
+	}
+	if t.order.Uint16(b[2:]) != 42 {
+		return nil, errInvalidTIFF
+	}
+
+	var (
+		pointers map[uint16]uint32
+		err      error
+	)
+
+	t.ifd0, pointers, err = parseIFD(b, t.order, t.order.Uint32(b[4:]))
+	if err != nil {
+		return nil, err
+	}
+	if offset, found := pointers[tagExifIFD]; found {
+		if t.exif, _, err = parseIFD(b, t.order, offset); err != nil {
+			return nil, err
+		}
+	}
+	if offset, found := pointers[tagGPSIFD]; found {
+		if t.gps, _, err = parseIFD(b, t.order, offset); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+func parseIFD(b []byte, order binary.ByteOrder, offset uint32) (tiffIFD, map[uint16]uint32, error) {
+	if uint64(offset)+2 > uint64(len(b)) {
+		return nil, nil, errInvalidTIFF
+	}
+	n := int(order.Uint16(b[offset:]))
+	start := int(offset) + 2
+	if start+n*12 > len(b) {
+		return nil, nil, errInvalidTIFF
+	}
+
+	var (
+		ifd      tiffIFD
+		pointers = make(map[uint16]uint32)
+	)
+
+	for i := 0; i < n; i++ {
+		e := b[start+i*12:]
+		tag := order.Uint16(e)
+		typ := order.Uint16(e[2:])
+		count := order.Uint32(e[4:])
+
+		if typ == 0 || int(typ) >= len(tiffTypeSizes) {
+			// Unknown type.
+			continue
+		}
+
+		size := uint64(tiffTypeSizes[typ]) * uint64(count)
+		var val []byte
+		if size <= 4 {
+			val = e[8 : 8+size]
+		} else {
+			valOffset := uint64(order.Uint32(e[8:]))
+			if valOffset+size > uint64(len(b)) {
+				continue
+			}
+			val = b[valOffset : valOffset+size]
+		}
+
+		switch tag {
+		case tagExifIFD, tagGPSIFD, tagInteropIFD:
+			if (typ == typeLong || typ == typeIFD) && count == 1 {
+				pointers[tag] = order.Uint32(val)
+			}
+			continue
+		case tagMakerNote:
+			continue
+		}
+
+		ifd = append(ifd, tiffEntry{tag: tag, typ: typ, count: count, val: append([]byte(nil), val...)})
+	}
+
+	return ifd, pointers, nil
+}
+
+func (t *tiffData) encode() []byte {
+	order := t.order
+
+	ifd0 := append(tiffIFD(nil), t.ifd0...)
+	if len(t.exif) > 0 {
+		ifd0 = append(ifd0, tiffEntry{tag: tagExifIFD, typ: typeLong, count: 1, val: make([]byte, 4)})
+	}
+	if len(t.gps) > 0 {
+		ifd0 = append(ifd0, tiffEntry{tag: tagGPSIFD, typ: typeLong, count: 1, val: make([]byte, 4)})
+	}
+
+	b := make([]byte, 8)
+	if order == binary.LittleEndian {
+		copy(b, "II")
+	} else {
+		copy(b, "MM")
+	}
+	order.PutUint16(b[2:], 42)
+	order.PutUint32(b[4:], 8)
+
+	writeIFD := func(ifd tiffIFD) uint32 {
+		sort.SliceStable(ifd, func(i, j int) bool { return ifd[i].tag < ifd[j].tag })
+		if len(b)%2 == 1 {
+			b = append(b, 0)
+		}
+		start := len(b)
+		b = append(b, make([]byte, 2+12*len(ifd)+4)...)
+		order.PutUint16(b[start:], uint16(len(ifd)))
+		for i, e := range ifd {
+			p := start + 2 + 12*i
+			order.PutUint16(b[p:], e.tag)
+			order.PutUint16(b[p+2:], e.typ)
+			order.PutUint32(b[p+4:], e.count)
+			if len(e.val) <= 4 {
+				copy(b[p+8:p+12], e.val)
+				continue
+			}
+			if len(b)%2 == 1 {
+				b = append(b, 0)
+			}
+			order.PutUint32(b[p+8:], uint32(len(b)))
+			b = append(b, e.val...)
+		}
+		return uint32(start)
+	}
+
+	setPointer := func(ifdStart uint32, ifd tiffIFD, tag uint16, offset uint32) {
+		for i, e := range ifd {
+			if e.tag == tag {
+				order.PutUint32(b[int(ifdStart)+2+12*i+8:], offset)
+			}
+		}
+	}
+
+	ifd0Start := writeIFD(ifd0)
+	if len(t.exif) > 0 {
+		setPointer(ifd0Start, ifd0, tagExifIFD, writeIFD(append(tiffIFD(nil), t.exif...)))
+	}
+	if len(t.gps) > 0 {
+		setPointer(ifd0Start, ifd0, tagGPSIFD, writeIFD(append(tiffIFD(nil), t.gps...)))
+	}
+
+	return b
+}
@@ -0,0 +1,292 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exif
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// XMPInfo holds the decoded XMP data for an Image.
+type XMPInfo struct {
+	// The title (dc:title).
+	Title string
+
+	// The description or caption (dc:description).
+	Description string
+
+	// The creators (dc:creator).
+	Creator []string
+
+	// The keywords (dc:subject).
+	Keywords []string
+
+	// The copyright statement (dc:rights).
+	Rights string
+
+	// The rating, from -1 (rejected) to 5 (xmp:Rating).
+	Rating int
+
+	// The date the image was created (photoshop:DateCreated or xmp:CreateDate).
+	Date time.Time
+
+	// A collection of the available XMP properties for this Image, keyed by
+	// their name without the namespace prefix.
+	// Array values are represented as slices.
+	Tags Tags
+}
+
+const (
+	nsRDF       = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	nsXML       = "http://www.w3.org/XML/1998/namespace"
+	nsDC        = "http://purl.org/dc/elements/1.1/"
+	nsXMP       = "http://ns.adobe.com/xap/1.0/"
+	nsPhotoshop = "http://ns.adobe.com/photoshop/1.0/"
+)
+
+// Namespaces with editing history and develop settings, which are of no
+// interest when publishing images.
+var xmpIgnoredNamespaces = map[string]bool{
+	"http://ns.adobe.com/camera-raw-settings/1.0/":         true,
+	"http://ns.adobe.com/xap/1.0/mm/":                      true,
+	"http://ns.adobe.com/xap/1.0/sType/ResourceEvent#":     true,
+	"http://ns.adobe.com/xap/1.0/sType/ResourceRef#":       true,
+	"http://ns.adobe.com/lightroom/1.0/":                   true,
+	"http://ns.adobe.com/photoshop/1.0/camera-profile":     true,
+	"http://ns.adobe.com/xmp/1.0/DynamicMedia/":            true,
+	"http://www.metadataworkinggroup.com/schemas/regions/": true,
+}
+
+func (d *Decoder) decodeXMP(b []byte) (*XMPInfo, error) {
+	x := &XMPInfo{Tags: make(Tags)}
+	var createDate, dateCreated string
+
+	set := func(name xml.Name, v any) {
+		if xmpIgnoredNamespaces[name.Space] {
+			return
+		}
+
+		s, _ := v.(string)
+		ss, isSlice := v.([]string)
+		if !isSlice && s != "" {
+			ss = []string{s}
+		}
+
+		switch name.Space {
+		case nsDC:
+			switch name.Local {
+			case "title":
+				x.Title = firstString(ss)
+			case "description":
+				x.Description = firstString(ss)
+			case "creator":
+				x.Creator = ss
+			case "subject":
+				x.Keywords = ss
+			case "rights":
+				x.Rights = firstString(ss)
+			}
+		case nsXMP:
+			switch name.Local {
+			case "Rating":
+				x.Rating = cast.ToInt(s)
+			case "CreateDate":
+				createDate = s
+			}
+		case nsPhotoshop:
+			if name.Local == "DateCreated" {
+				dateCreated = s
+			}
+		}
+
+		if !d.includeTag(name.Local) {
+			return
+		}
+		if _, found := x.Tags[name.Local]; found {
+			// First wins.
+			return
+		}
+		if isSlice {
+			x.Tags[name.Local] = toAnySlice(ss)
+		} else {
+			x.Tags[name.Local] = v
+		}
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(b))
+	dec.Strict = false
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		se, ok := tok.(xml.StartElement)
+		if !ok || se.Name.Space != nsRDF || se.Name.Local != "Description" {
+			continue
+		}
+
+		for _, attr := range se.Attr {
+			if attr.Name.Space == "xmlns" || attr.Name.Space == nsRDF || attr.Name.Space == nsXML || attr.Name.Space == "" {
+				continue
+			}
+			set(attr.Name, strings.TrimSpace(attr.Value))
+		}
+
+		// The properties of this description.
+		for {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := tok.(xml.EndElement); ok {
+				break
+			}
+			if se, ok := tok.(xml.StartElement); ok {
+				v, err := decodeXMPValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				if v != nil {
+					set(se.Name, v)
+				}
+			}
+		}
+	}
+
+	if !d.noDate {
+		for _, s := range []string{dateCreated, createDate} {
+			if s == "" {
+				continue
+			}
+			if t, err := parseXMPDate(s); err == nil {
+				x.Date = t
+				break
+			}
+		}
+	}
+
+	return x, nil
+}
+
+// decodeXMPValue decodes a simple or array property value up until its
+// end element. Structured values are skipped and returns nil.
+func decodeXMPValue(dec *xml.Decoder) (any, error) {
+	var (
+		text       strings.Builder
+		items      []string
+		isArray    bool
+		isAlt      bool
+		defaultAlt = -1
+		depth      = 0
+		complex    = false
+	)
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			depth++
+			switch {
+			case depth == 1 && t.Name.Space == nsRDF && (t.Name.Local == "Bag" || t.Name.Local == "Seq" || t.Name.Local == "Alt"):
+				isArray = true
+				isAlt = t.Name.Local == "Alt"
+			case depth == 2 && isArray && t.Name.Space == nsRDF && t.Name.Local == "li":
+				for _, attr := range t.Attr {
+					if attr.Name.Space == nsXML && attr.Name.Local == "lang" && attr.Value == "x-default" {
+						defaultAlt = len(items)
+					}
+				}
+				var s string
+				if err := dec.DecodeElement(&s, &t); err != nil {
+					return nil, err
+				}
+				depth--
+				items = append(items, strings.TrimSpace(s))
+			default:
+				complex = true
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				switch {
+				case complex:
+					return nil, nil
+				case isAlt:
+					if defaultAlt >= 0 {
+						return items[defaultAlt], nil
+					}
+					return firstString(items), nil
+				case isArray:
+					return items, nil
+				default:
+					return strings.TrimSpace(text.String()), nil
+				}
+			}
+			depth--
+		case xml.CharData:
+			if depth == 0 {
+				text.Write(t)
+			}
+		}
+	}
+}
+
+func toAnySlice(ss []string) []any {
+	vals := make([]any, len(ss))
+	for i, s := range ss {
+		vals[i] = s
+	}
+	return vals
+}
+
+func firstString(ss []string) string {
+	if len(ss) == 0 {
+		return ""
+	}
+	return ss[0]
+}
+
+var xmpDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"2006-01",
+	"2006",
+}
+
+func parseXMPDate(s string) (time.Time, error) {
+	var err error
+	for _, layout := range xmpDateLayouts {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
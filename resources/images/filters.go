@@ -16,6 +16,8 @@ package images
 
 import (
 	"fmt"
+	"image/color"
+	"strings"
 
 	"github.com/gohugoio/hugo/common/hugio"
 	"github.com/gohugoio/hugo/common/maps"
@@ -39,6 +41,170 @@ func (*Filters) Overlay(src ImageSource, x, y any) gift.Filter {
 	}
 }
 
+// Mask creates a filter that applies the mask image src to an image. The mask
+// is scaled to the size of the image. White, opaque areas of the mask keep
+// the image as is, black or transparent areas make it transparent.
+func (*Filters) Mask(src ImageSource) gift.Filter {
+	return filter{
+		Options: newFilterOpts(src.Key()),
+		Filter:  maskFilter{mask: src},
+	}
+}
+
+// Padding creates a filter that adds padding around an image, using the same
+// shorthand as CSS: one value for all sides; vertical and horizontal; top,
+// horizontal and bottom; or top, right, bottom and left.
+// An optional last argument sets the padding color as a hex RGB or RGBA color,
+// e.g. "#fff" or "#ffffff80". The default is transparent.
+func (*Filters) Padding(args ...any) gift.Filter {
+	if len(args) < 1 || len(args) > 5 {
+		panic(fmt.Sprintf("must provide between 1 and 5 arguments, got %d", len(args)))
+	}
+
+	var (
+		ccolor color.Color = color.Transparent
+		vals               = args
+	)
+
+	if s, ok := args[len(args)-1].(string); ok {
+		if _, err := cast.ToIntE(s); err != nil {
+			c, err := hexStringToColor(s)
+			if err != nil {
+				panic(fmt.Sprintf("invalid padding color: %s", err))
+			}
+			ccolor = c
+			vals = args[:len(args)-1]
+		}
+	}
+
+	var p []int
+	for _, v := range vals {
+		i, err := cast.ToIntE(v)
+		if err != nil || i < 0 {
+			panic(fmt.Sprintf("invalid padding value %v", v))
+		}
+		p = append(p, i)
+	}
+
+	var top, right, bottom, left int
+	switch len(p) {
+	case 1:
+		top, right, bottom, left = p[0], p[0], p[0], p[0]
+	case 2:
+		top, right, bottom, left = p[0], p[1], p[0], p[1]
+	case 3:
+		top, right, bottom, left = p[0], p[1], p[2], p[1]
+	case 4:
+		top, right, bottom, left = p[0], p[1], p[2], p[3]
+	default:
+		panic(fmt.Sprintf("must provide between 1 and 4 padding values, got %d", len(p)))
+	}
+
+	return filter{
+		Options: newFilterOpts(args...),
+		Filter: paddingFilter{
+			top:    top,
+			right:  right,
+			bottom: bottom,
+			left:   left,
+			ccolor: ccolor,
+		},
+	}
+}
+
+// RoundedCorners creates a filter that makes the corners of an image
+// transparent. The radius is given in pixels or, with a "%" suffix, as a
+// percentage of the shortest side, e.g. "50%" for a circle or ellipse.
+func (*Filters) RoundedCorners(radius any) gift.Filter {
+	rf := roundedCornersFilter{}
+	if s, ok := radius.(string); ok && strings.HasSuffix(s, "%") {
+		rf.percent = true
+		radius = strings.TrimSuffix(s, "%")
+	}
+
+	r, err := cast.ToFloat64E(radius)
+	if err != nil || r < 0 {
+		panic(fmt.Sprintf("invalid corner radius %v", radius))
+	}
+	rf.radius = r
+
+	return filter{
+		Options: newFilterOpts(r, rf.percent),
+		Filter:  rf,
+	}
+}
+
+// Dither creates a filter that reduces the colors of an image to the given
+// palette using dithering. Transparent pixels are kept transparent. When last
+// in a filter chain, the result is stored as a paletted image, which makes
+// for small PNG and GIF files.
+//
+// Options:
+//
+//	colors: a slice of hex colors. Default is black and white.
+//	method: the dithering method. One of FloydSteinberg (default), Atkinson,
+//	        Burkes, JarvisJudiceNinke, Sierra, SierraLite, Stucki or Bayer
+//	        (ordered dithering).
+//	serpentine: whether to alternate the scan direction per row. Default true.
+//	strength: the strength of the dithering, 0 to 1. Default 1.
+func (*Filters) Dither(options ...any) gift.Filter {
+	df := ditherFilter{
+		colors:     []color.NRGBA{{A: 255}, {R: 255, G: 255, B: 255, A: 255}},
+		method:     strings.ToLower("FloydSteinberg"),
+		serpentine: true,
+		strength:   1,
+	}
+
+	var opt maps.Params
+	if len(options) > 0 {
+		opt = maps.MustToParamsAndPrepare(options[0])
+		for option, v := range opt {
+			switch option {
+			case "colors":
+				colors := cast.ToStringSlice(v)
+				if len(colors) < 2 || len(colors) > 256 {
+					panic(fmt.Sprintf("dither palette must have between 2 and 256 colors, got %d", len(colors)))
+				}
+				df.colors = make([]color.NRGBA, len(colors))
+				for i, s := range colors {
+					c, err := hexStringToColor(s)
+					if err != nil {
+						panic(fmt.Sprintf("invalid dither color: %s", err))
+					}
+					df.colors[i] = color.NRGBAModel.Convert(c).(color.NRGBA)
+				}
+			case "method":
+				df.method = strings.ToLower(cast.ToString(v))
+				if _, found := ditherMatrices[df.method]; !found && df.method != ditherMethodBayer {
+					panic(fmt.Sprintf("invalid dither method %q", v))
+				}
+			case "serpentine":
+				df.serpentine = cast.ToBool(v)
+			case "strength":
+				df.strength = cast.ToFloat32(v)
+				if df.strength < 0 || df.strength > 1 {
+					panic(fmt.Sprintf("dither strength must be between 0 and 1, got %v", v))
+				}
+			}
+		}
+	}
+
+	return filter{
+		Options: newFilterOpts(opt),
+		Filter:  df,
+	}
+}
+
+// AutoOrient creates a filter that rotates and flips an image as needed to
+// display it upright, based on the Exif orientation of the original image.
+// It does nothing if the image has no Exif orientation.
+func (*Filters) AutoOrient() gift.Filter {
+	return filter{
+		Options: newFilterOpts("autoorient"),
+		Filter:  autoOrientFilter{},
+	}
+}
+
 // Text creates a filter that draws text with the given options.
 func (*Filters) Text(text string, options ...any) gift.Filter {
 	tf := textFilter{
@@ -228,6 +394,14 @@ type filterOpts struct {
 	Vals    any
 }
 
+// UnwrapFilter returns the underlying filter of a filter created by Filters.
+func UnwrapFilter(in gift.Filter) gift.Filter {
+	if f, ok := in.(filter); ok {
+		return f.Filter
+	}
+	return in
+}
+
 func newFilterOpts(vals ...any) filterOpts {
 	return filterOpts{
 		Version: filterAPIVersion,
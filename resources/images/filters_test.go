@@ -14,9 +14,13 @@
 package images
 
 import (
+	"image"
+	"image/color"
 	"testing"
 
+	"github.com/disintegration/gift"
 	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/resources/images/exif"
 
 	qt "github.com/frankban/quicktest"
 )
@@ -31,3 +35,157 @@ func TestFilterHash(t *testing.T) {
 	c.Assert(helpers.HashString(f.Gamma(32)), qt.Not(qt.Equals), helpers.HashString(f.Gamma(33)))
 	c.Assert(helpers.HashString(f.Gamma(32)), qt.Equals, helpers.HashString(f.Gamma(32)))
 }
+
+func TestFilterHashNew(t *testing.T) {
+	c := qt.New(t)
+
+	f := &Filters{}
+
+	c.Assert(helpers.HashString(f.Padding(10)), qt.Equals, helpers.HashString(f.Padding(10)))
+	c.Assert(helpers.HashString(f.Padding(10)), qt.Not(qt.Equals), helpers.HashString(f.Padding(10, "#fff")))
+	c.Assert(helpers.HashString(f.RoundedCorners(10)), qt.Not(qt.Equals), helpers.HashString(f.RoundedCorners("10%")))
+	c.Assert(helpers.HashString(f.Dither()), qt.Not(qt.Equals), helpers.HashString(f.Dither(map[string]any{"method": "Atkinson"})))
+	c.Assert(helpers.HashString(f.Mask(testImageSource{key: "a"})), qt.Not(qt.Equals), helpers.HashString(f.Mask(testImageSource{key: "b"})))
+}
+
+func TestPaddingFilter(t *testing.T) {
+	c := qt.New(t)
+
+	f := &Filters{}
+	src := uniformImage(4, 2, color.NRGBA{R: 255, A: 255})
+
+	for _, test := range []struct {
+		args          []any
+		width, height int
+	}{
+		{[]any{1}, 6, 4},
+		{[]any{1, 2}, 8, 4},
+		{[]any{1, 2, 3}, 8, 6},
+		{[]any{1, 2, 3, 4, "#00f"}, 10, 6},
+	} {
+		g := gift.New(f.Padding(test.args...))
+		dst := image.NewNRGBA(g.Bounds(src.Bounds()))
+		g.Draw(dst, src)
+		c.Assert(dst.Bounds().Dx(), qt.Equals, test.width)
+		c.Assert(dst.Bounds().Dy(), qt.Equals, test.height)
+	}
+
+	g := gift.New(f.Padding(1, 2, 3, 4, "#00f"))
+	dst := image.NewNRGBA(g.Bounds(src.Bounds()))
+	g.Draw(dst, src)
+	c.Assert(dst.NRGBAAt(0, 0), qt.Equals, color.NRGBA{B: 255, A: 255})
+	c.Assert(dst.NRGBAAt(4, 1), qt.Equals, color.NRGBA{R: 255, A: 255})
+
+	c.Assert(func() { f.Padding() }, qt.PanicMatches, ".*between 1 and 5.*")
+	c.Assert(func() { f.Padding(1, "#zzz") }, qt.PanicMatches, ".*invalid padding color.*")
+}
+
+func TestRoundedCornersFilter(t *testing.T) {
+	c := qt.New(t)
+
+	f := &Filters{}
+	src := uniformImage(20, 20, color.NRGBA{G: 255, A: 255})
+
+	g := gift.New(f.RoundedCorners("50%"))
+	dst := image.NewNRGBA(g.Bounds(src.Bounds()))
+	g.Draw(dst, src)
+
+	c.Assert(dst.NRGBAAt(0, 0).A, qt.Equals, uint8(0))
+	c.Assert(dst.NRGBAAt(19, 19).A, qt.Equals, uint8(0))
+	c.Assert(dst.NRGBAAt(10, 10).A, qt.Equals, uint8(255))
+	c.Assert(dst.NRGBAAt(10, 0).A, qt.Equals, uint8(255))
+}
+
+func TestDitherFilter(t *testing.T) {
+	c := qt.New(t)
+
+	f := &Filters{}
+	src := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			src.SetNRGBA(x, y, color.NRGBA{R: 128, G: 128, B: 128, A: 255})
+		}
+	}
+	src.SetNRGBA(0, 0, color.NRGBA{})
+
+	p := &ImageProcessor{}
+	for _, method := range []string{"FloydSteinberg", "Atkinson", "Bayer"} {
+		img, err := p.Filter(src, f.Dither(map[string]any{"method": method}))
+		c.Assert(err, qt.IsNil)
+		paletted, ok := img.(*image.Paletted)
+		c.Assert(ok, qt.IsTrue)
+		c.Assert(paletted.Palette, qt.HasLen, 3)
+
+		var black, white int
+		for y := 0; y < 8; y++ {
+			for x := 0; x < 8; x++ {
+				switch color.NRGBAModel.Convert(paletted.At(x, y)).(color.NRGBA) {
+				case color.NRGBA{A: 255}:
+					black++
+				case color.NRGBA{R: 255, G: 255, B: 255, A: 255}:
+					white++
+				}
+			}
+		}
+		c.Assert(black+white, qt.Equals, 63)
+		c.Assert(black > 20 && white > 20, qt.IsTrue, qt.Commentf("%s: %d/%d", method, black, white))
+		c.Assert(paletted.At(0, 0), qt.Equals, color.Color(color.NRGBA{}))
+	}
+
+	c.Assert(func() { f.Dither(map[string]any{"method": "foo"}) }, qt.PanicMatches, ".*invalid dither method.*")
+}
+
+func TestMaskFilter(t *testing.T) {
+	c := qt.New(t)
+
+	f := &Filters{}
+	src := uniformImage(10, 10, color.NRGBA{R: 255, A: 255})
+
+	mask := image.NewGray(image.Rect(0, 0, 2, 1))
+	mask.SetGray(1, 0, color.Gray{Y: 255})
+
+	g := gift.New(f.Mask(testImageSource{key: "mask", img: mask}))
+	dst := image.NewNRGBA(g.Bounds(src.Bounds()))
+	g.Draw(dst, src)
+
+	c.Assert(dst.NRGBAAt(0, 5).A, qt.Equals, uint8(0))
+	c.Assert(dst.NRGBAAt(9, 5), qt.Equals, color.NRGBA{R: 255, A: 255})
+}
+
+func TestAutoOrient(t *testing.T) {
+	c := qt.New(t)
+
+	f := &Filters{}
+	op := UnwrapFilter(f.AutoOrient()).(ImageFilterFromOrientationProvider)
+
+	c.Assert(op.AutoOrient(nil), qt.IsNil)
+	c.Assert(op.AutoOrient(&exif.ExifInfo{Tags: exif.Tags{"Orientation": 1}}), qt.IsNil)
+
+	rotated := op.AutoOrient(&exif.ExifInfo{Tags: exif.Tags{"Orientation": 6}})
+	c.Assert(rotated, qt.Not(qt.IsNil))
+	c.Assert(rotated.Bounds(image.Rect(0, 0, 20, 10)), qt.Equals, image.Rect(0, 0, 10, 20))
+	c.Assert(helpers.HashString(rotated), qt.Not(qt.Equals), helpers.HashString(op.AutoOrient(&exif.ExifInfo{Tags: exif.Tags{"Orientation": 8}})))
+}
+
+type testImageSource struct {
+	key string
+	img image.Image
+}
+
+func (s testImageSource) DecodeImage() (image.Image, error) {
+	return s.img, nil
+}
+
+func (s testImageSource) Key() string {
+	return s.key
+}
+
+func uniformImage(w, h int, c color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
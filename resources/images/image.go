@@ -14,6 +14,7 @@
 package images
 
 import (
+	"bytes"
 	"fmt"
 	"image"
 	"image/color"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/gohugoio/hugo/media"
 	"github.com/gohugoio/hugo/resources/images/exif"
+	"github.com/gohugoio/hugo/resources/images/svg"
 
 	"github.com/disintegration/gift"
 	"golang.org/x/image/bmp"
@@ -58,29 +60,24 @@ type Image struct {
 	Format Format
 	Proc   *ImageProcessor
 	Spec   Spec
+
+	// Metadata to write when encoding to JPEG.
+	Metadata *exif.Metadata
+
 	*imageConfig
 }
 
 func (i *Image) EncodeTo(conf ImageConfig, img image.Image, w io.Writer) error {
 	switch conf.TargetFormat {
 	case JPEG:
-
-		var rgba *image.RGBA
-		quality := conf.Quality
-
-		if nrgba, ok := img.(*image.NRGBA); ok {
-			if nrgba.Opaque() {
-				rgba = &image.RGBA{
-					Pix:    nrgba.Pix,
-					Stride: nrgba.Stride,
-					Rect:   nrgba.Rect,
-				}
+		if i.Metadata != nil {
+			var buf bytes.Buffer
+			if err := encodeJPEG(&buf, img, conf.Quality); err != nil {
+				return err
 			}
+			return i.Metadata.WriteJPEG(w, &buf)
 		}
-		if rgba != nil {
-			return jpeg.Encode(w, rgba, &jpeg.Options{Quality: quality})
-		}
-		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+		return encodeJPEG(w, img, conf.Quality)
 	case PNG:
 		encoder := png.Encoder{CompressionLevel: png.DefaultCompression}
 		return encoder.Encode(w, img)
@@ -107,11 +104,31 @@ func (i *Image) EncodeTo(conf ImageConfig, img image.Image, w io.Writer) error {
 				UseSharpYuv:    true,
 			},
 		)
+	case SVG:
+		return errors.New("SVG is not supported as a target format")
 	default:
 		return errors.New("format not supported")
 	}
 }
 
+func encodeJPEG(w io.Writer, img image.Image, quality int) error {
+	var rgba *image.RGBA
+
+	if nrgba, ok := img.(*image.NRGBA); ok {
+		if nrgba.Opaque() {
+			rgba = &image.RGBA{
+				Pix:    nrgba.Pix,
+				Stride: nrgba.Stride,
+				Rect:   nrgba.Rect,
+			}
+		}
+	}
+	if rgba != nil {
+		return jpeg.Encode(w, rgba, &jpeg.Options{Quality: quality})
+	}
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+}
+
 // Height returns i's height.
 func (i *Image) Height() int {
 	i.initConfig()
@@ -126,6 +143,7 @@ func (i *Image) Width() int {
 
 func (i Image) WithImage(img image.Image) *Image {
 	i.Spec = nil
+	i.Metadata = nil
 	i.imageConfig = &imageConfig{
 		config:       imageConfigFromImage(img),
 		configLoaded: true,
@@ -136,6 +154,7 @@ func (i Image) WithImage(img image.Image) *Image {
 
 func (i Image) WithSpec(s Spec) *Image {
 	i.Spec = s
+	i.Metadata = nil
 	i.imageConfig = &imageConfig{}
 	return &i
 }
@@ -144,7 +163,7 @@ func (i Image) WithSpec(s Spec) *Image {
 func (i *Image) InitConfig(r io.Reader) error {
 	var err error
 	i.configInit.Do(func() {
-		i.config, _, err = image.DecodeConfig(r)
+		i.config, err = i.Proc.DecodeConfig(i.Format, r)
 	})
 	return err
 }
@@ -164,7 +183,7 @@ func (i *Image) initConfig() error {
 		}
 		defer f.Close()
 
-		i.config, _, err = image.DecodeConfig(f)
+		i.config, err = i.Proc.DecodeConfig(i.Format, f)
 	})
 
 	if err != nil {
@@ -186,21 +205,57 @@ func NewImageProcessor(cfg ImagingConfig) (*ImageProcessor, error) {
 		return nil, err
 	}
 
+	s := cfg.Cfg.SVG
+	svgDecoder, err := svg.NewDecoder(
+		svg.WithMaxBytes(s.MaxBytes),
+		svg.WithMaxElements(s.MaxElements),
+		svg.WithMaxDepth(s.MaxDepth),
+		svg.WithMaxPixels(s.MaxPixels),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ImageProcessor{
 		Cfg:         cfg,
 		exifDecoder: exifDecoder,
+		svgDecoder:  svgDecoder,
 	}, nil
 }
 
 type ImageProcessor struct {
 	Cfg         ImagingConfig
 	exifDecoder *exif.Decoder
+	svgDecoder  *svg.Decoder
 }
 
 func (p *ImageProcessor) DecodeExif(r io.Reader) (*exif.ExifInfo, error) {
 	return p.exifDecoder.Decode(r)
 }
 
+// DecodeMetadata decodes the IPTC and XMP metadata from the JPEG image in r.
+func (p *ImageProcessor) DecodeMetadata(r io.Reader) (*exif.IPTCInfo, *exif.XMPInfo, error) {
+	return p.exifDecoder.DecodeMetadata(r)
+}
+
+// DecodeConfig returns the dimensions of the image in r, which must be of format f.
+// For SVG this is the intrinsic size given by the root element's
+// width, height and viewBox attributes.
+func (p *ImageProcessor) DecodeConfig(f Format, r io.Reader) (image.Config, error) {
+	if f == SVG {
+		return p.svgDecoder.DecodeConfig(r)
+	}
+	config, _, err := image.DecodeConfig(r)
+	return config, err
+}
+
+// DecodeSVG rasterizes the SVG document in r to the given size.
+// If both width and height are 0, the intrinsic size is used; if only one of them
+// is 0, it is derived from the intrinsic aspect ratio.
+func (p *ImageProcessor) DecodeSVG(r io.Reader, width, height int) (image.Image, error) {
+	return p.svgDecoder.Decode(r, width, height)
+}
+
 func (p *ImageProcessor) ApplyFiltersFromConfig(src image.Image, conf ImageConfig) (image.Image, error) {
 	var filters []gift.Filter
 
@@ -292,14 +347,24 @@ func (p *ImageProcessor) Filter(src image.Image, filters ...gift.Filter) (image.
 	}
 	filter.Draw(dst, src)
 
+	if len(filters) > 0 {
+		if pp, ok := UnwrapFilter(filters[len(filters)-1]).(paletteProvider); ok {
+			// Store the result with its reduced set of colors.
+			paletted := image.NewPaletted(bounds, pp.Palette())
+			draw.Draw(paletted, bounds, dst, bounds.Min, draw.Src)
+			return paletted, nil
+		}
+	}
+
 	return dst, nil
 }
 
 func GetDefaultImageConfig(action string, defaults ImagingConfig) ImageConfig {
 	return ImageConfig{
-		Action:  action,
-		Hint:    defaults.Hint,
-		Quality: defaults.Cfg.Quality,
+		Action:   action,
+		Hint:     defaults.Hint,
+		Quality:  defaults.Cfg.Quality,
+		Metadata: defaults.MetadataPolicy,
 	}
 }
 
@@ -318,6 +383,7 @@ const (
 	TIFF
 	BMP
 	WEBP
+	SVG
 )
 
 // RequiresDefaultQuality returns if the default quality needs to be applied to
@@ -331,6 +397,21 @@ func (f Format) SupportsTransparency() bool {
 	return f != JPEG
 }
 
+// IsVector reports whether this is a vector format that needs to be
+// rasterized before processing.
+func (f Format) IsVector() bool {
+	return f == SVG
+}
+
+// DefaultTargetFormat returns the format to use for processed images when no
+// target format is given. Vector images are rasterized to PNG.
+func (f Format) DefaultTargetFormat() Format {
+	if f.IsVector() {
+		return PNG
+	}
+	return f
+}
+
 // DefaultExtension returns the default file extension of this format, starting with a dot.
 // For example: .jpg for JPEG
 func (f Format) DefaultExtension() string {
@@ -352,6 +433,8 @@ func (f Format) MediaType() media.Type {
 		return media.BMPType
 	case WEBP:
 		return media.WEBPType
+	case SVG:
+		return media.SVGType
 	default:
 		panic(fmt.Sprintf("%d is not a valid image format", f))
 	}
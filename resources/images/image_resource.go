@@ -48,6 +48,17 @@ type ImageResourceOps interface {
 	// Exif returns an ExifInfo object containing Image metadata.
 	Exif() *exif.ExifInfo
 
+	// IPTC returns an IPTCInfo object containing the IPTC metadata of a JPEG Image.
+	IPTC() *exif.IPTCInfo
+
+	// XMP returns an XMPInfo object containing the XMP metadata of a JPEG Image.
+	XMP() *exif.XMPInfo
+
+	// Colors returns the dominant colors in the Image with their share of the
+	// Image, the most dominant first.
+	//    {{ $bg := (index $image.Colors 0).Hex }}
+	Colors() ([]DominantColor, error)
+
 	// Internal
 	DecodeImage() (image.Image, error)
 }
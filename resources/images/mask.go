@@ -0,0 +1,58 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/disintegration/gift"
+)
+
+var _ gift.Filter = (*maskFilter)(nil)
+
+type maskFilter struct {
+	mask ImageSource
+}
+
+// Draw draws src onto dst with its alpha channel multiplied by the mask,
+// scaled to the size of src. The mask value of a pixel is its luminance
+// multiplied by its alpha, so both grayscale and transparent masks work.
+func (f maskFilter) Draw(dst draw.Image, src image.Image, options *gift.Options) {
+	maskSrc, err := f.mask.DecodeImage()
+	if err != nil {
+		panic(fmt.Sprintf("failed to decode image: %s", err))
+	}
+
+	b := src.Bounds()
+	mask := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	gift.New(gift.Resize(b.Dx(), b.Dy(), gift.LinearResampling)).Draw(mask, maskSrc)
+
+	db := dst.Bounds()
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			c := color.NRGBAModel.Convert(src.At(b.Min.X+x, b.Min.Y+y)).(color.NRGBA)
+			m := mask.NRGBAAt(x, y)
+			lum := (299*uint32(m.R) + 587*uint32(m.G) + 114*uint32(m.B)) / 1000
+			c.A = uint8(uint32(c.A) * lum * uint32(m.A) / (255 * 255))
+			dst.Set(db.Min.X+x, db.Min.Y+y, c)
+		}
+	}
+}
+
+func (f maskFilter) Bounds(srcBounds image.Rectangle) image.Rectangle {
+	return image.Rect(0, 0, srcBounds.Dx(), srcBounds.Dy())
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"image"
+	"image/draw"
+
+	"github.com/disintegration/gift"
+	"github.com/gohugoio/hugo/resources/images/exif"
+	"github.com/spf13/cast"
+)
+
+var (
+	_ gift.Filter                        = (*autoOrientFilter)(nil)
+	_ ImageFilterFromOrientationProvider = (*autoOrientFilter)(nil)
+)
+
+// ImageFilterFromOrientationProvider is implemented by filters that need to
+// be resolved from the Exif orientation of the source image before use.
+type ImageFilterFromOrientationProvider interface {
+	AutoOrient(exifInfo *exif.ExifInfo) gift.Filter
+}
+
+type autoOrientFilter struct{}
+
+// AutoOrient returns the filter(s) needed to display an image with the given
+// Exif data upright, or nil if none is needed.
+func (f autoOrientFilter) AutoOrient(exifInfo *exif.ExifInfo) gift.Filter {
+	if exifInfo == nil {
+		return nil
+	}
+
+	orientation, err := cast.ToIntE(exifInfo.Tags["Orientation"])
+	if err != nil {
+		return nil
+	}
+
+	var g gift.Filter
+	switch orientation {
+	case 2:
+		g = gift.FlipHorizontal()
+	case 3:
+		g = gift.Rotate180()
+	case 4:
+		g = gift.FlipVertical()
+	case 5:
+		g = gift.Transpose()
+	case 6:
+		g = gift.Rotate270()
+	case 7:
+		g = gift.Transverse()
+	case 8:
+		g = gift.Rotate90()
+	default:
+		return nil
+	}
+
+	return filter{
+		Options: newFilterOpts("autoorient", orientation),
+		Filter:  g,
+	}
+}
+
+// Draw draws src unchanged. The filter is replaced with the filter returned
+// by AutoOrient when applied to an image resource.
+func (f autoOrientFilter) Draw(dst draw.Image, src image.Image, options *gift.Options) {
+	gift.New().Draw(dst, src)
+}
+
+func (f autoOrientFilter) Bounds(srcBounds image.Rectangle) image.Rectangle {
+	return image.Rect(0, 0, srcBounds.Dx(), srcBounds.Dy())
+}
@@ -0,0 +1,227 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+)
+
+const (
+	// DefaultDominantColors is the number of clusters used when extracting
+	// the dominant colors of an image.
+	DefaultDominantColors = 6
+
+	// Max number of pixels sampled, the image is sampled evenly.
+	dominantColorsMaxSamples = 10000
+
+	// Max number of k-means iterations.
+	dominantColorsMaxIterations = 20
+)
+
+// DominantColor is a color found in an image and how much of the image it covers.
+type DominantColor struct {
+	// The color as a hex string, e.g. "#1a2b3c".
+	Hex string
+
+	// The share of the (opaque) pixels in the image closest to this color,
+	// from 0 to 1.
+	Ratio float64
+
+	// The relative luminance of the color as defined in WCAG 2, from 0 to 1.
+	Luminance float64
+}
+
+func (c DominantColor) String() string {
+	return c.Hex
+}
+
+type rgbPoint [3]float64
+
+func (p rgbPoint) dist(q rgbPoint) float64 {
+	d0, d1, d2 := p[0]-q[0], p[1]-q[1], p[2]-q[2]
+	return d0*d0 + d1*d1 + d2*d2
+}
+
+// DominantColors returns up to k dominant colors in img using k-means
+// clustering, sorted by ratio with the most dominant first.
+// Transparent pixels are ignored.
+func DominantColors(img image.Image, k int) []DominantColor {
+	if k <= 0 {
+		k = DefaultDominantColors
+	}
+
+	samples := sampleColors(img)
+	if len(samples) == 0 {
+		return nil
+	}
+
+	centers := initCenters(samples, k)
+	assignments := make([]int, len(samples))
+	counts := make([]int, len(centers))
+
+	for iter := 0; iter < dominantColorsMaxIterations; iter++ {
+		changed := false
+		for i, s := range samples {
+			nearest := nearestCenter(centers, s)
+			if iter == 0 || assignments[i] != nearest {
+				assignments[i] = nearest
+				changed = true
+			}
+		}
+
+		sums := make([]rgbPoint, len(centers))
+		for i := range counts {
+			counts[i] = 0
+		}
+		for i, s := range samples {
+			c := assignments[i]
+			counts[c]++
+			sums[c][0] += s[0]
+			sums[c][1] += s[1]
+			sums[c][2] += s[2]
+		}
+		for i := range centers {
+			if counts[i] == 0 {
+				continue
+			}
+			n := float64(counts[i])
+			centers[i] = rgbPoint{sums[i][0] / n, sums[i][1] / n, sums[i][2] / n}
+		}
+
+		if !changed {
+			break
+		}
+	}
+
+	// Clusters may end up with the same color after rounding.
+	merged := make(map[string]int)
+	for i, c := range centers {
+		if counts[i] == 0 {
+			continue
+		}
+		nc := color.NRGBA{R: roundColorValue(c[0]), G: roundColorValue(c[1]), B: roundColorValue(c[2]), A: 0xff}
+		merged[ColorToHexString(nc)] += counts[i]
+	}
+
+	colors := make([]DominantColor, 0, len(merged))
+	for hex, count := range merged {
+		c, _ := hexStringToColor(hex)
+		colors = append(colors, DominantColor{
+			Hex:       hex,
+			Ratio:     float64(count) / float64(len(samples)),
+			Luminance: RelativeLuminance(c),
+		})
+	}
+
+	sort.Slice(colors, func(i, j int) bool {
+		if colors[i].Ratio != colors[j].Ratio {
+			return colors[i].Ratio > colors[j].Ratio
+		}
+		return colors[i].Hex < colors[j].Hex
+	})
+
+	return colors
+}
+
+func sampleColors(img image.Image) []rgbPoint {
+	b := img.Bounds()
+	step := 1
+	if n := b.Dx() * b.Dy(); n > dominantColorsMaxSamples {
+		step = int(math.Ceil(math.Sqrt(float64(n) / dominantColorsMaxSamples)))
+	}
+
+	var samples []rgbPoint
+	for y := b.Min.Y; y < b.Max.Y; y += step {
+		for x := b.Min.X; x < b.Max.X; x += step {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A < 0x80 {
+				continue
+			}
+			samples = append(samples, rgbPoint{float64(c.R), float64(c.G), float64(c.B)})
+		}
+	}
+
+	return samples
+}
+
+// initCenters picks the initial cluster centers using the maximin method:
+// start with the mean color and repeatedly add the sample farthest from the
+// existing centers. This is deterministic, which we need for stable builds.
+func initCenters(samples []rgbPoint, k int) []rgbPoint {
+	var mean rgbPoint
+	for _, s := range samples {
+		mean[0] += s[0]
+		mean[1] += s[1]
+		mean[2] += s[2]
+	}
+	n := float64(len(samples))
+	mean = rgbPoint{mean[0] / n, mean[1] / n, mean[2] / n}
+
+	// Start with the sample closest to the mean.
+	centers := []rgbPoint{samples[nearestSample(samples, mean)]}
+
+	dists := make([]float64, len(samples))
+	for i, s := range samples {
+		dists[i] = s.dist(centers[0])
+	}
+
+	for len(centers) < k {
+		farthest, maxDist := -1, 0.0
+		for i, d := range dists {
+			if d > maxDist {
+				farthest, maxDist = i, d
+			}
+		}
+		if farthest == -1 {
+			// Fewer distinct colors than k.
+			break
+		}
+		c := samples[farthest]
+		centers = append(centers, c)
+		for i, s := range samples {
+			if d := s.dist(c); d < dists[i] {
+				dists[i] = d
+			}
+		}
+	}
+
+	return centers
+}
+
+func nearestSample(samples []rgbPoint, p rgbPoint) int {
+	nearest, minDist := 0, math.MaxFloat64
+	for i, s := range samples {
+		if d := s.dist(p); d < minDist {
+			nearest, minDist = i, d
+		}
+	}
+	return nearest
+}
+
+func nearestCenter(centers []rgbPoint, p rgbPoint) int {
+	nearest, minDist := 0, math.MaxFloat64
+	for i, c := range centers {
+		if d := p.dist(c); d < minDist {
+			nearest, minDist = i, d
+		}
+	}
+	return nearest
+}
+
+func roundColorValue(v float64) uint8 {
+	return uint8(math.Max(0, math.Min(255, math.Round(v))))
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestDominantColors(t *testing.T) {
+	c := qt.New(t)
+
+	fill := func(img draw.Image, r image.Rectangle, c color.Color) {
+		draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+	}
+
+	blue := color.NRGBA{0x1a, 0x2b, 0x3c, 0xff}
+	yellow := color.NRGBA{0xff, 0xcc, 0x00, 0xff}
+
+	img := image.NewNRGBA(image.Rect(0, 0, 200, 200))
+	fill(img, img.Bounds(), blue)
+	fill(img, image.Rect(0, 150, 200, 200), yellow)
+	// Transparent pixels are ignored.
+	fill(img, image.Rect(0, 0, 200, 100), color.Transparent)
+
+	colors := DominantColors(img, 4)
+	c.Assert(colors, qt.HasLen, 2)
+	c.Assert(colors[0].Hex, qt.Equals, "#1a2b3c")
+	c.Assert(colors[0].Ratio, qt.Equals, 0.5)
+	c.Assert(colors[0].String(), qt.Equals, "#1a2b3c")
+	c.Assert(colors[1].Hex, qt.Equals, "#ffcc00")
+	c.Assert(colors[1].Ratio, qt.Equals, 0.5)
+	c.Assert(colors[1].Luminance > colors[0].Luminance, qt.IsTrue)
+
+	// Large images are sampled.
+	large := image.NewNRGBA(image.Rect(0, 0, 1000, 1000))
+	fill(large, large.Bounds(), blue)
+	fill(large, image.Rect(0, 0, 1000, 250), yellow)
+	colors = DominantColors(large, 0)
+	c.Assert(colors, qt.HasLen, 2)
+	c.Assert(colors[0].Hex, qt.Equals, "#1a2b3c")
+	c.Assert(colors[0].Ratio > 0.7 && colors[0].Ratio < 0.8, qt.IsTrue)
+
+	// Deterministic.
+	c.Assert(DominantColors(large, 0), qt.DeepEquals, colors)
+
+	c.Assert(DominantColors(image.NewNRGBA(image.Rect(0, 0, 10, 10)), 3), qt.IsNil)
+}
+
+func TestDominantColorsGradient(t *testing.T) {
+	c := qt.New(t)
+
+	img := image.NewGray(image.Rect(0, 0, 256, 10))
+	for x := 0; x < 256; x++ {
+		for y := 0; y < 10; y++ {
+			img.SetGray(x, y, color.Gray{Y: uint8(x)})
+		}
+	}
+
+	colors := DominantColors(img, 4)
+	c.Assert(colors, qt.HasLen, 4)
+	var sum float64
+	for _, cc := range colors {
+		sum += cc.Ratio
+	}
+	c.Assert(sum > 0.999 && sum < 1.001, qt.IsTrue)
+}
@@ -0,0 +1,387 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package svg
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/colornames"
+)
+
+// paint is the value of a fill or stroke property.
+type paint struct {
+	none     bool
+	color    color.NRGBA
+	gradient *gradient
+}
+
+var paintNone = paint{none: true}
+
+// parsePaint parses a paint specification, e.g. "#fff", "none",
+// "currentColor" or "url(#grad) red".
+func (r *renderer) parsePaint(s string, current color.NRGBA) (paint, error) {
+	s = strings.TrimSpace(s)
+	switch s {
+	case "", "none":
+		return paintNone, nil
+	case "currentColor":
+		return paint{color: current}, nil
+	}
+
+	if strings.HasPrefix(s, "url(") {
+		end := strings.IndexByte(s, ')')
+		if end == -1 {
+			return paintNone, fmt.Errorf("invalid paint %q", s)
+		}
+		id := strings.TrimPrefix(strings.Trim(s[4:end], `'" `), "#")
+		if g := r.gradient(id, 0); g != nil {
+			return paint{gradient: g}, nil
+		}
+		// Fall back to the color given after the URL, if any.
+		return r.parsePaint(s[end+1:], current)
+	}
+
+	c, err := parseColor(s)
+	if err != nil {
+		return paintNone, err
+	}
+	return paint{color: c}, nil
+}
+
+// parseColor parses a CSS color value.
+func parseColor(s string) (color.NRGBA, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if strings.HasPrefix(s, "#") {
+		return parseHexColor(s[1:])
+	}
+
+	if strings.HasPrefix(s, "rgb(") || strings.HasPrefix(s, "rgba(") {
+		open, closing := strings.IndexByte(s, '('), strings.LastIndexByte(s, ')')
+		if closing < open {
+			return color.NRGBA{}, fmt.Errorf("invalid color %q", s)
+		}
+		parts := strings.FieldsFunc(s[open+1:closing], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '/'
+		})
+		if len(parts) != 3 && len(parts) != 4 {
+			return color.NRGBA{}, fmt.Errorf("invalid color %q", s)
+		}
+		var c [4]uint8
+		c[3] = 255
+		for i, part := range parts {
+			var (
+				v   float64
+				err error
+			)
+			if strings.HasSuffix(part, "%") {
+				v, err = strconv.ParseFloat(strings.TrimSuffix(part, "%"), 64)
+				v = v / 100
+				if i < 3 {
+					v *= 255
+				}
+			} else {
+				v, err = strconv.ParseFloat(part, 64)
+			}
+			if err != nil {
+				return color.NRGBA{}, fmt.Errorf("invalid color %q", s)
+			}
+			if i == 3 && !strings.HasSuffix(part, "%") {
+				v *= 255
+			}
+			c[i] = clampUint8(v)
+		}
+		return color.NRGBA{R: c[0], G: c[1], B: c[2], A: c[3]}, nil
+	}
+
+	if s == "transparent" {
+		return color.NRGBA{}, nil
+	}
+
+	if c, found := colornames.Map[s]; found {
+		return color.NRGBA{R: c.R, G: c.G, B: c.B, A: c.A}, nil
+	}
+
+	return color.NRGBA{}, fmt.Errorf("invalid color %q", s)
+}
+
+func parseHexColor(s string) (color.NRGBA, error) {
+	switch len(s) {
+	case 3, 4:
+		var b strings.Builder
+		for _, r := range s {
+			b.WriteRune(r)
+			b.WriteRune(r)
+		}
+		s = b.String()
+	case 6, 8:
+	default:
+		return color.NRGBA{}, fmt.Errorf("invalid hex color %q", s)
+	}
+	if len(s) == 6 {
+		s += "ff"
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, fmt.Errorf("invalid hex color %q", s)
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+func clampUint8(v float64) uint8 {
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(math.Round(v))
+}
+
+type gradientStop struct {
+	offset float64
+	color  color.NRGBA
+}
+
+type gradient struct {
+	radial    bool
+	userSpace bool
+	spread    string
+	transform matrix
+
+	// Linear.
+	x1, y1, x2, y2 float64
+
+	// Radial.
+	cx, cy, r, fx, fy float64
+
+	stops []gradientStop
+}
+
+// maxGradientRefs limits the length of href chains between gradients.
+const maxGradientRefs = 10
+
+// gradient resolves the gradient with the given id, or nil if not found.
+func (r *renderer) gradient(id string, depth int) *gradient {
+	if depth > maxGradientRefs {
+		return nil
+	}
+	n, found := r.doc.ids[id]
+	if !found || (n.name != "linearGradient" && n.name != "radialGradient") {
+		return nil
+	}
+
+	g := &gradient{
+		radial:    n.name == "radialGradient",
+		spread:    "pad",
+		transform: identity,
+		x2:        1,
+		cx:        0.5,
+		cy:        0.5,
+		r:         0.5,
+	}
+
+	// Attributes and stops may be inherited from a referenced gradient.
+	if ref := n.href(); ref != "" {
+		if parent := r.gradient(ref, depth+1); parent != nil && parent.radial == g.radial {
+			*g = *parent
+		}
+	}
+
+	if v, found := n.attrs["gradientUnits"]; found {
+		g.userSpace = v == "userSpaceOnUse"
+	}
+	if v, found := n.attrs["spreadMethod"]; found {
+		g.spread = v
+	}
+	if v, found := n.attrs["gradientTransform"]; found {
+		if m, err := parseTransform(v); err == nil {
+			g.transform = m
+		}
+	}
+
+	coord := func(name string, dst *float64, ref float64) {
+		if v, found := n.attrs[name]; found {
+			if g.userSpace {
+				*dst = parseLengthOr(v, ref, *dst)
+			} else {
+				*dst = parseLengthOr(v, 1, *dst)
+			}
+		}
+	}
+
+	w, h := r.viewport()
+	if g.radial {
+		_, hasFx := n.attrs["fx"]
+		_, hasFy := n.attrs["fy"]
+		coord("cx", &g.cx, w)
+		coord("cy", &g.cy, h)
+		coord("r", &g.r, math.Hypot(w, h)/math.Sqrt2)
+		g.fx, g.fy = g.cx, g.cy
+		if hasFx {
+			coord("fx", &g.fx, w)
+		}
+		if hasFy {
+			coord("fy", &g.fy, h)
+		}
+	} else {
+		coord("x1", &g.x1, w)
+		coord("y1", &g.y1, h)
+		coord("x2", &g.x2, w)
+		coord("y2", &g.y2, h)
+	}
+
+	var stops []gradientStop
+	for _, c := range n.children {
+		if c.name != "stop" {
+			continue
+		}
+		st := c.style()
+		var s gradientStop
+		s.offset = math.Max(0, math.Min(1, parseLengthOr(st["offset"], 1, 0)))
+		col, err := parseColor(st.get("stop-color", "black"))
+		if err != nil {
+			col = color.NRGBA{A: 255}
+		}
+		if op, err := strconv.ParseFloat(st.get("stop-opacity", "1"), 64); err == nil {
+			col.A = clampUint8(float64(col.A) * op)
+		}
+		s.color = col
+		if len(stops) > 0 && s.offset < stops[len(stops)-1].offset {
+			s.offset = stops[len(stops)-1].offset
+		}
+		stops = append(stops, s)
+	}
+	if len(stops) > 0 {
+		g.stops = stops
+	}
+	if len(g.stops) == 0 {
+		return nil
+	}
+
+	return g
+}
+
+// at returns the color at t, where t is 0 at the first and 1 at the last stop.
+func (g *gradient) at(t float64) color.NRGBA {
+	switch g.spread {
+	case "repeat":
+		t = t - math.Floor(t)
+	case "reflect":
+		t = math.Mod(math.Abs(t), 2)
+		if t > 1 {
+			t = 2 - t
+		}
+	}
+	stops := g.stops
+	if t <= stops[0].offset {
+		return stops[0].color
+	}
+	if t >= stops[len(stops)-1].offset {
+		return stops[len(stops)-1].color
+	}
+	i := sort.Search(len(stops), func(i int) bool { return stops[i].offset >= t })
+	s0, s1 := stops[i-1], stops[i]
+	f := (t - s0.offset) / (s1.offset - s0.offset)
+	lerp := func(a, b uint8) uint8 {
+		return clampUint8(float64(a) + (float64(b)-float64(a))*f)
+	}
+	return color.NRGBA{
+		R: lerp(s0.color.R, s1.color.R),
+		G: lerp(s0.color.G, s1.color.G),
+		B: lerp(s0.color.B, s1.color.B),
+		A: lerp(s0.color.A, s1.color.A),
+	}
+}
+
+// gradientImage is an image.Image painting a gradient in device space.
+type gradientImage struct {
+	g       *gradient
+	inv     matrix // Device space to gradient space.
+	opacity float64
+}
+
+func (g *gradient) image(ctm matrix, bboxf [4]float64, opacity float64) image.Image {
+	m := ctm
+	if !g.userSpace {
+		// Map the unit square to the bounding box.
+		m = m.mul(matrix{bboxf[2] - bboxf[0], 0, 0, bboxf[3] - bboxf[1], bboxf[0], bboxf[1]})
+	}
+	m = m.mul(g.transform)
+	inv, ok := m.invert()
+	if !ok {
+		return image.NewUniform(g.stops[0].color)
+	}
+	return &gradientImage{g: g, inv: inv, opacity: opacity}
+}
+
+func (gi *gradientImage) ColorModel() color.Model { return color.NRGBAModel }
+
+func (gi *gradientImage) Bounds() image.Rectangle {
+	return image.Rect(-1e9, -1e9, 1e9, 1e9)
+}
+
+func (gi *gradientImage) At(x, y int) color.Color {
+	p := gi.inv.apply(point{float64(x) + 0.5, float64(y) + 0.5})
+	g := gi.g
+	var t float64
+	if g.radial {
+		if g.r <= 0 {
+			t = 1
+		} else {
+			// Simplified focal handling: measure from the focal point
+			// towards the circle edge.
+			f := point{g.fx, g.fy}
+			c := point{g.cx, g.cy}
+			d := p.sub(f)
+			if d.len() == 0 {
+				t = 0
+			} else {
+				// Solve |f + s*d - c| = r for s > 0.
+				fc := f.sub(c)
+				a := d.X*d.X + d.Y*d.Y
+				b := 2 * (d.X*fc.X + d.Y*fc.Y)
+				cc := fc.X*fc.X + fc.Y*fc.Y - g.r*g.r
+				disc := b*b - 4*a*cc
+				if disc < 0 {
+					t = 1
+				} else {
+					s := (-b + math.Sqrt(disc)) / (2 * a)
+					if s <= 0 {
+						t = 1
+					} else {
+						t = 1 / s
+					}
+				}
+			}
+		}
+	} else {
+		d := point{g.x2 - g.x1, g.y2 - g.y1}
+		l := d.X*d.X + d.Y*d.Y
+		if l == 0 {
+			t = 1
+		} else {
+			t = ((p.X-g.x1)*d.X + (p.Y-g.y1)*d.Y) / l
+		}
+	}
+	c := g.at(t)
+	c.A = clampUint8(float64(c.A) * gi.opacity)
+	return c
+}
@@ -0,0 +1,501 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package svg
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+type point struct {
+	X, Y float64
+}
+
+func (p point) add(q point) point     { return point{p.X + q.X, p.Y + q.Y} }
+func (p point) sub(q point) point     { return point{p.X - q.X, p.Y - q.Y} }
+func (p point) mul(f float64) point   { return point{p.X * f, p.Y * f} }
+func (p point) len() float64          { return math.Hypot(p.X, p.Y) }
+func (p point) cross(q point) float64 { return p.X*q.Y - p.Y*q.X }
+func (p point) lerp(q point, t float64) point {
+	return point{p.X + (q.X-p.X)*t, p.Y + (q.Y-p.Y)*t}
+}
+
+type opKind int
+
+const (
+	opMove opKind = iota
+	opLine
+	opCubic
+	opClose
+)
+
+type pathOp struct {
+	kind opKind
+	pts  [3]point
+}
+
+// path is a sequence of absolute path operations in user space.
+type path []pathOp
+
+func (p *path) moveTo(pt point) { *p = append(*p, pathOp{kind: opMove, pts: [3]point{pt}}) }
+func (p *path) lineTo(pt point) { *p = append(*p, pathOp{kind: opLine, pts: [3]point{pt}}) }
+func (p *path) close()          { *p = append(*p, pathOp{kind: opClose}) }
+func (p *path) cubicTo(c1, c2, pt point) {
+	*p = append(*p, pathOp{kind: opCubic, pts: [3]point{c1, c2, pt}})
+}
+
+// subpath is a flattened polyline in device space.
+type subpath struct {
+	pts    []point
+	closed bool
+}
+
+// flatten transforms p by m and approximates all curves with line segments.
+func (p path) flatten(m matrix) []subpath {
+	var (
+		subpaths []subpath
+		cur      *subpath
+		last     point
+		start    point
+	)
+
+	begin := func(pt point) {
+		subpaths = append(subpaths, subpath{pts: []point{pt}})
+		cur = &subpaths[len(subpaths)-1]
+	}
+
+	for _, op := range p {
+		switch op.kind {
+		case opMove:
+			last = m.apply(op.pts[0])
+			start = last
+			begin(last)
+		case opLine:
+			if cur == nil {
+				begin(last)
+			}
+			last = m.apply(op.pts[0])
+			cur.pts = append(cur.pts, last)
+		case opCubic:
+			if cur == nil {
+				begin(last)
+			}
+			c1, c2, end := m.apply(op.pts[0]), m.apply(op.pts[1]), m.apply(op.pts[2])
+			// Estimate the number of segments from the length of the control polygon.
+			l := last.sub(c1).len() + c1.sub(c2).len() + c2.sub(end).len()
+			n := int(math.Ceil(math.Sqrt(l) * 1.5))
+			if n < 2 {
+				n = 2
+			} else if n > 200 {
+				n = 200
+			}
+			for i := 1; i <= n; i++ {
+				cur.pts = append(cur.pts, cubicPoint(last, c1, c2, end, float64(i)/float64(n)))
+			}
+			last = end
+		case opClose:
+			if cur != nil {
+				cur.closed = true
+				cur = nil
+			}
+			last = start
+		}
+	}
+
+	return subpaths
+}
+
+func cubicPoint(p0, p1, p2, p3 point, t float64) point {
+	mt := 1 - t
+	a := mt * mt * mt
+	b := 3 * mt * mt * t
+	c := 3 * mt * t * t
+	d := t * t * t
+	return point{
+		a*p0.X + b*p1.X + c*p2.X + d*p3.X,
+		a*p0.Y + b*p1.Y + c*p2.Y + d*p3.Y,
+	}
+}
+
+// parsePath parses SVG path data, see
+// https://www.w3.org/TR/SVG11/paths.html#PathData
+func parsePath(d string) (path, error) {
+	var (
+		p       path
+		s       = &numberScanner{s: d}
+		cmd     byte
+		cur     point
+		start   point
+		lastCtl point // Last control point, used for S and T.
+		lastCmd byte
+	)
+
+	for {
+		s.skipSpace()
+		if s.done() {
+			break
+		}
+		if c := s.s[s.i]; isCommand(c) {
+			cmd = c
+			s.i++
+		} else if cmd == 0 {
+			return nil, fmt.Errorf("path data must start with a command: %q", d)
+		}
+
+		rel := cmd >= 'a'
+		offset := func(pt point) point {
+			if rel {
+				return pt.add(cur)
+			}
+			return pt
+		}
+
+		switch cmd {
+		case 'M', 'm':
+			pt, err := s.point()
+			if err != nil {
+				return nil, err
+			}
+			cur = offset(pt)
+			start = cur
+			p.moveTo(cur)
+			// Subsequent pairs are implicit lineto commands.
+			if rel {
+				cmd = 'l'
+			} else {
+				cmd = 'L'
+			}
+		case 'L', 'l':
+			pt, err := s.point()
+			if err != nil {
+				return nil, err
+			}
+			cur = offset(pt)
+			p.lineTo(cur)
+		case 'H', 'h':
+			x, err := s.number()
+			if err != nil {
+				return nil, err
+			}
+			if rel {
+				x += cur.X
+			}
+			cur = point{x, cur.Y}
+			p.lineTo(cur)
+		case 'V', 'v':
+			y, err := s.number()
+			if err != nil {
+				return nil, err
+			}
+			if rel {
+				y += cur.Y
+			}
+			cur = point{cur.X, y}
+			p.lineTo(cur)
+		case 'C', 'c':
+			pts, err := s.points(3)
+			if err != nil {
+				return nil, err
+			}
+			c1, c2, end := offset(pts[0]), offset(pts[1]), offset(pts[2])
+			p.cubicTo(c1, c2, end)
+			lastCtl, cur = c2, end
+		case 'S', 's':
+			pts, err := s.points(2)
+			if err != nil {
+				return nil, err
+			}
+			c1 := cur
+			if lastCmd == 'C' || lastCmd == 'c' || lastCmd == 'S' || lastCmd == 's' {
+				c1 = cur.mul(2).sub(lastCtl)
+			}
+			c2, end := offset(pts[0]), offset(pts[1])
+			p.cubicTo(c1, c2, end)
+			lastCtl, cur = c2, end
+		case 'Q', 'q':
+			pts, err := s.points(2)
+			if err != nil {
+				return nil, err
+			}
+			ctl, end := offset(pts[0]), offset(pts[1])
+			p.quadTo(cur, ctl, end)
+			lastCtl, cur = ctl, end
+		case 'T', 't':
+			pt, err := s.point()
+			if err != nil {
+				return nil, err
+			}
+			ctl := cur
+			if lastCmd == 'Q' || lastCmd == 'q' || lastCmd == 'T' || lastCmd == 't' {
+				ctl = cur.mul(2).sub(lastCtl)
+			}
+			end := offset(pt)
+			p.quadTo(cur, ctl, end)
+			lastCtl, cur = ctl, end
+		case 'A', 'a':
+			rx, err := s.number()
+			if err != nil {
+				return nil, err
+			}
+			ry, err := s.number()
+			if err != nil {
+				return nil, err
+			}
+			rot, err := s.number()
+			if err != nil {
+				return nil, err
+			}
+			large, err := s.flag()
+			if err != nil {
+				return nil, err
+			}
+			sweep, err := s.flag()
+			if err != nil {
+				return nil, err
+			}
+			pt, err := s.point()
+			if err != nil {
+				return nil, err
+			}
+			end := offset(pt)
+			p.arcTo(cur, rx, ry, rot, large, sweep, end)
+			cur = end
+		case 'Z', 'z':
+			p.close()
+			cur = start
+		default:
+			return nil, fmt.Errorf("unsupported path command %q", cmd)
+		}
+		lastCmd = cmd
+	}
+
+	return p, nil
+}
+
+func isCommand(c byte) bool {
+	switch c {
+	case 'M', 'm', 'L', 'l', 'H', 'h', 'V', 'v', 'C', 'c', 'S', 's', 'Q', 'q', 'T', 't', 'A', 'a', 'Z', 'z':
+		return true
+	}
+	return false
+}
+
+func (p *path) quadTo(from, ctl, end point) {
+	// Elevate to a cubic.
+	c1 := from.add(ctl.sub(from).mul(2.0 / 3))
+	c2 := end.add(ctl.sub(end).mul(2.0 / 3))
+	p.cubicTo(c1, c2, end)
+}
+
+// arcTo appends an elliptical arc approximated by cubic curves, see
+// https://www.w3.org/TR/SVG11/implnote.html#ArcImplementationNotes
+func (p *path) arcTo(from point, rx, ry, rotDeg float64, large, sweep bool, to point) {
+	if from == to {
+		return
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		p.lineTo(to)
+		return
+	}
+
+	sinPhi, cosPhi := math.Sincos(rotDeg * math.Pi / 180)
+	dx, dy := (from.X-to.X)/2, (from.Y-to.Y)/2
+	x1p := cosPhi*dx + sinPhi*dy
+	y1p := -sinPhi*dx + cosPhi*dy
+
+	// Scale up the radii if needed.
+	lambda := (x1p*x1p)/(rx*rx) + (y1p*y1p)/(ry*ry)
+	if lambda > 1 {
+		sq := math.Sqrt(lambda)
+		rx, ry = rx*sq, ry*sq
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1p*y1p - ry*ry*x1p*x1p
+	den := rx*rx*y1p*y1p + ry*ry*x1p*x1p
+	coef := 0.0
+	if den != 0 && num > 0 {
+		coef = math.Sqrt(num / den)
+	}
+	if large == sweep {
+		coef = -coef
+	}
+	cxp := coef * rx * y1p / ry
+	cyp := -coef * ry * x1p / rx
+
+	cx := cosPhi*cxp - sinPhi*cyp + (from.X+to.X)/2
+	cy := sinPhi*cxp + cosPhi*cyp + (from.Y+to.Y)/2
+
+	theta1 := vectorAngle(1, 0, (x1p-cxp)/rx, (y1p-cyp)/ry)
+	dtheta := vectorAngle((x1p-cxp)/rx, (y1p-cyp)/ry, (-x1p-cxp)/rx, (-y1p-cyp)/ry)
+	if !sweep && dtheta > 0 {
+		dtheta -= 2 * math.Pi
+	} else if sweep && dtheta < 0 {
+		dtheta += 2 * math.Pi
+	}
+
+	// Split into segments of at most 90 degrees.
+	n := int(math.Ceil(math.Abs(dtheta) / (math.Pi / 2)))
+	if n < 1 {
+		n = 1
+	}
+	delta := dtheta / float64(n)
+	k := 4.0 / 3 * math.Tan(delta/4)
+
+	ellipse := func(theta float64) (point, point) {
+		sinT, cosT := math.Sincos(theta)
+		pt := point{
+			cx + rx*cosT*cosPhi - ry*sinT*sinPhi,
+			cy + rx*cosT*sinPhi + ry*sinT*cosPhi,
+		}
+		deriv := point{
+			-rx*sinT*cosPhi - ry*cosT*sinPhi,
+			-rx*sinT*sinPhi + ry*cosT*cosPhi,
+		}
+		return pt, deriv
+	}
+
+	theta := theta1
+	p0, d0 := ellipse(theta)
+	for i := 0; i < n; i++ {
+		theta += delta
+		p1, d1 := ellipse(theta)
+		if i == n-1 {
+			p1 = to
+		}
+		p.cubicTo(p0.add(d0.mul(k)), p1.sub(d1.mul(k)), p1)
+		p0, d0 = p1, d1
+	}
+}
+
+func vectorAngle(ux, uy, vx, vy float64) float64 {
+	return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+}
+
+// numberScanner scans numbers according to the SVG number grammar, where
+// e.g. "1.5.5" is two numbers and "10-5" is also two numbers.
+type numberScanner struct {
+	s string
+	i int
+}
+
+func (s *numberScanner) done() bool {
+	return s.i >= len(s.s)
+}
+
+func (s *numberScanner) skipSpace() {
+	for s.i < len(s.s) {
+		switch s.s[s.i] {
+		case ' ', '\t', '\r', '\n', ',':
+			s.i++
+		default:
+			return
+		}
+	}
+}
+
+func (s *numberScanner) number() (float64, error) {
+	s.skipSpace()
+	start := s.i
+	if s.i < len(s.s) && (s.s[s.i] == '+' || s.s[s.i] == '-') {
+		s.i++
+	}
+	var sawDigit, sawDot bool
+	for s.i < len(s.s) {
+		c := s.s[s.i]
+		if c >= '0' && c <= '9' {
+			sawDigit = true
+			s.i++
+		} else if c == '.' && !sawDot {
+			sawDot = true
+			s.i++
+		} else {
+			break
+		}
+	}
+	if sawDigit && s.i < len(s.s) && (s.s[s.i] == 'e' || s.s[s.i] == 'E') {
+		j := s.i + 1
+		if j < len(s.s) && (s.s[j] == '+' || s.s[j] == '-') {
+			j++
+		}
+		if j < len(s.s) && s.s[j] >= '0' && s.s[j] <= '9' {
+			for j < len(s.s) && s.s[j] >= '0' && s.s[j] <= '9' {
+				j++
+			}
+			s.i = j
+		}
+	}
+	if !sawDigit {
+		return 0, fmt.Errorf("expected number at offset %d in %q", start, s.s)
+	}
+	return strconv.ParseFloat(s.s[start:s.i], 64)
+}
+
+func (s *numberScanner) flag() (bool, error) {
+	s.skipSpace()
+	if s.i < len(s.s) {
+		switch s.s[s.i] {
+		case '0':
+			s.i++
+			return false, nil
+		case '1':
+			s.i++
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("expected flag at offset %d in %q", s.i, s.s)
+}
+
+func (s *numberScanner) point() (point, error) {
+	x, err := s.number()
+	if err != nil {
+		return point{}, err
+	}
+	y, err := s.number()
+	if err != nil {
+		return point{}, err
+	}
+	return point{x, y}, nil
+}
+
+func (s *numberScanner) points(n int) ([]point, error) {
+	pts := make([]point, n)
+	for i := range pts {
+		pt, err := s.point()
+		if err != nil {
+			return nil, err
+		}
+		pts[i] = pt
+	}
+	return pts, nil
+}
+
+func parseNumberList(v string) ([]float64, error) {
+	var (
+		s    = &numberScanner{s: v}
+		nums []float64
+	)
+	for {
+		s.skipSpace()
+		if s.done() {
+			return nums, nil
+		}
+		f, err := s.number()
+		if err != nil {
+			return nil, err
+		}
+		nums = append(nums, f)
+	}
+}
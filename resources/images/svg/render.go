@@ -0,0 +1,632 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package svg
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/vector"
+)
+
+var rgbaModel = color.RGBAModel
+
+// state holds the inherited presentation properties.
+type state struct {
+	ctm matrix
+
+	fill          string
+	stroke        string
+	color         color.NRGBA
+	fillOpacity   float64
+	strokeOpacity float64
+	opacity       float64 // Group opacity, multiplied into fill and stroke opacity.
+	strokeWidth   float64
+	lineCap       string
+	lineJoin      string
+	miterLimit    float64
+}
+
+type renderer struct {
+	d   *Decoder
+	doc *document
+	dst *image.RGBA
+
+	vw, vh float64 // The root viewport in user units.
+
+	budget   int
+	useDepth int
+}
+
+func newRenderer(d *Decoder, doc *document, width, height int) *renderer {
+	return &renderer{
+		d:      d,
+		doc:    doc,
+		dst:    image.NewRGBA(image.Rect(0, 0, width, height)),
+		budget: d.maxElements,
+	}
+}
+
+func (r *renderer) viewport() (float64, float64) {
+	return r.vw, r.vh
+}
+
+func (r *renderer) render() error {
+	root := r.doc.root
+	iw, ih := r.doc.size()
+	b := r.dst.Bounds()
+
+	ctm := scale(float64(b.Dx())/iw, float64(b.Dy())/ih)
+	r.vw, r.vh = iw, ih
+	if vb, ok := root.viewBox(); ok {
+		ctm = ctm.mul(viewBoxTransform(vb, iw, ih, root.attrs["preserveAspectRatio"]))
+		r.vw, r.vh = vb[2], vb[3]
+	}
+
+	st := state{
+		ctm:           ctm,
+		fill:          "black",
+		stroke:        "none",
+		color:         color.NRGBA{A: 255},
+		fillOpacity:   1,
+		strokeOpacity: 1,
+		opacity:       1,
+		strokeWidth:   1,
+		lineCap:       "butt",
+		lineJoin:      "miter",
+		miterLimit:    4,
+	}
+
+	st, visible := r.applyStyle(root, st)
+	if !visible {
+		return nil
+	}
+
+	return r.renderChildren(root, st)
+}
+
+func (r *renderer) renderChildren(n *node, st state) error {
+	for _, c := range n.children {
+		if err := r.renderNode(c, st); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *renderer) renderNode(n *node, st state) error {
+	r.budget--
+	if r.budget < 0 {
+		return fmt.Errorf("%w: more than %d elements to render", ErrLimitExceeded, r.d.maxElements)
+	}
+
+	switch n.name {
+	case "g", "a", "switch", "svg", "use", "path", "rect", "circle", "ellipse", "line", "polyline", "polygon":
+	default:
+		// Definitions, text, filters etc. are not rendered.
+		return nil
+	}
+
+	st, visible := r.applyStyle(n, st)
+	if !visible {
+		return nil
+	}
+
+	if v, found := n.attrs["transform"]; found {
+		m, err := parseTransform(v)
+		if err != nil {
+			return fmt.Errorf("svg: %w", err)
+		}
+		st.ctm = st.ctm.mul(m)
+	}
+
+	switch n.name {
+	case "g", "a", "switch":
+		return r.renderChildren(n, st)
+	case "svg":
+		x := r.length(n, "x", r.vw, 0)
+		y := r.length(n, "y", r.vh, 0)
+		st.ctm = st.ctm.mul(translate(x, y))
+		if vb, ok := n.viewBox(); ok {
+			w := r.length(n, "width", r.vw, r.vw)
+			h := r.length(n, "height", r.vh, r.vh)
+			st.ctm = st.ctm.mul(viewBoxTransform(vb, w, h, n.attrs["preserveAspectRatio"]))
+		}
+		return r.renderChildren(n, st)
+	case "use":
+		return r.renderUse(n, st)
+	}
+
+	p, err := r.shape(n)
+	if err != nil {
+		return err
+	}
+	if len(p) == 0 {
+		return nil
+	}
+
+	return r.draw(p, st)
+}
+
+func (r *renderer) renderUse(n *node, st state) error {
+	ref, found := r.doc.ids[n.href()]
+	if !found {
+		return nil
+	}
+
+	r.useDepth++
+	defer func() { r.useDepth-- }()
+	if r.useDepth > r.d.maxDepth {
+		return fmt.Errorf("%w: use references nested deeper than %d", ErrLimitExceeded, r.d.maxDepth)
+	}
+
+	st.ctm = st.ctm.mul(translate(r.length(n, "x", r.vw, 0), r.length(n, "y", r.vh, 0)))
+
+	if ref.name == "symbol" {
+		st, visible := r.applyStyle(ref, st)
+		if !visible {
+			return nil
+		}
+		if vb, ok := ref.viewBox(); ok {
+			w := r.length(n, "width", r.vw, vb[2])
+			h := r.length(n, "height", r.vh, vb[3])
+			st.ctm = st.ctm.mul(viewBoxTransform(vb, w, h, ref.attrs["preserveAspectRatio"]))
+		}
+		return r.renderChildren(ref, st)
+	}
+
+	return r.renderNode(ref, st)
+}
+
+func (r *renderer) length(n *node, attr string, ref, def float64) float64 {
+	return parseLengthOr(n.attrs[attr], ref, def)
+}
+
+// applyStyle applies the presentation properties of n to st and reports
+// whether n should be rendered.
+func (r *renderer) applyStyle(n *node, st state) (state, bool) {
+	s := n.style()
+
+	if s["display"] == "none" {
+		return st, false
+	}
+
+	if v, found := s["color"]; found && v != "inherit" {
+		if c, err := parseColor(v); err == nil {
+			st.color = c
+		}
+	}
+
+	inherit := func(key string, dst *string) {
+		if v, found := s[key]; found && v != "inherit" {
+			*dst = v
+		}
+	}
+	inheritFloat := func(key string, dst *float64) {
+		if v, found := s[key]; found && v != "inherit" {
+			v = strings.TrimSpace(v)
+			var f float64
+			var err error
+			if strings.HasSuffix(v, "%") {
+				f, err = strconv.ParseFloat(strings.TrimSuffix(v, "%"), 64)
+				f /= 100
+			} else {
+				f, err = strconv.ParseFloat(v, 64)
+			}
+			if err == nil {
+				*dst = math.Max(0, math.Min(1, f))
+			}
+		}
+	}
+
+	inherit("fill", &st.fill)
+	inherit("stroke", &st.stroke)
+	inherit("stroke-linecap", &st.lineCap)
+	inherit("stroke-linejoin", &st.lineJoin)
+	inheritFloat("fill-opacity", &st.fillOpacity)
+	inheritFloat("stroke-opacity", &st.strokeOpacity)
+
+	if v, found := s["stroke-width"]; found && v != "inherit" {
+		if w, err := parseLength(v, math.Hypot(r.vw, r.vh)/math.Sqrt2); err == nil && w >= 0 {
+			st.strokeWidth = w
+		}
+	}
+	if v, found := s["stroke-miterlimit"]; found {
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 1 {
+			st.miterLimit = f
+		}
+	}
+
+	opacity := 1.0
+	inheritFloat("opacity", &opacity)
+	// Group opacity is approximated by applying it to each descendant.
+	st.opacity *= opacity
+
+	if v := s["visibility"]; v == "hidden" || v == "collapse" {
+		st.fill, st.stroke = "none", "none"
+	}
+
+	return st, true
+}
+
+// shape returns the geometry of a basic shape or path element in user space.
+func (r *renderer) shape(n *node) (path, error) {
+	var p path
+	num := func(attr string, ref float64) float64 {
+		return r.length(n, attr, ref, 0)
+	}
+	diag := math.Hypot(r.vw, r.vh) / math.Sqrt2
+
+	switch n.name {
+	case "path":
+		var err error
+		p, err = parsePath(n.attrs["d"])
+		if err != nil {
+			// Render what we can, like the browsers do.
+			return p, nil
+		}
+	case "rect":
+		x, y := num("x", r.vw), num("y", r.vh)
+		w, h := num("width", r.vw), num("height", r.vh)
+		if w <= 0 || h <= 0 {
+			return nil, nil
+		}
+		rx, hasRx := n.attrs["rx"]
+		ry, hasRy := n.attrs["ry"]
+		var rxv, ryv float64
+		if hasRx {
+			rxv = parseLengthOr(rx, r.vw, 0)
+		}
+		if hasRy {
+			ryv = parseLengthOr(ry, r.vh, 0)
+		}
+		if !hasRx {
+			rxv = ryv
+		}
+		if !hasRy {
+			ryv = rxv
+		}
+		rxv, ryv = math.Min(math.Max(rxv, 0), w/2), math.Min(math.Max(ryv, 0), h/2)
+		if rxv == 0 || ryv == 0 {
+			p.moveTo(point{x, y})
+			p.lineTo(point{x + w, y})
+			p.lineTo(point{x + w, y + h})
+			p.lineTo(point{x, y + h})
+			p.close()
+		} else {
+			p.moveTo(point{x + rxv, y})
+			p.lineTo(point{x + w - rxv, y})
+			p.arcTo(point{x + w - rxv, y}, rxv, ryv, 0, false, true, point{x + w, y + ryv})
+			p.lineTo(point{x + w, y + h - ryv})
+			p.arcTo(point{x + w, y + h - ryv}, rxv, ryv, 0, false, true, point{x + w - rxv, y + h})
+			p.lineTo(point{x + rxv, y + h})
+			p.arcTo(point{x + rxv, y + h}, rxv, ryv, 0, false, true, point{x, y + h - ryv})
+			p.lineTo(point{x, y + ryv})
+			p.arcTo(point{x, y + ryv}, rxv, ryv, 0, false, true, point{x + rxv, y})
+			p.close()
+		}
+	case "circle":
+		cx, cy, rad := num("cx", r.vw), num("cy", r.vh), num("r", diag)
+		if rad <= 0 {
+			return nil, nil
+		}
+		p.ellipse(cx, cy, rad, rad)
+	case "ellipse":
+		cx, cy, rx, ry := num("cx", r.vw), num("cy", r.vh), num("rx", r.vw), num("ry", r.vh)
+		if rx <= 0 || ry <= 0 {
+			return nil, nil
+		}
+		p.ellipse(cx, cy, rx, ry)
+	case "line":
+		p.moveTo(point{num("x1", r.vw), num("y1", r.vh)})
+		p.lineTo(point{num("x2", r.vw), num("y2", r.vh)})
+	case "polyline", "polygon":
+		nums, err := parseNumberList(n.attrs["points"])
+		if err != nil || len(nums) < 4 {
+			return nil, nil
+		}
+		for i := 0; i+1 < len(nums); i += 2 {
+			pt := point{nums[i], nums[i+1]}
+			if i == 0 {
+				p.moveTo(pt)
+			} else {
+				p.lineTo(pt)
+			}
+		}
+		if n.name == "polygon" {
+			p.close()
+		}
+	}
+
+	return p, nil
+}
+
+func (p *path) ellipse(cx, cy, rx, ry float64) {
+	p.moveTo(point{cx + rx, cy})
+	p.arcTo(point{cx + rx, cy}, rx, ry, 0, false, true, point{cx - rx, cy})
+	p.arcTo(point{cx - rx, cy}, rx, ry, 0, false, true, point{cx + rx, cy})
+	p.close()
+}
+
+// bbox returns the approximate bounding box of p in user space as x0, y0, x1, y1.
+func (p path) bbox() [4]float64 {
+	b := [4]float64{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+	for _, op := range p {
+		n := 0
+		switch op.kind {
+		case opMove, opLine:
+			n = 1
+		case opCubic:
+			n = 3
+		}
+		for _, pt := range op.pts[:n] {
+			b[0], b[1] = math.Min(b[0], pt.X), math.Min(b[1], pt.Y)
+			b[2], b[3] = math.Max(b[2], pt.X), math.Max(b[3], pt.Y)
+		}
+	}
+	return b
+}
+
+func (r *renderer) draw(p path, st state) error {
+	subpaths := p.flatten(st.ctm)
+
+	fill, err := r.parsePaint(st.fill, st.color)
+	if err != nil {
+		fill = paintNone
+	}
+	if !fill.none {
+		z := r.newRasterizer()
+		for _, sp := range subpaths {
+			if len(sp.pts) < 3 {
+				continue
+			}
+			addPath(z, sp.pts)
+		}
+		r.paint(z, fill, p, st, st.fillOpacity*st.opacity)
+	}
+
+	stroke, err := r.parsePaint(st.stroke, st.color)
+	if err != nil {
+		stroke = paintNone
+	}
+	if !stroke.none && st.strokeWidth > 0 {
+		hw := st.strokeWidth * st.ctm.scale() / 2
+		z := r.newRasterizer()
+		for _, sp := range subpaths {
+			for _, poly := range strokePolygons(sp, hw, st.lineCap, st.lineJoin, st.miterLimit) {
+				addPolygon(z, poly)
+			}
+		}
+		r.paint(z, stroke, p, st, st.strokeOpacity*st.opacity)
+	}
+
+	return nil
+}
+
+func (r *renderer) newRasterizer() *vector.Rasterizer {
+	b := r.dst.Bounds()
+	return vector.NewRasterizer(b.Dx(), b.Dy())
+}
+
+func (r *renderer) paint(z *vector.Rasterizer, pt paint, p path, st state, opacity float64) {
+	var src image.Image
+	if pt.gradient != nil {
+		src = pt.gradient.image(st.ctm, p.bbox(), opacity)
+	} else {
+		c := pt.color
+		c.A = clampUint8(float64(c.A) * opacity)
+		if c.A == 0 {
+			return
+		}
+		src = image.NewUniform(c)
+	}
+	z.Draw(r.dst, r.dst.Bounds(), src, image.Point{})
+}
+
+// addPath adds pts as a closed path to z, preserving its winding so
+// subpaths with opposite winding cut holes.
+func addPath(z *vector.Rasterizer, pts []point) {
+	z.MoveTo(float32(pts[0].X), float32(pts[0].Y))
+	for _, pt := range pts[1:] {
+		z.LineTo(float32(pt.X), float32(pt.Y))
+	}
+	z.ClosePath()
+}
+
+// addPolygon adds a closed polygon to z. All polygons are added with the
+// same winding so overlapping parts accumulate instead of cancelling out.
+func addPolygon(z *vector.Rasterizer, pts []point) {
+	var area float64
+	for i := range pts {
+		area += pts[i].cross(pts[(i+1)%len(pts)])
+	}
+	if area > 0 {
+		rev := make([]point, len(pts))
+		for i, pt := range pts {
+			rev[len(pts)-1-i] = pt
+		}
+		pts = rev
+	}
+	addPath(z, pts)
+}
+
+// strokePolygons approximates the stroke of sp with half width hw as a set
+// of polygons: one quad per segment, plus joins and caps.
+func strokePolygons(sp subpath, hw float64, lineCap, lineJoin string, miterLimit float64) [][]point {
+	// Remove consecutive duplicates.
+	pts := make([]point, 0, len(sp.pts))
+	for _, pt := range sp.pts {
+		if len(pts) == 0 || pt.sub(pts[len(pts)-1]).len() > 1e-9 {
+			pts = append(pts, pt)
+		}
+	}
+	closed := sp.closed
+	if closed && len(pts) > 1 && pts[0].sub(pts[len(pts)-1]).len() <= 1e-9 {
+		pts = pts[:len(pts)-1]
+	}
+
+	if len(pts) == 1 {
+		// A zero length subpath is only painted with round or square caps.
+		switch lineCap {
+		case "round":
+			return [][]point{circlePolygon(pts[0], hw)}
+		case "square":
+			c := pts[0]
+			return [][]point{{{c.X - hw, c.Y - hw}, {c.X + hw, c.Y - hw}, {c.X + hw, c.Y + hw}, {c.X - hw, c.Y + hw}}}
+		}
+		return nil
+	}
+
+	var polys [][]point
+
+	n := len(pts) - 1
+	if closed {
+		n = len(pts)
+	}
+
+	dir := func(i int) point {
+		a, b := pts[i%len(pts)], pts[(i+1)%len(pts)]
+		d := b.sub(a)
+		return d.mul(1 / d.len())
+	}
+
+	for i := 0; i < n; i++ {
+		a, b := pts[i], pts[(i+1)%len(pts)]
+		d := dir(i)
+		nrm := point{-d.Y, d.X}.mul(hw)
+		polys = append(polys, []point{a.add(nrm), b.add(nrm), b.sub(nrm), a.sub(nrm)})
+	}
+
+	// Joins.
+	for i := 0; i < len(pts); i++ {
+		if !closed && (i == 0 || i == len(pts)-1) {
+			continue
+		}
+		prev := i - 1
+		if prev < 0 {
+			prev = len(pts) - 1
+		}
+		if poly := joinPolygon(pts[i], dir(prev), dir(i), hw, lineJoin, miterLimit); poly != nil {
+			polys = append(polys, poly)
+		}
+	}
+
+	// Caps.
+	if !closed {
+		switch lineCap {
+		case "round":
+			polys = append(polys, circlePolygon(pts[0], hw), circlePolygon(pts[len(pts)-1], hw))
+		case "square":
+			polys = append(polys, squareCap(pts[0], dir(0).mul(-1), hw), squareCap(pts[len(pts)-1], dir(len(pts)-2), hw))
+		}
+	}
+
+	return polys
+}
+
+func joinPolygon(v, d1, d2 point, hw float64, lineJoin string, miterLimit float64) []point {
+	cross := d1.cross(d2)
+	if math.Abs(cross) < 1e-9 {
+		return nil
+	}
+	if lineJoin == "round" {
+		return circlePolygon(v, hw)
+	}
+
+	// The outer side of the turn.
+	n1, n2 := point{d1.Y, -d1.X}, point{d2.Y, -d2.X}
+	if cross < 0 {
+		n1, n2 = n1.mul(-1), n2.mul(-1)
+	}
+	a, b := v.add(n1.mul(hw)), v.add(n2.mul(hw))
+
+	if lineJoin != "bevel" && !strings.HasPrefix(lineJoin, "miter") {
+		lineJoin = "miter"
+	}
+	if lineJoin != "bevel" {
+		t := b.sub(a).cross(d2) / d1.cross(d2)
+		tip := a.add(d1.mul(t))
+		if tip.sub(v).len()/hw <= miterLimit {
+			return []point{v, a, tip, b}
+		}
+	}
+
+	return []point{v, a, b}
+}
+
+func squareCap(v, d point, hw float64) []point {
+	nrm := point{-d.Y, d.X}.mul(hw)
+	e := v.add(d.mul(hw))
+	return []point{v.add(nrm), e.add(nrm), e.sub(nrm), v.sub(nrm)}
+}
+
+func circlePolygon(c point, radius float64) []point {
+	n := int(math.Ceil(math.Sqrt(radius) * 6))
+	if n < 8 {
+		n = 8
+	} else if n > 128 {
+		n = 128
+	}
+	pts := make([]point, n)
+	for i := range pts {
+		s, co := math.Sincos(2 * math.Pi * float64(i) / float64(n))
+		pts[i] = point{c.X + radius*co, c.Y + radius*s}
+	}
+	return pts
+}
+
+// viewBoxTransform returns the transform mapping the viewBox vb onto a
+// viewport of size w x h, see
+// https://www.w3.org/TR/SVG11/coords.html#PreserveAspectRatioAttribute
+func viewBoxTransform(vb [4]float64, w, h float64, preserveAspectRatio string) matrix {
+	sx, sy := w/vb[2], h/vb[3]
+
+	fields := strings.Fields(preserveAspectRatio)
+	align, meetOrSlice := "xMidYMid", "meet"
+	if len(fields) > 0 {
+		align = fields[0]
+	}
+	if len(fields) > 1 {
+		meetOrSlice = fields[1]
+	}
+
+	if align == "none" {
+		return scale(sx, sy).mul(translate(-vb[0], -vb[1]))
+	}
+
+	s := math.Min(sx, sy)
+	if meetOrSlice == "slice" {
+		s = math.Max(sx, sy)
+	}
+
+	tx, ty := -vb[0]*s, -vb[1]*s
+	switch {
+	case strings.Contains(align, "xMid"):
+		tx += (w - vb[2]*s) / 2
+	case strings.Contains(align, "xMax"):
+		tx += w - vb[2]*s
+	}
+	switch {
+	case strings.Contains(align, "YMid"):
+		ty += (h - vb[3]*s) / 2
+	case strings.Contains(align, "YMax"):
+		ty += h - vb[3]*s
+	}
+
+	return matrix{s, 0, 0, s, tx, ty}
+}
@@ -0,0 +1,380 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package svg provides a small, pure Go SVG rasterizer.
+//
+// It supports the static subset of SVG commonly found in logos and icons:
+// basic shapes, paths, groups, use/symbol references, transforms, solid
+// colors and linear/radial gradients. Text, filters, masks, clip paths,
+// dash arrays and CSS style sheets are ignored. The even-odd fill rule is
+// rendered as non-zero.
+//
+// The decoder never expands XML entities and enforces limits on input size,
+// element count (including elements instantiated via use), nesting depth and
+// output size to guard against "billion laughs" style inputs.
+package svg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultMaxBytes    = 10 << 20
+	defaultMaxElements = 50000
+	defaultMaxDepth    = 64
+	defaultMaxPixels   = 25000000
+
+	// The default viewport size used by browsers when none is specified.
+	defaultWidth  = 300
+	defaultHeight = 150
+)
+
+// ErrLimitExceeded is returned when the input exceeds one of the configured limits.
+var ErrLimitExceeded = errors.New("svg: limit exceeded")
+
+// Decoder decodes and rasterizes SVG documents.
+type Decoder struct {
+	maxBytes    int64
+	maxElements int
+	maxDepth    int
+	maxPixels   int
+}
+
+// WithMaxBytes sets the maximum size of the SVG source in bytes.
+func WithMaxBytes(n int64) func(*Decoder) error {
+	return func(d *Decoder) error {
+		if n > 0 {
+			d.maxBytes = n
+		}
+		return nil
+	}
+}
+
+// WithMaxElements sets the maximum number of elements to parse and render.
+// Elements instantiated through use references count towards this limit.
+func WithMaxElements(n int) func(*Decoder) error {
+	return func(d *Decoder) error {
+		if n > 0 {
+			d.maxElements = n
+		}
+		return nil
+	}
+}
+
+// WithMaxDepth sets the maximum element nesting depth, including nested use references.
+func WithMaxDepth(n int) func(*Decoder) error {
+	return func(d *Decoder) error {
+		if n > 0 {
+			d.maxDepth = n
+		}
+		return nil
+	}
+}
+
+// WithMaxPixels sets the maximum number of pixels (width * height) in a
+// rasterized image.
+func WithMaxPixels(n int) func(*Decoder) error {
+	return func(d *Decoder) error {
+		if n > 0 {
+			d.maxPixels = n
+		}
+		return nil
+	}
+}
+
+func NewDecoder(options ...func(*Decoder) error) (*Decoder, error) {
+	d := &Decoder{
+		maxBytes:    defaultMaxBytes,
+		maxElements: defaultMaxElements,
+		maxDepth:    defaultMaxDepth,
+		maxPixels:   defaultMaxPixels,
+	}
+	for _, opt := range options {
+		if err := opt(d); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// DecodeConfig returns the intrinsic dimensions of the SVG document in r,
+// rounded up to whole pixels. The dimensions are taken from the width and
+// height attributes of the root element, falling back to its viewBox.
+func (d *Decoder) DecodeConfig(r io.Reader) (image.Config, error) {
+	doc, err := d.parse(r)
+	if err != nil {
+		return image.Config{}, err
+	}
+	w, h := doc.size()
+	return image.Config{
+		ColorModel: rgbaModel,
+		Width:      int(math.Ceil(w)),
+		Height:     int(math.Ceil(h)),
+	}, nil
+}
+
+// Decode rasterizes the SVG document in r. If both width and height are 0,
+// the intrinsic size is used; if only one of them is 0, it is derived from
+// the intrinsic aspect ratio.
+func (d *Decoder) Decode(r io.Reader, width, height int) (image.Image, error) {
+	doc, err := d.parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	iw, ih := doc.size()
+	switch {
+	case width == 0 && height == 0:
+		width, height = int(math.Ceil(iw)), int(math.Ceil(ih))
+	case width == 0:
+		width = int(math.Ceil(float64(height) * iw / ih))
+	case height == 0:
+		height = int(math.Ceil(float64(width) * ih / iw))
+	}
+
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("svg: invalid image dimensions %dx%d", width, height)
+	}
+	if width*height > d.maxPixels || width > d.maxPixels || height > d.maxPixels {
+		return nil, fmt.Errorf("%w: image dimensions %dx%d exceed the maximum of %d pixels", ErrLimitExceeded, width, height, d.maxPixels)
+	}
+
+	rr := newRenderer(d, doc, width, height)
+	if err := rr.render(); err != nil {
+		return nil, err
+	}
+
+	return rr.dst, nil
+}
+
+type document struct {
+	root *node
+	ids  map[string]*node
+}
+
+// size returns the intrinsic size of the document in pixels.
+func (doc *document) size() (float64, float64) {
+	vb, hasViewBox := doc.root.viewBox()
+
+	w, errw := parseLength(doc.root.attrs["width"], 0)
+	h, errh := parseLength(doc.root.attrs["height"], 0)
+	hasW, hasH := errw == nil && w > 0, errh == nil && h > 0
+
+	switch {
+	case hasW && hasH:
+	case hasViewBox && hasW:
+		h = w * vb[3] / vb[2]
+	case hasViewBox && hasH:
+		w = h * vb[2] / vb[3]
+	case hasViewBox:
+		w, h = vb[2], vb[3]
+	default:
+		if !hasW {
+			w = defaultWidth
+		}
+		if !hasH {
+			h = defaultHeight
+		}
+	}
+
+	return w, h
+}
+
+type node struct {
+	name     string
+	attrs    map[string]string
+	children []*node
+}
+
+func (n *node) href() string {
+	return strings.TrimPrefix(strings.TrimSpace(n.attrs["href"]), "#")
+}
+
+// viewBox returns the parsed viewBox attribute, if set and valid.
+func (n *node) viewBox() ([4]float64, bool) {
+	var vb [4]float64
+	v, found := n.attrs["viewBox"]
+	if !found {
+		return vb, false
+	}
+	nums, err := parseNumberList(v)
+	if err != nil || len(nums) != 4 || nums[2] <= 0 || nums[3] <= 0 {
+		return vb, false
+	}
+	copy(vb[:], nums)
+	return vb, true
+}
+
+type styleMap map[string]string
+
+func (s styleMap) get(key, def string) string {
+	if v, found := s[key]; found {
+		return v
+	}
+	return def
+}
+
+// style returns the presentation attributes of n, overridden by any
+// declarations in its style attribute.
+func (n *node) style() styleMap {
+	m := make(styleMap, len(n.attrs))
+	for k, v := range n.attrs {
+		m[k] = strings.TrimSpace(v)
+	}
+	for _, decl := range strings.Split(n.attrs["style"], ";") {
+		k, v, found := strings.Cut(decl, ":")
+		if !found {
+			continue
+		}
+		v = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(v), "!important"))
+		m[strings.TrimSpace(k)] = v
+	}
+	return m
+}
+
+func (d *Decoder) parse(r io.Reader) (*document, error) {
+	data, err := io.ReadAll(io.LimitReader(r, d.maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > d.maxBytes {
+		return nil, fmt.Errorf("%w: document is larger than %d bytes", ErrLimitExceeded, d.maxBytes)
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = true
+
+	doc := &document{ids: make(map[string]*node)}
+
+	var (
+		stack    []*node
+		elements int
+	)
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("svg: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.Directive:
+			if bytes.Contains(t, []byte("ENTITY")) {
+				return nil, errors.New("svg: entity declarations are not supported")
+			}
+		case xml.StartElement:
+			elements++
+			if elements > d.maxElements {
+				return nil, fmt.Errorf("%w: more than %d elements", ErrLimitExceeded, d.maxElements)
+			}
+			if len(stack) >= d.maxDepth {
+				return nil, fmt.Errorf("%w: elements nested deeper than %d", ErrLimitExceeded, d.maxDepth)
+			}
+
+			n := &node{name: t.Name.Local, attrs: make(map[string]string, len(t.Attr))}
+			for _, a := range t.Attr {
+				n.attrs[a.Name.Local] = a.Value
+			}
+
+			if len(stack) == 0 {
+				if doc.root != nil {
+					return nil, errors.New("svg: multiple root elements")
+				}
+				if n.name != "svg" {
+					return nil, fmt.Errorf("svg: expected root element <svg>, got <%s>", n.name)
+				}
+				doc.root = n
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			}
+
+			if id := n.attrs["id"]; id != "" {
+				if _, found := doc.ids[id]; !found {
+					doc.ids[id] = n
+				}
+			}
+
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if doc.root == nil {
+		return nil, errors.New("svg: no <svg> element found")
+	}
+
+	return doc, nil
+}
+
+// parseLength parses an SVG length, e.g. "10", "10px", "2em" or "50%".
+// Percentages are resolved against ref.
+func parseLength(s string, ref float64) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("empty length")
+	}
+
+	unit := 1.0
+	for _, u := range lengthUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, u.suffix))
+			unit = u.factor
+			if u.suffix == "%" {
+				unit = ref / 100
+			}
+			break
+		}
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return v * unit, nil
+}
+
+func parseLengthOr(s string, ref, def float64) float64 {
+	v, err := parseLength(s, ref)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+var lengthUnits = []struct {
+	suffix string
+	factor float64
+}{
+	{"%", 0},
+	{"px", 1},
+	{"pt", 4.0 / 3},
+	{"pc", 16},
+	{"mm", 96 / 25.4},
+	{"cm", 96 / 2.54},
+	{"in", 96},
+	{"em", 16},
+	{"ex", 8},
+}
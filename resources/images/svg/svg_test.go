@@ -0,0 +1,181 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package svg
+
+import (
+	"errors"
+	"image/color"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestDecodeConfig(t *testing.T) {
+	c := qt.New(t)
+
+	d, err := NewDecoder()
+	c.Assert(err, qt.IsNil)
+
+	for _, test := range []struct {
+		name          string
+		svg           string
+		width, height int
+	}{
+		{"viewBox", `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 120 60.5"></svg>`, 120, 61},
+		{"width and height", `<svg width="32px" height="2in" viewBox="0 0 1 1"></svg>`, 32, 192},
+		{"width only", `<svg width="50" viewBox="0 0 100 200"></svg>`, 50, 100},
+		{"percent", `<svg width="100%" height="100%" viewBox="0 0 10 20"></svg>`, 10, 20},
+		{"default", `<?xml version="1.0"?><svg></svg>`, 300, 150},
+	} {
+		c.Run(test.name, func(c *qt.C) {
+			cfg, err := d.DecodeConfig(strings.NewReader(test.svg))
+			c.Assert(err, qt.IsNil)
+			c.Assert(cfg.Width, qt.Equals, test.width)
+			c.Assert(cfg.Height, qt.Equals, test.height)
+		})
+	}
+
+	_, err = d.DecodeConfig(strings.NewReader(`<html></html>`))
+	c.Assert(err, qt.ErrorMatches, ".*expected root element.*")
+}
+
+func TestDecode(t *testing.T) {
+	c := qt.New(t)
+
+	d, err := NewDecoder()
+	c.Assert(err, qt.IsNil)
+
+	const src = `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 10 10">
+<rect width="10" height="10" fill="#00f"/>
+<g transform="translate(5 0)" style="fill: red">
+<path d="M0 0h5v5H0z"/>
+</g>
+<circle cx="2.5" cy="7.5" r="2" fill="rgb(0, 255, 0)" stroke="none"/>
+<line x1="5" y1="9.5" x2="10" y2="9.5" stroke="white" stroke-width="1"/>
+</svg>`
+
+	img, err := d.Decode(strings.NewReader(src), 100, 0)
+	c.Assert(err, qt.IsNil)
+	c.Assert(img.Bounds().Dx(), qt.Equals, 100)
+	c.Assert(img.Bounds().Dy(), qt.Equals, 100)
+
+	rgba := func(x, y int) color.RGBA {
+		return color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+	}
+
+	c.Assert(rgba(10, 10), qt.Equals, color.RGBA{B: 255, A: 255})
+	c.Assert(rgba(75, 25), qt.Equals, color.RGBA{R: 255, A: 255})
+	c.Assert(rgba(25, 75), qt.Equals, color.RGBA{G: 255, A: 255})
+	c.Assert(rgba(75, 97), qt.Equals, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+	c.Assert(rgba(75, 60), qt.Equals, color.RGBA{B: 255, A: 255})
+}
+
+func TestDecodeGradient(t *testing.T) {
+	c := qt.New(t)
+
+	d, err := NewDecoder()
+	c.Assert(err, qt.IsNil)
+
+	const src = `<svg viewBox="0 0 100 10">
+<defs><linearGradient id="g"><stop offset="0" stop-color="black"/><stop offset="100%" stop-color="white"/></linearGradient></defs>
+<rect width="100" height="10" fill="url(#g)"/>
+</svg>`
+
+	img, err := d.Decode(strings.NewReader(src), 0, 0)
+	c.Assert(err, qt.IsNil)
+
+	left := color.GrayModel.Convert(img.At(1, 5)).(color.Gray)
+	mid := color.GrayModel.Convert(img.At(50, 5)).(color.Gray)
+	right := color.GrayModel.Convert(img.At(98, 5)).(color.Gray)
+	c.Assert(left.Y < 10, qt.IsTrue)
+	c.Assert(mid.Y > 100 && mid.Y < 160, qt.IsTrue)
+	c.Assert(right.Y > 245, qt.IsTrue)
+}
+
+func TestDecodeLimits(t *testing.T) {
+	c := qt.New(t)
+
+	d, err := NewDecoder(WithMaxElements(1000), WithMaxPixels(1000*1000))
+	c.Assert(err, qt.IsNil)
+
+	const laughs = `<?xml version="1.0"?>
+<!DOCTYPE svg [
+<!ENTITY lol "lol">
+<!ENTITY lol2 "&lol;&lol;&lol;&lol;&lol;&lol;&lol;&lol;&lol;&lol;">
+]>
+<svg><text>&lol2;</text></svg>`
+
+	_, err = d.DecodeConfig(strings.NewReader(laughs))
+	c.Assert(err, qt.ErrorMatches, ".*entity declarations are not supported.*")
+
+	// Exponential expansion through use references.
+	var b strings.Builder
+	b.WriteString(`<svg viewBox="0 0 10 10"><defs><rect id="a0" width="1" height="1"/>`)
+	for i := 1; i < 10; i++ {
+		b.WriteString(`<g id="a` + string(rune('0'+i)) + `">`)
+		for j := 0; j < 10; j++ {
+			b.WriteString(`<use href="#a` + string(rune('0'+i-1)) + `"/>`)
+		}
+		b.WriteString(`</g>`)
+	}
+	b.WriteString(`</defs><use href="#a9"/></svg>`)
+
+	_, err = d.Decode(strings.NewReader(b.String()), 0, 0)
+	c.Assert(errors.Is(err, ErrLimitExceeded), qt.IsTrue)
+
+	// Self reference.
+	_, err = d.Decode(strings.NewReader(`<svg><g id="a"><use href="#a"/></g></svg>`), 0, 0)
+	c.Assert(errors.Is(err, ErrLimitExceeded), qt.IsTrue)
+
+	_, err = d.Decode(strings.NewReader(`<svg viewBox="0 0 10 10"></svg>`), 5000, 5000)
+	c.Assert(errors.Is(err, ErrLimitExceeded), qt.IsTrue)
+
+	d, err = NewDecoder(WithMaxBytes(10))
+	c.Assert(err, qt.IsNil)
+	_, err = d.DecodeConfig(strings.NewReader(`<svg viewBox="0 0 10 10"></svg>`))
+	c.Assert(errors.Is(err, ErrLimitExceeded), qt.IsTrue)
+}
+
+func TestParsePath(t *testing.T) {
+	c := qt.New(t)
+
+	p, err := parsePath("M10-20l1.5.5h-1V5c1 1 2 2 3 3s1 1 2 2q1 1 2 2t1 1a5 5 0 0 1 10 0z")
+	c.Assert(err, qt.IsNil)
+	c.Assert(p[0], qt.Equals, pathOp{kind: opMove, pts: [3]point{{10, -20}}})
+	c.Assert(p[1], qt.Equals, pathOp{kind: opLine, pts: [3]point{{11.5, -19.5}}})
+	c.Assert(p[2], qt.Equals, pathOp{kind: opLine, pts: [3]point{{10.5, -19.5}}})
+	c.Assert(p[3], qt.Equals, pathOp{kind: opLine, pts: [3]point{{10.5, 5}}})
+	c.Assert(p[len(p)-1].kind, qt.Equals, opClose)
+
+	_, err = parsePath("10 10")
+	c.Assert(err, qt.Not(qt.IsNil))
+}
+
+func TestParseTransform(t *testing.T) {
+	c := qt.New(t)
+
+	m, err := parseTransform("translate(10, 20) scale(2)")
+	c.Assert(err, qt.IsNil)
+	c.Assert(m.apply(point{1, 1}), qt.Equals, point{12, 22})
+
+	m, err = parseTransform("rotate(90 5 5)")
+	c.Assert(err, qt.IsNil)
+	p := m.apply(point{10, 5})
+	c.Assert(int(p.X+0.5), qt.Equals, 5)
+	c.Assert(int(p.Y+0.5), qt.Equals, 10)
+
+	_, err = parseTransform("foo(1)")
+	c.Assert(err, qt.Not(qt.IsNil))
+}
@@ -0,0 +1,156 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package svg
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// matrix is a 2D affine transform in the SVG order (a b c d e f), i.e.
+//
+//	| a c e |
+//	| b d f |
+//	| 0 0 1 |
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+// mul returns m * n, i.e. n is applied first.
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[2]*n[1],
+		m[1]*n[0] + m[3]*n[1],
+		m[0]*n[2] + m[2]*n[3],
+		m[1]*n[2] + m[3]*n[3],
+		m[0]*n[4] + m[2]*n[5] + m[4],
+		m[1]*n[4] + m[3]*n[5] + m[5],
+	}
+}
+
+func (m matrix) apply(p point) point {
+	return point{
+		X: m[0]*p.X + m[2]*p.Y + m[4],
+		Y: m[1]*p.X + m[3]*p.Y + m[5],
+	}
+}
+
+func (m matrix) invert() (matrix, bool) {
+	det := m[0]*m[3] - m[1]*m[2]
+	if det == 0 {
+		return identity, false
+	}
+	return matrix{
+		m[3] / det,
+		-m[1] / det,
+		-m[2] / det,
+		m[0] / det,
+		(m[2]*m[5] - m[3]*m[4]) / det,
+		(m[1]*m[4] - m[0]*m[5]) / det,
+	}, true
+}
+
+// scale returns the (geometric mean) scale factor of m, used to scale
+// stroke widths.
+func (m matrix) scale() float64 {
+	return math.Sqrt(math.Abs(m[0]*m[3] - m[1]*m[2]))
+}
+
+func translate(x, y float64) matrix {
+	return matrix{1, 0, 0, 1, x, y}
+}
+
+func scale(x, y float64) matrix {
+	return matrix{x, 0, 0, y, 0, 0}
+}
+
+func rotate(deg float64) matrix {
+	rad := deg * math.Pi / 180
+	s, c := math.Sincos(rad)
+	return matrix{c, s, -s, c, 0, 0}
+}
+
+// parseTransform parses the value of a transform attribute, e.g.
+// "translate(10 20) rotate(45)".
+func parseTransform(s string) (matrix, error) {
+	m := identity
+	s = strings.TrimSpace(s)
+	for s != "" {
+		open := strings.IndexByte(s, '(')
+		if open == -1 {
+			return identity, fmt.Errorf("invalid transform %q", s)
+		}
+		closing := strings.IndexByte(s, ')')
+		if closing < open {
+			return identity, fmt.Errorf("invalid transform %q", s)
+		}
+		name := strings.TrimSpace(s[:open])
+		args, err := parseNumberList(s[open+1 : closing])
+		if err != nil {
+			return identity, err
+		}
+		s = strings.TrimLeft(s[closing+1:], " \t\r\n,")
+
+		var t matrix
+		switch name {
+		case "matrix":
+			if len(args) != 6 {
+				return identity, fmt.Errorf("matrix requires 6 arguments, got %d", len(args))
+			}
+			copy(t[:], args)
+		case "translate":
+			switch len(args) {
+			case 1:
+				t = translate(args[0], 0)
+			case 2:
+				t = translate(args[0], args[1])
+			default:
+				return identity, fmt.Errorf("invalid number of arguments to translate: %d", len(args))
+			}
+		case "scale":
+			switch len(args) {
+			case 1:
+				t = scale(args[0], args[0])
+			case 2:
+				t = scale(args[0], args[1])
+			default:
+				return identity, fmt.Errorf("invalid number of arguments to scale: %d", len(args))
+			}
+		case "rotate":
+			switch len(args) {
+			case 1:
+				t = rotate(args[0])
+			case 3:
+				t = translate(args[1], args[2]).mul(rotate(args[0])).mul(translate(-args[1], -args[2]))
+			default:
+				return identity, fmt.Errorf("invalid number of arguments to rotate: %d", len(args))
+			}
+		case "skewX":
+			if len(args) != 1 {
+				return identity, fmt.Errorf("invalid number of arguments to skewX: %d", len(args))
+			}
+			t = matrix{1, 0, math.Tan(args[0] * math.Pi / 180), 1, 0, 0}
+		case "skewY":
+			if len(args) != 1 {
+				return identity, fmt.Errorf("invalid number of arguments to skewY: %d", len(args))
+			}
+			t = matrix{1, math.Tan(args[0] * math.Pi / 180), 0, 1, 0, 0}
+		default:
+			return identity, fmt.Errorf("unsupported transform %q", name)
+		}
+		m = m.mul(t)
+	}
+	return m, nil
+}
@@ -18,6 +18,7 @@ import (
 	"image/draw"
 	"io"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/disintegration/gift"
 	"github.com/gohugoio/hugo/common/hugio"
@@ -44,30 +45,7 @@ func (f textFilter) Draw(dst draw.Image, src image.Image, options *gift.Options)
 		panic(err)
 	}
 
-	// Load and parse font
-	ttf := goregular.TTF
-	if f.fontSource != nil {
-		rs, err := f.fontSource.ReadSeekCloser()
-		if err != nil {
-			panic(err)
-		}
-		defer rs.Close()
-		ttf, err = io.ReadAll(rs)
-		if err != nil {
-			panic(err)
-		}
-	}
-	otf, err := opentype.Parse(ttf)
-	if err != nil {
-		panic(err)
-	}
-
-	// Set font options
-	face, err := opentype.NewFace(otf, &opentype.FaceOptions{
-		Size:    f.size,
-		DPI:     72,
-		Hinting: font.HintingNone,
-	})
+	face, err := loadFontFace(f.fontSource, f.size)
 	if err != nil {
 		panic(err)
 	}
@@ -103,6 +81,110 @@ func (f textFilter) Draw(dst draw.Image, src image.Image, options *gift.Options)
 	}
 }
 
+// loadFontFace loads the font from fontSource, or the Go Regular font if nil,
+// and returns a face of the given size in pixels.
+func loadFontFace(fontSource hugio.ReadSeekCloserProvider, size float64) (font.Face, error) {
+	ttf := goregular.TTF
+	if fontSource != nil {
+		rs, err := fontSource.ReadSeekCloser()
+		if err != nil {
+			return nil, err
+		}
+		defer rs.Close()
+		ttf, err = io.ReadAll(rs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	otf, err := opentype.Parse(ttf)
+	if err != nil {
+		return nil, err
+	}
+
+	return opentype.NewFace(otf, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingNone,
+	})
+}
+
+// measureText returns the width in pixels of s drawn with face.
+func measureText(face font.Face, s string) int {
+	return font.MeasureString(face, s).Ceil()
+}
+
+// wrapText splits text into lines no wider than maxWidth pixels, breaking
+// between words where possible. Newlines in text always start a new line.
+// A maxWidth <= 0 disables wrapping.
+func wrapText(face font.Face, text string, maxWidth int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+		if maxWidth <= 0 {
+			lines = append(lines, strings.Join(words, " "))
+			continue
+		}
+
+		var line string
+		for _, word := range words {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if measureText(face, candidate) <= maxWidth {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			// Break words that are too long on their own.
+			for measureText(face, word) > maxWidth {
+				n := fitRunes(face, word, maxWidth)
+				lines = append(lines, word[:n])
+				word = word[n:]
+			}
+			line = word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// fitRunes returns the byte length of the longest prefix of s, at least one
+// rune, that fits within maxWidth pixels.
+func fitRunes(face font.Face, s string, maxWidth int) int {
+	n := 0
+	for i, r := range s {
+		end := i + utf8.RuneLen(r)
+		if n > 0 && measureText(face, s[:end]) > maxWidth {
+			break
+		}
+		n = end
+	}
+	return n
+}
+
+// truncateLines limits lines to maxLines, appending ellipsis to the last line
+// and shortening it as needed to fit within maxWidth.
+func truncateLines(face font.Face, lines []string, maxLines, maxWidth int, ellipsis string) []string {
+	if maxLines <= 0 || len(lines) <= maxLines {
+		return lines
+	}
+	lines = lines[:maxLines]
+	last := lines[maxLines-1]
+	for last != "" && maxWidth > 0 && measureText(face, last+ellipsis) > maxWidth {
+		_, size := utf8.DecodeLastRuneInString(last)
+		last = strings.TrimRight(last[:len(last)-size], " ")
+	}
+	lines[maxLines-1] = last + ellipsis
+	return lines
+}
+
 func (f textFilter) Bounds(srcBounds image.Rectangle) image.Rectangle {
 	return image.Rect(0, 0, srcBounds.Dx(), srcBounds.Dy())
 }
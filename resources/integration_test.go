@@ -70,7 +70,7 @@ bmp: {{ $bmp.RelPermalink }}|{{ $bmp.MediaType }}|
 
 }
 
-func TestSVGError(t *testing.T) {
+func TestSVGImage(t *testing.T) {
 	t.Parallel()
 
 	files := `
@@ -79,18 +79,110 @@ func TestSVGError(t *testing.T) {
 <svg height="100" width="100"><circle cx="50" cy="50" r="40" stroke="black" stroke-width="3" fill="red" /></svg> 
 -- layouts/index.html --
 {{ $svg := resources.Get "circle.svg" }}
-Width: {{ $svg.Width }}	
+{{ $resized := $svg.Resize "200x" }}
+{{ $filled := $svg.Fill "50x20 jpg" }}
+{{ $filtered := $svg.Filter (images.Grayscale) }}
+Width: {{ $svg.Width }}|{{ $svg.MediaType.SubType }}|
+Resized: {{ $resized.Width }}x{{ $resized.Height }}|{{ $resized.MediaType }}|
+Filled: {{ $filled.Width }}x{{ $filled.Height }}|{{ $filled.MediaType }}|
+Filtered: {{ $filtered.Width }}|{{ $filtered.MediaType }}|
+{{ with images.Config "assets/circle.svg" }}Config: {{ .Width }}x{{ .Height }}|{{ end }}
+Content: {{ $svg.Content | safeHTML }}
 `
 
-	b, err := hugolib.NewIntegrationTestBuilder(
+	b := hugolib.NewIntegrationTestBuilder(
 		hugolib.IntegrationTestConfig{
 			T:           t,
 			TxtarString: files,
 			NeedsOsFS:   true,
-			Running:     true,
+		}).Build()
+
+	b.AssertFileContent("public/index.html", `
+Width: 100|svg|
+Resized: 200x200|image/png|
+Filled: 50x20|image/jpeg|
+Filtered: 100|image/png|
+Config: 100x100|
+Content: <svg height="100" width="100">
+`)
+
+	_, err := hugolib.NewIntegrationTestBuilder(
+		hugolib.IntegrationTestConfig{
+			T:           t,
+			TxtarString: strings.Replace(files, `"200x"`, `"200x svg"`, 1),
+			NeedsOsFS:   true,
 		}).BuildE()
 
 	b.Assert(err, qt.IsNotNil)
-	b.Assert(err.Error(), qt.Contains, `error calling Width: this method is only available for raster images. To determine if an image is SVG, you can do {{ if eq .MediaType.SubType "svg" }}{{ end }}`)
+	b.Assert(err.Error(), qt.Contains, `SVG is not supported as a target format`)
+}
+
+func TestImageFiltersPaddingMaskDither(t *testing.T) {
+	t.Parallel()
+
+	files := `
+-- config.toml --
+-- assets/pixel.png --
+iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNkYPhfDwAChwGA60e6kgAAAABJRU5ErkJggg==
+-- assets/circle.svg --
+<svg height="100" width="100"><circle cx="50" cy="50" r="40" fill="white" /></svg>
+-- layouts/index.html --
+{{ $img := (resources.Get "pixel.png").Resize "20x20" }}
+{{ $mask := resources.Get "circle.svg" }}
+{{ $padded := $img.Filter (images.Padding 10 5 "#00f") }}
+{{ $rounded := $img.Filter (images.RoundedCorners "50%") (images.AutoOrient) }}
+{{ $masked := $img | images.Filter (images.Mask $mask) }}
+{{ $dithered := $padded.Filter (images.Dither (dict "colors" (slice "#000" "#fff" "#00f"))) }}
+Padded: {{ $padded.Width }}x{{ $padded.Height }}|
+Rounded: {{ $rounded.Width }}x{{ $rounded.Height }}|
+Masked: {{ $masked.Width }}x{{ $masked.Height }}|{{ $masked.MediaType }}|
+Dithered: {{ $dithered.Width }}x{{ $dithered.Height }}|
+`
+
+	b := hugolib.NewIntegrationTestBuilder(
+		hugolib.IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+			NeedsOsFS:   true,
+		}).Build()
+
+	b.AssertFileContent("public/index.html", `
+Padded: 30x40|
+Rounded: 20x20|
+Masked: 20x20|image/png|
+Dithered: 30x40|
+`)
+}
+
+func TestImageFilterAutoOrientTwice(t *testing.T) {
+	t.Parallel()
+
+	files := `
+-- config.toml --
+-- assets/rotated.jpg --
+/9j/4QAiRXhpZgAATU0AKgAAAAgAAQESAAMAAAABAAYAAAAAAAD/2wCEAAMCAgMCAgMDAwMEAwMEBQgFBQQEBQoHBwYIDAoMDAsKCwsNDhIQDQ4RDgsLEBYQERMUFRUVDA8XGBYUGBIUFRQBAwQEBQQFCQUFCRQNCw0UFBQUFBQUFBQUFBQUFBQUFBQUFBQUFBQUFBQUFBQUFBQUFBQUFBQUFBQUFBQUFBQUFP/AABEIAAIABAMBIgACEQEDEQH/xAGiAAABBQEBAQEBAQAAAAAAAAAAAQIDBAUGBwgJCgsQAAIBAwMCBAMFBQQEAAABfQECAwAEEQUSITFBBhNRYQcicRQygZGhCCNCscEVUtHwJDNicoIJChYXGBkaJSYnKCkqNDU2Nzg5OkNERUZHSElKU1RVVldYWVpjZGVmZ2hpanN0dXZ3eHl6g4SFhoeIiYqSk5SVlpeYmZqio6Slpqeoqaqys7S1tre4ubrCw8TFxsfIycrS09TV1tfY2drh4uPk5ebn6Onq8fLz9PX29/j5+gEAAwEBAQEBAQEBAQAAAAAAAAECAwQFBgcICQoLEQACAQIEBAMEBwUEBAABAncAAQIDEQQFITEGEkFRB2FxEyIygQgUQpGhscEJIzNS8BVictEKFiQ04SXxFxgZGiYnKCkqNTY3ODk6Q0RFRkdISUpTVFVWV1hZWmNkZWZnaGlqc3R1dnd4eXqCg4SFhoeIiYqSk5SVlpeYmZqio6Slpqeoqaqys7S1tre4ubrCw8TFxsfIycrS09TV1tfY2dri4+Tl5ufo6ery8/T19vf4+fr/2gAMAwEAAhEDEQA/APOfh74O0D/hF7X/AIkmm/8AgJH6D2rpP+EO0D/oCab/AOAkf+FUvh7/AMiva/57Cukr+VcVXq+3n773fVn6rkOJr/2VhvffwR6vsf/Z
+-- layouts/index.html --
+{{ $img := resources.Get "rotated.jpg" }}
+{{ $oriented := $img.Filter images.AutoOrient }}
+{{ $twice := $oriented.Filter images.AutoOrient }}
+{{ $resized := ($oriented.Resize "8x").Filter images.AutoOrient }}
+Original: {{ $img.Width }}x{{ $img.Height }}|
+Oriented: {{ $oriented.Width }}x{{ $oriented.Height }}|
+Twice: {{ $twice.Width }}x{{ $twice.Height }}|{{ eq $twice.RelPermalink $oriented.RelPermalink }}|
+Resized: {{ $resized.Width }}x{{ $resized.Height }}|
+`
+
+	b := hugolib.NewIntegrationTestBuilder(
+		hugolib.IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+			NeedsOsFS:   true,
+		}).Build()
 
+	b.AssertFileContent("public/index.html", `
+Original: 4x2|
+Oriented: 2x4|
+Twice: 2x4|true|
+Resized: 8x16|
+`)
 }
@@ -20,7 +20,6 @@ import (
 
 	"github.com/gohugoio/hugo/identity"
 
-	"github.com/bep/gitmap"
 	"github.com/gohugoio/hugo/config"
 	"github.com/gohugoio/hugo/tpl"
 
@@ -125,7 +124,7 @@ type GetPageProvider interface {
 
 // GitInfoProvider provides Git info.
 type GitInfoProvider interface {
-	GitInfo() *gitmap.GitInfo
+	GitInfo() *source.GitInfo
 	CodeOwners() []string
 }
 
@@ -277,6 +276,8 @@ type PageWithoutContent interface {
 	resource.TranslationKeyProvider
 	TranslationsProvider
 
+	VersionsProvider
+
 	SitesProvider
 
 	// Helper methods
@@ -373,6 +374,16 @@ type TranslationsProvider interface {
 	Translations() Pages
 }
 
+// VersionsProvider provides access to the same content in other versions.
+type VersionsProvider interface {
+	// AllVersions returns this Page in all versions, including the current Page,
+	// sorted by version weight.
+	AllVersions() Pages
+
+	// Versions returns this Page in the other versions, sorted by version weight.
+	Versions() Pages
+}
+
 // TreeProvider provides section tree navigation.
 type TreeProvider interface {
 
@@ -17,7 +17,6 @@ package page
 
 import (
 	"encoding/json"
-	"github.com/bep/gitmap"
 	"github.com/gohugoio/hugo/common/maps"
 	"github.com/gohugoio/hugo/config"
 	"github.com/gohugoio/hugo/hugofs/files"
@@ -89,6 +88,8 @@ func MarshalPageToJSON(p Page) ([]byte, error) {
 	isTranslated := p.IsTranslated()
 	allTranslations := p.AllTranslations()
 	translations := p.Translations()
+	allVersions := p.AllVersions()
+	versions := p.Versions()
 	getIdentity := p.GetIdentity()
 
 	s := struct {
@@ -139,7 +140,7 @@ func MarshalPageToJSON(p Page) ([]byte, error) {
 		Weight                   int
 		Language                 *langs.Language
 		File                     source.File
-		GitInfo                  *gitmap.GitInfo
+		GitInfo                  *source.GitInfo
 		OutputFormats            OutputFormats
 		AlternativeOutputFormats OutputFormats
 		Menus                    navigation.PageMenus
@@ -147,6 +148,8 @@ func MarshalPageToJSON(p Page) ([]byte, error) {
 		IsTranslated             bool
 		AllTranslations          Pages
 		Translations             Pages
+		AllVersions              Pages
+		Versions                 Pages
 		GetIdentity              identity.Identity
 	}{
 		Content:                  content,
@@ -204,6 +207,8 @@ func MarshalPageToJSON(p Page) ([]byte, error) {
 		IsTranslated:             isTranslated,
 		AllTranslations:          allTranslations,
 		Translations:             translations,
+		AllVersions:              allVersions,
+		Versions:                 versions,
 		GetIdentity:              getIdentity,
 	}
 
@@ -26,7 +26,6 @@ import (
 
 	"github.com/gohugoio/hugo/hugofs"
 
-	"github.com/bep/gitmap"
 	"github.com/gohugoio/hugo/navigation"
 
 	"github.com/gohugoio/hugo/common/hugo"
@@ -190,7 +189,7 @@ func (p *nopPage) GetTerms(taxonomy string) Pages {
 	return nil
 }
 
-func (p *nopPage) GitInfo() *gitmap.GitInfo {
+func (p *nopPage) GitInfo() *source.GitInfo {
 	return nil
 }
 
@@ -482,6 +481,14 @@ func (p *nopPage) TranslationKey() string {
 	return ""
 }
 
+func (p *nopPage) AllVersions() Pages {
+	return nil
+}
+
+func (p *nopPage) Versions() Pages {
+	return nil
+}
+
 func (p *nopPage) Translations() Pages {
 	return nil
 }
@@ -14,6 +14,7 @@
 package pagemeta
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -41,6 +42,9 @@ type FrontMatterHandler struct {
 	// A map of all date keys configured, including any custom.
 	allDateKeys map[string]bool
 
+	// A map of all the date identifiers configured, e.g. ":git".
+	allIdentifiers map[string]bool
+
 	logger loggers.Logger
 }
 
@@ -61,6 +65,15 @@ type FrontMatterDescriptor struct {
 	// May be set from the author date in Git.
 	GitAuthorDate time.Time
 
+	// May be set from the committer date in Git.
+	GitCommitDate time.Time
+
+	// May be set from the author date of the first commit of the file in Git.
+	GitFirstAuthorDate time.Time
+
+	// The dates from the sidecar file, if any, with lower case keys.
+	Sidecar map[string]any
+
 	// The below are pointers to values on Page and will be modified.
 
 	// This is the Page's params.
@@ -114,6 +127,12 @@ func (f FrontMatterHandler) HandleDates(d *FrontMatterDescriptor) error {
 	return nil
 }
 
+// UsesSidecar returns whether any of the dates are configured to be read
+// from a sidecar file.
+func (f FrontMatterHandler) UsesSidecar() bool {
+	return f.allIdentifiers[fmSidecar]
+}
+
 // IsDateKey returns whether the given front matter key is considered a date by the current
 // configuration.
 func (f FrontMatterHandler) IsDateKey(key string) bool {
@@ -182,8 +201,23 @@ const (
 
 	// Gets date from Git
 	fmGitAuthorDate = ":git"
+
+	// Gets date from the committer date in Git.
+	fmGitCommitterDate = ":gitcommitterdate"
+
+	// Gets date from the author date of the first commit of the file in Git,
+	// i.e. when it was created.
+	fmGitFirstAuthorDate = ":gitfirstauthordate"
+
+	// Gets date from the same key in a sidecar file next to the content
+	// file, e.g. mypage.dates.toml.
+	fmSidecar = ":sidecar"
 )
 
+// SidecarSuffix is the suffix of the sidecar files holding the dates of a
+// content file, e.g. mypage.dates.toml for mypage.md.
+const SidecarSuffix = ".dates"
+
 // This is the config you get when doing nothing.
 func newDefaultFrontmatterConfig() frontmatterConfig {
 	return frontmatterConfig{
@@ -275,9 +309,12 @@ func NewFrontmatterHandler(logger loggers.Logger, cfg config.Provider) (FrontMat
 	}
 
 	allDateKeys := make(map[string]bool)
+	allIdentifiers := make(map[string]bool)
 	addKeys := func(vals []string) {
 		for _, k := range vals {
-			if !strings.HasPrefix(k, ":") {
+			if strings.HasPrefix(k, ":") {
+				allIdentifiers[k] = true
+			} else {
 				allDateKeys[k] = true
 			}
 		}
@@ -288,7 +325,7 @@ func NewFrontmatterHandler(logger loggers.Logger, cfg config.Provider) (FrontMat
 	addKeys(frontMatterConfig.lastmod)
 	addKeys(frontMatterConfig.publishDate)
 
-	f := FrontMatterHandler{logger: logger, fmConfig: frontMatterConfig, allDateKeys: allDateKeys}
+	f := FrontMatterHandler{logger: logger, fmConfig: frontMatterConfig, allDateKeys: allDateKeys, allIdentifiers: allIdentifiers}
 
 	if err := f.createHandlers(); err != nil {
 		return f, err
@@ -300,7 +337,7 @@ func NewFrontmatterHandler(logger loggers.Logger, cfg config.Provider) (FrontMat
 func (f *FrontMatterHandler) createHandlers() error {
 	var err error
 
-	if f.dateHandler, err = f.createDateHandler(f.fmConfig.date,
+	if f.dateHandler, err = f.createDateHandler(fmDate, f.fmConfig.date,
 		func(d *FrontMatterDescriptor, t time.Time) {
 			d.Dates.FDate = t
 			setParamIfNotSet(fmDate, t, d)
@@ -308,7 +345,7 @@ func (f *FrontMatterHandler) createHandlers() error {
 		return err
 	}
 
-	if f.lastModHandler, err = f.createDateHandler(f.fmConfig.lastmod,
+	if f.lastModHandler, err = f.createDateHandler(fmLastmod, f.fmConfig.lastmod,
 		func(d *FrontMatterDescriptor, t time.Time) {
 			setParamIfNotSet(fmLastmod, t, d)
 			d.Dates.FLastmod = t
@@ -316,7 +353,7 @@ func (f *FrontMatterHandler) createHandlers() error {
 		return err
 	}
 
-	if f.publishDateHandler, err = f.createDateHandler(f.fmConfig.publishDate,
+	if f.publishDateHandler, err = f.createDateHandler(fmPubDate, f.fmConfig.publishDate,
 		func(d *FrontMatterDescriptor, t time.Time) {
 			setParamIfNotSet(fmPubDate, t, d)
 			d.Dates.FPublishDate = t
@@ -324,7 +361,7 @@ func (f *FrontMatterHandler) createHandlers() error {
 		return err
 	}
 
-	if f.expiryDateHandler, err = f.createDateHandler(f.fmConfig.expiryDate,
+	if f.expiryDateHandler, err = f.createDateHandler(fmExpiryDate, f.fmConfig.expiryDate,
 		func(d *FrontMatterDescriptor, t time.Time) {
 			setParamIfNotSet(fmExpiryDate, t, d)
 			d.Dates.FExpiryDate = t
@@ -342,7 +379,7 @@ func setParamIfNotSet(key string, value any, d *FrontMatterDescriptor) {
 	d.Params[key] = value
 }
 
-func (f FrontMatterHandler) createDateHandler(identifiers []string, setter func(d *FrontMatterDescriptor, t time.Time)) (frontMatterFieldHandler, error) {
+func (f FrontMatterHandler) createDateHandler(key string, identifiers []string, setter func(d *FrontMatterDescriptor, t time.Time)) (frontMatterFieldHandler, error) {
 	var h *frontmatterFieldHandlers
 	var handlers []frontMatterFieldHandler
 
@@ -354,6 +391,12 @@ func (f FrontMatterHandler) createDateHandler(identifiers []string, setter func(
 			handlers = append(handlers, h.newDateModTimeHandler(setter))
 		case fmGitAuthorDate:
 			handlers = append(handlers, h.newDateGitAuthorDateHandler(setter))
+		case fmGitCommitterDate:
+			handlers = append(handlers, h.newDateGitCommitterDateHandler(setter))
+		case fmGitFirstAuthorDate:
+			handlers = append(handlers, h.newDateGitFirstAuthorDateHandler(setter))
+		case fmSidecar:
+			handlers = append(handlers, h.newDateSidecarHandler(key, setter))
 		default:
 			handlers = append(handlers, h.newDateFieldHandler(identifier, setter))
 		}
@@ -425,3 +468,41 @@ func (f *frontmatterFieldHandlers) newDateGitAuthorDateHandler(setter func(d *Fr
 		return true, nil
 	}
 }
+
+func (f *frontmatterFieldHandlers) newDateGitCommitterDateHandler(setter func(d *FrontMatterDescriptor, t time.Time)) frontMatterFieldHandler {
+	return func(d *FrontMatterDescriptor) (bool, error) {
+		if d.GitCommitDate.IsZero() {
+			return false, nil
+		}
+		setter(d, d.GitCommitDate)
+		return true, nil
+	}
+}
+
+func (f *frontmatterFieldHandlers) newDateGitFirstAuthorDateHandler(setter func(d *FrontMatterDescriptor, t time.Time)) frontMatterFieldHandler {
+	return func(d *FrontMatterDescriptor) (bool, error) {
+		if d.GitFirstAuthorDate.IsZero() {
+			return false, nil
+		}
+		setter(d, d.GitFirstAuthorDate)
+		return true, nil
+	}
+}
+
+func (f *frontmatterFieldHandlers) newDateSidecarHandler(key string, setter func(d *FrontMatterDescriptor, t time.Time)) frontMatterFieldHandler {
+	return func(d *FrontMatterDescriptor) (bool, error) {
+		v, found := d.Sidecar[key]
+		if !found {
+			return false, nil
+		}
+
+		date, err := htime.ToTimeInDefaultLocationE(v, d.Location)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse %q from sidecar file: %w", key, err)
+		}
+
+		setter(d, date)
+
+		return true, nil
+	}
+}
@@ -117,7 +117,7 @@ func TestFrontMatterNewConfig(t *testing.T) {
 func TestFrontMatterDatesHandlers(t *testing.T) {
 	c := qt.New(t)
 
-	for _, handlerID := range []string{":filename", ":fileModTime", ":git"} {
+	for _, handlerID := range []string{":filename", ":fileModTime", ":git", ":gitCommitterDate", ":gitFirstAuthorDate", ":sidecar"} {
 
 		cfg := config.New()
 
@@ -139,6 +139,12 @@ func TestFrontMatterDatesHandlers(t *testing.T) {
 			d.ModTime = d1
 		case ":git":
 			d.GitAuthorDate = d1
+		case ":gitcommitterdate":
+			d.GitCommitDate = d1
+		case ":gitfirstauthordate":
+			d.GitFirstAuthorDate = d1
+		case ":sidecar":
+			d.Sidecar = map[string]any{"date": "2018-02-01"}
 		}
 		d.Frontmatter["date"] = d2
 		c.Assert(handler.HandleDates(d), qt.IsNil)
@@ -154,6 +160,35 @@ func TestFrontMatterDatesHandlers(t *testing.T) {
 	}
 }
 
+func TestFrontMatterDatesSidecarLocation(t *testing.T) {
+	c := qt.New(t)
+
+	cfg := config.New()
+	cfg.Set("frontmatter", map[string]any{
+		"date":    []string{":sidecar", ":default"},
+		"lastmod": []string{":sidecar", ":gitFirstAuthorDate"},
+	})
+
+	handler, err := NewFrontmatterHandler(nil, cfg)
+	c.Assert(err, qt.IsNil)
+	c.Assert(handler.UsesSidecar(), qt.IsTrue)
+
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	d1, _ := time.Parse("2006-01-02", "2018-02-01")
+
+	d := newTestFd()
+	d.Location = loc
+	d.Sidecar = map[string]any{"date": "2018-02-02T10:00:00"}
+	d.GitFirstAuthorDate = d1
+	c.Assert(handler.HandleDates(d), qt.IsNil)
+	c.Assert(d.Dates.FDate, qt.Equals, time.Date(2018, 2, 2, 10, 0, 0, 0, loc))
+	c.Assert(d.Dates.FLastmod, qt.Equals, d1)
+
+	handler, err = NewFrontmatterHandler(nil, config.New())
+	c.Assert(err, qt.IsNil)
+	c.Assert(handler.UsesSidecar(), qt.IsFalse)
+}
+
 func TestFrontMatterDatesCustomConfig(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,303 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagemeta
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gohugoio/hugo/common/htime"
+	"github.com/gohugoio/hugo/common/maps"
+	"github.com/spf13/cast"
+)
+
+// The field types supported in a front matter Schema.
+const (
+	SchemaTypeString = "string"
+	SchemaTypeInt    = "int"
+	SchemaTypeFloat  = "float"
+	SchemaTypeBool   = "bool"
+	SchemaTypeDate   = "date"
+	SchemaTypeSlice  = "slice"
+	SchemaTypeMap    = "map"
+)
+
+var schemaTypes = map[string]bool{
+	SchemaTypeString: true,
+	SchemaTypeInt:    true,
+	SchemaTypeFloat:  true,
+	SchemaTypeBool:   true,
+	SchemaTypeDate:   true,
+	SchemaTypeSlice:  true,
+	SchemaTypeMap:    true,
+}
+
+// Schema describes the front matter of a content type.
+type Schema struct {
+	// If set, front matter keys not defined in Fields are reported as errors.
+	Strict bool
+
+	// The fields keyed by their lower case front matter key.
+	Fields map[string]*SchemaField
+}
+
+// SchemaField describes a front matter field.
+type SchemaField struct {
+	// One of string, int, float, bool, date, slice (of strings) or map.
+	// If not set, any value is accepted.
+	Type string
+
+	// Whether the field must be set.
+	Required bool
+
+	// The value to use if the field is not set.
+	Default any
+
+	// If set, the value must be one of these.
+	Values []string
+}
+
+// SchemaError is an error for a given front matter field.
+type SchemaError struct {
+	// The lower case front matter key.
+	Key string
+
+	Err error
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("front matter field %q: %s", e.Key, e.Err)
+}
+
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeSchema creates a new Schema from m.
+func DecodeSchema(m map[string]any) (*Schema, error) {
+	s := &Schema{
+		Fields: make(map[string]*SchemaField),
+	}
+
+	for k, v := range m {
+		switch strings.ToLower(k) {
+		case "strict":
+			s.Strict = cast.ToBool(v)
+		case "fields":
+			fields, err := maps.ToStringMapE(v)
+			if err != nil {
+				return nil, fmt.Errorf("fields: %w", err)
+			}
+			for name, fv := range fields {
+				fm, err := maps.ToStringMapE(fv)
+				if err != nil {
+					return nil, fmt.Errorf("field %q: %w", name, err)
+				}
+				f, err := decodeSchemaField(fm)
+				if err != nil {
+					return nil, fmt.Errorf("field %q: %w", name, err)
+				}
+				s.Fields[strings.ToLower(name)] = f
+			}
+		default:
+			return nil, fmt.Errorf("unknown schema setting %q", k)
+		}
+	}
+
+	return s, nil
+}
+
+func decodeSchemaField(m map[string]any) (*SchemaField, error) {
+	f := &SchemaField{}
+
+	for k, v := range m {
+		switch strings.ToLower(k) {
+		case "type":
+			f.Type = strings.ToLower(cast.ToString(v))
+			if !schemaTypes[f.Type] {
+				return nil, fmt.Errorf("unknown type %q", f.Type)
+			}
+		case "required":
+			f.Required = cast.ToBool(v)
+		case "default":
+			f.Default = v
+		case "values":
+			f.Values = cast.ToStringSlice(v)
+		default:
+			return nil, fmt.Errorf("unknown setting %q", k)
+		}
+	}
+
+	if f.Default != nil {
+		// Make sure the default value is valid.
+		v, err := f.coerce(f.Default, time.UTC)
+		if err != nil {
+			return nil, fmt.Errorf("default: %w", err)
+		}
+		if err := f.checkValues(v); err != nil {
+			return nil, fmt.Errorf("default: %w", err)
+		}
+	}
+
+	return f, nil
+}
+
+// Apply validates frontmatter against the schema, converting the values to
+// the field types and setting any default values in frontmatter.
+// Dates without a time zone are parsed in loc.
+// The keys in frontmatter are expected to be lower case.
+func (s *Schema) Apply(frontmatter map[string]any, loc *time.Location) error {
+	keys := make([]string, 0, len(s.Fields))
+	for k := range s.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		f := s.Fields[k]
+		v, found := frontmatter[k]
+		if !found || v == nil {
+			if f.Default != nil {
+				v, _ = f.coerce(f.Default, loc)
+				frontmatter[k] = v
+				continue
+			}
+			if f.Required {
+				return &SchemaError{Key: k, Err: errors.New("required field is missing")}
+			}
+			continue
+		}
+
+		vv, err := f.coerce(v, loc)
+		if err != nil {
+			return &SchemaError{Key: k, Err: err}
+		}
+		if err := f.checkValues(vv); err != nil {
+			return &SchemaError{Key: k, Err: err}
+		}
+		frontmatter[k] = vv
+	}
+
+	return nil
+}
+
+// CheckUnknownFields returns an error for the first key in frontmatter not
+// defined in the schema if Strict is set.
+// The keys in frontmatter are expected to be lower case.
+func (s *Schema) CheckUnknownFields(frontmatter map[string]any) error {
+	if !s.Strict {
+		return nil
+	}
+
+	var unknown []string
+	for k := range frontmatter {
+		if _, found := s.Fields[k]; !found {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return &SchemaError{Key: unknown[0], Err: errors.New("field is not defined in the schema")}
+	}
+
+	return nil
+}
+
+func (f *SchemaField) coerce(v any, loc *time.Location) (any, error) {
+	var (
+		vv  any
+		err error
+	)
+
+	switch f.Type {
+	case "":
+		return v, nil
+	case SchemaTypeString:
+		switch v.(type) {
+		case []any, []string, map[string]any, maps.Params:
+			return nil, fmt.Errorf("expected a string, got %T", v)
+		}
+		vv, err = cast.ToStringE(v)
+	case SchemaTypeInt:
+		vv, err = cast.ToIntE(v)
+	case SchemaTypeFloat:
+		vv, err = cast.ToFloat64E(v)
+	case SchemaTypeBool:
+		vv, err = cast.ToBoolE(v)
+	case SchemaTypeDate:
+		vv, err = htime.ToTimeInDefaultLocationE(v, loc)
+	case SchemaTypeSlice:
+		if s, ok := v.(string); ok {
+			// Comma separated values, e.g. "a, b".
+			var ss []string
+			for _, part := range strings.Split(s, ",") {
+				if part = strings.TrimSpace(part); part != "" {
+					ss = append(ss, part)
+				}
+			}
+			return ss, nil
+		}
+		switch v.(type) {
+		case []any, []string:
+			vv, err = cast.ToStringSliceE(v)
+		default:
+			return nil, fmt.Errorf("expected a slice, got %T", v)
+		}
+	case SchemaTypeMap:
+		switch v.(type) {
+		case map[string]any, maps.Params:
+			return v, nil
+		default:
+			return nil, fmt.Errorf("expected a map, got %T", v)
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("expected a %s: %w", f.Type, err)
+	}
+
+	return vv, nil
+}
+
+func (f *SchemaField) checkValues(v any) error {
+	if len(f.Values) == 0 {
+		return nil
+	}
+
+	var values []string
+	switch vv := v.(type) {
+	case []string:
+		values = vv
+	default:
+		values = []string{cast.ToString(v)}
+	}
+
+	for _, value := range values {
+		found := false
+		for _, allowed := range f.Values {
+			if value == allowed {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%q is not one of %s", value, strings.Join(f.Values, ", "))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagemeta
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+	c := qt.New(t)
+
+	s, err := DecodeSchema(map[string]any{
+		"strict": true,
+		"fields": map[string]any{
+			"title":  map[string]any{"type": "string", "required": true},
+			"date":   map[string]any{"type": "date"},
+			"tags":   map[string]any{"type": "slice", "values": []any{"a", "b"}},
+			"weight": map[string]any{"type": "int", "default": "10"},
+		},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(s.Strict, qt.IsTrue)
+
+	fm := map[string]any{
+		"title": "T",
+		"date":  "2022-03-01",
+		"tags":  "a, b",
+	}
+	c.Assert(s.CheckUnknownFields(fm), qt.IsNil)
+	c.Assert(s.Apply(fm, time.UTC), qt.IsNil)
+	c.Assert(fm["date"], qt.Equals, time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(fm["tags"], qt.DeepEquals, []string{"a", "b"})
+	c.Assert(fm["weight"], qt.Equals, 10)
+
+	var serr *SchemaError
+
+	err = s.Apply(map[string]any{"date": "2022-03-01"}, time.UTC)
+	c.Assert(errors.As(err, &serr), qt.IsTrue)
+	c.Assert(serr.Key, qt.Equals, "title")
+
+	err = s.Apply(map[string]any{"title": "T", "tags": []any{"a", "c"}}, time.UTC)
+	c.Assert(err, qt.ErrorMatches, `front matter field "tags": "c" is not one of a, b`)
+
+	err = s.CheckUnknownFields(map[string]any{"title": "T", "foo": "bar"})
+	c.Assert(errors.As(err, &serr), qt.IsTrue)
+	c.Assert(serr.Key, qt.Equals, "foo")
+
+	_, err = DecodeSchema(map[string]any{"fields": map[string]any{"a": map[string]any{"type": "foo"}}})
+	c.Assert(err, qt.ErrorMatches, `field "a": unknown type "foo"`)
+
+	_, err = DecodeSchema(map[string]any{"fields": map[string]any{"a": map[string]any{"type": "int", "default": "x"}}})
+	c.Assert(err, qt.IsNotNil)
+}
@@ -32,6 +32,9 @@ type Site interface {
 	// Returns the Language configured for this Site.
 	Language() *langs.Language
 
+	// Returns the content Version configured for this Site, nil if none.
+	Version() *langs.Version
+
 	// Returns all the regular Pages in this Site.
 	RegularPages() Pages
 
@@ -78,7 +81,7 @@ type Site interface {
 	Data() map[string]any
 }
 
-// Sites represents an ordered list of sites (languages).
+// Sites represents an ordered list of sites (languages and versions).
 type Sites []Site
 
 // First is a convenience method to get the first Site, i.e. the main language.
@@ -126,6 +129,10 @@ func (t testSite) Language() *langs.Language {
 	return t.l
 }
 
+func (t testSite) Version() *langs.Version {
+	return nil
+}
+
 func (t testSite) Home() Page {
 	return nil
 }
@@ -26,7 +26,6 @@ import (
 
 	"github.com/gohugoio/hugo/modules"
 
-	"github.com/bep/gitmap"
 	"github.com/gohugoio/hugo/helpers"
 	"github.com/gohugoio/hugo/resources/resource"
 
@@ -250,7 +249,7 @@ func (p *testPage) GetRelatedDocsHandler() *RelatedDocsHandler {
 	return relatedDocsHandler
 }
 
-func (p *testPage) GitInfo() *gitmap.GitInfo {
+func (p *testPage) GitInfo() *source.GitInfo {
 	return nil
 }
 
@@ -569,6 +568,14 @@ func (p *testPage) TranslationKey() string {
 	return p.path
 }
 
+func (p *testPage) AllVersions() Pages {
+	panic("not implemented")
+}
+
+func (p *testPage) Versions() Pages {
+	panic("not implemented")
+}
+
 func (p *testPage) Translations() Pages {
 	panic("not implemented")
 }
@@ -17,6 +17,7 @@ package create
 
 import (
 	"net/http"
+	"os"
 	"path"
 	"path/filepath"
 	"strings"
@@ -42,10 +43,15 @@ type Client struct {
 
 // New creates a new Client with the given specification.
 func New(rs *resources.Spec) *Client {
+	timeout := 10 * time.Second
+	if rs.ExecHelper != nil {
+		timeout = rs.ExecHelper.Sec().HTTPTimeout(timeout)
+	}
+
 	return &Client{
 		rs: rs,
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout: timeout,
 		},
 		cacheGetResource: rs.FileCaches.GetResourceCache(),
 	}
@@ -59,14 +65,46 @@ func (c *Client) Copy(r resource.Resource, targetPath string) (resource.Resource
 }
 
 // Get creates a new Resource by opening the given filename in the assets filesystem.
+// Files not found in the asset mounts are read relative to the working dir,
+// if allowed by an explicitly set security.files.read policy.
 func (c *Client) Get(filename string) (resource.Resource, error) {
 	filename = filepath.Clean(filename)
 	return c.rs.ResourceCache.GetOrCreate(resources.ResourceCacheKey(filename), func() (resource.Resource, error) {
-		return c.rs.New(resources.ResourceSourceDescriptor{
+		r, err := c.rs.New(resources.ResourceSourceDescriptor{
 			Fs:             c.rs.BaseFs.Assets.Fs,
 			LazyPublish:    true,
 			SourceFilename: filename,
 		})
+		if err != nil || r != nil {
+			return r, err
+		}
+
+		if c.rs.ExecHelper == nil || !c.rs.ExecHelper.Sec().HasFilesReadPolicy() {
+			return nil, nil
+		}
+		workFs := c.rs.BaseFs.SourceFs
+		if workFs == nil {
+			return nil, nil
+		}
+		fi, err := workFs.Stat(filename)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if fi.IsDir() {
+			return nil, nil
+		}
+		if err := c.rs.ExecHelper.Sec().CheckAllowedFileRead(filename); err != nil {
+			return nil, err
+		}
+
+		return c.rs.New(resources.ResourceSourceDescriptor{
+			Fs:             workFs,
+			LazyPublish:    true,
+			SourceFilename: filename,
+		})
 	})
 }
 
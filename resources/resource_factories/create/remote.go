@@ -30,6 +30,7 @@ import (
 	"github.com/gohugoio/hugo/common/hugio"
 	"github.com/gohugoio/hugo/common/maps"
 	"github.com/gohugoio/hugo/common/types"
+	"github.com/gohugoio/hugo/config/security"
 	"github.com/gohugoio/hugo/helpers"
 	"github.com/gohugoio/hugo/media"
 	"github.com/gohugoio/hugo/resources"
@@ -103,12 +104,16 @@ func (c *Client) FromRemote(uri string, optionsm map[string]any) (resource.Resou
 
 		res, err := c.httpClient.Do(req)
 		if err != nil {
-			return nil, err
+			return nil, c.rs.ExecHelper.Sec().CheckHTTPError(uri, err)
 		}
+		res.Body = c.rs.ExecHelper.Sec().LimitHTTPBody(uri, res.Body)
 
 		httpResponse, err := httputil.DumpResponse(res, true)
 		if err != nil {
-			return nil, toHTTPError(err, res)
+			if security.IsAccessDenied(err) {
+				return nil, err
+			}
+			return nil, toHTTPError(c.rs.ExecHelper.Sec().CheckHTTPError(uri, err), res)
 		}
 
 		if res.StatusCode != http.StatusNotFound {
@@ -145,6 +145,11 @@ func (r *Spec) New(fd ResourceSourceDescriptor) (resource.Resource, error) {
 	return r.newResourceFor(fd)
 }
 
+// Imaging returns the image processor configured from the site's imaging config.
+func (r *Spec) Imaging() *images.ImageProcessor {
+	return r.imaging
+}
+
 func (r *Spec) CacheStats() string {
 	r.imageCache.mu.RLock()
 	defer r.imageCache.mu.RUnlock()
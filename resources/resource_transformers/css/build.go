@@ -0,0 +1,212 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package css
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/evanw/esbuild/pkg/api"
+
+	"github.com/gohugoio/hugo/hugofs"
+	"github.com/gohugoio/hugo/hugolib/filesystems"
+	"github.com/gohugoio/hugo/media"
+	"github.com/gohugoio/hugo/resources"
+	"github.com/gohugoio/hugo/resources/internal"
+	"github.com/gohugoio/hugo/resources/resource"
+	"github.com/gohugoio/hugo/resources/resource_transformers/internal/esbuild"
+)
+
+// Client context for building CSS with ESBuild.
+type Client struct {
+	rs  *resources.Spec
+	sfs *filesystems.SourceFilesystem
+}
+
+// New creates a new client context.
+func New(fs *filesystems.SourceFilesystem, rs *resources.Spec) *Client {
+	return &Client{
+		rs:  rs,
+		sfs: fs,
+	}
+}
+
+type buildTransformation struct {
+	optsm map[string]any
+	c     *Client
+}
+
+func (t *buildTransformation) Key() internal.ResourceTransformationKey {
+	return internal.NewResourceTransformationKey("cssbuild", t.optsm)
+}
+
+func (t *buildTransformation) Transform(ctx *resources.ResourceTransformationCtx) error {
+	ctx.OutMediaType = media.CSSType
+
+	opts, err := decodeOptions(t.optsm)
+	if err != nil {
+		return err
+	}
+
+	if opts.TargetPath != "" {
+		ctx.OutPath = opts.TargetPath
+	} else {
+		ctx.ReplaceOutPathExtension(".css")
+	}
+
+	src, err := ioutil.ReadAll(ctx.From)
+	if err != nil {
+		return err
+	}
+
+	opts.sourceDir = filepath.FromSlash(path.Dir(ctx.SourcePath))
+	opts.resolveDir = t.c.rs.WorkingDir // where node_modules gets resolved
+	opts.contents = string(src)
+
+	buildOptions, err := toBuildOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	buildOptions.Plugins = []api.Plugin{createImportResolverPlugin(t.c, opts)}
+
+	if buildOptions.Sourcemap == api.SourceMapExternal && buildOptions.Outdir == "" {
+		buildOptions.Outdir, err = ioutil.TempDir(os.TempDir(), "compileOutput")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(buildOptions.Outdir)
+	}
+
+	result := api.Build(buildOptions)
+
+	if len(result.Errors) > 0 {
+		return t.c.toBuildError(result.Errors, ctx.SourcePath)
+	}
+
+	if buildOptions.Sourcemap == api.SourceMapExternal {
+		content := string(result.OutputFiles[1].Contents)
+		symPath := path.Base(ctx.OutPath) + ".map"
+		// Make sure the source map is referenced by its published name.
+		re := regexp.MustCompile(`/\*# sourceMappingURL=.*\*/\n?`)
+		content = re.ReplaceAllString(content, "")
+		content = strings.TrimRight(content, "\n") + "\n/*# sourceMappingURL=" + symPath + " */\n"
+
+		if err = ctx.PublishSourceMap(string(result.OutputFiles[0].Contents)); err != nil {
+			return err
+		}
+		_, err := ctx.To.Write([]byte(content))
+		return err
+	}
+
+	_, err = ctx.To.Write(result.OutputFiles[0].Contents)
+	return err
+}
+
+// createImportResolverPlugin creates a plugin that resolves @import rules in
+// Hugo's union /assets filesystem, with module mounts.
+// Imports starting with a "." are resolved relative to the importing file,
+// others relative to /assets, before falling back to ESBuild's resolver,
+// e.g. for imports from node_modules.
+func createImportResolverPlugin(c *Client, opts Options) api.Plugin {
+	fs := c.rs.Assets
+
+	resolveImport := func(args api.OnResolveArgs) (api.OnResolveResult, error) {
+		if args.Kind == api.ResolveCSSURLToken || isRemoteURL(args.Path) {
+			// Leave remote imports and url() references to images, fonts
+			// etc. as is.
+			return api.OnResolveResult{Path: args.Path, External: true}, nil
+		}
+
+		impPath := args.Path
+		var relDir string
+		if args.Importer != stdinImporter {
+			rel, found := fs.MakePathRelative(args.Importer)
+			if !found {
+				// Not in any of the /assets folders.
+				return api.OnResolveResult{}, nil
+			}
+			relDir = filepath.Dir(rel)
+		} else {
+			relDir = opts.sourceDir
+		}
+
+		if strings.HasPrefix(impPath, ".") {
+			impPath = filepath.Join(relDir, impPath)
+		}
+		impPath = filepath.FromSlash(strings.TrimPrefix(impPath, "/"))
+
+		for _, candidate := range []string{impPath, impPath + ".css"} {
+			fi, err := fs.Fs.Stat(candidate)
+			if err != nil || fi.IsDir() {
+				continue
+			}
+			m := fi.(hugofs.FileMetaInfo).Meta()
+			return api.OnResolveResult{Path: m.Filename, Namespace: nsImportHugo}, nil
+		}
+
+		// Fall back to ESBuild's resolve.
+		return api.OnResolveResult{}, nil
+	}
+
+	return api.Plugin{
+		Name: "hugo-css-import-resolver",
+		Setup: func(build api.PluginBuild) {
+			build.OnResolve(api.OnResolveOptions{Filter: `.*`},
+				func(args api.OnResolveArgs) (api.OnResolveResult, error) {
+					return resolveImport(args)
+				})
+			build.OnLoad(api.OnLoadOptions{Filter: `.*`, Namespace: nsImportHugo},
+				func(args api.OnLoadArgs) (api.OnLoadResult, error) {
+					b, err := ioutil.ReadFile(args.Path)
+					if err != nil {
+						return api.OnLoadResult{}, fmt.Errorf("failed to read %q: %w", args.Path, err)
+					}
+					loader := api.LoaderText
+					if isCSSFile(args.Path) {
+						loader = api.LoaderCSS
+					}
+					contents := string(b)
+					return api.OnLoadResult{
+						ResolveDir: opts.resolveDir,
+						Contents:   &contents,
+						Loader:     loader,
+					}, nil
+				})
+		},
+	}
+}
+
+// toBuildError converts the esbuild errors in msgs to file errors.
+// The first error is returned, the rest is logged.
+func (c *Client) toBuildError(msgs []api.Message, sourcePath string) error {
+	return esbuild.ToBuildError(msgs, c.sfs.Fs, func(path string) string {
+		if path == stdinImporter {
+			return sourcePath
+		}
+		return path
+	}, c.rs.Logger, "css.Build")
+}
+
+// Process builds the CSS in res with ESBuild, bundling any @import.
+func (c *Client) Process(res resources.ResourceTransformer, opts map[string]any) (resource.Resource, error) {
+	return res.Transform(
+		&buildTransformation{c: c, optsm: opts},
+	)
+}
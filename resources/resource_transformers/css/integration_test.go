@@ -0,0 +1,119 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package css_test
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/hugolib"
+)
+
+func TestBuild(t *testing.T) {
+	files := `
+-- config.toml --
+disableKinds=["page", "section", "taxonomy", "term", "sitemap", "robotsTXT"]
+[[module.mounts]]
+source = "assets"
+target = "assets"
+[[module.mounts]]
+source = "vendor/lib"
+target = "assets/lib"
+-- assets/css/main.css --
+@import "./partials/base.css";
+@import "lib/reset";
+@import "https://fonts.googleapis.com/css?family=Roboto";
+.box { user-select: none; background: url(../images/bg.png); color: #ff000080; }
+-- assets/css/partials/base.css --
+body { position: sticky; }
+-- vendor/lib/reset.css --
+* { margin: 0; }
+-- layouts/index.html --
+{{ $css := resources.Get "css/main.css" | css.Build (dict "targets" "safari12,chrome60") }}
+Default: {{ $css.RelPermalink }}|{{ $css.Content | safeHTML }}|
+{{ $min := resources.Get "css/main.css" | css.Build (dict "minify" true "targetPath" "css/min.css") }}
+Min: {{ $min.RelPermalink }}|{{ $min.Content | safeHTML }}|
+{{ $sm := resources.Get "css/main.css" | css.Build (dict "sourceMap" "external" "targetPath" "css/sm.css") }}
+SourceMap: {{ $sm.RelPermalink }}|{{ $sm.Content | safeHTML }}|
+`
+
+	b := hugolib.NewIntegrationTestBuilder(hugolib.IntegrationTestConfig{T: t, NeedsOsFS: true, TxtarString: files}).Build()
+
+	b.AssertFileContent("public/index.html",
+		"Default: /css/main.css|",
+		`@import "https://fonts.googleapis.com/css?family=Roboto";`,
+		"margin: 0;",
+		"position: -webkit-sticky;\n  position: sticky;",
+		"-webkit-user-select: none;\n  user-select: none;",
+		`background: url(../images/bg.png);`,
+		// Lowered for the targets.
+		`color: rgba(255, 0, 0, .5);`,
+		"Min: /css/min.css|",
+		`body{position:sticky}*{margin:0}.box{user-select:none;background:url(../images/bg.png);color:#ff000080}`,
+		"SourceMap: /css/sm.css|",
+		"/*# sourceMappingURL=sm.css.map */",
+	)
+	b.AssertFileContent("public/css/sm.css.map", `"version": 3`, "partials/base.css")
+}
+
+func TestBuildError(t *testing.T) {
+	c := qt.New(t)
+
+	files := `
+-- config.toml --
+disableKinds=["page", "section", "taxonomy", "term", "sitemap", "robotsTXT"]
+-- assets/css/main.css --
+@import "./base.css";
+-- assets/css/base.css --
+/* Base */
+
+@import "./nope.css";
+-- layouts/index.html --
+{{ $css := resources.Get "css/main.css" | css.Build }}
+{{ $css.Content }}
+`
+
+	b, err := hugolib.NewIntegrationTestBuilder(hugolib.IntegrationTestConfig{T: c, NeedsOsFS: true, TxtarString: files}).BuildE()
+	b.Assert(err, qt.IsNotNil)
+	b.Assert(strings.Contains(err.Error(), "base.css:3:"), qt.IsTrue, qt.Commentf(err.Error()))
+}
+
+func TestPurge(t *testing.T) {
+	files := `
+-- config.toml --
+disableKinds=["page", "section", "taxonomy", "term", "sitemap", "robotsTXT"]
+[build]
+writeStats = true
+-- assets/css/main.css --
+body { margin: 0; }
+.used { color: red; }
+.unused { color: blue; }
+.keep-me { color: green; }
+#main .used { color: black; }
+@media (max-width: 600px) { .unused { display: none; } }
+@keyframes spin { from { opacity: 0; } }
+@keyframes unused { from { opacity: 0; } }
+.spinner { animation: spin 1s; }
+-- layouts/index.html --
+{{ $css := resources.Get "css/main.css" | css.Purge (dict "safelist" (slice "^keep-") "keyframes" true) | minify | resources.PostProcess }}
+<html><head><link rel="stylesheet" href="{{ $css.RelPermalink }}"></head>
+<body><div id="main" class="used spinner"></div></body></html>
+`
+
+	b := hugolib.NewIntegrationTestBuilder(hugolib.IntegrationTestConfig{T: t, TxtarString: files}).Build()
+
+	b.AssertFileContent("public/index.html", `<link rel="stylesheet" href="/css/main.min.css">`)
+	b.AssertFileContent("public/css/main.min.css", `body{margin:0}.used{color:red}.keep-me{color:green}#main .used{color:#000}@keyframes spin{from{opacity:0}}.spinner{animation:spin 1s}`)
+}
@@ -0,0 +1,187 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package css
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/evanw/esbuild/pkg/api"
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/resources/resource_transformers/internal/esbuild"
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	nsImportHugo = esbuild.NsImportHugo
+
+	stdinImporter = "<stdin>"
+)
+
+// Options esbuild configuration for CSS.
+type Options struct {
+	// If not set, the source path will be used as the base target path.
+	// Note that the target path's extension may change if the target MIME type
+	// is different, e.g. when the source is SCSS.
+	TargetPath string
+
+	// Whether to minify to output.
+	Minify bool
+
+	// Whether to write mapfiles, one of "inline" or "external".
+	SourceMap string
+
+	// The browsers to target, e.g. ["chrome58", "firefox57", "safari11"].
+	// Comma separated lists are also supported.
+	// This is used to lower newer syntax, e.g. #RRGGBBAA colors, and to add
+	// vendor prefixes to declarations that need them.
+	// Default is no lowering and no prefixing.
+	Targets []string
+
+	// External imports, e.g. "https://fonts.googleapis.com/css?family=Roboto".
+	Externals []string
+
+	sourceDir  string
+	resolveDir string
+	contents   string
+}
+
+func decodeOptions(m map[string]any) (Options, error) {
+	var opts Options
+
+	if err := mapstructure.WeakDecode(m, &opts); err != nil {
+		return opts, err
+	}
+
+	if opts.TargetPath != "" {
+		opts.TargetPath = helpers.ToSlashTrimLeading(opts.TargetPath)
+	}
+
+	var targets []string
+	for _, t := range opts.Targets {
+		for _, tt := range strings.Split(t, ",") {
+			if tt = strings.ToLower(strings.TrimSpace(tt)); tt != "" {
+				targets = append(targets, tt)
+			}
+		}
+	}
+	opts.Targets = targets
+
+	return opts, nil
+}
+
+var engineNames = map[string]api.EngineName{
+	"chrome":  api.EngineChrome,
+	"edge":    api.EngineEdge,
+	"firefox": api.EngineFirefox,
+	"ie":      api.EngineIE,
+	"ios":     api.EngineIOS,
+	"opera":   api.EngineOpera,
+	"safari":  api.EngineSafari,
+}
+
+var targetRe = regexp.MustCompile(`^([a-z]+)(\d+(?:\.\d+){0,2})$`)
+
+// parseTargets parses browser targets on the form chrome58 or safari13.1.
+func parseTargets(targets []string) ([]api.Engine, error) {
+	var engines []api.Engine
+	for _, t := range targets {
+		m := targetRe.FindStringSubmatch(t)
+		if m == nil {
+			return nil, fmt.Errorf("invalid target %q, must be on the form browser+version, e.g. chrome58", t)
+		}
+		name, found := engineNames[m[1]]
+		if !found {
+			return nil, fmt.Errorf("invalid target %q, unsupported browser %q", t, m[1])
+		}
+		engines = append(engines, api.Engine{Name: name, Version: m[2]})
+	}
+	return engines, nil
+}
+
+// compareVersions compares the dot separated versions v1 and v2, returning
+// -1, 0 or 1.
+func compareVersions(v1, v2 string) int {
+	p1, p2 := strings.Split(v1, "."), strings.Split(v2, ".")
+	for i := 0; i < len(p1) || i < len(p2); i++ {
+		var n1, n2 int
+		if i < len(p1) {
+			n1, _ = strconv.Atoi(p1[i])
+		}
+		if i < len(p2) {
+			n2, _ = strconv.Atoi(p2[i])
+		}
+		switch {
+		case n1 < n2:
+			return -1
+		case n1 > n2:
+			return 1
+		}
+	}
+	return 0
+}
+
+func toBuildOptions(opts Options) (buildOptions api.BuildOptions, err error) {
+	engines, err := parseTargets(opts.Targets)
+	if err != nil {
+		return
+	}
+
+	var sourceMap api.SourceMap
+	switch opts.SourceMap {
+	case "inline":
+		sourceMap = api.SourceMapInline
+	case "external":
+		sourceMap = api.SourceMapExternal
+	case "":
+		sourceMap = api.SourceMapNone
+	default:
+		err = fmt.Errorf("unsupported sourcemap type: %q", opts.SourceMap)
+		return
+	}
+
+	buildOptions = api.BuildOptions{
+		Bundle: true,
+
+		Engines:   engines,
+		Sourcemap: sourceMap,
+
+		MinifyWhitespace: opts.Minify,
+		MinifySyntax:     opts.Minify,
+
+		External: opts.Externals,
+
+		// Note: We're not passing Sourcefile to ESBuild.
+		// This makes ESBuild pass `stdin` as the Importer to the import
+		// resolver, which is what we need/expect.
+		Stdin: &api.StdinOptions{
+			Contents:   opts.contents,
+			ResolveDir: opts.resolveDir,
+			Loader:     api.LoaderCSS,
+		},
+	}
+
+	return
+}
+
+func isCSSFile(filename string) bool {
+	return strings.EqualFold(filepath.Ext(filename), ".css")
+}
+
+func isRemoteURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "//")
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package css
+
+import (
+	"testing"
+
+	"github.com/evanw/esbuild/pkg/api"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// This test is added to test/warn against breaking the "stability" of the
+// cache key. It's sometimes needed to break this, but should be avoided if possible.
+func TestOptionKey(t *testing.T) {
+	c := qt.New(t)
+
+	opts := map[string]any{
+		"TargetPath": "foo",
+		"Targets":    "safari12",
+	}
+
+	key := (&buildTransformation{optsm: opts}).Key()
+
+	c.Assert(key.Value(), qt.Equals, "cssbuild_10665366131422491184")
+}
+
+func TestDecodeOptions(t *testing.T) {
+	c := qt.New(t)
+
+	opts, err := decodeOptions(map[string]any{
+		"targetPath": "/css/main.css",
+		"targets":    []string{"Chrome58, safari13.1", "ios12"},
+		"minify":     true,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(opts.TargetPath, qt.Equals, "css/main.css")
+	c.Assert(opts.Targets, qt.DeepEquals, []string{"chrome58", "safari13.1", "ios12"})
+	c.Assert(opts.Minify, qt.IsTrue)
+
+	opts, err = decodeOptions(map[string]any{"targets": "firefox60"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(opts.Targets, qt.DeepEquals, []string{"firefox60"})
+}
+
+func TestToBuildOptions(t *testing.T) {
+	c := qt.New(t)
+
+	opts, err := toBuildOptions(Options{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(opts, qt.DeepEquals, api.BuildOptions{
+		Bundle: true,
+		Stdin: &api.StdinOptions{
+			Loader: api.LoaderCSS,
+		},
+	})
+
+	opts, err = toBuildOptions(Options{
+		Minify:    true,
+		SourceMap: "external",
+		Targets:   []string{"chrome58", "safari13.1"},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(opts, qt.DeepEquals, api.BuildOptions{
+		Bundle:           true,
+		Engines:          []api.Engine{{Name: api.EngineChrome, Version: "58"}, {Name: api.EngineSafari, Version: "13.1"}},
+		Sourcemap:        api.SourceMapExternal,
+		MinifyWhitespace: true,
+		MinifySyntax:     true,
+		Stdin: &api.StdinOptions{
+			Loader: api.LoaderCSS,
+		},
+	})
+
+	_, err = toBuildOptions(Options{Targets: []string{"netscape4"}})
+	c.Assert(err, qt.ErrorMatches, `invalid target "netscape4", unsupported browser "netscape"`)
+
+	_, err = toBuildOptions(Options{Targets: []string{"chrome"}})
+	c.Assert(err, qt.ErrorMatches, `invalid target "chrome".*`)
+
+	_, err = toBuildOptions(Options{SourceMap: "foo"})
+	c.Assert(err, qt.ErrorMatches, `unsupported sourcemap type: "foo"`)
+}
+
+func TestCompareVersions(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(compareVersions("13", "13.1"), qt.Equals, -1)
+	c.Assert(compareVersions("13.1", "13.1.0"), qt.Equals, 0)
+	c.Assert(compareVersions("15.4", "15"), qt.Equals, 1)
+	c.Assert(compareVersions("9", "10"), qt.Equals, -1)
+}
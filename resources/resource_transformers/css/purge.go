@@ -0,0 +1,557 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package css
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gohugoio/hugo/publisher"
+	"github.com/gohugoio/hugo/resources"
+	"github.com/gohugoio/hugo/resources/internal"
+	"github.com/gohugoio/hugo/resources/resource"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/afero"
+	"github.com/tdewolff/parse/v2"
+	"github.com/tdewolff/parse/v2/css"
+)
+
+const defaultStatsFilename = "hugo_stats.json"
+
+// PurgeOptions configures the removal of unused CSS.
+type PurgeOptions struct {
+	// The build stats file to read the HTML elements in use from, relative to
+	// the project's working dir. Default is hugo_stats.json, which is written
+	// when build.writeStats is enabled.
+	Stats string
+
+	// Regular expressions matched against tag names, classes and IDs (without
+	// the leading . or #), keyframe and font family names to always keep.
+	Safelist []string
+
+	// Whether to remove @keyframes not referenced in any of the remaining rules.
+	Keyframes bool
+
+	// Whether to remove @font-face rules for font families not referenced in
+	// any of the remaining rules.
+	FontFace bool
+}
+
+func decodePurgeOptions(m map[string]any) (PurgeOptions, error) {
+	var opts PurgeOptions
+
+	if err := mapstructure.WeakDecode(m, &opts); err != nil {
+		return opts, err
+	}
+
+	if opts.Stats == "" {
+		opts.Stats = defaultStatsFilename
+	}
+
+	return opts, nil
+}
+
+type purgeTransformation struct {
+	optsm map[string]any
+	c     *Client
+}
+
+func (t *purgeTransformation) Key() internal.ResourceTransformationKey {
+	return internal.NewResourceTransformationKey("csspurge", t.optsm)
+}
+
+func (t *purgeTransformation) Transform(ctx *resources.ResourceTransformationCtx) error {
+	opts, err := decodePurgeOptions(t.optsm)
+	if err != nil {
+		return err
+	}
+
+	p := &purger{opts: opts}
+	for _, s := range opts.Safelist {
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return fmt.Errorf("css.Purge: invalid safelist pattern %q: %w", s, err)
+		}
+		p.safelist = append(p.safelist, re)
+	}
+
+	// When wrapped in resources.PostProcess, this is done after the stats
+	// for the current build is written.
+	stats, err := afero.ReadFile(t.c.rs.Fs.WorkingDirReadOnly, opts.Stats)
+	if err != nil {
+		if os.IsNotExist(err) {
+			t.c.rs.Logger.Warnf("css.Purge: %s not found, make sure build.writeStats is enabled and that the resource is wrapped in resources.PostProcess; skipping purge of %q", opts.Stats, ctx.SourcePath)
+			_, err = io.Copy(ctx.To, ctx.From)
+			return err
+		}
+		return err
+	}
+
+	var ps publisher.PublishStats
+	if err := json.Unmarshal(stats, &ps); err != nil {
+		return fmt.Errorf("css.Purge: failed to parse %s: %w", opts.Stats, err)
+	}
+	p.tags = toSet(ps.HTMLElements.Tags, true)
+	p.classes = toSet(ps.HTMLElements.Classes, false)
+	p.ids = toSet(ps.HTMLElements.IDs, false)
+
+	src, err := ioutil.ReadAll(ctx.From)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := parseCSS(src)
+	if err != nil {
+		return fmt.Errorf("css.Purge: failed to parse %q: %w", ctx.SourcePath, err)
+	}
+
+	nodes = p.purge(nodes)
+
+	var b bytes.Buffer
+	writeCSSNodes(&b, nodes)
+	_, err = ctx.To.Write(b.Bytes())
+	return err
+}
+
+// Purge removes the CSS rules in res with selectors not matching any of the
+// HTML elements in the build stats.
+// For the stats to be complete, the result must be wrapped in
+// resources.PostProcess.
+func (c *Client) Purge(res resources.ResourceTransformer, opts map[string]any) (resource.Resource, error) {
+	return res.Transform(
+		&purgeTransformation{c: c, optsm: opts},
+	)
+}
+
+func toSet(ss []string, lower bool) map[string]bool {
+	m := make(map[string]bool)
+	for _, s := range ss {
+		if lower {
+			s = strings.ToLower(s)
+		}
+		m[s] = true
+	}
+	return m
+}
+
+// cssNode is a node in a simplified CSS syntax tree.
+type cssNode struct {
+	// Set for rulesets.
+	selectors []cssSelector
+
+	// Set for at-rules, e.g. "@media", with its prelude, e.g. "screen".
+	atRule  string
+	prelude string
+	block   bool
+
+	// Declarations in a ruleset or an at-rule block, e.g. @font-face.
+	decls []cssDeclaration
+
+	// Nested rules, e.g. in @media.
+	children []*cssNode
+
+	// Unparsed content, e.g. for unknown at-rule blocks and comments.
+	raw string
+}
+
+func (n *cssNode) isKeyframes() bool {
+	return strings.HasSuffix(n.atRule, "keyframes")
+}
+
+type cssSelector struct {
+	text    string
+	tags    []string
+	classes []string
+	ids     []string
+}
+
+type cssDeclaration struct {
+	property string
+	value    string
+	tokens   []css.Token
+}
+
+func (d cssDeclaration) String() string {
+	return d.property + ":" + d.value
+}
+
+func tokensToString(tokens []css.Token) string {
+	var sb strings.Builder
+	for _, t := range tokens {
+		sb.Write(t.Data)
+	}
+	return sb.String()
+}
+
+func copyTokens(tokens []css.Token) []css.Token {
+	c := make([]css.Token, len(tokens))
+	for i, t := range tokens {
+		c[i] = css.Token{TokenType: t.TokenType, Data: append([]byte(nil), t.Data...)}
+	}
+	return c
+}
+
+func parseCSS(src []byte) ([]*cssNode, error) {
+	p := css.NewParser(parse.NewInputBytes(src), false)
+
+	root := &cssNode{}
+	stack := []*cssNode{root}
+	var selectors []cssSelector
+
+	for {
+		gt, _, data := p.Next()
+		cur := stack[len(stack)-1]
+
+		switch gt {
+		case css.ErrorGrammar:
+			if p.Err() == io.EOF {
+				return root.children, nil
+			}
+			if cur != root && !cur.isKeyframes() && len(cur.children) == 0 {
+				// An invalid declaration, keep it as is.
+				cur.decls = append(cur.decls, cssDeclaration{property: string(data), value: tokensToString(p.Values())})
+				continue
+			}
+			return nil, p.Err()
+		case css.CommentGrammar:
+			// Keep license comments only.
+			if bytes.HasPrefix(data, []byte("/*!")) {
+				cur.children = append(cur.children, &cssNode{raw: string(data) + "\n"})
+			}
+		case css.AtRuleGrammar:
+			n := &cssNode{atRule: string(data), prelude: strings.TrimSpace(tokensToString(p.Values()))}
+			cur.children = append(cur.children, n)
+		case css.BeginAtRuleGrammar:
+			n := &cssNode{atRule: string(data), prelude: strings.TrimSpace(tokensToString(p.Values())), block: true}
+			cur.children = append(cur.children, n)
+			stack = append(stack, n)
+		case css.QualifiedRuleGrammar:
+			selectors = append(selectors, newCSSSelector(p.Values()))
+		case css.BeginRulesetGrammar:
+			selectors = append(selectors, newCSSSelector(p.Values()))
+			n := &cssNode{selectors: selectors}
+			selectors = nil
+			cur.children = append(cur.children, n)
+			stack = append(stack, n)
+		case css.EndAtRuleGrammar, css.EndRulesetGrammar:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case css.DeclarationGrammar, css.CustomPropertyGrammar:
+			tokens := copyTokens(p.Values())
+			cur.decls = append(cur.decls, cssDeclaration{property: string(data), value: tokensToString(tokens), tokens: tokens})
+		case css.TokenGrammar:
+			cur.raw += string(data)
+		}
+	}
+}
+
+func newCSSSelector(tokens []css.Token) cssSelector {
+	s := cssSelector{text: strings.TrimSpace(tokensToString(tokens))}
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch t.TokenType {
+		case css.LeftBracketToken:
+			// Attribute selectors are ignored.
+			for i < len(tokens) && tokens[i].TokenType != css.RightBracketToken {
+				i++
+			}
+		case css.ColonToken:
+			if i+1 < len(tokens) && tokens[i+1].TokenType == css.ColonToken {
+				// Pseudo element.
+				i++
+			}
+			if i+1 < len(tokens) {
+				i++
+				if tokens[i].TokenType == css.FunctionToken {
+					// E.g. :not(.foo), we cannot say much about these,
+					// so ignore the arguments.
+					depth := 1
+					for depth > 0 && i+1 < len(tokens) {
+						i++
+						switch tokens[i].TokenType {
+						case css.FunctionToken, css.LeftParenthesisToken:
+							depth++
+						case css.RightParenthesisToken:
+							depth--
+						}
+					}
+				}
+			}
+		case css.DelimToken:
+			if len(t.Data) == 1 && t.Data[0] == '.' && i+1 < len(tokens) && tokens[i+1].TokenType == css.IdentToken {
+				i++
+				s.classes = append(s.classes, unescapeCSS(tokens[i].Data))
+			}
+		case css.HashToken:
+			s.ids = append(s.ids, unescapeCSS(t.Data[1:]))
+		case css.IdentToken:
+			s.tags = append(s.tags, strings.ToLower(unescapeCSS(t.Data)))
+		}
+	}
+
+	return s
+}
+
+// unescapeCSS resolves the CSS escapes in b, e.g. md\:flex => md:flex.
+func unescapeCSS(b []byte) string {
+	if bytes.IndexByte(b, '\\') == -1 {
+		return string(b)
+	}
+	var sb strings.Builder
+	for i := 0; i < len(b); i++ {
+		if b[i] != '\\' || i+1 >= len(b) {
+			sb.WriteByte(b[i])
+			continue
+		}
+		i++
+		j := i
+		for j < len(b) && j < i+6 && isHex(b[j]) {
+			j++
+		}
+		if j == i {
+			sb.WriteByte(b[i])
+			continue
+		}
+		r, _ := strconv.ParseUint(string(b[i:j]), 16, 32)
+		sb.WriteRune(rune(r))
+		if j < len(b) && b[j] == ' ' {
+			// A single space terminates a hex escape.
+			j++
+		}
+		i = j - 1
+	}
+	return sb.String()
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+type purger struct {
+	opts     PurgeOptions
+	safelist []*regexp.Regexp
+
+	tags    map[string]bool
+	classes map[string]bool
+	ids     map[string]bool
+}
+
+func (p *purger) isSafelisted(name string) bool {
+	for _, re := range p.safelist {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether the selector may match any of the HTML elements
+// in use. This is a conservative check: all of the tags, classes and IDs
+// in the selector must be in use somewhere.
+func (p *purger) matches(s cssSelector) bool {
+	for _, tag := range s.tags {
+		if !p.tags[tag] && !p.isSafelisted(tag) {
+			return false
+		}
+	}
+	for _, class := range s.classes {
+		if !p.classes[class] && !p.isSafelisted(class) {
+			return false
+		}
+	}
+	for _, id := range s.ids {
+		if !p.ids[id] && !p.isSafelisted(id) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *purger) purge(nodes []*cssNode) []*cssNode {
+	nodes = p.purgeRules(nodes)
+
+	if p.opts.Keyframes {
+		used := make(map[string]bool)
+		walkDeclarations(nodes, func(d cssDeclaration) {
+			if strings.HasSuffix(d.property, "animation") || strings.HasSuffix(d.property, "animation-name") {
+				for _, t := range d.tokens {
+					if t.TokenType == css.IdentToken || t.TokenType == css.StringToken {
+						used[unquote(string(t.Data))] = true
+					}
+				}
+			}
+		})
+		nodes = filterNodes(nodes, func(n *cssNode) bool {
+			if !n.isKeyframes() {
+				return true
+			}
+			name := unquote(strings.TrimSpace(n.prelude))
+			return used[name] || p.isSafelisted(name)
+		})
+	}
+
+	if p.opts.FontFace {
+		used := make(map[string]bool)
+		walkDeclarations(nodes, func(d cssDeclaration) {
+			switch d.property {
+			case "font-family":
+				for _, f := range strings.Split(d.value, ",") {
+					used[strings.ToLower(unquote(strings.TrimSpace(f)))] = true
+				}
+			case "font":
+				// The family is last in the shorthand, after the size.
+				for i, f := range strings.Split(d.value, ",") {
+					f = strings.TrimSpace(f)
+					used[strings.ToLower(unquote(f))] = true
+					if i == 0 {
+						words := strings.Fields(f)
+						for j := range words {
+							used[strings.ToLower(unquote(strings.Join(words[j:], " ")))] = true
+						}
+					}
+				}
+			}
+		})
+		nodes = filterNodes(nodes, func(n *cssNode) bool {
+			if n.atRule != "@font-face" {
+				return true
+			}
+			for _, d := range n.decls {
+				if d.property == "font-family" {
+					name := unquote(strings.TrimSpace(d.value))
+					return used[strings.ToLower(name)] || p.isSafelisted(name)
+				}
+			}
+			return true
+		})
+	}
+
+	return nodes
+}
+
+func (p *purger) purgeRules(nodes []*cssNode) []*cssNode {
+	var kept []*cssNode
+	for _, n := range nodes {
+		switch {
+		case len(n.selectors) > 0:
+			var selectors []cssSelector
+			for _, s := range n.selectors {
+				if p.matches(s) {
+					selectors = append(selectors, s)
+				}
+			}
+			if len(selectors) == 0 {
+				continue
+			}
+			n.selectors = selectors
+		case n.isKeyframes():
+			// Keyframe selectors, e.g. from and to, are not elements.
+		case len(n.children) > 0:
+			n.children = p.purgeRules(n.children)
+			if len(n.children) == 0 && n.raw == "" {
+				continue
+			}
+		}
+		kept = append(kept, n)
+	}
+	return kept
+}
+
+func filterNodes(nodes []*cssNode, keep func(n *cssNode) bool) []*cssNode {
+	var kept []*cssNode
+	for _, n := range nodes {
+		if !keep(n) {
+			continue
+		}
+		if len(n.children) > 0 && !n.isKeyframes() {
+			n.children = filterNodes(n.children, keep)
+		}
+		kept = append(kept, n)
+	}
+	return kept
+}
+
+func walkDeclarations(nodes []*cssNode, fn func(d cssDeclaration)) {
+	for _, n := range nodes {
+		if n.isKeyframes() || n.atRule == "@font-face" {
+			continue
+		}
+		for _, d := range n.decls {
+			fn(d)
+		}
+		walkDeclarations(n.children, fn)
+	}
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func writeCSSNodes(w *bytes.Buffer, nodes []*cssNode) {
+	for _, n := range nodes {
+		switch {
+		case len(n.selectors) > 0:
+			for i, s := range n.selectors {
+				if i > 0 {
+					w.WriteByte(',')
+				}
+				w.WriteString(s.text)
+			}
+			w.WriteByte('{')
+			writeCSSDeclarations(w, n.decls)
+			w.WriteString("}\n")
+		case n.atRule != "":
+			w.WriteString(n.atRule)
+			if n.prelude != "" {
+				w.WriteByte(' ')
+				w.WriteString(n.prelude)
+			}
+			if !n.block {
+				w.WriteString(";\n")
+				continue
+			}
+			w.WriteByte('{')
+			writeCSSDeclarations(w, n.decls)
+			if len(n.children) > 0 {
+				w.WriteByte('\n')
+				writeCSSNodes(w, n.children)
+			}
+			w.WriteString(n.raw)
+			w.WriteString("}\n")
+		default:
+			w.WriteString(n.raw)
+		}
+	}
+}
+
+func writeCSSDeclarations(w *bytes.Buffer, decls []cssDeclaration) {
+	for i, d := range decls {
+		if i > 0 {
+			w.WriteByte(';')
+		}
+		w.WriteString(d.String())
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package css
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestPurge(t *testing.T) {
+	c := qt.New(t)
+
+	src := `/*! License */
+/* Comment */
+@charset "utf-8";
+@import url("foo.css");
+:root { --main-color: #06c; }
+* { box-sizing: border-box; }
+html, body { margin: 0; }
+h1, h2 { font-weight: bold; }
+.used, .unused { color: red; }
+.unused .used { color: blue; }
+#main > p:first-child::before { content: "a;b"; }
+#nope { color: green; }
+a:hover, a:not(.nope) { color: black; }
+input[type="text"] { border: 0; }
+.md\:flex { display: flex; }
+.safe-1 { color: pink; }
+@media (min-width: 600px) {
+  .used { color: green; }
+  .unused { color: yellow; }
+}
+@media print {
+  .unused { display: none; }
+}
+@keyframes spin { from { transform: rotate(0deg); } to { transform: rotate(360deg); } }
+@keyframes fade { 0% { opacity: 0; } 100% { opacity: 1; } }
+@-webkit-keyframes spin { from { opacity: 0; } }
+.spinner { animation: spin 1s linear infinite; }
+.unused-fade { animation-name: fade; }
+@font-face { font-family: "Open Sans"; src: url(open-sans.woff2); }
+@font-face { font-family: Unused; src: url(unused.woff2); }
+body { font: 12px/1.5 "Open Sans", sans-serif; }
+`
+
+	newPurger := func(opts PurgeOptions) *purger {
+		return &purger{
+			opts:    opts,
+			tags:    toSet([]string{"html", "body", "h1", "p", "a", "input"}, true),
+			classes: toSet([]string{"used", "spinner", "md:flex"}, false),
+			ids:     toSet([]string{"main"}, false),
+		}
+	}
+
+	purge := func(p *purger) string {
+		nodes, err := parseCSS([]byte(src))
+		c.Assert(err, qt.IsNil)
+		var b bytes.Buffer
+		writeCSSNodes(&b, p.purge(nodes))
+		return b.String()
+	}
+
+	c.Run("Default", func(c *qt.C) {
+		got := purge(newPurger(PurgeOptions{}))
+		c.Assert(got, qt.Equals, `/*! License */
+@charset "utf-8";
+@import url("foo.css");
+:root{--main-color: #06c}
+*{box-sizing:border-box}
+html,body{margin:0}
+h1{font-weight:bold}
+.used{color:red}
+#main>p:first-child::before{content:"a;b"}
+a:hover,a:not(.nope){color:black}
+input[type="text"]{border:0}
+.md\:flex{display:flex}
+@media (min-width:600px){
+.used{color:green}
+}
+@keyframes spin{
+from{transform:rotate(0deg)}
+to{transform:rotate(360deg)}
+}
+@keyframes fade{
+0%{opacity:0}
+100%{opacity:1}
+}
+@-webkit-keyframes spin{
+from{opacity:0}
+}
+.spinner{animation:spin 1s linear infinite}
+@font-face{font-family:"Open Sans";src:url(open-sans.woff2)}
+@font-face{font-family:Unused;src:url(unused.woff2)}
+body{font:12px/1.5 "Open Sans",sans-serif}
+`)
+	})
+
+	c.Run("Keyframes and font faces", func(c *qt.C) {
+		got := purge(newPurger(PurgeOptions{Keyframes: true, FontFace: true}))
+		c.Assert(got, qt.Contains, "@keyframes spin{")
+		c.Assert(got, qt.Contains, "@-webkit-keyframes spin{")
+		c.Assert(got, qt.Not(qt.Contains), "@keyframes fade")
+		c.Assert(got, qt.Contains, `@font-face{font-family:"Open Sans"`)
+		c.Assert(got, qt.Not(qt.Contains), "Unused")
+	})
+
+	c.Run("Safelist", func(c *qt.C) {
+		p := newPurger(PurgeOptions{Keyframes: true})
+		p.safelist = append(p.safelist, regexp.MustCompile(`^safe-`), regexp.MustCompile(`^fade$`))
+		got := purge(p)
+		c.Assert(got, qt.Contains, ".safe-1{color:pink}")
+		c.Assert(got, qt.Contains, "@keyframes fade")
+	})
+}
+
+func TestUnescapeCSS(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(unescapeCSS([]byte(`md\:flex`)), qt.Equals, "md:flex")
+	c.Assert(unescapeCSS([]byte(`w-1\/2`)), qt.Equals, "w-1/2")
+	c.Assert(unescapeCSS([]byte(`\31 0`)), qt.Equals, "10")
+	c.Assert(unescapeCSS([]byte(`plain`)), qt.Equals, "plain")
+}
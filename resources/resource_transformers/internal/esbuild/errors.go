@@ -0,0 +1,96 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package esbuild contains helpers shared by the ESBuild based resource
+// transformers.
+package esbuild
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/evanw/esbuild/pkg/api"
+	"github.com/spf13/afero"
+
+	"github.com/gohugoio/hugo/common/herrors"
+	"github.com/gohugoio/hugo/common/loggers"
+	"github.com/gohugoio/hugo/common/text"
+	"github.com/gohugoio/hugo/hugofs"
+)
+
+// NsImportHugo is the namespace of the imports resolved by Hugo.
+const NsImportHugo = "ns-hugo"
+
+// ToBuildError converts the esbuild errors in msgs to file errors.
+// The file of each error location is mapped with resolvePath and then
+// looked up in fs, unless it's in the NsImportHugo namespace, which holds
+// absolute filenames.
+// The first error is returned, the rest is logged prefixed with name,
+// e.g. "js.Build".
+func ToBuildError(msgs []api.Message, fs afero.Fs, resolvePath func(path string) string, logger loggers.Logger, name string) error {
+	createErr := func(msg api.Message) error {
+		loc := msg.Location
+		if loc == nil {
+			return errors.New(msg.Text)
+		}
+		path := resolvePath(loc.File)
+
+		errorMessage := strings.ReplaceAll(msg.Text, NsImportHugo+":", "")
+
+		var (
+			f   afero.File
+			err error
+		)
+
+		if strings.HasPrefix(path, NsImportHugo) {
+			path = strings.TrimPrefix(path, NsImportHugo+":")
+			f, err = hugofs.Os.Open(path)
+		} else {
+			var fi os.FileInfo
+			fi, err = fs.Stat(path)
+			if err == nil {
+				m := fi.(hugofs.FileMetaInfo).Meta()
+				path = m.Filename
+				f, err = m.Open()
+			}
+		}
+
+		if err == nil {
+			fe := herrors.
+				NewFileErrorFromName(errors.New(errorMessage), path).
+				UpdatePosition(text.Position{Offset: -1, LineNumber: loc.Line, ColumnNumber: loc.Column}).
+				UpdateContent(f, nil)
+
+			f.Close()
+			return fe
+		}
+
+		return fmt.Errorf("%s", errorMessage)
+	}
+
+	var errs []error
+	for _, msg := range msgs {
+		errs = append(errs, createErr(msg))
+	}
+
+	// Return 1, log the rest.
+	for i, err := range errs {
+		if i > 0 {
+			logger.Errorf("%s failed: %s", name, err)
+		}
+	}
+
+	return errs[0]
+}
@@ -0,0 +1,391 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package js
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/evanw/esbuild/pkg/api"
+	"github.com/gohugoio/hugo/common/hugio"
+	"github.com/gohugoio/hugo/common/maps"
+	"github.com/gohugoio/hugo/common/paths"
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/resources"
+	"github.com/gohugoio/hugo/resources/resource"
+)
+
+const defaultBatchTargetPath = "js"
+
+// BatchResult holds the output of a batch build, published together.
+type BatchResult struct {
+	// All the published files: entries, shared chunks, CSS, assets and
+	// source maps.
+	Resources resource.Resources
+
+	entries []BatchEntry
+}
+
+// BatchEntry holds the output files for an entry in a batch build.
+type BatchEntry struct {
+	// The entry name, e.g. "main".
+	Name string
+
+	// The entry script.
+	Script resource.Resource
+
+	// The CSS imported by the entry and its dependencies. Nil if none.
+	CSS resource.Resource
+}
+
+// Entries returns the entries in the order given.
+func (b *BatchResult) Entries() []BatchEntry {
+	return b.entries
+}
+
+// Get returns the entry with the given name, nil if not found.
+func (b *BatchResult) Get(name string) *BatchEntry {
+	for i, e := range b.entries {
+		if e.Name == name {
+			return &b.entries[i]
+		}
+	}
+	return nil
+}
+
+// ImportMap returns an import map, as JSON, mapping the entry names to
+// their scripts' relative permalinks, e.g.:
+//
+//	{"imports":{"main":"/js/main.js"}}
+func (b *BatchResult) ImportMap() (string, error) {
+	imports := make(map[string]string)
+	for _, e := range b.entries {
+		imports[e.Name] = e.Script.RelPermalink()
+	}
+	bb, err := json.Marshal(map[string]any{"imports": imports})
+	if err != nil {
+		return "", err
+	}
+	return string(bb), nil
+}
+
+// batchEntry is an entry point in a batch build.
+type batchEntry struct {
+	name       string
+	r          resource.Resource
+	params     any
+	sourcePath string
+	sourceDir  string
+	contents   string
+	loader     api.Loader
+}
+
+// Batch builds the entries in m["entries"] with code splitting enabled.
+// An entry is either a Resource or a map with the keys "resource", "name"
+// (defaults to the resource's base name without extension) and "params",
+// available as "@params" in the entry module. Imports of "@params" from any
+// other module resolves to the top level params.
+// The remaining options are the same as in Process, but the target path is
+// the directory to publish to (default "js") and the format must be esm.
+func (c *Client) Batch(m map[string]any) (*BatchResult, error) {
+	entriesv, _ := maps.LookupEqualFold(m, "entries")
+	entries, err := decodeBatchEntries(entriesv)
+	if err != nil {
+		return nil, err
+	}
+
+	optsm := make(map[string]any)
+	for k, v := range m {
+		if !strings.EqualFold(k, "entries") {
+			optsm[k] = v
+		}
+	}
+
+	// All resources in the cache is cleared on change in /assets.
+	keyParts := []any{optsm}
+	for _, e := range entries {
+		var rkey string
+		if id, ok := e.r.(resource.Identifier); ok {
+			rkey = id.Key()
+		} else {
+			rkey = e.r.Name()
+		}
+		keyParts = append(keyParts, e.name, rkey, e.params)
+	}
+	key := path.Join(resources.CACHE_OTHER, "jsbatch", helpers.HashString(keyParts...))
+
+	opts, err := decodeOptions(optsm)
+	if err != nil {
+		return nil, err
+	}
+	if opts.TargetPath == "" {
+		opts.TargetPath = defaultBatchTargetPath
+	}
+	opts.TargetPath = strings.TrimSuffix(opts.TargetPath, "/")
+
+	rs, err := c.rs.ResourceCache.GetOrCreateResources(key, func() (resource.Resources, error) {
+		return c.buildBatch(opts, entries)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BatchResult{Resources: rs}
+	for _, e := range entries {
+		base := path.Join(opts.TargetPath, e.name)
+		be := BatchEntry{
+			Name:   e.name,
+			Script: rs.Get(base + ".js"),
+			CSS:    rs.Get(base + ".css"),
+		}
+		if be.Script == nil {
+			return nil, fmt.Errorf("js.Batch: no output found for entry %q", e.name)
+		}
+		result.entries = append(result.entries, be)
+	}
+
+	return result, nil
+}
+
+func decodeBatchEntries(v any) ([]*batchEntry, error) {
+	if v == nil {
+		return nil, fmt.Errorf("js.Batch: no entries provided")
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("js.Batch: entries must be a slice, got %T", v)
+	}
+	items := make([]any, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		items[i] = rv.Index(i).Interface()
+	}
+
+	var (
+		entries []*batchEntry
+		seen    = make(map[string]bool)
+	)
+
+	for _, item := range items {
+		e := &batchEntry{}
+		switch vv := item.(type) {
+		case resource.Resource:
+			e.r = vv
+		default:
+			em, err := maps.ToStringMapE(item)
+			if err != nil {
+				return nil, fmt.Errorf("js.Batch: entry must be a Resource or a map, got %T", item)
+			}
+			name, _ := maps.LookupEqualFold(em, "name")
+			if name != nil {
+				e.name = fmt.Sprint(name)
+			}
+			rv, _ := maps.LookupEqualFold(em, "resource")
+			r, ok := rv.(resource.Resource)
+			if !ok {
+				return nil, fmt.Errorf("js.Batch: entry %q: no Resource provided", e.name)
+			}
+			e.r = r
+			e.params, _ = maps.LookupEqualFold(em, "params")
+		}
+
+		if e.name == "" {
+			e.name = paths.PathNoExt(path.Base(e.r.Name()))
+		}
+		e.name = helpers.ToSlashTrimLeading(e.name)
+		if e.name == "" || strings.Contains(e.name, "..") {
+			return nil, fmt.Errorf("js.Batch: invalid entry name %q", e.name)
+		}
+		if seen[e.name] {
+			return nil, fmt.Errorf("js.Batch: duplicate entry name %q", e.name)
+		}
+		seen[e.name] = true
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+func (c *Client) buildBatch(opts Options, entries []*batchEntry) (resource.Resources, error) {
+	if opts.Format == "" {
+		opts.Format = "esm"
+	}
+	if opts.Format != "esm" {
+		return nil, fmt.Errorf("js.Batch: code splitting requires the esm format, got %q", opts.Format)
+	}
+
+	opts.resolveDir = c.rs.WorkingDir
+	opts.entries = make(map[string]*batchEntry)
+
+	for _, e := range entries {
+		loader, err := loaderFromMediaType(e.r.MediaType())
+		if err != nil {
+			return nil, fmt.Errorf("js.Batch: entry %q: %w", e.name, err)
+		}
+		content, err := readResourceContent(e.r)
+		if err != nil {
+			return nil, fmt.Errorf("js.Batch: entry %q: %w", e.name, err)
+		}
+		e.loader = loader
+		e.contents = content
+		e.sourcePath = filepath.FromSlash(e.r.Name())
+		e.sourceDir = filepath.Dir(e.sourcePath)
+		opts.entries[e.name] = e
+	}
+
+	buildOptions, err := toBuildOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	buildOptions.Stdin = nil
+
+	plugins, err := createBuildPlugins(c, opts)
+	if err != nil {
+		return nil, err
+	}
+	buildOptions.Plugins = append([]api.Plugin{createBatchEntriesPlugin(opts)}, plugins...)
+
+	if opts.Inject != nil {
+		for i, ext := range opts.Inject {
+			impPath := filepath.FromSlash(ext)
+			if filepath.IsAbs(impPath) {
+				return nil, fmt.Errorf("inject: absolute paths not supported, must be relative to /assets")
+			}
+			m := resolveComponentInAssets(c.rs.Assets.Fs, impPath)
+			if m == nil {
+				return nil, fmt.Errorf("inject: file %q not found", ext)
+			}
+			opts.Inject[i] = m.Filename
+		}
+		buildOptions.Inject = opts.Inject
+	}
+
+	// Nothing gets written to disk, but esbuild needs a directory to
+	// resolve the output paths.
+	buildOptions.Outdir, err = ioutil.TempDir(os.TempDir(), "compileOutput")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(buildOptions.Outdir)
+
+	buildOptions.Write = false
+	buildOptions.Splitting = true
+	buildOptions.ChunkNames = path.Join(opts.TargetPath, "chunks", "[name]-[hash]")
+	buildOptions.AssetNames = path.Join(opts.TargetPath, "assets", "[name]-[hash]")
+	for _, e := range entries {
+		buildOptions.EntryPointsAdvanced = append(buildOptions.EntryPointsAdvanced, api.EntryPoint{
+			InputPath:  e.name,
+			OutputPath: path.Join(opts.TargetPath, e.name),
+		})
+	}
+
+	result := api.Build(buildOptions)
+
+	if len(result.Errors) > 0 {
+		return nil, c.toBuildError(result.Errors, func(p string) string {
+			if e, found := opts.entries[strings.TrimPrefix(p, nsBatchEntry+":")]; found {
+				return e.sourcePath
+			}
+			return p
+		})
+	}
+
+	// Sort the output files to get a stable order.
+	sort.Slice(result.OutputFiles, func(i, j int) bool {
+		return result.OutputFiles[i].Path < result.OutputFiles[j].Path
+	})
+
+	var rs resource.Resources
+	for _, f := range result.OutputFiles {
+		targetPath, err := filepath.Rel(buildOptions.Outdir, f.Path)
+		if err != nil {
+			return nil, err
+		}
+		contents := string(f.Contents)
+		r, err := c.rs.New(
+			resources.ResourceSourceDescriptor{
+				Fs:          c.rs.FileCaches.AssetsCache().Fs,
+				LazyPublish: true,
+				OpenReadSeekCloser: func() (hugio.ReadSeekCloser, error) {
+					return hugio.NewReadSeekerNoOpCloserFromString(contents), nil
+				},
+				RelTargetFilename: targetPath,
+			})
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, r)
+	}
+
+	// The entries may import any of the chunks and assets, so publish
+	// them all.
+	for _, r := range rs {
+		if err := r.(resource.Source).Publish(); err != nil {
+			return nil, err
+		}
+	}
+
+	return rs, nil
+}
+
+func createBatchEntriesPlugin(opts Options) api.Plugin {
+	return api.Plugin{
+		Name: "hugo-batch-entries",
+		Setup: func(build api.PluginBuild) {
+			build.OnResolve(api.OnResolveOptions{Filter: `.*`},
+				func(args api.OnResolveArgs) (api.OnResolveResult, error) {
+					if args.Kind != api.ResolveEntryPoint {
+						return api.OnResolveResult{}, nil
+					}
+					if _, found := opts.entries[args.Path]; !found {
+						return api.OnResolveResult{}, nil
+					}
+					return api.OnResolveResult{Path: args.Path, Namespace: nsBatchEntry}, nil
+				})
+			build.OnLoad(api.OnLoadOptions{Filter: `.*`, Namespace: nsBatchEntry},
+				func(args api.OnLoadArgs) (api.OnLoadResult, error) {
+					e := opts.entries[args.Path]
+					return api.OnLoadResult{
+						ResolveDir: opts.resolveDir,
+						Contents:   &e.contents,
+						Loader:     e.loader,
+					}, nil
+				})
+		},
+	}
+}
+
+func readResourceContent(r resource.Resource) (string, error) {
+	rr, ok := r.(resource.ReadSeekCloserResource)
+	if !ok {
+		return "", fmt.Errorf("resource %q does not support reading its content", r.Name())
+	}
+	f, err := rr.ReadSeekCloser()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	b, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -20,20 +20,11 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
-	"strings"
-
-	"errors"
-
-	"github.com/spf13/afero"
-
-	"github.com/gohugoio/hugo/hugofs"
-
-	"github.com/gohugoio/hugo/common/herrors"
-	"github.com/gohugoio/hugo/common/text"
 
 	"github.com/gohugoio/hugo/hugolib/filesystems"
 	"github.com/gohugoio/hugo/media"
 	"github.com/gohugoio/hugo/resources/internal"
+	"github.com/gohugoio/hugo/resources/resource_transformers/internal/esbuild"
 
 	"github.com/evanw/esbuild/pkg/api"
 	"github.com/gohugoio/hugo/resources"
@@ -130,66 +121,12 @@ func (t *buildTransformation) Transform(ctx *resources.ResourceTransformationCtx
 	result := api.Build(buildOptions)
 
 	if len(result.Errors) > 0 {
-
-		createErr := func(msg api.Message) error {
-			loc := msg.Location
-			if loc == nil {
-				return errors.New(msg.Text)
-			}
-			path := loc.File
+		return t.c.toBuildError(result.Errors, func(path string) string {
 			if path == stdinImporter {
-				path = ctx.SourcePath
-			}
-
-			errorMessage := msg.Text
-			errorMessage = strings.ReplaceAll(errorMessage, nsImportHugo+":", "")
-
-			var (
-				f   afero.File
-				err error
-			)
-
-			if strings.HasPrefix(path, nsImportHugo) {
-				path = strings.TrimPrefix(path, nsImportHugo+":")
-				f, err = hugofs.Os.Open(path)
-			} else {
-				var fi os.FileInfo
-				fi, err = t.c.sfs.Fs.Stat(path)
-				if err == nil {
-					m := fi.(hugofs.FileMetaInfo).Meta()
-					path = m.Filename
-					f, err = m.Open()
-				}
-
-			}
-
-			if err == nil {
-				fe := herrors.
-					NewFileErrorFromName(errors.New(errorMessage), path).
-					UpdatePosition(text.Position{Offset: -1, LineNumber: loc.Line, ColumnNumber: loc.Column}).
-					UpdateContent(f, nil)
-
-				f.Close()
-				return fe
+				return ctx.SourcePath
 			}
-
-			return fmt.Errorf("%s", errorMessage)
-		}
-
-		var errors []error
-
-		for _, msg := range result.Errors {
-			errors = append(errors, createErr(msg))
-		}
-
-		// Return 1, log the rest.
-		for i, err := range errors {
-			if i > 0 {
-				t.c.rs.Logger.Errorf("js.Build failed: %s", err)
-			}
-		}
-
-		return errors[0]
+			return path
+		})
 	}
 
 	if buildOptions.Sourcemap == api.SourceMapExternal {
@@ -214,6 +151,13 @@ func (t *buildTransformation) Transform(ctx *resources.ResourceTransformationCtx
 	return nil
 }
 
+// toBuildError converts the esbuild errors in msgs to file errors, resolving
+// the error location with sourcePath. The first error is returned, the rest
+// is logged.
+func (c *Client) toBuildError(msgs []api.Message, sourcePath func(path string) string) error {
+	return esbuild.ToBuildError(msgs, c.sfs.Fs, sourcePath, c.rs.Logger, "js.Build")
+}
+
 // Process process esbuild transform
 func (c *Client) Process(res resources.ResourceTransformer, opts map[string]any) (resource.Resource, error) {
 	return res.Transform(
@@ -259,3 +259,127 @@ JS Content:{{ $js.Content }}:End:
 	})
 
 }
+
+func TestBatch(t *testing.T) {
+	files := `
+-- config.toml --
+disableKinds=["page", "section", "taxonomy", "term", "sitemap", "robotsTXT"]
+-- assets/js/main.js --
+import { shared } from './shared';
+import * as params from '@params';
+import './main.css';
+console.log('main', shared(), params.title);
+-- assets/js/about.ts --
+import { shared } from './shared';
+import * as params from '@params';
+console.log('about', shared(), params.title);
+-- assets/js/shared.js --
+export function shared() {
+	return 'shared-value';
+}
+-- assets/js/main.css --
+body { color: red; }
+-- layouts/index.html --
+{{ $main := resources.Get "js/main.js" }}
+{{ $about := resources.Get "js/about.ts" }}
+{{ $entries := slice (dict "resource" $main "params" (dict "title" "Main Title")) (dict "name" "pages/about" "resource" $about "params" (dict "title" "About Title")) }}
+{{ $batch := js.Batch (dict "entries" $entries "targetPath" "scripts") }}
+{{ range $batch.Entries }}
+Entry: {{ .Name }}|{{ .Script.RelPermalink }}|{{ with .CSS }}{{ .RelPermalink }}{{ end }}|
+{{ end }}
+Num: {{ len $batch.Resources }}|
+<script type="importmap">{{ $batch.ImportMap | safeHTML }}</script>
+About: {{ ($batch.Get "pages/about").Script.RelPermalink }}|
+`
+
+	b := hugolib.NewIntegrationTestBuilder(hugolib.IntegrationTestConfig{T: t, NeedsOsFS: true, TxtarString: files}).Build()
+
+	b.AssertFileContent("public/index.html",
+		"Entry: main|/scripts/main.js|/scripts/main.css|",
+		"Entry: pages/about|/scripts/pages/about.js||",
+		"Num: 4|",
+		`<script type="importmap">{"imports":{"main":"/scripts/main.js","pages/about":"/scripts/pages/about.js"}}</script>`,
+		"About: /scripts/pages/about.js|",
+	)
+	b.AssertFileContent("public/scripts/main.js", `Main Title`, `from "./chunks/chunk-`)
+	b.AssertFileContent("public/scripts/pages/about.js", `About Title`, `from "../chunks/chunk-`)
+	b.AssertFileContent("public/scripts/main.css", "color: red")
+}
+
+func TestBatchErrors(t *testing.T) {
+	c := qt.New(t)
+
+	files := `
+-- config.toml --
+disableKinds=["page", "section", "taxonomy", "term", "sitemap", "robotsTXT"]
+-- assets/js/main.js --
+import { nope } from './nope';
+console.log(nope);
+-- layouts/index.html --
+{{ $batch := js.Batch (dict "entries" (slice (resources.Get "js/main.js")) "format" "iife") }}
+`
+
+	b, err := hugolib.NewIntegrationTestBuilder(hugolib.IntegrationTestConfig{T: c, NeedsOsFS: true, TxtarString: files}).BuildE()
+	b.Assert(err, qt.IsNotNil)
+	b.Assert(err.Error(), qt.Contains, "code splitting requires the esm format")
+
+	files = strings.Replace(files, `"format" "iife"`, `"format" "esm"`, 1)
+	b, err = hugolib.NewIntegrationTestBuilder(hugolib.IntegrationTestConfig{T: c, NeedsOsFS: true, TxtarString: files}).BuildE()
+	b.Assert(err, qt.IsNotNil)
+	b.Assert(err.Error(), qt.Contains, `Could not resolve "./nope"`)
+	b.Assert(err.Error(), qt.Contains, filepath.FromSlash("assets/js/main.js:1:21"))
+}
+
+// Checks of the esbuild output for the most common js.Build options, e.g. to
+// catch changes in behaviour when upgrading esbuild.
+func TestBuildOptions(t *testing.T) {
+	c := qt.New(t)
+
+	files := `
+-- config.toml --
+disableKinds=["page", "section", "taxonomy", "term", "sitemap", "robotsTXT"]
+-- assets/js/main.js --
+import * as params from '@params';
+import { hello } from './util';
+const v = window.foo ?? "default";
+console.log(hello(), v, params.greeting, process.env.NODE_ENV);
+-- assets/js/util.js --
+export function hello() {
+	return "hello";
+}
+-- assets/js/lib.js --
+import React from 'react';
+export function greet() {
+	return React.version;
+}
+-- assets/js/app.jsx --
+export const App = () => <div className="app">Hello</div>;
+-- assets/js/app.ts --
+interface Greeting { text: string }
+const greeting: Greeting = { text: "typed" };
+console.log(greeting.text);
+-- layouts/index.html --
+{{ $params := dict "greeting" "hola" }}
+{{ $defines := dict "process.env.NODE_ENV" "\"production\"" }}
+{{ (resources.Get "js/main.js" | js.Build (dict "targetPath" "js/default.js" "params" $params "defines" $defines)).RelPermalink }}
+{{ (resources.Get "js/main.js" | js.Build (dict "targetPath" "js/es2018.js" "target" "es2018" "params" $params "defines" $defines)).RelPermalink }}
+{{ (resources.Get "js/main.js" | js.Build (dict "targetPath" "js/minified.js" "minify" true "params" $params "defines" $defines)).RelPermalink }}
+{{ (resources.Get "js/main.js" | js.Build (dict "targetPath" "js/sourcemap.js" "sourceMap" "inline" "params" $params "defines" $defines)).RelPermalink }}
+{{ (resources.Get "js/lib.js" | js.Build (dict "targetPath" "js/lib.js" "format" "esm" "externals" (slice "react"))).RelPermalink }}
+{{ (resources.Get "js/app.jsx" | js.Build (dict "targetPath" "js/app.js" "format" "esm" "jsxFactory" "h")).RelPermalink }}
+{{ (resources.Get "js/app.ts" | js.Build (dict "targetPath" "js/ts.js")).RelPermalink }}
+`
+
+	b := hugolib.NewIntegrationTestBuilder(hugolib.IntegrationTestConfig{T: c, NeedsOsFS: true, TxtarString: files}).Build()
+
+	b.AssertFileContent("public/js/default.js", `(() => {`, `function hello() {`, `window.foo ?? "default"`, `"hola"`, `"production"`)
+	b.AssertFileContent("public/js/es2018.js", `!= null ?`)
+	b.Assert(b.FileContent("public/js/es2018.js"), qt.Not(qt.Contains), "??")
+	b.AssertFileContent("public/js/minified.js", `return"hello"`, `"production"`)
+	b.Assert(strings.Count(strings.TrimSpace(b.FileContent("public/js/minified.js")), "\n"), qt.Equals, 0)
+	b.AssertFileContent("public/js/sourcemap.js", `//# sourceMappingURL=data:application/json;base64,`)
+	b.AssertFileContent("public/js/lib.js", `from "react";`, `export {`, `greet`)
+	b.AssertFileContent("public/js/app.js", `h("div", {`, `className: "app"`, `"Hello")`)
+	b.AssertFileContent("public/js/ts.js", `text: "typed"`)
+	b.Assert(b.FileContent("public/js/ts.js"), qt.Not(qt.Contains), "interface")
+}
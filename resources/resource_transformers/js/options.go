@@ -28,12 +28,14 @@ import (
 	"github.com/gohugoio/hugo/helpers"
 	"github.com/gohugoio/hugo/hugofs"
 	"github.com/gohugoio/hugo/media"
+	"github.com/gohugoio/hugo/resources/resource_transformers/internal/esbuild"
 	"github.com/mitchellh/mapstructure"
 )
 
 const (
-	nsImportHugo = "ns-hugo"
+	nsImportHugo = esbuild.NsImportHugo
 	nsParams     = "ns-params"
+	nsBatchEntry = "ns-batch-entry"
 
 	stdinImporter = "<stdin>"
 )
@@ -103,6 +105,9 @@ type Options struct {
 	sourceDir  string
 	resolveDir string
 	tsConfig   string
+
+	// Set when building a batch, keyed by the entry name.
+	entries map[string]*batchEntry
 }
 
 func decodeOptions(m map[string]any) (Options, error) {
@@ -142,6 +147,23 @@ func loaderFromFilename(filename string) api.Loader {
 	return api.LoaderJS
 }
 
+func loaderFromMediaType(mediaType media.Type) (api.Loader, error) {
+	switch mediaType.SubType {
+	// TODO(bep) ESBuild support a set of other loaders, but I currently fail
+	// to see the relevance. That may change as we start using this.
+	case media.JavascriptType.SubType:
+		return api.LoaderJS, nil
+	case media.TypeScriptType.SubType:
+		return api.LoaderTS, nil
+	case media.TSXType.SubType:
+		return api.LoaderTSX, nil
+	case media.JSXType.SubType:
+		return api.LoaderJSX, nil
+	default:
+		return api.LoaderNone, fmt.Errorf("unsupported Media Type: %q", mediaType)
+	}
+}
+
 func resolveComponentInAssets(fs afero.Fs, impPath string) *hugofs.FileMeta {
 	findFirst := func(base string) *hugofs.FileMeta {
 		// This is the most common sub-set of ESBuild's default extensions.
@@ -213,7 +235,9 @@ func createBuildPlugins(c *Client, opts Options) ([]api.Plugin, error) {
 		}
 		isStdin := args.Importer == stdinImporter
 		var relDir string
-		if !isStdin {
+		if e, found := opts.entries[args.Importer]; found && args.Namespace == nsBatchEntry {
+			relDir = e.sourceDir
+		} else if !isStdin {
 			rel, found := fs.MakePathRelative(args.Importer)
 			if !found {
 				// Not in any of the /assets folders.
@@ -285,20 +309,42 @@ func createBuildPlugins(c *Client, opts Options) ([]api.Plugin, error) {
 		return nil, fmt.Errorf("failed to marshal params: %w", err)
 	}
 	bs := string(b)
+
+	// Entries in a batch may have their own params, keyed by the entry name.
+	entryParams := make(map[string]string)
+	for name, e := range opts.entries {
+		if e.params == nil {
+			continue
+		}
+		b, err := json.Marshal(e.params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal params for entry %q: %w", name, err)
+		}
+		entryParams[name] = string(b)
+	}
+
 	paramsPlugin := api.Plugin{
 		Name: "hugo-params-plugin",
 		Setup: func(build api.PluginBuild) {
 			build.OnResolve(api.OnResolveOptions{Filter: `^@params$`},
 				func(args api.OnResolveArgs) (api.OnResolveResult, error) {
+					p := args.Path
+					if _, found := entryParams[args.Importer]; found && args.Namespace == nsBatchEntry {
+						p = args.Importer
+					}
 					return api.OnResolveResult{
-						Path:      args.Path,
+						Path:      p,
 						Namespace: nsParams,
 					}, nil
 				})
 			build.OnLoad(api.OnLoadOptions{Filter: `.*`, Namespace: nsParams},
 				func(args api.OnLoadArgs) (api.OnLoadResult, error) {
+					contents := bs
+					if s, found := entryParams[args.Path]; found {
+						contents = s
+					}
 					return api.OnLoadResult{
-						Contents: &bs,
+						Contents: &contents,
 						Loader:   api.LoaderJSON,
 					}, nil
 				})
@@ -337,20 +383,8 @@ func toBuildOptions(opts Options) (buildOptions api.BuildOptions, err error) {
 		mediaType = media.JavascriptType
 	}
 
-	var loader api.Loader
-	switch mediaType.SubType {
-	// TODO(bep) ESBuild support a set of other loaders, but I currently fail
-	// to see the relevance. That may change as we start using this.
-	case media.JavascriptType.SubType:
-		loader = api.LoaderJS
-	case media.TypeScriptType.SubType:
-		loader = api.LoaderTS
-	case media.TSXType.SubType:
-		loader = api.LoaderTSX
-	case media.JSXType.SubType:
-		loader = api.LoaderJSX
-	default:
-		err = fmt.Errorf("unsupported Media Type: %q", opts.mediaType)
+	loader, err := loaderFromMediaType(mediaType)
+	if err != nil {
 		return
 	}
 
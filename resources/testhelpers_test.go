@@ -30,6 +30,7 @@ type specDescriptor struct {
 	baseURL string
 	c       *qt.C
 	fs      afero.Fs
+	imaging map[string]any
 }
 
 func createTestCfg() config.Provider {
@@ -76,6 +77,9 @@ func newTestResourceSpec(desc specDescriptor) *Spec {
 		"quality":        68,
 		"anchor":         "left",
 	}
+	for k, v := range desc.imaging {
+		imagingCfg[k] = v
+	}
 
 	cfg.Set("imaging", imagingCfg)
 
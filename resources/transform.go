@@ -213,6 +213,18 @@ func (r *resourceAdapter) Exif() *exif.ExifInfo {
 	return r.getImageOps().Exif()
 }
 
+func (r *resourceAdapter) IPTC() *exif.IPTCInfo {
+	return r.getImageOps().IPTC()
+}
+
+func (r *resourceAdapter) XMP() *exif.XMPInfo {
+	return r.getImageOps().XMP()
+}
+
+func (r *resourceAdapter) Colors() ([]images.DominantColor, error) {
+	return r.getImageOps().Colors()
+}
+
 func (r *resourceAdapter) Key() string {
 	r.init(false, false)
 	return r.target.(resource.Identifier).Key()
@@ -297,9 +309,6 @@ func (r *resourceAdapter) DecodeImage() (image.Image, error) {
 func (r *resourceAdapter) getImageOps() images.ImageResourceOps {
 	img, ok := r.target.(images.ImageResourceOps)
 	if !ok {
-		if r.MediaType().SubType == "svg" {
-			panic("this method is only available for raster images. To determine if an image is SVG, you can do {{ if eq .MediaType.SubType \"svg\" }}{{ end }}")
-		}
 		fmt.Println(r.MediaType().SubType)
 		panic("this method is only available for image resources")
 	}
@@ -0,0 +1,60 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"time"
+
+	"github.com/bep/gitmap"
+)
+
+// GitInfo provides information about the Git history of a source file.
+// The fields of the last commit, e.g. AuthorName, are available directly.
+type GitInfo struct {
+	// The last commit of the file.
+	*GitCommit
+
+	// The author date of the first commit of the file, i.e. when it was created.
+	CreatedDate time.Time `json:"createdDate"`
+
+	// The last commits of the file, newest first.
+	// The number of commits is limited by the gitInfoMaxHistory setting.
+	History []*GitCommit `json:"history"`
+
+	// The unique authors and co-authors of all the commits of the file,
+	// most commits first.
+	Contributors []*GitContributor `json:"contributors"`
+}
+
+// GitCommit holds information about a Git commit.
+type GitCommit struct {
+	*gitmap.GitInfo
+
+	// The co-authors from the Co-authored-by trailers in the commit message.
+	CoAuthors []GitAuthor `json:"coAuthors"`
+}
+
+// GitAuthor is an author of a Git commit.
+type GitAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// GitContributor is an author or co-author of one or more Git commits.
+type GitContributor struct {
+	GitAuthor
+
+	// The number of commits authored or co-authored.
+	Commits int `json:"commits"`
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package css provides functions for building CSS resources.
+package css
+
+import (
+	"github.com/gohugoio/hugo/deps"
+	"github.com/gohugoio/hugo/resources"
+	"github.com/gohugoio/hugo/resources/resource"
+	"github.com/gohugoio/hugo/resources/resource_transformers/css"
+	"github.com/gohugoio/hugo/tpl/internal/resourcehelpers"
+)
+
+// New returns a new instance of the css-namespaced template functions.
+func New(deps *deps.Deps) *Namespace {
+	if deps.ResourceSpec == nil {
+		return &Namespace{}
+	}
+	return &Namespace{
+		client: css.New(deps.BaseFs.Assets, deps.ResourceSpec),
+	}
+}
+
+// Namespace provides template functions for the "css" namespace.
+type Namespace struct {
+	client *css.Client
+}
+
+// Build bundles the given CSS Resource and its imports with ESBuild.
+func (ns *Namespace) Build(args ...any) (resource.Resource, error) {
+	var (
+		r          resources.ResourceTransformer
+		m          map[string]any
+		targetPath string
+		err        error
+		ok         bool
+	)
+
+	r, targetPath, ok = resourcehelpers.ResolveIfFirstArgIsString(args)
+
+	if !ok {
+		r, m, err = resourcehelpers.ResolveArgs(args)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if targetPath != "" {
+		m = map[string]any{"targetPath": targetPath}
+	}
+
+	return ns.client.Process(r, m)
+}
+
+// Purge removes the CSS rules in the given Resource with selectors not
+// matching any of the HTML elements collected in hugo_stats.json.
+// The result should be wrapped in resources.PostProcess.
+func (ns *Namespace) Purge(args ...any) (resource.Resource, error) {
+	r, m, err := resourcehelpers.ResolveArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return ns.client.Purge(r, m)
+}
@@ -40,11 +40,18 @@ import (
 
 // New returns a new instance of the data-namespaced template functions.
 func New(deps *deps.Deps) *Namespace {
+	client := http.DefaultClient
+	if deps.ExecHelper != nil {
+		if timeout := deps.ExecHelper.Sec().HTTPTimeout(0); timeout > 0 {
+			client = &http.Client{Timeout: timeout}
+		}
+	}
+
 	return &Namespace{
 		deps:         deps,
 		cacheGetCSV:  deps.FileCaches.GetCSVCache(),
 		cacheGetJSON: deps.FileCaches.GetJSONCache(),
-		client:       http.DefaultClient,
+		client:       client,
 	}
 }
 
@@ -58,15 +58,15 @@ func (ns *Namespace) getRemote(cache *filecache.Cache, unmarshal func([]byte) (b
 			var res *http.Response
 			res, err = ns.client.Do(req)
 			if err != nil {
-				return nil, err
+				return nil, ns.deps.ExecHelper.Sec().CheckHTTPError(url, err)
 			}
 
 			var b []byte
-			b, err = ioutil.ReadAll(res.Body)
+			b, err = ioutil.ReadAll(ns.deps.ExecHelper.Sec().LimitHTTPBody(url, res.Body))
+			res.Body.Close()
 			if err != nil {
-				return nil, err
+				return nil, ns.deps.ExecHelper.Sec().CheckHTTPError(url, err)
 			}
-			res.Body.Close()
 
 			if isHTTPError(res) {
 				return nil, fmt.Errorf("Failed to retrieve remote file: %s, body: %q", http.StatusText(res.StatusCode), b)
@@ -0,0 +1,51 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gohugoio/hugo/resources/images"
+)
+
+// Card creates a social card image, e.g. for Open Graph, by filling the
+// background image to the card size and drawing the text boxes and images
+// described by layout on top of it. See images.DecodeCardLayout for the
+// layout options.
+//
+//	{{ $card := images.Card $bg (dict
+//	  "texts" (slice (dict "text" .Title "x" 80 "y" 80 "width" 1040 "size" 64 "maxLines" 3))
+//	  "images" (slice (dict "image" $logo "x" 1040 "y" 470 "width" 80 "height" 80))
+//	) }}
+func (ns *Namespace) Card(bg any, layout any) (images.ImageResource, error) {
+	img, ok := bg.(images.ImageResource)
+	if !ok {
+		return nil, errors.New("card background must be an image resource")
+	}
+
+	l, err := images.DecodeCardLayout(layout)
+	if err != nil {
+		return nil, err
+	}
+
+	if img.Width() != l.Width || img.Height() != l.Height {
+		img, err = img.Fill(fmt.Sprintf("%dx%d %s", l.Width, l.Height, l.Anchor))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return img.Filter(l.Filter())
+}
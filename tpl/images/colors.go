@@ -0,0 +1,101 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"image/color"
+
+	"github.com/gohugoio/hugo/resources/images"
+	"github.com/spf13/cast"
+)
+
+// Luminance returns the relative luminance of the hex color c as defined in
+// WCAG 2, from 0 (black) to 1 (white).
+//
+//	{{ images.Luminance "#ff0000" }} → 0.2126
+func (ns *Namespace) Luminance(c any) (float64, error) {
+	cc, err := toColor(c)
+	if err != nil {
+		return 0, err
+	}
+	return images.RelativeLuminance(cc), nil
+}
+
+// ContrastRatio returns the contrast ratio between the hex colors c1 and c2 as
+// defined in WCAG 2, from 1 (no contrast) to 21 (black on white).
+// WCAG AA requires a ratio of at least 4.5 for normal text.
+//
+//	{{ images.ContrastRatio "#ffffff" "#767676" }} → 4.54
+func (ns *Namespace) ContrastRatio(c1, c2 any) (float64, error) {
+	cc1, err := toColor(c1)
+	if err != nil {
+		return 0, err
+	}
+	cc2, err := toColor(c2)
+	if err != nil {
+		return 0, err
+	}
+	return images.ContrastRatio(cc1, cc2), nil
+}
+
+// ReadableColor returns the color among candidates with the highest contrast
+// ratio against the background color bg. The candidates default to black and
+// white.
+//
+//	{{ $bg := index $image.Colors 0 }}
+//	{{ $fg := images.ReadableColor $bg }}
+func (ns *Namespace) ReadableColor(bg any, candidates ...any) (string, error) {
+	bgc, err := toColor(bg)
+	if err != nil {
+		return "", err
+	}
+
+	if len(candidates) == 1 {
+		// Allow a slice of candidates.
+		if s, err := cast.ToSliceE(candidates[0]); err == nil {
+			candidates = s
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = []any{"#000000", "#ffffff"}
+	}
+
+	var (
+		best      string
+		bestRatio float64
+	)
+	for _, candidate := range candidates {
+		s, err := cast.ToStringE(candidate)
+		if err != nil {
+			return "", err
+		}
+		c, err := images.ColorFromHexString(s)
+		if err != nil {
+			return "", err
+		}
+		if ratio := images.ContrastRatio(bgc, c); ratio > bestRatio {
+			best, bestRatio = s, ratio
+		}
+	}
+
+	return best, nil
+}
+
+func toColor(v any) (color.Color, error) {
+	s, err := cast.ToStringE(v)
+	if err != nil {
+		return nil, err
+	}
+	return images.ColorFromHexString(s)
+}
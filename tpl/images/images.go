@@ -16,6 +16,8 @@ package images
 
 import (
 	"image"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"errors"
@@ -79,7 +81,15 @@ func (ns *Namespace) Config(path any) (image.Config, error) {
 	}
 	defer f.Close()
 
-	config, _, err = image.DecodeConfig(f)
+	if format, ok := images.ImageFormatFromExt(strings.ToLower(filepath.Ext(filename))); ok && format.IsVector() {
+		proc, err := ns.imaging()
+		if err != nil {
+			return image.Config{}, err
+		}
+		config, err = proc.DecodeConfig(format, f)
+	} else {
+		config, _, err = image.DecodeConfig(f)
+	}
 	if err != nil {
 		return config, err
 	}
@@ -91,6 +101,19 @@ func (ns *Namespace) Config(path any) (image.Config, error) {
 	return config, nil
 }
 
+// imaging returns the site's image processor, or one with the default
+// configuration if not available.
+func (ns *Namespace) imaging() (*images.ImageProcessor, error) {
+	if ns.deps.ResourceSpec != nil {
+		return ns.deps.ResourceSpec.Imaging(), nil
+	}
+	cfg, err := images.DecodeConfig(nil)
+	if err != nil {
+		return nil, err
+	}
+	return images.NewImageProcessor(cfg)
+}
+
 func (ns *Namespace) Filter(args ...any) (images.ImageResource, error) {
 	if len(args) < 2 {
 		return nil, errors.New("must provide an image and one or more filters")
@@ -72,6 +72,15 @@ var configTests = []struct {
 			ColorModel: color.NRGBAModel,
 		},
 	},
+	{
+		path:  "logo.svg",
+		input: []byte(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 64 32.5"><rect width="64" height="32"/></svg>`),
+		expect: image.Config{
+			Width:      64,
+			Height:     33,
+			ColorModel: color.RGBAModel,
+		},
+	},
 	// errors
 	{path: tstNoStringer{}, expect: false},
 	{path: "non-existent.png", expect: false},
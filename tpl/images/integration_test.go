@@ -0,0 +1,110 @@
+// Copyright 2022 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images_test
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gohugoio/hugo/hugolib"
+)
+
+func TestCard(t *testing.T) {
+	t.Parallel()
+
+	files := `
+-- config.toml --
+-- assets/bg.png --
+iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNkYPhfDwAChwGA60e6kgAAAABJRU5ErkJggg==
+-- assets/logo.svg --
+<svg viewBox="0 0 10 10"><circle cx="5" cy="5" r="5" fill="red"/></svg>
+-- content/p1.md --
+---
+title: "A Long Title That Needs To Wrap Over More Than One Line"
+author: "Jane Doe"
+---
+-- layouts/_default/single.html --
+{{ $bg := resources.Get "bg.png" }}
+{{ $logo := resources.Get "logo.svg" }}
+{{ $card := images.Card $bg (dict
+  "texts" (slice
+    (dict "text" .Title "x" 80 "y" 80 "width" 1040 "size" 64 "maxLines" 2 "lineHeight" 1.3)
+    (dict "text" .Params.author "x" 80 "y" 500 "size" 32 "color" "#cccccc")
+  )
+  "images" (slice (dict "image" $logo "x" 1000 "y" 450 "width" 120 "height" 120 "fit" "contain"))
+) }}
+{{ $small := images.Card $bg (dict "width" 600 "height" 315 "texts" (slice (dict "text" .Title "size" 20))) }}
+Card: {{ $card.Width }}x{{ $card.Height }}|{{ $card.MediaType }}|{{ $card.RelPermalink }}|
+Small: {{ $small.Width }}x{{ $small.Height }}|
+`
+
+	b := hugolib.NewIntegrationTestBuilder(
+		hugolib.IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+			NeedsOsFS:   true,
+		},
+	).Build()
+
+	b.AssertFileContent("public/p1/index.html", `
+Card: 1200x630|image/png|/bg_hu
+Small: 600x315|
+`)
+}
+
+func TestColors(t *testing.T) {
+	t.Parallel()
+
+	files := `
+-- config.toml --
+-- assets/img.png --
+iVBORw0KGgoAAAANSUhEUgAAAAQAAAAECAIAAAAmkwkpAAAAQUlEQVR4nAA0AMv/BBorPAAAAAAAAAAAAAIAAAAAAAAAAAAAAAACAAAAAAAAAAAAAAAAAf/MAAAAAAAAAAAAAAMAL1sCVm7nJCAAAAAASUVORK5CYII=
+-- layouts/index.html --
+{{ $img := resources.Get "img.png" }}
+{{ range $img.Colors }}Color: {{ .Hex }}|{{ .Ratio }}|{{ printf "%.2f" .Luminance }}|{{ end }}
+{{ $bg := index $img.Colors 0 }}
+Readable: {{ images.ReadableColor $bg }}|{{ images.ReadableColor "#ffcc00" }}|{{ images.ReadableColor "#ffcc00" "#333" "#ffffff" }}|
+Contrast: {{ images.ContrastRatio "#fff" "#000" }}|{{ printf "%.2f" (images.ContrastRatio "#ffffff" "#767676") }}|
+Luminance: {{ images.Luminance "#fff" }}|{{ images.Luminance "000" }}|
+{{ $small := $img.Resize "4x1" }}
+Resized: {{ len $small.Colors }}|
+`
+
+	b := hugolib.NewIntegrationTestBuilder(
+		hugolib.IntegrationTestConfig{
+			T:           t,
+			TxtarString: files,
+			NeedsOsFS:   true,
+		},
+	).Build()
+
+	b.AssertFileContent("public/index.html", `
+Color: #1a2b3c|0.75|0.02|Color: #ffcc00|0.25|0.64|
+Readable: #ffffff|#000000|#333|
+Contrast: 21|4.54|
+Luminance: 1|0|
+Resized: 1|
+`)
+
+	b = hugolib.NewIntegrationTestBuilder(
+		hugolib.IntegrationTestConfig{
+			T:           t,
+			TxtarString: strings.Replace(files, `"#fff" "#000"`, `"#fff" "#00"`, 1),
+			NeedsOsFS:   true,
+		},
+	)
+	_, err := b.BuildE()
+	b.Assert(err, qt.ErrorMatches, `(?s).*invalid color code.*`)
+}
@@ -15,6 +15,9 @@
 package js
 
 import (
+	"fmt"
+
+	"github.com/gohugoio/hugo/common/maps"
 	"github.com/gohugoio/hugo/deps"
 	"github.com/gohugoio/hugo/resources"
 	"github.com/gohugoio/hugo/resources/resource"
@@ -63,3 +66,15 @@ func (ns *Namespace) Build(args ...any) (resource.Resource, error) {
 
 	return ns.client.Process(r, m)
 }
+
+// Batch builds the given entries with code splitting enabled and returns
+// the result, with the entries, shared chunks, CSS and source maps
+// published together. See js.Client.Batch for the options available.
+func (ns *Namespace) Batch(options any) (*js.BatchResult, error) {
+	m, err := maps.ToStringMapE(options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid options type: %w", err)
+	}
+
+	return ns.client.Batch(m)
+}
@@ -74,6 +74,40 @@ func init() {
 			},
 		)
 
+		ns.AddMethodMapping(ctx.FormatRelativeTime,
+			nil,
+			[][2]string{
+				{`{{ lang.FormatRelativeTime (dict "now" "2022-06-10") "2022-06-07" }}`, `3 days ago`},
+				{`{{ -3 | lang.FormatRelativeTime "day" }}`, `3 days ago`},
+				{`{{ 2 | lang.FormatRelativeTime (dict "style" "short") "hour" }}`, `in 2 hr.`},
+			},
+		)
+
+		ns.AddMethodMapping(ctx.FormatList,
+			nil,
+			[][2]string{
+				{`{{ slice "A" "B" "C" | lang.FormatList }}`, `A, B, and C`},
+				{`{{ slice "A" "B" | lang.FormatList "or" }}`, `A or B`},
+			},
+		)
+
+		ns.AddMethodMapping(ctx.FormatUnit,
+			nil,
+			[][2]string{
+				{`{{ 5 | lang.FormatUnit "kilometer" }}`, `5 km`},
+				{`{{ 1.234 | lang.FormatUnit (dict "style" "long" "precision" 1) "mile" }}`, `1.2 miles`},
+			},
+		)
+
+		ns.AddMethodMapping(ctx.PluralCategory,
+			nil,
+			[][2]string{
+				{`{{ lang.PluralCategory 1 }}`, `one`},
+				{`{{ lang.PluralCategory "1.0" }}`, `other`},
+				{`{{ lang.PluralCategory "ordinal" 3 }}`, `few`},
+			},
+		)
+
 		return ns
 	}
 
@@ -19,6 +19,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	"errors"
 
@@ -26,7 +27,13 @@ import (
 	translators "github.com/gohugoio/localescompressed"
 
 	"github.com/gohugoio/hugo/common/hreflect"
+	"github.com/gohugoio/hugo/common/htime"
+	"github.com/gohugoio/hugo/common/maps"
 	"github.com/gohugoio/hugo/deps"
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/gohugoio/hugo/langs"
+	"github.com/gohugoio/hugo/langs/cldr"
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/cast"
 )
 
@@ -34,6 +41,7 @@ import (
 func New(deps *deps.Deps, translator locales.Translator) *Namespace {
 	return &Namespace{
 		translator: translator,
+		formatter:  cldr.New(translator),
 		deps:       deps,
 	}
 }
@@ -41,6 +49,7 @@ func New(deps *deps.Deps, translator locales.Translator) *Namespace {
 // Namespace provides template functions for the "lang" namespace.
 type Namespace struct {
 	translator locales.Translator
+	formatter  *cldr.Formatter
 	deps       *deps.Deps
 }
 
@@ -239,6 +248,217 @@ func (ns *Namespace) FormatNumberCustom(precision, number any, options ...any) (
 	return string(b), nil
 }
 
+// FormatRelativeTime formats the time t relative to now for the current language,
+// using the largest unit that fits, e.g. "3 days ago" or "in 2 hours":
+//
+//	{{ .Date | lang.FormatRelativeTime }}
+//
+// Given a unit, one of year, month, week, day, hour, minute and second, the
+// number is formatted as that number of units relative to now instead:
+//
+//	{{ -3 | lang.FormatRelativeTime "day" }} => 3 days ago
+//
+// An options map may be passed as the first argument with the style, long
+// (default) or short, and, for times, now, the time to format t relative to.
+func (ns *Namespace) FormatRelativeTime(args ...any) (string, error) {
+	opts, args, err := ns.formatOptionsFromArgs(args)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(args) {
+	case 1:
+		loc := time.UTC
+		if ns.deps.Language != nil {
+			loc = langs.GetLocation(ns.deps.Language)
+		}
+		t, err := htime.ToTimeInDefaultLocationE(args[0], loc)
+		if err != nil {
+			return "", err
+		}
+		now := htime.Now()
+		if opts.Now != nil {
+			if now, err = htime.ToTimeInDefaultLocationE(opts.Now, loc); err != nil {
+				return "", err
+			}
+		}
+		value, unit := cldr.RelativeTimeUnit(t.Sub(now))
+		ns.warnIfNoCLDRData()
+		return ns.formatter.FormatRelativeTime(value, 0, unit, opts.Style)
+	case 2:
+		unit, err := cast.ToStringE(args[0])
+		if err != nil {
+			return "", err
+		}
+		n, v, err := castNumberWithPrecision(args[1], opts.Precision)
+		if err != nil {
+			return "", err
+		}
+		ns.warnIfNoCLDRData()
+		return ns.formatter.FormatRelativeTime(n, v, unit, opts.Style)
+	default:
+		return "", errors.New("wrong number of arguments, expecting a time or a unit and a number")
+	}
+}
+
+// FormatList joins the given items as a list for the current language, e.g.
+// "A, B, and C". The optional list type is one of and (default) and or.
+func (ns *Namespace) FormatList(args ...any) (string, error) {
+	var typ string
+	switch len(args) {
+	case 1:
+	case 2:
+		var err error
+		if typ, err = cast.ToStringE(args[0]); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("wrong number of arguments, expecting 1 or 2, got %d", len(args))
+	}
+
+	items, err := cast.ToStringSliceE(args[len(args)-1])
+	if err != nil {
+		return "", err
+	}
+
+	ns.warnIfNoCLDRData()
+	return ns.formatter.FormatList(items, typ)
+}
+
+// warnIfNoCLDRData warns, once per language, if relative times, lists and
+// units are formatted in English because there is no CLDR data for the
+// current language.
+func (ns *Namespace) warnIfNoCLDRData() {
+	if ns.formatter.HasData() {
+		return
+	}
+	helpers.DistinctWarnLog.Warnf("No CLDR data for language %q: lang.FormatRelativeTime, lang.FormatList and lang.FormatUnit fall back to English.", ns.translator.Locale())
+}
+
+// FormatUnit formats number in the given unit for the current language, e.g.
+// "5 km" for {{ 5 | lang.FormatUnit "kilometer" }}.
+// An options map may be passed as the first argument with the style, short
+// (default) or long, and the precision, by default as many decimals as needed.
+func (ns *Namespace) FormatUnit(args ...any) (string, error) {
+	opts, args, err := ns.formatOptionsFromArgs(args)
+	if err != nil {
+		return "", err
+	}
+	if len(args) != 2 {
+		return "", errors.New("wrong number of arguments, expecting a unit and a number")
+	}
+
+	unit, err := cast.ToStringE(args[0])
+	if err != nil {
+		return "", err
+	}
+	n, v, err := castNumberWithPrecision(args[1], opts.Precision)
+	if err != nil {
+		return "", err
+	}
+
+	ns.warnIfNoCLDRData()
+	return ns.formatter.FormatUnit(n, v, unit, opts.Style)
+}
+
+// PluralCategory returns the CLDR plural category of number for the current
+// language, one of zero, one, two, few, many and other.
+// The optional type is one of cardinal (default), e.g. "one" for 1 in English,
+// and ordinal, e.g. "two" for 2 in English (2nd).
+//
+// Note that the number of decimals in numbers given as strings counts, so
+// "1.0" is "other" in English.
+func (ns *Namespace) PluralCategory(args ...any) (string, error) {
+	typ := "cardinal"
+	switch len(args) {
+	case 1:
+	case 2:
+		var err error
+		if typ, err = cast.ToStringE(args[0]); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("wrong number of arguments, expecting 1 or 2, got %d", len(args))
+	}
+
+	n, v, err := castNumberWithPrecision(args[len(args)-1], -1)
+	if err != nil {
+		return "", err
+	}
+
+	switch typ {
+	case "cardinal":
+		return ns.formatter.PluralCategory(n, v), nil
+	case "ordinal":
+		return ns.formatter.OrdinalCategory(n), nil
+	default:
+		return "", fmt.Errorf("invalid plural type %q, must be one of cardinal and ordinal", typ)
+	}
+}
+
+type formatOptions struct {
+	// One of long and short.
+	Style string
+
+	// The number of decimals, -1 for as many as needed.
+	Precision int
+
+	// The time to format relative to, default now.
+	Now any
+}
+
+// formatOptionsFromArgs decodes the options map if given as the first argument
+// and returns the remaining arguments.
+func (ns *Namespace) formatOptionsFromArgs(args []any) (formatOptions, []any, error) {
+	opts := formatOptions{Precision: -1}
+	if len(args) == 0 {
+		return opts, args, nil
+	}
+
+	switch args[0].(type) {
+	case map[string]any, maps.Params:
+	default:
+		return opts, args, nil
+	}
+
+	m, err := maps.ToStringMapE(args[0])
+	if err != nil {
+		return opts, nil, err
+	}
+	if err := mapstructure.WeakDecode(m, &opts); err != nil {
+		return opts, nil, err
+	}
+
+	return opts, args[1:], nil
+}
+
+// castNumberWithPrecision returns number as a float and the number of visible
+// decimals, the given precision if >= 0, else the decimals in number.
+func castNumberWithPrecision(number any, precision int) (float64, uint64, error) {
+	n, err := cast.ToFloat64E(number)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if precision >= 0 {
+		if precision > 20 {
+			return 0, 0, fmt.Errorf("invalid precision: %d", precision)
+		}
+		return n, uint64(precision), nil
+	}
+
+	s, ok := number.(string)
+	if !ok {
+		s = strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	var v uint64
+	if i := strings.IndexByte(s, '.'); i != -1 {
+		v = uint64(len(strings.TrimSpace(s)) - i - 1)
+	}
+
+	return n, v, nil
+}
+
 // NumFmt is deprecated, use FormatNumberCustom.
 // We renamed this in Hugo 0.87.
 // Deprecated: Use FormatNumberCustom
@@ -2,6 +2,7 @@ package lang
 
 import (
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 	"github.com/gohugoio/hugo/deps"
@@ -106,6 +107,76 @@ func TestFormatNumbers(t *testing.T) {
 
 }
 
+func TestFormatLocaleAware(t *testing.T) {
+	c := qt.New(t)
+
+	nsDe := New(&deps.Deps{}, translators.GetTranslator("de"))
+	nsEn := New(&deps.Deps{}, translators.GetTranslator("en"))
+
+	c.Run("FormatRelativeTime", func(c *qt.C) {
+		c.Parallel()
+		now := time.Date(2022, 6, 10, 12, 0, 0, 0, time.UTC)
+
+		got, err := nsDe.FormatRelativeTime(map[string]any{"now": now}, now.Add(-50*time.Hour))
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "vor 2 Tagen")
+
+		got, err = nsEn.FormatRelativeTime(map[string]any{"now": now}, "2022-06-10T15:00:00Z")
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "in 3 hours")
+
+		got, err = nsEn.FormatRelativeTime("minutes", "1.5")
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "in 1.5 minutes")
+
+		_, err = nsEn.FormatRelativeTime()
+		c.Assert(err, qt.Not(qt.IsNil))
+	})
+
+	c.Run("FormatList", func(c *qt.C) {
+		c.Parallel()
+		got, err := nsDe.FormatList([]any{"A", "B", "C"})
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "A, B und C")
+
+		got, err = nsEn.FormatList("or", []string{"A", "B", "C"})
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "A, B, or C")
+	})
+
+	c.Run("FormatUnit", func(c *qt.C) {
+		c.Parallel()
+		got, err := nsDe.FormatUnit("kilometer-per-hour", 120.5)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "120,5 km/h")
+
+		got, err = nsEn.FormatUnit(map[string]any{"style": "long", "precision": 0}, "day", 1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "1 day")
+
+		_, err = nsEn.FormatUnit("day")
+		c.Assert(err, qt.Not(qt.IsNil))
+	})
+
+	c.Run("PluralCategory", func(c *qt.C) {
+		c.Parallel()
+		got, err := nsEn.PluralCategory(1)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "one")
+
+		got, err = nsEn.PluralCategory("1.0")
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "other")
+
+		got, err = nsEn.PluralCategory("ordinal", 22)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, "two")
+
+		_, err = nsEn.PluralCategory("dual", 2)
+		c.Assert(err, qt.Not(qt.IsNil))
+	})
+}
+
 // Issue 9446
 func TestLanguageKeyFormat(t *testing.T) {
 
@@ -101,9 +101,20 @@ func (ns *Namespace) ReadFile(i any) (string, error) {
 		s = ns.deps.PathSpec.RelPathify(s)
 	}
 
+	if err := ns.checkAllowedFileRead(s); err != nil {
+		return "", err
+	}
+
 	return readFile(ns.readFileFs, s)
 }
 
+func (ns *Namespace) checkAllowedFileRead(filename string) error {
+	if ns.deps.ExecHelper == nil {
+		return nil
+	}
+	return ns.deps.ExecHelper.Sec().CheckAllowedFileRead(filepath.Clean(filename))
+}
+
 // ReadDir lists the directory contents relative to the configured WorkingDir.
 func (ns *Namespace) ReadDir(i any) ([]_os.FileInfo, error) {
 	path, err := cast.ToStringE(i)
@@ -111,6 +122,10 @@ func (ns *Namespace) ReadDir(i any) ([]_os.FileInfo, error) {
 		return nil, err
 	}
 
+	if err := ns.checkAllowedFileRead(path); err != nil {
+		return nil, err
+	}
+
 	list, err := afero.ReadDir(ns.workFs, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read directory %q: %s", path, err)
@@ -130,6 +145,10 @@ func (ns *Namespace) FileExists(i any) (bool, error) {
 		return false, errors.New("fileExists needs a path to a file")
 	}
 
+	if err := ns.checkAllowedFileRead(path); err != nil {
+		return false, err
+	}
+
 	status, err := afero.Exists(ns.readFileFs, path)
 	if err != nil {
 		return false, err
@@ -149,6 +168,10 @@ func (ns *Namespace) Stat(i any) (_os.FileInfo, error) {
 		return nil, errors.New("fileStat needs a path to a file")
 	}
 
+	if err := ns.checkAllowedFileRead(path); err != nil {
+		return nil, err
+	}
+
 	r, err := ns.readFileFs.Stat(path)
 	if err != nil {
 		return nil, err
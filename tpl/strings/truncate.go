@@ -21,6 +21,7 @@ import (
 	"unicode"
 	"unicode/utf8"
 
+	htext "github.com/gohugoio/hugo/common/text"
 	"github.com/spf13/cast"
 )
 
@@ -40,6 +41,9 @@ type htmlTag struct {
 }
 
 // Truncate truncates a given string to the specified length.
+// When truncating HTML with a direction different from the site language's,
+// e.g. Arabic text on an English site, the truncated text and the ellipsis
+// are wrapped in a bidi isolate so the ellipsis ends up at the end of the text.
 func (ns *Namespace) Truncate(a any, options ...any) (template.HTML, error) {
 	length, err := cast.ToIntE(a)
 	if err != nil {
@@ -85,6 +89,7 @@ func (ns *Namespace) Truncate(a any, options ...any) (template.HTML, error) {
 
 	tags := []htmlTag{}
 	var lastWordIndex, lastNonSpace, currentLen, endTextPos, nextTag int
+	textStart := -1
 
 	for i, r := range text {
 		if i < nextTag {
@@ -108,6 +113,10 @@ func (ns *Namespace) Truncate(a any, options ...any) (template.HTML, error) {
 			}
 		}
 
+		if textStart == -1 {
+			textStart = i
+		}
+
 		currentLen++
 		if unicode.IsSpace(r) {
 			lastWordIndex = lastNonSpace
@@ -124,6 +133,11 @@ func (ns *Namespace) Truncate(a any, options ...any) (template.HTML, error) {
 				endTextPos = lastWordIndex
 			}
 			out := text[0:endTextPos]
+			isolate := isHTML && ellipsis != "" && ns.differsFromSiteDirection(out[textStart:])
+			if isolate {
+				out = out[:textStart] + htext.FirstStrongIsolate + out[textStart:]
+				ellipsis += htext.PopDirectionalIsolate
+			}
 			if isHTML {
 				out += ellipsis
 				// Close out any open HTML tags
@@ -155,3 +169,17 @@ func (ns *Namespace) Truncate(a any, options ...any) (template.HTML, error) {
 	}
 	return template.HTML(html.EscapeString(text)), nil
 }
+
+// differsFromSiteDirection reports whether the first strong character in s
+// has a different direction than the site language.
+func (ns *Namespace) differsFromSiteDirection(s string) bool {
+	dir := htext.Direction(s)
+	if dir == "" {
+		return false
+	}
+	siteDir := htext.DirectionLTR
+	if ns.deps != nil && ns.deps.Language != nil && ns.deps.Language.LanguageDirection == htext.DirectionRTL {
+		siteDir = htext.DirectionRTL
+	}
+	return dir != siteDir
+}
@@ -18,6 +18,10 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/gohugoio/hugo/config"
+	"github.com/gohugoio/hugo/deps"
+	"github.com/gohugoio/hugo/langs"
 )
 
 func TestTruncate(t *testing.T) {
@@ -47,6 +51,10 @@ func TestTruncate(t *testing.T) {
 		{3, template.HTML(strings.Repeat("<p>P</p>", 20)), nil, template.HTML("<p>P</p><p>P</p><p>P …</p>"), false},
 		{18, template.HTML("<p>test <b>hello</b> test something</p>"), nil, template.HTML("<p>test <b>hello</b> test …</p>"), false},
 		{4, template.HTML("<p>a<b><i>b</b>c d e</p>"), nil, template.HTML("<p>a<b><i>b</b>c …</p>"), false},
+		{10, "שלום עולם ומלואו", nil, template.HTML("שלום עולם \u2026"), false},
+		{10, template.HTML("שלום עולם ומלואו"), nil, template.HTML("\u2068שלום עולם \u2026\u2069"), false},
+		{10, "", "שלום עולם ומלואו", template.HTML("שלום עולם"), false},
+		{13, template.HTML("<p>مرحبا <b>بالعالم</b> الجميل</p>"), nil, template.HTML("<p>\u2068مرحبا <b>بالعالم \u2026\u2069</b></p>"), false},
 		{10, nil, nil, template.HTML(""), true},
 		{nil, nil, nil, template.HTML(""), true},
 	}
@@ -75,6 +83,24 @@ func TestTruncate(t *testing.T) {
 		}
 	}
 
+	rtl := New(&deps.Deps{Cfg: config.New(), Language: &langs.Language{LanguageDirection: "rtl"}})
+	for _, c := range []struct {
+		v1   any
+		want template.HTML
+	}{
+		// Left-to-right text, not HTML.
+		{"I am a test sentence", "I am a …"},
+		// Left-to-right HTML.
+		{template.HTML("<p>I am a test sentence</p>"), "<p>\u2068I am a …\u2069</p>"},
+		// Right-to-left HTML, the same direction as the site.
+		{template.HTML("<p>שלום עולם ומלואו</p>"), "<p>שלום עולם …</p>"},
+	} {
+		result, err := rtl.Truncate(10, c.v1)
+		if err != nil || result != c.want {
+			t.Errorf("got '%s' but expected '%s' for a right-to-left site: %v", result, c.want, err)
+		}
+	}
+
 	// Too many arguments
 	_, err = ns.Truncate(10, " ...", "I am a test sentence", "wrong")
 	if err == nil {
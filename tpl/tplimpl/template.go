@@ -624,9 +624,15 @@ func (t *templateHandler) addTemplateFile(name, path string) error {
 		s := removeLeadingBOM(string(b))
 
 		realFilename := filename
+		var (
+			module    string
+			isProject bool
+		)
 		if fi, err := fs.Stat(filename); err == nil {
 			if fim, ok := fi.(hugofs.FileMetaInfo); ok {
-				realFilename = fim.Meta().Filename
+				meta := fim.Meta()
+				realFilename = meta.Filename
+				module, isProject = meta.Module, meta.IsProject
 			}
 		}
 
@@ -640,6 +646,8 @@ func (t *templateHandler) addTemplateFile(name, path string) error {
 			filename:     filename,
 			realFilename: realFilename,
 			fs:           fs,
+			module:       module,
+			isProject:    isProject,
 		}, nil
 	}
 
@@ -33,6 +33,10 @@ type templateInfo struct {
 
 	// The real filename (if possible). Used for logging.
 	realFilename string
+
+	// The path of the module providing this template, if any.
+	module    string
+	isProject bool
 }
 
 func (t templateInfo) Name() string {
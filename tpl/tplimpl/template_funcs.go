@@ -22,6 +22,7 @@ import (
 
 	"github.com/gohugoio/hugo/common/hreflect"
 	"github.com/gohugoio/hugo/common/maps"
+	"github.com/gohugoio/hugo/config/security"
 	"github.com/gohugoio/hugo/tpl"
 
 	template "github.com/gohugoio/hugo/tpl/internal/go_templates/htmltemplate"
@@ -36,6 +37,7 @@ import (
 	_ "github.com/gohugoio/hugo/tpl/collections"
 	_ "github.com/gohugoio/hugo/tpl/compare"
 	_ "github.com/gohugoio/hugo/tpl/crypto"
+	_ "github.com/gohugoio/hugo/tpl/css"
 	_ "github.com/gohugoio/hugo/tpl/data"
 	_ "github.com/gohugoio/hugo/tpl/debug"
 	_ "github.com/gohugoio/hugo/tpl/diagrams"
@@ -71,9 +73,47 @@ var (
 type templateExecHelper struct {
 	running bool // whether we're in server mode.
 	funcs   map[string]reflect.Value
+
+	// Set when there are template funcs denied for some modules.
+	sec       *security.Config
+	funcNames funcNames
+}
+
+// funcNames is used to resolve the namespaced name of a template func,
+// e.g. "data.GetJSON".
+type funcNames struct {
+	// Maps aliases, e.g. "getJSON", to the namespaced name.
+	aliases map[string]string
+
+	// Maps the namespace receiver types to their names, e.g. "data".
+	namespaces map[reflect.Type]string
+}
+
+// checkAllowedFunc checks the func with the given names against the
+// security.funcs.modules policy for the module providing tmpl.
+// It returns a func that fails with the AccessDeniedError if denied.
+func (t *templateExecHelper) checkAllowedFunc(tmpl texttemplate.Preparer, names ...string) (reflect.Value, bool) {
+	ts, ok := tmpl.(*templateState)
+	if !ok || ts.info.module == "" || ts.info.isProject {
+		return zero, true
+	}
+	if err := t.sec.CheckAllowedModuleFunc(ts.info.module, names...); err != nil {
+		return reflect.ValueOf(func(...any) (any, error) {
+			return nil, err
+		}), false
+	}
+	return zero, true
 }
 
 func (t *templateExecHelper) GetFunc(ctx context.Context, tmpl texttemplate.Preparer, name string) (fn reflect.Value, firstArg reflect.Value, found bool) {
+	if t.sec != nil {
+		if alias, found := t.funcNames.aliases[name]; found {
+			if denied, ok := t.checkAllowedFunc(tmpl, alias, name); !ok {
+				return denied, zero, true
+			}
+		}
+	}
+
 	if fn, found := t.funcs[name]; found {
 		if fn.Type().NumIn() > 0 {
 			first := fn.Type().In(0)
@@ -121,6 +161,14 @@ func (t *templateExecHelper) GetMethod(ctx context.Context, tmpl texttemplate.Pr
 		}
 	}
 
+	if t.sec != nil {
+		if ns, found := t.funcNames.namespaces[receiver.Type()]; found {
+			if denied, ok := t.checkAllowedFunc(tmpl, ns+"."+name); !ok {
+				return denied, zero
+			}
+		}
+	}
+
 	fn := hreflect.GetMethodByName(receiver, name)
 	if !fn.IsValid() {
 		return zero, zero
@@ -139,7 +187,7 @@ func (t *templateExecHelper) GetMethod(ctx context.Context, tmpl texttemplate.Pr
 }
 
 func newTemplateExecuter(d *deps.Deps) (texttemplate.Executer, map[string]reflect.Value) {
-	funcs := createFuncMap(d)
+	funcs, names := createFuncMap(d)
 	funcsv := make(map[string]reflect.Value)
 
 	for k, v := range funcs {
@@ -169,13 +217,24 @@ func newTemplateExecuter(d *deps.Deps) (texttemplate.Executer, map[string]reflec
 		funcs:   funcsv,
 	}
 
+	if d.ExecHelper != nil {
+		if sec := d.ExecHelper.Sec(); sec.HasModuleFuncsPolicies() {
+			exeHelper.sec = &sec
+			exeHelper.funcNames = names
+		}
+	}
+
 	return texttemplate.NewExecuter(
 		exeHelper,
 	), funcsv
 }
 
-func createFuncMap(d *deps.Deps) map[string]any {
+func createFuncMap(d *deps.Deps) (map[string]any, funcNames) {
 	funcMap := template.FuncMap{}
+	names := funcNames{
+		aliases:    make(map[string]string),
+		namespaces: make(map[reflect.Type]string),
+	}
 
 	// Merge the namespace funcs
 	for _, nsf := range internal.TemplateFuncsNamespaceRegistry {
@@ -184,12 +243,16 @@ func createFuncMap(d *deps.Deps) map[string]any {
 			panic(ns.Name + " is a duplicate template func")
 		}
 		funcMap[ns.Name] = ns.Context
-		for _, mm := range ns.MethodMappings {
+		if receiver, err := ns.Context(); err == nil && receiver != nil {
+			names.namespaces[reflect.TypeOf(receiver)] = ns.Name
+		}
+		for name, mm := range ns.MethodMappings {
 			for _, alias := range mm.Aliases {
 				if _, exists := funcMap[alias]; exists {
 					panic(alias + " is a duplicate template func")
 				}
 				funcMap[alias] = mm.Method
+				names.aliases[alias] = ns.Name + "." + name
 			}
 		}
 	}
@@ -200,5 +263,5 @@ func createFuncMap(d *deps.Deps) map[string]any {
 		}
 	}
 
-	return funcMap
+	return funcMap, names
 }